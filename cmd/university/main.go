@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	_ "university_system/docs"
+	"university_system/internal/auth"
 	"university_system/internal/routes"
 	"university_system/pkg/config"
 	"university_system/pkg/databases"
@@ -26,6 +27,11 @@ import (
 // @name Authorization
 func main() {
 	cfg := config.LoadConfig()
+	if err := auth.InitKeys(cfg.JWT); err != nil {
+		logrus.Errorf("Failed to load JWT keys: %v", err)
+		return
+	}
+
 	pgURL := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.DB.Host, cfg.DB.Port, cfg.DB.User, cfg.DB.Password, cfg.DB.DBName, cfg.DB.SSLMode)
 
@@ -46,9 +52,14 @@ func main() {
 		return
 	}
 
+	if err := auth.InitCasbin(db); err != nil {
+		logrus.Errorf("Failed to init Casbin: %v", err)
+		return
+	}
+
 	logrus.SetFormatter(new(logrus.JSONFormatter))
 	router := gin.Default()
-	routes.RegisterUserRoutes(router)
+	routes.RegisterUserRoutes(router, cfg)
 	logrus.Println(fmt.Sprintf("Listening on port %s", os.Getenv("PORT")))
 	logrus.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", os.Getenv("PORT")), router))
 }
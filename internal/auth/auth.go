@@ -1,8 +1,8 @@
 package auth
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"log"
 	"net/http"
 	"university_system/internal/domain/models"
@@ -42,14 +42,17 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := GenerateAccessToken(user.Username, user.Role)
+	accessToken, err := GenerateAccessToken(user.ID, user.Username, user.Role)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Could not generate access token"})
 		return
 	}
 
-	refreshToken, err := GenerateRefreshToken(user.Username, user.Role)
+	refreshRepo := infraRepo.NewRefreshTokenRepository(databases.Instance)
+	subject := refreshSubject{UserID: user.ID, Username: user.Username, Role: user.Role}
+	refreshToken, err := startRefreshTokenFamily(c.Request.Context(), refreshRepo, subject, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
+		log.Println(err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Could not generate refresh token"})
 		return
 	}
@@ -61,13 +64,14 @@ func Login(c *gin.Context) {
 }
 
 // Refresh
-// @Summary Обновление access-токена
-// @Description Получение нового access-токена с помощью refresh-токена
+// @Summary Обновление токенов
+// @Description Обменивает refresh-токен на новую пару токенов. Старый refresh-токен становится недействительным;
+// @Description повторное его использование отзывает все токены этой сессии.
 // @Tags Authorization
 // @Accept json
 // @Produce json
 // @Param input body models.RefreshRequest true "Refresh-токен"
-// @Success 200 {object} models.AccessTokenResponse
+// @Success 200 {object} models.AuthResponse
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 401 {object} models.ErrorResponse
 // @Router /refresh [post]
@@ -79,39 +83,104 @@ func Refresh(c *gin.Context) {
 		return
 	}
 
-	token, err := jwt.Parse(refreshData.RefreshToken, func(token *jwt.Token) (interface{}, error) {
-		return []byte(RefreshTokenSecret), nil
-	})
+	refreshRepo := infraRepo.NewRefreshTokenRepository(databases.Instance)
+	userRepo := infraRepo.NewUserRepository(databases.Instance)
+	refreshToken, subject, err := rotateRefreshToken(c.Request.Context(), refreshRepo, userRepo, refreshData.RefreshToken, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		respondRefreshError(c, err)
+		return
+	}
 
+	accessToken, err := GenerateAccessToken(subject.UserID, subject.Username, subject.Role)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid refresh token"})
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Could not generate access token"})
 		return
 	}
 
-	if !token.Valid {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid refresh token"})
+	c.JSON(http.StatusOK, models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// Logout
+// @Summary Выход из системы
+// @Description Отзывает refresh-токен и все токены, полученные из него ротацией
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param input body models.RefreshRequest true "Refresh-токен"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Router /logout [post]
+func Logout(c *gin.Context) {
+	var refreshData models.RefreshRequest
+
+	if err := c.ShouldBindJSON(&refreshData); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid data"})
 		return
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid token claims"})
+	refreshRepo := infraRepo.NewRefreshTokenRepository(databases.Instance)
+	if err := revokeRefreshToken(c.Request.Context(), refreshRepo, refreshData.RefreshToken); err != nil {
+		respondRefreshError(c, err)
 		return
 	}
 
-	username, ok := claims["username"].(string)
-	if !ok {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid username claim"})
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll
+// @Summary Выход со всех устройств
+// @Description Отзывает все refresh-токены пользователя, которому принадлежит переданный токен
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param input body models.RefreshRequest true "Refresh-токен"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Router /logout-all [post]
+func LogoutAll(c *gin.Context) {
+	var refreshData models.RefreshRequest
+
+	if err := c.ShouldBindJSON(&refreshData); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid data"})
 		return
 	}
 
-	accessToken, err := GenerateAccessToken(username, claims["role"].(string))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Could not generate access token"})
+	refreshRepo := infraRepo.NewRefreshTokenRepository(databases.Instance)
+	if err := revokeAllRefreshTokens(c.Request.Context(), refreshRepo, refreshData.RefreshToken); err != nil {
+		respondRefreshError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"access_token": accessToken,
-	})
+	c.Status(http.StatusNoContent)
+}
+
+func respondRefreshError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Refresh token reuse detected, session revoked"})
+	case errors.Is(err, ErrInvalidRefreshToken),
+		errors.Is(err, models.ErrRefreshTokenNotFound),
+		errors.Is(err, models.ErrRefreshTokenRevoked):
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid refresh token"})
+	default:
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Could not process refresh token"})
+	}
+}
+
+// JWKS
+// @Summary Открытые ключи проверки токенов
+// @Description Возвращает открытые ключи (JWK Set), которыми другие сервисы могут проверять access-токены
+// @Tags Authorization
+// @Produce json
+// @Success 200 {object} auth.JWKSet
+// @Router /.well-known/jwks.json [get]
+func JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, accessKeys.PublicJWKS())
 }
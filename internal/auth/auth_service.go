@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"golang.org/x/crypto/bcrypt"
@@ -13,26 +15,43 @@ var secretKey = []byte("your-secret-key")
 var AccessTokenSecret = []byte("access-token-secret")
 var RefreshTokenSecret = []byte("refresh-token-secret")
 
-func GenerateAccessToken(username string, role string) (string, error) {
+// RefreshTokenTTL — срок жизни одного refresh-токена
+const RefreshTokenTTL = time.Hour * 24 * 7
+
+// GenerateAccessToken подписывает access-токен активным ключом из набора ключей
+func GenerateAccessToken(userID string, username string, role string) (string, error) {
 	claims := &jwt.MapClaims{
+		"user_id":  userID,
 		"username": username,
 		"role":     role,
 		"exp":      time.Now().Add(time.Hour * 2).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(AccessTokenSecret)
+	return accessKeys.sign(claims)
 }
 
-func GenerateRefreshToken(username string, role string) (string, error) {
+// GenerateRefreshToken подписывает refresh-токен с идентификатором jti,
+// по которому он находится в таблице refresh_tokens
+func GenerateRefreshToken(userID, username, role, jti string, expiresAt time.Time) (string, error) {
 	claims := &jwt.MapClaims{
+		"sub":      userID,
 		"username": username,
 		"role":     role,
-		"exp":      time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 дней
+		"jti":      jti,
+		"exp":      expiresAt.Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(RefreshTokenSecret)
 }
 
+// NewTokenID генерирует случайный идентификатор для jti и семейства токенов
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func ParseRefreshToken(tokenString string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -42,13 +61,9 @@ func ParseRefreshToken(tokenString string) (*jwt.Token, error) {
 	})
 }
 
+// ParseAccessToken проверяет access-токен ключом, указанным в заголовке kid
 func ParseAccessToken(tokenString string) (*jwt.Token, error) {
-	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return AccessTokenSecret, nil
-	})
+	return jwt.Parse(tokenString, accessKeys.keyFunc)
 }
 
 // CheckPassword сравнивает хешированный пароль с обычным паролем
@@ -3,36 +3,67 @@ package auth
 import (
 	"log"
 	"net/http"
-	"path/filepath"
+	infraRepo "university_system/internal/infrastructure/repository"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
 	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
 )
 
-var enforcer *casbin.Enforcer
+// rbacModel — модель доступа: субъект — роль из токена, объект — шаблон маршрута gin
+// (например /students/:id, поддерживаются * и :param), действие — HTTP-метод или *.
+// Роль admin имеет доступ ко всем маршрутам, g задаёт наследование ролей.
+const rbacModel = `
+[request_definition]
+r = sub, obj, act
 
-func InitCasbin() error {
-	modelPath := filepath.Join("config", "rbac_model.conf")
-	adapter := filepath.Join("config", "rbac_policy.csv")
+[policy_definition]
+p = sub, obj, act
 
-	var err error
-	enforcer, err = casbin.NewEnforcer(modelPath, adapter)
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = r.sub == "admin" || (g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && (r.act == p.act || p.act == "*"))
+`
+
+// enforcer синхронизирован: политики меняются через API во время обработки запросов
+var enforcer *casbin.SyncedEnforcer
+
+// InitCasbin создаёт enforcer, политики которого хранятся в таблице casbin_rule
+func InitCasbin(db *sqlx.DB) error {
+	return initEnforcer(infraRepo.NewCasbinAdapter(db))
+}
+
+func initEnforcer(adapter persist.Adapter) error {
+	m, err := model.NewModelFromString(rbacModel)
+	if err != nil {
+		return err
+	}
+
+	e, err := casbin.NewSyncedEnforcer(m, adapter)
 	if err != nil {
 		return err
 	}
 
 	// Загружаем политики
-	if err := enforcer.LoadPolicy(); err != nil {
+	if err := e.LoadPolicy(); err != nil {
 		return err
 	}
 
+	enforcer = e
 	return nil
 }
 
 func CasbinMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Получаем роль пользователя из контекста
-		userRole, exists := c.Get("user_role")
+		userRole, exists := c.Get(ContextUserRole)
 		if !exists {
 			log.Printf("Role not found in context")
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
@@ -40,13 +71,10 @@ func CasbinMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Проверяем разрешения
-		obj := c.Request.URL.Path
+		// Проверяем разрешения по шаблону маршрута, а не по конкретному URL
+		obj := c.FullPath()
 		act := c.Request.Method
 
-		log.Printf("Casbin middleware - Role from context: %v (type: %T)", userRole, userRole)
-		log.Printf("Checking permissions for role: %v, path: %s, method: %s", userRole, obj, act)
-
 		// Преобразуем роль в строку, если это не строка
 		roleStr, ok := userRole.(string)
 		if !ok {
@@ -66,36 +94,67 @@ func CasbinMiddleware() gin.HandlerFunc {
 
 		if !ok {
 			log.Printf("Access denied for role: %v, path: %s, method: %s", roleStr, obj, act)
-			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: insufficient rights"})
 			c.Abort()
 			return
 		}
 
-		log.Printf("Access granted for role: %v, path: %s, method: %s", roleStr, obj, act)
 		c.Next()
 	}
 }
 
-// AddPolicy добавляет новую политику
-func AddPolicy(sub, obj, act string) error {
-	_, err := enforcer.AddPolicy(sub, obj, act)
-	return err
+// ReloadPolicy перечитывает политики из базы (например, после изменений на другом экземпляре)
+func ReloadPolicy() error {
+	return enforcer.LoadPolicy()
+}
+
+// GetPolicies возвращает все правила доступа в виде [роль, маршрут, метод]
+func GetPolicies() ([][]string, error) {
+	return enforcer.GetPolicy()
+}
+
+// GetRoleInheritance возвращает пары [роль, родительская роль]
+func GetRoleInheritance() ([][]string, error) {
+	return enforcer.GetGroupingPolicy()
+}
+
+// GetAllRoles возвращает все роли, упомянутые в политиках
+func GetAllRoles() ([]string, error) {
+	subjects, err := enforcer.GetAllSubjects()
+	if err != nil {
+		return nil, err
+	}
+	roles, err := enforcer.GetAllRoles()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	result := []string{}
+	for _, role := range append(subjects, roles...) {
+		if !seen[role] {
+			seen[role] = true
+			result = append(result, role)
+		}
+	}
+	return result, nil
+}
+
+// AddPolicy добавляет новую политику. Возвращает false, если она уже существовала
+func AddPolicy(sub, obj, act string) (bool, error) {
+	return enforcer.AddPolicy(sub, obj, act)
 }
 
-// RemovePolicy удаляет политику
-func RemovePolicy(sub, obj, act string) error {
-	_, err := enforcer.RemovePolicy(sub, obj, act)
-	return err
+// RemovePolicy удаляет политику. Возвращает false, если её не было
+func RemovePolicy(sub, obj, act string) (bool, error) {
+	return enforcer.RemovePolicy(sub, obj, act)
 }
 
-// AddRoleForUser назначает роль пользователю
-func AddRoleForUser(user, role string) error {
-	_, err := enforcer.AddRoleForUser(user, role)
-	return err
+// AddRoleInheritance даёт роли role все права роли parent
+func AddRoleInheritance(role, parent string) (bool, error) {
+	return enforcer.AddGroupingPolicy(role, parent)
 }
 
-// RemoveRoleForUser удаляет роль у пользователя
-func RemoveRoleForUser(user, role string) error {
-	_, err := enforcer.DeleteRoleForUser(user, role)
-	return err
+// RemoveRoleInheritance отменяет наследование прав
+func RemoveRoleInheritance(role, parent string) (bool, error) {
+	return enforcer.RemoveGroupingPolicy(role, parent)
 }
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"sync"
+	"testing"
+
+	stringadapter "github.com/casbin/casbin/v2/persist/string-adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACModel(t *testing.T) {
+	saved := enforcer
+	t.Cleanup(func() { enforcer = saved })
+
+	require.NoError(t, initEnforcer(stringadapter.NewAdapter(`
+p, student, /students/:id, GET
+p, manager, /students/:id, *
+p, teacher, /marks/*, GET
+g, dean, manager
+`)))
+
+	cases := []struct {
+		name    string
+		role    string
+		path    string
+		method  string
+		allowed bool
+	}{
+		{"Allowed Role And Method", "student", "/students/:id", "GET", true},
+		{"Wrong Method", "student", "/students/:id", "PUT", false},
+		{"Method Wildcard", "manager", "/students/:id", "DELETE", true},
+		{"Path Wildcard", "teacher", "/marks/course/:course_id", "GET", true},
+		{"Inherited Role", "dean", "/students/:id", "PUT", true},
+		{"Admin Is Superuser", "admin", "/api/policies", "POST", true},
+		{"Unknown Role", "guest", "/students/:id", "GET", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := enforcer.Enforce(tc.role, tc.path, tc.method)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.allowed, ok)
+		})
+	}
+
+	t.Run("Policy Changes Apply Immediately", func(t *testing.T) {
+		ok, err := enforcer.Enforce("student", "/courses/", "GET")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		_, err = enforcer.AddPolicy("student", "/courses/", "GET")
+		require.NoError(t, err)
+
+		ok, err = enforcer.Enforce("student", "/courses/", "GET")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+	t.Run("Policy Changes During Requests", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := enforcer.Enforce("teacher", "/marks/course/:course_id", "GET")
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := AddPolicy("teacher", "/rooms/", "GET")
+				assert.NoError(t, err)
+				_, err = RemovePolicy("teacher", "/rooms/", "GET")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		ok, err := enforcer.Enforce("teacher", "/marks/course/:course_id", "GET")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,20 @@
+package auth
+
+import "github.com/gin-gonic/gin"
+
+// Ключи, под которыми AuthMiddleware кладёт данные из access-токена в контекст запроса
+const (
+	ContextUserID   = "user_id"
+	ContextUsername = "username"
+	ContextUserRole = "user_role"
+)
+
+// CurrentUserID возвращает ID пользователя из проверенного access-токена
+func CurrentUserID(c *gin.Context) string {
+	return c.GetString(ContextUserID)
+}
+
+// CurrentUserRole возвращает роль пользователя из проверенного access-токена
+func CurrentUserRole(c *gin.Context) string {
+	return c.GetString(ContextUserRole)
+}
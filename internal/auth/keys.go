@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"university_system/pkg/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// signingKey — один ключ из набора: чем подписывать и чем проверять
+type signingKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private interface{}
+	Public  interface{}
+}
+
+// KeySet хранит активный ключ подписи access-токенов и ключи,
+// которые ещё принимаются при проверке (по заголовку kid)
+type KeySet struct {
+	active *signingKey
+	keys   map[string]*signingKey
+}
+
+var accessKeys = newHMACKeySet("default", AccessTokenSecret)
+
+func newHMACKeySet(kid string, secret []byte) *KeySet {
+	key := &signingKey{ID: kid, Method: jwt.SigningMethodHS256, Private: secret, Public: secret}
+	return &KeySet{active: key, keys: map[string]*signingKey{kid: key}}
+}
+
+// InitKeys загружает ключи подписи из конфигурации.
+// Без JWT_SECRET для HS256 остаются встроенные ключи — это годится только для разработки.
+func InitKeys(cfg config.JWTConfig) error {
+	if cfg.RefreshSecret != "" {
+		RefreshTokenSecret = []byte(cfg.RefreshSecret)
+	} else {
+		logrus.Warn("JWT_REFRESH_SECRET is not set, using built-in refresh token secret")
+	}
+
+	active, err := loadActiveKey(cfg)
+	if err != nil {
+		return err
+	}
+
+	set := &KeySet{active: active, keys: map[string]*signingKey{active.ID: active}}
+	if cfg.VerificationKeys != "" {
+		for _, entry := range strings.Split(cfg.VerificationKeys, ",") {
+			kid, path, ok := strings.Cut(strings.TrimSpace(entry), ":")
+			if !ok || kid == "" || path == "" {
+				return fmt.Errorf("invalid JWT_VERIFICATION_KEYS entry %q, expected kid:path", entry)
+			}
+			if _, exists := set.keys[kid]; exists {
+				return fmt.Errorf("duplicate key id %q", kid)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("read verification key %q: %w", kid, err)
+			}
+			key, err := parseKeyMaterial(kid, data)
+			if err != nil {
+				return err
+			}
+			set.keys[kid] = key
+		}
+	}
+
+	accessKeys = set
+	logrus.Infof("JWT signing key %q (%s), %d key(s) accepted for verification", active.ID, active.Method.Alg(), len(set.keys))
+	return nil
+}
+
+func loadActiveKey(cfg config.JWTConfig) (*signingKey, error) {
+	switch cfg.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		secret := []byte(cfg.Secret)
+		if cfg.Secret == "" {
+			logrus.Warn("JWT_SECRET is not set, using built-in access token secret")
+			secret = AccessTokenSecret
+		}
+		return &signingKey{ID: cfg.KeyID, Method: jwt.SigningMethodHS256, Private: secret, Public: secret}, nil
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg():
+		if cfg.PrivateKeyFile == "" {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE is required for %s", cfg.Algorithm)
+		}
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read signing key: %w", err)
+		}
+		key, err := parseKeyMaterial(cfg.KeyID, data)
+		if err != nil {
+			return nil, err
+		}
+		if key.Private == nil {
+			return nil, errors.New("JWT_PRIVATE_KEY_FILE must contain a private key")
+		}
+		if key.Method.Alg() != cfg.Algorithm {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE holds a %s key, JWT_ALGORITHM is %s", key.Method.Alg(), cfg.Algorithm)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q", cfg.Algorithm)
+	}
+}
+
+// parseKeyMaterial определяет тип ключа по содержимому: PEM с RSA или Ed25519
+// (закрытый или открытый), иначе — общий секрет HS256
+func parseKeyMaterial(kid string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		secret := []byte(strings.TrimSpace(string(data)))
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("key %q is empty", kid)
+		}
+		return &signingKey{ID: kid, Method: jwt.SigningMethodHS256, Private: secret, Public: secret}, nil
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %q: unsupported PEM block %q", kid, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", kid, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &signingKey{ID: kid, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &signingKey{ID: kid, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return &signingKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &signingKey{ID: kid, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %T", kid, parsed)
+	}
+}
+
+func (s *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.active.Method, claims)
+	token.Header["kid"] = s.active.ID
+	return token.SignedString(s.active.Private)
+}
+
+func (s *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	key := s.active
+	if kid, ok := token.Header["kid"].(string); ok {
+		key, ok = s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("Unknown key id: %v", kid)
+		}
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Public, nil
+}
+
+// JWK — открытый ключ в формате RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet — ответ эндпоинта /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicJWKS возвращает открытые ключи проверки access-токенов.
+// Симметричные ключи HS256 не публикуются.
+func (s *KeySet) PublicJWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range s.keys {
+		enc := base64.RawURLEncoding
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA", Kid: key.ID, Use: "sig", Alg: key.Method.Alg(),
+				N: enc.EncodeToString(pub.N.Bytes()),
+				E: enc.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP", Kid: key.ID, Use: "sig", Alg: key.Method.Alg(),
+				Crv: "Ed25519", X: enc.EncodeToString(pub),
+			})
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"university_system/pkg/config"
+)
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return path
+}
+
+func restoreKeys(t *testing.T) {
+	saved, savedRefresh := accessKeys, RefreshTokenSecret
+	t.Cleanup(func() {
+		accessKeys, RefreshTokenSecret = saved, savedRefresh
+	})
+}
+
+func TestInitKeys_HS256(t *testing.T) {
+	restoreKeys(t)
+	require.NoError(t, InitKeys(config.JWTConfig{Algorithm: "HS256", KeyID: "k1", Secret: "s3cret"}))
+
+	tokenString, err := GenerateAccessToken("1", "admin", "admin")
+	require.NoError(t, err)
+
+	token, err := ParseAccessToken(tokenString)
+	require.NoError(t, err)
+	assert.True(t, token.Valid)
+	assert.Equal(t, "k1", token.Header["kid"])
+	assert.Empty(t, accessKeys.PublicJWKS().Keys)
+}
+
+func TestInitKeys_RotationRS256ToEdDSA(t *testing.T) {
+	restoreKeys(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPath := writePEM(t, "old.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	require.NoError(t, InitKeys(config.JWTConfig{Algorithm: "RS256", KeyID: "old", PrivateKeyFile: rsaPath}))
+	oldToken, err := GenerateAccessToken("5", "teacher1", "teacher")
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	edPath := writePEM(t, "new.pem", "PRIVATE KEY", der)
+	pubDer, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	oldPubPath := writePEM(t, "old.pub.pem", "PUBLIC KEY", pubDer)
+
+	require.NoError(t, InitKeys(config.JWTConfig{
+		Algorithm:        "EdDSA",
+		KeyID:            "new",
+		PrivateKeyFile:   edPath,
+		VerificationKeys: "old:" + oldPubPath,
+	}))
+
+	newToken, err := GenerateAccessToken("5", "teacher1", "teacher")
+	require.NoError(t, err)
+
+	for _, tokenString := range []string{oldToken, newToken} {
+		token, err := ParseAccessToken(tokenString)
+		require.NoError(t, err)
+		assert.True(t, token.Valid)
+	}
+
+	jwks := accessKeys.PublicJWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "new", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "old", jwks.Keys[1].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func TestParseAccessToken_RejectsUnknownKidAndAlgorithm(t *testing.T) {
+	restoreKeys(t)
+	require.NoError(t, InitKeys(config.JWTConfig{Algorithm: "HS256", KeyID: "k1", Secret: "s3cret"}))
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"role": "admin"})
+	unknown.Header["kid"] = "k2"
+	tokenString, err := unknown.SignedString([]byte("s3cret"))
+	require.NoError(t, err)
+	_, err = ParseAccessToken(tokenString)
+	assert.Error(t, err)
+
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"role": "admin"})
+	none.Header["kid"] = "k1"
+	tokenString, err = none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = ParseAccessToken(tokenString)
+	assert.Error(t, err)
+}
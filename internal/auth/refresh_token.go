@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// refreshSubject — данные пользователя, которые переносятся из токена в токен
+type refreshSubject struct {
+	UserID   string
+	Username string
+	Role     string
+}
+
+// issueRefreshToken выпускает новый refresh-токен в семействе familyID и сохраняет его
+func issueRefreshToken(ctx context.Context, repo repository.RefreshTokenRepository, subject refreshSubject, jti, familyID, userAgent, ip string) (string, error) {
+	now := time.Now().UTC()
+	record := &models.RefreshToken{
+		JTI:       jti,
+		FamilyID:  familyID,
+		UserID:    subject.UserID,
+		UserAgent: userAgent,
+		IPAddress: ip,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(RefreshTokenTTL),
+	}
+
+	token, err := GenerateRefreshToken(subject.UserID, subject.Username, subject.Role, jti, record.ExpiresAt)
+	if err != nil {
+		return "", err
+	}
+	if err := repo.CreateRefreshToken(ctx, record); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// startRefreshTokenFamily выпускает первый refresh-токен нового семейства (при логине)
+func startRefreshTokenFamily(ctx context.Context, repo repository.RefreshTokenRepository, subject refreshSubject, userAgent, ip string) (string, error) {
+	jti, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+	familyID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+	return issueRefreshToken(ctx, repo, subject, jti, familyID, userAgent, ip)
+}
+
+// lookupRefreshToken проверяет подпись токена и находит его запись в хранилище
+func lookupRefreshToken(ctx context.Context, repo repository.RefreshTokenRepository, tokenString string) (*models.RefreshToken, error) {
+	token, err := ParseRefreshToken(tokenString)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return repo.GetRefreshTokenByJTI(ctx, jti)
+}
+
+// currentSubject перечитывает владельца токена из таблицы users, чтобы новые токены
+// получили его актуальные логин и роль. Токены удалённого пользователя отзываются.
+func currentSubject(ctx context.Context, repo repository.RefreshTokenRepository, users repository.UserRepository, record *models.RefreshToken) (refreshSubject, error) {
+	user, err := users.GetUserByID(ctx, record.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err := repo.RevokeRefreshTokenFamily(ctx, record.FamilyID); err != nil {
+			return refreshSubject{}, err
+		}
+		return refreshSubject{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return refreshSubject{}, err
+	}
+	return refreshSubject{UserID: user.ID, Username: user.Username, Role: user.Role}, nil
+}
+
+// rotateRefreshToken обменивает действующий refresh-токен на новый из того же семейства.
+// Повторное предъявление уже заменённого токена считается утечкой: всё семейство отзывается.
+func rotateRefreshToken(ctx context.Context, repo repository.RefreshTokenRepository, users repository.UserRepository, tokenString, userAgent, ip string) (string, refreshSubject, error) {
+	record, err := lookupRefreshToken(ctx, repo, tokenString)
+	if err != nil {
+		return "", refreshSubject{}, err
+	}
+
+	if record.RevokedAt != nil {
+		if record.ReplacedBy != nil {
+			if err := repo.RevokeRefreshTokenFamily(ctx, record.FamilyID); err != nil {
+				return "", refreshSubject{}, err
+			}
+			return "", refreshSubject{}, models.ErrRefreshTokenReused
+		}
+		return "", refreshSubject{}, models.ErrRefreshTokenRevoked
+	}
+
+	subject, err := currentSubject(ctx, repo, users, record)
+	if err != nil {
+		return "", refreshSubject{}, err
+	}
+
+	newJTI, err := NewTokenID()
+	if err != nil {
+		return "", refreshSubject{}, err
+	}
+
+	replaced, err := repo.MarkRefreshTokenReplaced(ctx, record.JTI, newJTI)
+	if err != nil {
+		return "", refreshSubject{}, err
+	}
+	if !replaced {
+		// Токен успел использовать параллельный запрос
+		if err := repo.RevokeRefreshTokenFamily(ctx, record.FamilyID); err != nil {
+			return "", refreshSubject{}, err
+		}
+		return "", refreshSubject{}, models.ErrRefreshTokenReused
+	}
+
+	newToken, err := issueRefreshToken(ctx, repo, subject, newJTI, record.FamilyID, userAgent, ip)
+	if err != nil {
+		return "", refreshSubject{}, err
+	}
+	return newToken, subject, nil
+}
+
+// revokeRefreshToken завершает сессию, к которой относится токен (всё его семейство)
+func revokeRefreshToken(ctx context.Context, repo repository.RefreshTokenRepository, tokenString string) error {
+	record, err := lookupRefreshToken(ctx, repo, tokenString)
+	if err != nil {
+		return err
+	}
+	return repo.RevokeRefreshTokenFamily(ctx, record.FamilyID)
+}
+
+// revokeAllRefreshTokens завершает все сессии владельца токена
+func revokeAllRefreshTokens(ctx context.Context, repo repository.RefreshTokenRepository, tokenString string) error {
+	record, err := lookupRefreshToken(ctx, repo, tokenString)
+	if err != nil {
+		return err
+	}
+	return repo.RevokeUserRefreshTokens(ctx, record.UserID)
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockRefreshTokenRepo struct {
+	mock.Mock
+}
+
+func (m *mockRefreshTokenRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockRefreshTokenRepo) GetRefreshTokenByJTI(ctx context.Context, jti string) (*models.RefreshToken, error) {
+	args := m.Called(ctx, jti)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefreshToken), args.Error(1)
+}
+
+func (m *mockRefreshTokenRepo) MarkRefreshTokenReplaced(ctx context.Context, jti string, replacedBy string) (bool, error) {
+	args := m.Called(ctx, jti, replacedBy)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockRefreshTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *mockRefreshTokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type mockUserRepo struct {
+	mock.Mock
+}
+
+func (m *mockUserRepo) GetUsers(ctx context.Context) ([]models.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *mockUserRepo) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *mockUserRepo) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *mockUserRepo) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *mockUserRepo) UpdateUser(ctx context.Context, user models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *mockUserRepo) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func signedRefreshToken(t *testing.T, jti string) string {
+	token, err := GenerateRefreshToken("7", "student1", "student", jti, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	return token
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mockRefreshTokenRepo)
+		mockUsers := new(mockUserRepo)
+		stored := &models.RefreshToken{JTI: "old", FamilyID: "fam", UserID: "7"}
+		mockRepo.On("GetRefreshTokenByJTI", ctx, "old").Return(stored, nil).Once()
+		mockUsers.On("GetUserByID", ctx, "7").Return(&models.User{ID: "7", Username: "student1", Role: "student"}, nil).Once()
+		mockRepo.On("MarkRefreshTokenReplaced", ctx, "old", mock.AnythingOfType("string")).Return(true, nil).Once()
+		mockRepo.On("CreateRefreshToken", ctx, mock.MatchedBy(func(token *models.RefreshToken) bool {
+			return token.FamilyID == "fam" && token.UserID == "7" && token.UserAgent == "curl"
+		})).Return(nil).Once()
+
+		newToken, subject, err := rotateRefreshToken(ctx, mockRepo, mockUsers, signedRefreshToken(t, "old"), "curl", "127.0.0.1")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, newToken)
+		assert.Equal(t, refreshSubject{UserID: "7", Username: "student1", Role: "student"}, subject)
+		mockRepo.AssertExpectations(t)
+		mockUsers.AssertExpectations(t)
+	})
+
+	t.Run("Role Changed Since Login", func(t *testing.T) {
+		mockRepo := new(mockRefreshTokenRepo)
+		mockUsers := new(mockUserRepo)
+		stored := &models.RefreshToken{JTI: "old", FamilyID: "fam", UserID: "7"}
+		mockRepo.On("GetRefreshTokenByJTI", ctx, "old").Return(stored, nil).Once()
+		mockUsers.On("GetUserByID", ctx, "7").Return(&models.User{ID: "7", Username: "student1", Role: "teacher"}, nil).Once()
+		mockRepo.On("MarkRefreshTokenReplaced", ctx, "old", mock.AnythingOfType("string")).Return(true, nil).Once()
+		mockRepo.On("CreateRefreshToken", ctx, mock.Anything).Return(nil).Once()
+
+		_, subject, err := rotateRefreshToken(ctx, mockRepo, mockUsers, signedRefreshToken(t, "old"), "curl", "127.0.0.1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "teacher", subject.Role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Deleted User Revokes Family", func(t *testing.T) {
+		mockRepo := new(mockRefreshTokenRepo)
+		mockUsers := new(mockUserRepo)
+		stored := &models.RefreshToken{JTI: "old", FamilyID: "fam", UserID: "7"}
+		mockRepo.On("GetRefreshTokenByJTI", ctx, "old").Return(stored, nil).Once()
+		mockUsers.On("GetUserByID", ctx, "7").Return(nil, sql.ErrNoRows).Once()
+		mockRepo.On("RevokeRefreshTokenFamily", ctx, "fam").Return(nil).Once()
+
+		newToken, _, err := rotateRefreshToken(ctx, mockRepo, mockUsers, signedRefreshToken(t, "old"), "curl", "127.0.0.1")
+
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.Empty(t, newToken)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "MarkRefreshTokenReplaced", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reuse Revokes Family", func(t *testing.T) {
+		mockRepo := new(mockRefreshTokenRepo)
+		revokedAt := time.Now()
+		replacedBy := "next"
+		stored := &models.RefreshToken{JTI: "old", FamilyID: "fam", UserID: "7", RevokedAt: &revokedAt, ReplacedBy: &replacedBy}
+		mockRepo.On("GetRefreshTokenByJTI", ctx, "old").Return(stored, nil).Once()
+		mockRepo.On("RevokeRefreshTokenFamily", ctx, "fam").Return(nil).Once()
+
+		newToken, _, err := rotateRefreshToken(ctx, mockRepo, new(mockUserRepo), signedRefreshToken(t, "old"), "curl", "127.0.0.1")
+
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+		assert.Empty(t, newToken)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent Reuse Revokes Family", func(t *testing.T) {
+		mockRepo := new(mockRefreshTokenRepo)
+		mockUsers := new(mockUserRepo)
+		stored := &models.RefreshToken{JTI: "old", FamilyID: "fam", UserID: "7"}
+		mockRepo.On("GetRefreshTokenByJTI", ctx, "old").Return(stored, nil).Once()
+		mockUsers.On("GetUserByID", ctx, "7").Return(&models.User{ID: "7", Username: "student1", Role: "student"}, nil).Once()
+		mockRepo.On("MarkRefreshTokenReplaced", ctx, "old", mock.AnythingOfType("string")).Return(false, nil).Once()
+		mockRepo.On("RevokeRefreshTokenFamily", ctx, "fam").Return(nil).Once()
+
+		_, _, err := rotateRefreshToken(ctx, mockRepo, mockUsers, signedRefreshToken(t, "old"), "curl", "127.0.0.1")
+
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Logged Out Token", func(t *testing.T) {
+		mockRepo := new(mockRefreshTokenRepo)
+		revokedAt := time.Now()
+		stored := &models.RefreshToken{JTI: "old", FamilyID: "fam", UserID: "7", RevokedAt: &revokedAt}
+		mockRepo.On("GetRefreshTokenByJTI", ctx, "old").Return(stored, nil).Once()
+
+		_, _, err := rotateRefreshToken(ctx, mockRepo, new(mockUserRepo), signedRefreshToken(t, "old"), "curl", "127.0.0.1")
+
+		assert.ErrorIs(t, err, models.ErrRefreshTokenRevoked)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Signature", func(t *testing.T) {
+		mockRepo := new(mockRefreshTokenRepo)
+
+		_, _, err := rotateRefreshToken(ctx, mockRepo, new(mockUserRepo), "not-a-token", "curl", "127.0.0.1")
+
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRevokeAllRefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRefreshTokenRepo)
+	stored := &models.RefreshToken{JTI: "old", FamilyID: "fam", UserID: "7"}
+	mockRepo.On("GetRefreshTokenByJTI", ctx, "old").Return(stored, nil).Once()
+	mockRepo.On("RevokeUserRefreshTokens", ctx, "7").Return(nil).Once()
+
+	err := revokeAllRefreshTokens(ctx, mockRepo, signedRefreshToken(t, "old"))
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Сезоны и статусы академического периода
+const (
+	SeasonFall   = "fall"
+	SeasonSpring = "spring"
+	SeasonSummer = "summer"
+
+	TermStatusPlanned = "planned"
+	TermStatusActive  = "active"
+	TermStatusClosed  = "closed"
+)
+
+// AcademicTerm — академический период (семестр), в котором проходят курсы
+type AcademicTerm struct {
+	ID              string    `json:"id" db:"id"`
+	Year            int       `json:"year" db:"year" binding:"required"`
+	Season          string    `json:"season" db:"season" binding:"required,oneof=fall spring summer"`
+	StartDate       time.Time `json:"start_date" db:"start_date" binding:"required"`
+	EndDate         time.Time `json:"end_date" db:"end_date" binding:"required"`
+	EnrollmentStart time.Time `json:"enrollment_start" db:"enrollment_start" binding:"required"`
+	EnrollmentEnd   time.Time `json:"enrollment_end" db:"enrollment_end" binding:"required"`
+	GradingDeadline time.Time `json:"grading_deadline" db:"grading_deadline" binding:"required"`
+	// До add_drop_deadline курс можно бросить без следа в транскрипте,
+	// до withdrawal_deadline — отозвать с отметкой W. Пустые значения заполняются
+	// концом записи и концом периода соответственно.
+	AddDropDeadline    time.Time `json:"add_drop_deadline" db:"add_drop_deadline"`
+	WithdrawalDeadline time.Time `json:"withdrawal_deadline" db:"withdrawal_deadline"`
+	Status             string    `json:"status" db:"status"`
+	// Максимальная учебная нагрузка студента в кредитах за период, 0 — без ограничения
+	MaxCredits int    `json:"max_credits" db:"max_credits"`
+	CreatedAt  string `json:"created_at" db:"created_at"`
+	UpdatedAt  string `json:"updated_at" db:"updated_at"`
+}
+
+// IsEnrollmentOpen сообщает, открыта ли запись на курсы периода в момент at
+func (t AcademicTerm) IsEnrollmentOpen(at time.Time) bool {
+	return t.Status != TermStatusClosed && !at.Before(t.EnrollmentStart) && notAfterDay(at, t.EnrollmentEnd)
+}
+
+// CanDrop сообщает, можно ли в момент at бросить курс периода
+func (t AcademicTerm) CanDrop(at time.Time) bool {
+	return t.Status != TermStatusClosed && notAfterDay(at, t.AddDropDeadline)
+}
+
+// CanWithdraw сообщает, можно ли в момент at отозваться с курса периода
+func (t AcademicTerm) CanWithdraw(at time.Time) bool {
+	return t.Status != TermStatusClosed && !notAfterDay(at, t.AddDropDeadline) && notAfterDay(at, t.WithdrawalDeadline)
+}
+
+// IsCurrent сообщает, что запись на период уже началась, а срок отзыва ещё не прошёл
+func (t AcademicTerm) IsCurrent(at time.Time) bool {
+	return t.Status != TermStatusClosed && !at.Before(t.EnrollmentStart) && notAfterDay(at, t.WithdrawalDeadline)
+}
+
+// InSession сообщает, что период идёт: запись уже открылась, а сам период ещё не закончился
+func (t AcademicTerm) InSession(at time.Time) bool {
+	return t.Status != TermStatusClosed && !at.Before(t.EnrollmentStart) && notAfterDay(at, t.EndDate)
+}
+
+// notAfterDay сообщает, что момент at не позже конца дня deadline
+func notAfterDay(at, deadline time.Time) bool {
+	return at.Before(deadline.AddDate(0, 0, 1))
+}
+
+// CourseOffering — курс, открытый в конкретном академическом периоде.
+// К нему привязаны записи студентов и оценки.
+type CourseOffering struct {
+	ID        string `json:"id" db:"id"`
+	CourseID  string `json:"course_id" db:"course_id" binding:"required"`
+	TermID    string `json:"term_id" db:"term_id"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+var (
+	ErrTermNotFound       = errors.New("academic term not found")
+	ErrInvalidTerm        = errors.New("invalid academic term")
+	ErrOfferingNotFound   = errors.New("course offering not found")
+	ErrEnrollmentClosed   = errors.New("enrollment is closed")
+	ErrNoOpenEnrollment   = errors.New("no academic term is open for enrollment")
+	ErrCourseNotOffered   = errors.New("course is not offered in this term")
+	ErrStudentNotEnrolled = errors.New("student is not enrolled in this course")
+	ErrTermHasHistory     = errors.New("academic term has enrollments or marks")
+	ErrOfferingHasHistory = errors.New("course offering has enrollments or marks")
+)
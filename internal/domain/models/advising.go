@@ -0,0 +1,107 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Видимость заметки консультанта: private — только автору, advisors — консультантам студента,
+// менеджерам и администраторам, student — ещё и самому студенту
+const (
+	NoteVisibilityPrivate  = "private"
+	NoteVisibilityAdvisors = "advisors"
+	NoteVisibilityStudent  = "student"
+)
+
+// Признаки риска на панели консультанта
+const (
+	RiskProbation     = "probation"
+	RiskFailedCourse  = "failed_course"
+	RiskLowAttendance = "low_attendance"
+)
+
+// Статусы согласования записи. Если программа студента требует согласования (Program.AdvisorApproval)
+// и у студента есть консультант, запись на курс создаёт заявку (pending); консультант, менеджер
+// или администратор одобряет её — студент записывается на курс — или отклоняет.
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+)
+
+// AdvisorAssignment — консультант (преподаватель) студента по программе. По каждой программе
+// у студента один консультант; Current — программа назначения совпадает с текущей программой студента.
+type AdvisorAssignment struct {
+	ID          string    `json:"id" db:"id"`
+	StudentID   string    `json:"student_id" db:"student_id"`
+	TeacherID   string    `json:"teacher_id" db:"teacher_id" binding:"required"`
+	TeacherName string    `json:"teacher_name" db:"teacher_name"`
+	ProgramID   string    `json:"program_id" db:"program_id"`
+	ProgramName string    `json:"program_name" db:"program_name"`
+	Current     bool      `json:"current" db:"is_current"`
+	AssignedBy  *string   `json:"assigned_by,omitempty" db:"assigned_by"`
+	AssignedAt  time.Time `json:"assigned_at" db:"assigned_at"`
+}
+
+// Advisee — строка панели консультанта: студент с успеваемостью и признаками риска
+type Advisee struct {
+	StudentID        string   `json:"student_id" db:"student_id"`
+	Firstname        string   `json:"firstname" db:"firstname"`
+	Lastname         string   `json:"lastname" db:"lastname"`
+	Email            string   `json:"email" db:"email"`
+	StudentYear      int      `json:"student_year" db:"student_year"`
+	ProgramID        string   `json:"program_id" db:"program_id"`
+	ProgramName      string   `json:"program_name" db:"program_name"`
+	PendingApprovals int      `json:"pending_approvals" db:"pending_approvals"`
+	CumulativeGPA    float64  `json:"cumulative_gpa" db:"-"`
+	EarnedCredits    int      `json:"earned_credits" db:"-"`
+	Standing         string   `json:"standing" db:"-"`
+	AtRisk           bool     `json:"at_risk" db:"-"`
+	RiskFlags        []string `json:"risk_flags" db:"-"`
+}
+
+// AdvisingNote — заметка о консультации студента
+type AdvisingNote struct {
+	ID         string    `json:"id" db:"id"`
+	StudentID  string    `json:"student_id" db:"student_id"`
+	AuthorID   *string   `json:"author_id,omitempty" db:"author_id"`
+	AuthorRole string    `json:"author_role" db:"author_role"`
+	Visibility string    `json:"visibility" db:"visibility" binding:"omitempty,oneof=private advisors student"`
+	Body       string    `json:"body" db:"body" binding:"required"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// EnrollmentApproval — заявка студента на запись, ожидающая согласования консультанта
+type EnrollmentApproval struct {
+	ID          string     `json:"id" db:"id"`
+	StudentID   string     `json:"student_id" db:"student_id"`
+	CourseID    string     `json:"course_id" db:"course_id"`
+	CourseCode  string     `json:"course_code" db:"course_code"`
+	CourseName  string     `json:"course_name" db:"course_name"`
+	OfferingID  string     `json:"offering_id" db:"offering_id"`
+	TermID      string     `json:"term_id" db:"term_id"`
+	SectionID   *string    `json:"section_id,omitempty" db:"section_id"`
+	Status      string     `json:"status" db:"status"`
+	Comment     string     `json:"comment" db:"comment"`
+	DecidedBy   *string    `json:"decided_by,omitempty" db:"decided_by"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty" db:"decided_at"`
+	RequestedAt time.Time  `json:"requested_at" db:"requested_at"`
+	// Enrollment — итог записи после одобрения (студент может попасть в лист ожидания)
+	Enrollment *EnrollmentResult `json:"enrollment,omitempty" db:"-"`
+}
+
+// ApprovalDecision — комментарий консультанта к решению по заявке
+type ApprovalDecision struct {
+	Comment string `json:"comment"`
+}
+
+var (
+	ErrAdvisorAssignmentNotFound = errors.New("advisor assignment not found")
+	ErrAdvisorNotTeacher         = errors.New("advisor must be an existing teacher")
+	ErrAdvisingForbidden         = errors.New("forbidden: not an advisor of this student")
+	ErrAdvisingNoteNotFound      = errors.New("advising note not found")
+	ErrApprovalNotFound          = errors.New("enrollment approval not found")
+	ErrApprovalPending           = errors.New("enrollment is already awaiting advisor approval")
+	ErrApprovalDecided           = errors.New("enrollment approval has already been decided")
+)
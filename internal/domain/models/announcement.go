@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Охват объявления: весь университет, кафедра (её преподаватели, менеджер и студенты факультета кафедры)
+// или курс (записанные студенты и преподаватели курса)
+const (
+	AnnouncementScopeUniversity = "university"
+	AnnouncementScopeDepartment = "department"
+	AnnouncementScopeCourse     = "course"
+)
+
+// Адресаты объявления внутри охвата: все или только студенты либо преподаватели
+const (
+	AnnouncementAudienceAll      = "all"
+	AnnouncementAudienceStudents = "student"
+	AnnouncementAudienceTeachers = "teacher"
+)
+
+// Размер страницы ленты объявлений
+const (
+	DefaultFeedPageSize = 20
+	MaxFeedPageSize     = 100
+)
+
+// Announcement — объявление. Университетские и кафедральные объявления публикуют менеджеры
+// (кафедральные — только своей кафедры), объявления курса — ещё и преподаватели курса.
+// Read — объявление прочитано пользователем, для которого собрана лента.
+type Announcement struct {
+	ID             string    `json:"id" db:"id"`
+	Scope          string    `json:"scope" db:"scope" binding:"required,oneof=university department course"`
+	DepartmentID   *string   `json:"department_id,omitempty" db:"department_id"`
+	DepartmentName *string   `json:"department_name,omitempty" db:"department_name"`
+	CourseID       *string   `json:"course_id,omitempty" db:"course_id"`
+	CourseCode     *string   `json:"course_code,omitempty" db:"course_code"`
+	Audience       string    `json:"audience" db:"audience" binding:"omitempty,oneof=all student teacher"`
+	Title          string    `json:"title" db:"title" binding:"required,max=255"`
+	Body           string    `json:"body" db:"body" binding:"required"`
+	AuthorID       *string   `json:"author_id,omitempty" db:"author_id"`
+	AuthorName     string    `json:"author_name" db:"author_name"`
+	Read           bool      `json:"read" db:"is_read"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// AnnouncementFeed — страница ленты: объявления от новых к старым, всего объявлений в ленте и непрочитанных
+type AnnouncementFeed struct {
+	Items    []Announcement `json:"items"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+	Total    int            `json:"total"`
+	Unread   int            `json:"unread"`
+}
+
+var (
+	ErrAnnouncementNotFound  = errors.New("announcement not found")
+	ErrInvalidAnnouncement   = errors.New("invalid announcement")
+	ErrAnnouncementForbidden = errors.New("not allowed to manage this announcement")
+)
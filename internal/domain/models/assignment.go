@@ -0,0 +1,107 @@
+package models
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// Правила приёма работ после срока: принимать без штрафа (accept), не принимать (reject)
+// или принимать со штрафом за каждый начатый день опоздания (penalty)
+const (
+	LatePolicyAccept  = "accept"
+	LatePolicyReject  = "reject"
+	LatePolicyPenalty = "penalty"
+)
+
+// MaxSubmissionSize — максимальный размер файла работы
+const MaxSubmissionSize = 10 << 20
+
+// Assignment — задание курса в периоде. Если задан MarkType, баллы за задание входят
+// в соответствующую аттестацию при переносе оценок.
+type Assignment struct {
+	ID                 string    `json:"id" db:"id"`
+	OfferingID         string    `json:"offering_id" db:"offering_id"`
+	CourseID           string    `json:"course_id" db:"course_id"`
+	Title              string    `json:"title" db:"title" binding:"required"`
+	Description        string    `json:"description" db:"description"`
+	DueAt              time.Time `json:"due_at" db:"due_at" binding:"required"`
+	MaxPoints          float64   `json:"max_points" db:"max_points" binding:"required,gt=0"`
+	LatePolicy         string    `json:"late_policy" db:"late_policy" binding:"omitempty,oneof=accept reject penalty"`
+	LatePenaltyPercent float64   `json:"late_penalty_percent" db:"late_penalty_percent" binding:"min=0,max=100"`
+	MarkType           *string   `json:"mark_type,omitempty" db:"mark_type" binding:"omitempty,oneof=first_attestation second_attestation"`
+	CreatedBy          *string   `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Submission — работа студента по заданию. Пока работа не оценена, студент может заменить файл.
+// DaysLate — число начатых дней опоздания, FinalScore — балл с учётом штрафа за опоздание.
+type Submission struct {
+	ID           string     `json:"id" db:"id"`
+	AssignmentID string     `json:"assignment_id" db:"assignment_id"`
+	StudentID    string     `json:"student_id" db:"student_id"`
+	Firstname    string     `json:"firstname,omitempty" db:"firstname"`
+	Lastname     string     `json:"lastname,omitempty" db:"lastname"`
+	FileName     string     `json:"file_name" db:"file_name"`
+	ContentType  string     `json:"content_type" db:"content_type"`
+	Size         int64      `json:"size" db:"size"`
+	StorageKey   string     `json:"-" db:"storage_key"`
+	Comment      string     `json:"comment,omitempty" db:"comment"`
+	SubmittedAt  time.Time  `json:"submitted_at" db:"submitted_at"`
+	DaysLate     int        `json:"days_late" db:"days_late"`
+	Score        *float64   `json:"score,omitempty" db:"score"`
+	FinalScore   *float64   `json:"final_score,omitempty" db:"final_score"`
+	Feedback     string     `json:"feedback,omitempty" db:"feedback"`
+	GradedBy     *string    `json:"graded_by,omitempty" db:"graded_by"`
+	GradedAt     *time.Time `json:"graded_at,omitempty" db:"graded_at"`
+}
+
+// SubmissionUpload — загружаемый файл работы
+type SubmissionUpload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Data        io.Reader
+	Comment     string
+}
+
+// SubmissionFile — файл работы для скачивания; Data закрывает вызывающий
+type SubmissionFile struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Data        io.ReadCloser
+}
+
+// SubmissionGrade — оценка преподавателя за работу
+type SubmissionGrade struct {
+	Score    *float64 `json:"score" binding:"required,min=0"`
+	Feedback string   `json:"feedback"`
+}
+
+// AssignmentMarks — запрос на перенос баллов за задания в аттестацию
+type AssignmentMarks struct {
+	MarkType string `json:"mark_type" binding:"required,oneof=first_attestation second_attestation"`
+}
+
+// AssignmentMark — аттестационная оценка студента, посчитанная по заданиям
+type AssignmentMark struct {
+	StudentID string  `json:"student_id"`
+	Points    float64 `json:"points"`
+	MaxPoints float64 `json:"max_points"`
+	Value     float64 `json:"value"`
+}
+
+var (
+	ErrAssignmentNotFound  = errors.New("assignment not found")
+	ErrInvalidAssignment   = errors.New("invalid assignment")
+	ErrSubmissionNotFound  = errors.New("submission not found")
+	ErrSubmissionForbidden = errors.New("not allowed to access this submission")
+	ErrSubmissionClosed    = errors.New("the deadline has passed and late submissions are not accepted")
+	ErrSubmissionGraded    = errors.New("submission is already graded")
+	ErrSubmissionTooLarge  = errors.New("submission file is too large")
+	ErrScoreOutOfRange     = errors.New("score is out of range")
+	ErrNoLinkedAssignments = errors.New("no assignments are linked to this mark type")
+	ErrBlobNotFound        = errors.New("stored file not found")
+)
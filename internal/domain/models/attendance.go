@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Отметки посещаемости занятия
+const (
+	AttendancePresent = "present"
+	AttendanceAbsent  = "absent"
+	AttendanceLate    = "late"
+	AttendanceExcused = "excused"
+)
+
+// ClassSession — занятие курса в периоде; если задана секция, занятие только для её студентов
+type ClassSession struct {
+	ID         string    `json:"id" db:"id"`
+	OfferingID string    `json:"offering_id" db:"offering_id"`
+	CourseID   string    `json:"course_id" db:"course_id"`
+	SectionID  *string   `json:"section_id,omitempty" db:"section_id"`
+	StartsAt   time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt     time.Time `json:"ends_at" db:"ends_at"`
+	Topic      string    `json:"topic" db:"topic"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// SessionSchedule — еженедельное расписание, по которому создаются занятия. Weekdays — дни недели
+// (0 — воскресенье), StartTime — время начала "15:04". Без From/To занятия создаются на весь период.
+type SessionSchedule struct {
+	SectionID       *string    `json:"section_id"`
+	Weekdays        []int      `json:"weekdays" binding:"required,min=1,dive,min=0,max=6"`
+	StartTime       string     `json:"start_time" binding:"required"`
+	DurationMinutes int        `json:"duration_minutes" binding:"required,min=1,max=600"`
+	From            *time.Time `json:"from"`
+	To              *time.Time `json:"to"`
+}
+
+// AttendanceRecord — отметка студента на занятии. В списке занятия Status пуст, пока отметки нет.
+type AttendanceRecord struct {
+	SessionID string     `json:"session_id" db:"session_id"`
+	StudentID string     `json:"student_id" db:"student_id"`
+	Firstname string     `json:"firstname,omitempty" db:"firstname"`
+	Lastname  string     `json:"lastname,omitempty" db:"lastname"`
+	Status    string     `json:"status" db:"status"`
+	Note      string     `json:"note,omitempty" db:"note"`
+	MarkedBy  *string    `json:"marked_by,omitempty" db:"marked_by"`
+	MarkedAt  *time.Time `json:"marked_at,omitempty" db:"marked_at"`
+}
+
+// AttendanceMark — отметка одного студента в запросе преподавателя
+type AttendanceMark struct {
+	StudentID string `json:"student_id"`
+	Status    string `json:"status" binding:"required,oneof=present absent late excused"`
+	Note      string `json:"note"`
+}
+
+// BulkAttendance — отметки занятия разом. Если задан Default, он ставится всем студентам
+// занятия, которых нет в Records.
+type BulkAttendance struct {
+	Default string           `json:"default" binding:"omitempty,oneof=present absent late excused"`
+	Records []AttendanceMark `json:"records" binding:"dive"`
+}
+
+// AttendanceSummary — посещаемость студента по курсу в периоде. Процент считается по отмеченным
+// занятиям без уважительных пропусков; опоздание считается присутствием.
+type AttendanceSummary struct {
+	StudentID      string  `json:"student_id" db:"student_id"`
+	Firstname      string  `json:"firstname,omitempty" db:"firstname"`
+	Lastname       string  `json:"lastname,omitempty" db:"lastname"`
+	OfferingID     string  `json:"offering_id" db:"offering_id"`
+	CourseID       string  `json:"course_id" db:"course_id"`
+	Code           string  `json:"code" db:"code"`
+	Name           string  `json:"name" db:"name"`
+	TermID         string  `json:"term_id" db:"term_id"`
+	Present        int     `json:"present" db:"present"`
+	Late           int     `json:"late" db:"late"`
+	Absent         int     `json:"absent" db:"absent"`
+	Excused        int     `json:"excused" db:"excused"`
+	Percentage     float64 `json:"percentage" db:"-"`
+	BelowThreshold bool    `json:"below_threshold" db:"-"`
+}
+
+// AttendanceSettings — правило посещаемости: студент попадает в список менеджера, если его процент
+// ниже MinPercentage после как минимум MinSessions учтённых занятий
+type AttendanceSettings struct {
+	MinPercentage float64 `json:"min_percentage" db:"min_percentage" binding:"min=0,max=100"`
+	MinSessions   int     `json:"min_sessions" db:"min_sessions" binding:"min=0"`
+}
+
+var (
+	ErrSessionNotFound      = errors.New("class session not found")
+	ErrInvalidSchedule      = errors.New("invalid session schedule")
+	ErrNotInSession         = errors.New("student is not enrolled in this class session")
+	ErrSectionNotInOffering = errors.New("section does not belong to this course offering")
+)
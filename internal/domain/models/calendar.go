@@ -0,0 +1,31 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// CalendarFeed — персональная ссылка на iCalendar-ленту пользователя. Токен в ссылке заменяет
+// авторизацию, поэтому его можно перевыпустить, и старая ссылка перестанет работать.
+type CalendarFeed struct {
+	UserID    string    `json:"user_id" db:"user_id"`
+	Role      string    `json:"-" db:"role"`
+	Token     string    `json:"token" db:"token"`
+	URL       string    `json:"url" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Holiday — нерабочий день периода; занятия в этот день исключаются из расписания в ленте
+type Holiday struct {
+	ID     string    `json:"id" db:"id"`
+	TermID string    `json:"term_id" db:"term_id"`
+	Date   time.Time `json:"date" db:"date" binding:"required"`
+	Name   string    `json:"name" db:"name" binding:"required"`
+}
+
+var (
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+	ErrHolidayNotFound      = errors.New("holiday not found")
+	ErrHolidayExists        = errors.New("holiday on this date already exists")
+	ErrHolidayOutsideTerm   = errors.New("holiday is outside the term")
+)
@@ -1,13 +1,22 @@
 package models
 
+import "github.com/lib/pq"
+
 type Course struct {
-	ID          string  `json:"id" db:"id"`
-	Name        string  `json:"name" db:"name"`
-	Code        string  `json:"code" db:"code"`
-	Description string  `json:"description" db:"description"`
-	TeacherID   *uint   `json:"teacher_id,omitempty" db:"teacher_id"`
-	Credits     int     `json:"credits" db:"credits"`
-	CreatedAt   string  `json:"created_at" db:"created_at"`
-	UpdatedAt   string  `json:"updated_at" db:"updated_at"`
-	DeletedAt   *string `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID          string `json:"id" db:"id"`
+	Name        string `json:"name" db:"name"`
+	Code        string `json:"code" db:"code"`
+	Description string `json:"description" db:"description"`
+	TeacherID   *uint  `json:"teacher_id,omitempty" db:"teacher_id"`
+	Credits     int    `json:"credits" db:"credits"`
+	// DepartmentID — кафедра курса; курсы без кафедры изменяет только администратор
+	DepartmentID *string `json:"department_id,omitempty" db:"department_id"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+	UpdatedAt    string  `json:"updated_at" db:"updated_at"`
+	DeletedAt    *string `json:"deleted_at,omitempty" db:"deleted_at"`
+	// Условия записи: выражения над кодами курсов, например "(CS101 >= 70 or CS102) and MATH101"
+	Prerequisites    string         `json:"prerequisites" db:"prerequisites"`
+	Corequisites     string         `json:"corequisites" db:"corequisites"`
+	MinStudentYear   int            `json:"min_student_year" db:"min_student_year"`
+	AllowedFaculties pq.StringArray `json:"allowed_faculties" db:"allowed_faculties" swaggertype:"array,string"`
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Типы официальных документов
+const (
+	DocumentTypeTranscript            = "transcript"
+	DocumentTypeEnrollmentCertificate = "enrollment_certificate"
+)
+
+// IssuedDocument — выданный PDF-документ. Сам файл не хранится: подлинность
+// подтверждается кодом проверки и SHA-256 содержимого.
+type IssuedDocument struct {
+	ID          string    `json:"id" db:"id"`
+	Code        string    `json:"code" db:"code"`
+	Type        string    `json:"type" db:"type"`
+	StudentID   string    `json:"student_id" db:"student_id"`
+	StudentName string    `json:"student_name" db:"student_name"`
+	TermID      *string   `json:"term_id,omitempty" db:"term_id"`
+	ContentHash string    `json:"content_hash" db:"content_hash"`
+	IssuedBy    *string   `json:"issued_by,omitempty" db:"issued_by"`
+	IssuedAt    time.Time `json:"issued_at" db:"issued_at"`
+}
+
+// DocumentVerification — ответ публичной проверки документа по коду.
+// HashMatches заполняется, если при проверке передан хеш файла.
+type DocumentVerification struct {
+	Valid       bool      `json:"valid"`
+	Code        string    `json:"code"`
+	Type        string    `json:"type"`
+	StudentName string    `json:"student_name"`
+	TermID      *string   `json:"term_id,omitempty"`
+	IssuedAt    time.Time `json:"issued_at"`
+	ContentHash string    `json:"content_hash"`
+	HashMatches *bool     `json:"hash_matches,omitempty"`
+}
+
+var (
+	ErrDocumentNotFound  = errors.New("document not found")
+	ErrNotEnrolledInTerm = errors.New("student has no active enrollments in the current term")
+)
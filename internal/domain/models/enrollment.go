@@ -0,0 +1,51 @@
+package models
+
+import "errors"
+
+// Статусы записи студента на курс. Записи не удаляются: отписка и отзыв меняют статус,
+// а по закрытию периода запись становится completed или failed.
+// Waitlisted и pending_approval — только результаты попытки записи, в student_courses не хранятся.
+const (
+	EnrollmentStatusEnrolled        = "enrolled"
+	EnrollmentStatusDropped         = "dropped"
+	EnrollmentStatusWithdrawn       = "withdrawn"
+	EnrollmentStatusCompleted       = "completed"
+	EnrollmentStatusFailed          = "failed"
+	EnrollmentStatusWaitlisted      = "waitlisted"
+	EnrollmentStatusPendingApproval = "pending_approval"
+)
+
+// Enrollment — запись студента на курс в периоде
+type Enrollment struct {
+	StudentID       string  `json:"student_id" db:"student_id"`
+	CourseID        string  `json:"course_id" db:"course_id"`
+	CourseCode      string  `json:"course_code" db:"course_code"`
+	CourseName      string  `json:"course_name" db:"course_name"`
+	OfferingID      string  `json:"offering_id" db:"offering_id"`
+	TermID          string  `json:"term_id" db:"term_id"`
+	SectionID       *string `json:"section_id,omitempty" db:"section_id"`
+	Status          string  `json:"status" db:"status"`
+	EnrolledAt      string  `json:"enrolled_at" db:"enrolled_at"`
+	StatusChangedAt string  `json:"status_changed_at" db:"status_changed_at"`
+}
+
+// EnrollmentEvent — смена статуса записи; журнал сохраняет и повторные записи после отписки
+type EnrollmentEvent struct {
+	ID         string  `json:"id" db:"id"`
+	StudentID  string  `json:"student_id" db:"student_id"`
+	OfferingID string  `json:"offering_id" db:"offering_id"`
+	CourseID   string  `json:"course_id" db:"course_id"`
+	FromStatus *string `json:"from_status,omitempty" db:"from_status"`
+	ToStatus   string  `json:"to_status" db:"to_status"`
+	ChangedBy  *string `json:"changed_by,omitempty" db:"changed_by"`
+	ChangedAt  string  `json:"changed_at" db:"changed_at"`
+}
+
+var (
+	ErrEnrollmentNotFound  = errors.New("enrollment not found")
+	ErrNotActiveEnrollment = errors.New("enrollment is not active")
+	ErrAddDropClosed       = errors.New("add/drop deadline has passed")
+	ErrWithdrawalNotOpen   = errors.New("withdrawal opens after the add/drop deadline, drop the course instead")
+	ErrWithdrawalClosed    = errors.New("withdrawal deadline has passed")
+	ErrNoActiveTerm        = errors.New("no academic term is in progress")
+)
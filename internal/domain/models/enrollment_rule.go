@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// Виды правил записи на курс
+const (
+	RulePrerequisite = "prerequisite"
+	RuleCorequisite  = "corequisite"
+	RuleStudentYear  = "student_year"
+	RuleFaculty      = "faculty"
+	RuleCreditLimit  = "credit_limit"
+)
+
+// RuleViolation объясняет, какое правило записи не выполнено и чего не хватает
+type RuleViolation struct {
+	Rule       string   `json:"rule"`
+	Message    string   `json:"message"`
+	Expression string   `json:"expression,omitempty"`
+	Unmet      []string `json:"unmet,omitempty"`
+}
+
+// EnrollmentRuleError возвращается, когда студент не проходит правила записи на курс
+type EnrollmentRuleError struct {
+	Violations []RuleViolation `json:"violations"`
+}
+
+func (e *EnrollmentRuleError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return ErrEnrollmentRulesFailed.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *EnrollmentRuleError) Unwrap() error {
+	return ErrEnrollmentRulesFailed
+}
+
+var (
+	ErrEnrollmentRulesFailed = errors.New("enrollment rules are not satisfied")
+	ErrInvalidRequisite      = errors.New("invalid requisite expression")
+)
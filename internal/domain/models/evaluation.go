@@ -0,0 +1,114 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Типы вопросов анкеты: likert — оценка по шкале от LikertMin до LikertMax, text — свободный ответ
+const (
+	EvaluationQuestionLikert = "likert"
+	EvaluationQuestionText   = "text"
+)
+
+const (
+	LikertMin = 1
+	LikertMax = 5
+)
+
+// DefaultEvaluationMinResponses — минимум ответов, без которого результаты анкеты не показываются,
+// если в шаблоне не задан свой порог
+const DefaultEvaluationMinResponses = 5
+
+// EvaluationTemplate — шаблон анкеты оценки преподавания. MinResponses защищает анонимность:
+// при меньшем числе ответов результаты не показываются никому.
+type EvaluationTemplate struct {
+	ID           string               `json:"id" db:"id"`
+	Name         string               `json:"name" db:"name" binding:"required"`
+	Description  string               `json:"description" db:"description"`
+	MinResponses int                  `json:"min_responses" db:"min_responses" binding:"omitempty,min=3"`
+	Questions    []EvaluationQuestion `json:"questions" db:"-" binding:"required,min=1,dive"`
+	CreatedBy    *string              `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt    time.Time            `json:"created_at" db:"created_at"`
+}
+
+// EvaluationQuestion — вопрос анкеты; Position задаёт порядок вопросов в шаблоне
+type EvaluationQuestion struct {
+	ID       string `json:"id" db:"id"`
+	Position int    `json:"position" db:"position"`
+	Kind     string `json:"kind" db:"kind" binding:"required,oneof=likert text"`
+	Text     string `json:"text" db:"text" binding:"required"`
+	Required bool   `json:"required" db:"required"`
+}
+
+// CourseEvaluation — анкета, открытая для курса в периоде. Answered заполняется только в списке анкет студента.
+type CourseEvaluation struct {
+	ID            string    `json:"id" db:"id"`
+	TemplateID    string    `json:"template_id" db:"template_id"`
+	TemplateName  string    `json:"template_name" db:"template_name"`
+	OfferingID    string    `json:"offering_id" db:"offering_id"`
+	CourseID      string    `json:"course_id" db:"course_id"`
+	CourseCode    string    `json:"course_code" db:"course_code"`
+	CourseName    string    `json:"course_name" db:"course_name"`
+	TermID        string    `json:"term_id" db:"term_id"`
+	ClosesAt      time.Time `json:"closes_at" db:"closes_at"`
+	ResponseCount int       `json:"response_count" db:"response_count"`
+	EnrolledCount int       `json:"enrolled_count" db:"enrolled_count"`
+	Answered      bool      `json:"answered" db:"answered"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// OpenEvaluationsRequest — открыть анкету по шаблону для всех курсов периода до ClosesAt
+type OpenEvaluationsRequest struct {
+	TemplateID string    `json:"template_id" binding:"required"`
+	ClosesAt   time.Time `json:"closes_at" binding:"required"`
+}
+
+// EvaluationAnswer — ответ на вопрос: Rating для likert, Comment для text
+type EvaluationAnswer struct {
+	QuestionID string `json:"question_id" db:"question_id" binding:"required"`
+	Rating     *int   `json:"rating,omitempty" db:"rating"`
+	Comment    string `json:"comment,omitempty" db:"comment"`
+}
+
+// EvaluationResponse — заполненная студентом анкета. Ответы хранятся без ссылки на студента.
+type EvaluationResponse struct {
+	Answers []EvaluationAnswer `json:"answers" binding:"required,min=1,dive"`
+}
+
+// QuestionResult — сводка ответов на вопрос. Distribution[i] — число оценок LikertMin+i;
+// комментарии упорядочены по алфавиту, чтобы порядок не выдавал порядок ответов.
+type QuestionResult struct {
+	QuestionID   string   `json:"question_id"`
+	Position     int      `json:"position"`
+	Kind         string   `json:"kind"`
+	Text         string   `json:"text"`
+	Answers      int      `json:"answers"`
+	Average      *float64 `json:"average,omitempty"`
+	Distribution []int    `json:"distribution,omitempty"`
+	Comments     []string `json:"comments,omitempty"`
+}
+
+// EvaluationResults — обезличенные результаты анкеты курса
+type EvaluationResults struct {
+	EvaluationID  string           `json:"evaluation_id"`
+	OfferingID    string           `json:"offering_id"`
+	CourseCode    string           `json:"course_code"`
+	CourseName    string           `json:"course_name"`
+	TermID        string           `json:"term_id"`
+	ResponseCount int              `json:"response_count"`
+	EnrolledCount int              `json:"enrolled_count"`
+	Questions     []QuestionResult `json:"questions"`
+}
+
+var (
+	ErrEvaluationTemplateNotFound = errors.New("evaluation template not found")
+	ErrEvaluationTemplateInUse    = errors.New("evaluation template is used by course evaluations")
+	ErrEvaluationNotFound         = errors.New("course evaluation not found")
+	ErrInvalidEvaluation          = errors.New("invalid course evaluation")
+	ErrInvalidEvaluationAnswer    = errors.New("invalid evaluation answer")
+	ErrEvaluationClosed           = errors.New("course evaluation is closed")
+	ErrEvaluationAnswered         = errors.New("course evaluation has already been answered")
+	ErrEvaluationGradesNotLocked  = errors.New("evaluation results are available after grades are locked")
+	ErrNotEnoughResponses         = errors.New("not enough responses to show evaluation results anonymously")
+)
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Exam — экзамен курса в периоде. Аудитории и наблюдатели (преподаватели) задаются списками ID;
+// Rooms — подписи аудиторий вида "Main, A-101" в том же порядке, что и RoomIDs.
+type Exam struct {
+	ID              string         `json:"id" db:"id"`
+	OfferingID      string         `json:"offering_id" db:"offering_id"`
+	TermID          string         `json:"term_id" db:"term_id"`
+	CourseID        string         `json:"course_id" db:"course_id"`
+	Code            string         `json:"code" db:"code"`
+	Name            string         `json:"name" db:"name"`
+	Title           string         `json:"title" db:"title"`
+	StartsAt        time.Time      `json:"starts_at" db:"starts_at" binding:"required"`
+	DurationMinutes int            `json:"duration_minutes" db:"duration_minutes" binding:"required,min=1,max=600"`
+	EndsAt          time.Time      `json:"ends_at" db:"ends_at"`
+	RoomIDs         pq.StringArray `json:"room_ids" db:"room_ids" binding:"required,min=1" swaggertype:"array,string"`
+	Rooms           pq.StringArray `json:"rooms" db:"rooms" swaggertype:"array,string"`
+	ProctorIDs      pq.StringArray `json:"proctor_ids" db:"proctor_ids" swaggertype:"array,string"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+}
+
+// ExamRoom — аудитория экзамена с её вместимостью
+type ExamRoom struct {
+	RoomID   string `json:"room_id" db:"room_id"`
+	Building string `json:"building" db:"building"`
+	Name     string `json:"name" db:"name"`
+	Capacity int    `json:"capacity" db:"capacity"`
+}
+
+// ExamSeat — место студента на экзамене. В списке студентов экзамена до рассадки RoomID и Seat пусты.
+type ExamSeat struct {
+	ExamID    string `json:"exam_id" db:"exam_id"`
+	StudentID string `json:"student_id" db:"student_id"`
+	Firstname string `json:"firstname" db:"firstname"`
+	Lastname  string `json:"lastname" db:"lastname"`
+	RoomID    string `json:"room_id" db:"room_id"`
+	Building  string `json:"building" db:"building"`
+	RoomName  string `json:"room_name" db:"room_name"`
+	Seat      int    `json:"seat" db:"seat"`
+}
+
+// StudentExam — экзамен студента с его местом, если рассадка уже составлена
+type StudentExam struct {
+	Exam
+	SeatRoomID *string `json:"seat_room_id,omitempty" db:"seat_room_id"`
+	Building   string  `json:"building,omitempty" db:"building"`
+	RoomName   string  `json:"room_name,omitempty" db:"room_name"`
+	Seat       *int    `json:"seat,omitempty" db:"seat"`
+}
+
+// ExamConflict — пересечение экзамена с другим экзаменом: общая аудитория (room),
+// наблюдатель (teacher) или записанный на оба курса студент (student). Для нехватки мест
+// (capacity) SubjectID — число студентов, ConflictExamID пуст.
+type ExamConflict struct {
+	Kind               string    `json:"kind" db:"kind"`
+	SubjectID          string    `json:"subject_id" db:"subject_id"`
+	ExamID             string    `json:"exam_id,omitempty" db:"exam_id"`
+	ConflictExamID     string    `json:"conflict_exam_id,omitempty" db:"conflict_exam_id"`
+	ConflictCourseCode string    `json:"conflict_course_code,omitempty" db:"conflict_course_code"`
+	ConflictTitle      string    `json:"conflict_title,omitempty" db:"conflict_title"`
+	StartsAt           time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt             time.Time `json:"ends_at" db:"ends_at"`
+}
+
+// ExamConflictError возвращается, когда экзамен нельзя назначить из-за конфликтов
+type ExamConflictError struct {
+	Conflicts []ExamConflict
+}
+
+func (e *ExamConflictError) Error() string {
+	return fmt.Sprintf("%s: %d conflict(s)", ErrExamConflict, len(e.Conflicts))
+}
+
+func (e *ExamConflictError) Unwrap() error {
+	return ErrExamConflict
+}
+
+var (
+	ErrExamNotFound        = errors.New("exam not found")
+	ErrExamOutsideTerm     = errors.New("exam is outside the term")
+	ErrExamConflict        = errors.New("exam conflict")
+	ErrProctorNotFound     = errors.New("proctor is not a teacher")
+	ErrExamRoomNotFound    = errors.New("room is not used by this exam")
+	ErrNotEnoughExamSeats  = errors.New("exam rooms do not have enough seats")
+	ErrSeatingNotAllocated = errors.New("exam seating has not been allocated")
+)
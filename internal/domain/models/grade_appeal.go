@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Статусы апелляции. Студент подаёт апелляцию (submitted), преподаватель курса или менеджер
+// берёт её на рассмотрение (under_review) и закрывает решением: оценка оставлена (upheld)
+// или изменена (changed). Пока решения нет, студент может отозвать апелляцию (withdrawn).
+const (
+	AppealStatusSubmitted   = "submitted"
+	AppealStatusUnderReview = "under_review"
+	AppealStatusUpheld      = "upheld"
+	AppealStatusChanged     = "changed"
+	AppealStatusWithdrawn   = "withdrawn"
+)
+
+// MaxAppealAttachmentSize — максимальный размер файла, прикладываемого к апелляции
+const MaxAppealAttachmentSize = 5 << 20
+
+// GradeAppeal — апелляция студента на одну часть оценки курса в периоде
+type GradeAppeal struct {
+	ID             string          `json:"id" db:"id"`
+	StudentID      string          `json:"student_id" db:"student_id"`
+	OfferingID     string          `json:"offering_id" db:"offering_id" form:"offering_id" binding:"required"`
+	CourseID       string          `json:"course_id" db:"course_id"`
+	MarkType       string          `json:"mark_type" db:"mark_type" form:"mark_type" binding:"required,oneof=first_attestation second_attestation final"`
+	Reason         string          `json:"reason" db:"reason" form:"reason" binding:"required"`
+	OriginalValue  float64         `json:"original_value" db:"original_value"`
+	ResolvedValue  *float64        `json:"resolved_value,omitempty" db:"resolved_value"`
+	Status         string          `json:"status" db:"status"`
+	AttachmentName *string         `json:"attachment_name,omitempty" db:"attachment_name"`
+	AttachmentType *string         `json:"attachment_type,omitempty" db:"attachment_type"`
+	ResolvedBy     *string         `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty" db:"resolved_at"`
+	FiledAt        time.Time       `json:"filed_at" db:"filed_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+	Comments       []AppealComment `json:"comments,omitempty" db:"-"`
+}
+
+// AppealAttachment — файл, приложенный студентом к апелляции
+type AppealAttachment struct {
+	Name        string `db:"attachment_name"`
+	ContentType string `db:"attachment_type"`
+	Data        []byte `db:"attachment"`
+}
+
+// AppealComment — комментарий студента, преподавателя или менеджера к апелляции
+type AppealComment struct {
+	ID         string    `json:"id" db:"id"`
+	AppealID   string    `json:"appeal_id" db:"appeal_id"`
+	AuthorID   *string   `json:"author_id,omitempty" db:"author_id"`
+	AuthorRole string    `json:"author_role" db:"author_role"`
+	Body       string    `json:"body" db:"body" binding:"required"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// AppealResolution — решение по апелляции; NewValue обязателен, если оценка изменена
+type AppealResolution struct {
+	Outcome  string   `json:"outcome" binding:"required,oneof=upheld changed"`
+	NewValue *float64 `json:"new_value"`
+	Comment  string   `json:"comment"`
+}
+
+// IsOpen сообщает, ждёт ли апелляция решения
+func (a *GradeAppeal) IsOpen() bool {
+	return a.Status == AppealStatusSubmitted || a.Status == AppealStatusUnderReview
+}
+
+var (
+	ErrAppealNotFound           = errors.New("grade appeal not found")
+	ErrAppealExists             = errors.New("an open appeal for this mark already exists")
+	ErrAppealState              = errors.New("grade appeal is not in the required state")
+	ErrAppealForbidden          = errors.New("not allowed to access this grade appeal")
+	ErrNoMarkToAppeal           = errors.New("student has no mark for this course")
+	ErrInvalidAppealResolution  = errors.New("invalid appeal resolution")
+	ErrAttachmentTooLarge       = errors.New("attachment is too large")
+	ErrAppealAttachmentNotFound = errors.New("grade appeal has no attachment")
+	ErrAppealSheetLocked        = errors.New("grade sheet is submitted or locked, only a manager can change the mark")
+)
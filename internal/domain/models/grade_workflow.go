@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Состояния ведомости курса в периоде. Пока ведомость открыта, преподаватель выставляет
+// оценки напрямую; отправленную ведомость менеджер утверждает (locked) или возвращает на доработку.
+// Оценки утверждённой ведомости меняются только через запрос на изменение.
+const (
+	GradeSheetOpen      = "open"
+	GradeSheetSubmitted = "submitted"
+	GradeSheetLocked    = "locked"
+)
+
+// Статусы запроса на изменение оценки
+const (
+	GradeChangePending  = "pending"
+	GradeChangeApproved = "approved"
+	GradeChangeRejected = "rejected"
+)
+
+// GradeSheet — ведомость курса в периоде
+type GradeSheet struct {
+	OfferingID    string       `json:"offering_id" db:"offering_id"`
+	CourseID      string       `json:"course_id" db:"course_id"`
+	TermID        string       `json:"term_id" db:"term_id"`
+	Status        string       `json:"status" db:"status"`
+	SubmittedBy   *string      `json:"submitted_by,omitempty" db:"submitted_by"`
+	SubmittedAt   *time.Time   `json:"submitted_at,omitempty" db:"submitted_at"`
+	ReviewedBy    *string      `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt    *time.Time   `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewComment string       `json:"review_comment,omitempty" db:"review_comment"`
+	Marks         []GradedMark `json:"marks" db:"-"`
+}
+
+// GradeChangeRequest — запрос преподавателя на изменение оценки в утверждённой ведомости
+type GradeChangeRequest struct {
+	ID            string     `json:"id" db:"id"`
+	OfferingID    string     `json:"offering_id" db:"offering_id"`
+	StudentID     string     `json:"student_id" db:"student_id" binding:"required"`
+	MarkType      string     `json:"mark_type" db:"mark_type" binding:"required,oneof=first_attestation second_attestation final"`
+	OldValue      float64    `json:"old_value" db:"old_value"`
+	NewValue      float64    `json:"new_value" db:"new_value"`
+	Reason        string     `json:"reason" db:"reason" binding:"required"`
+	Status        string     `json:"status" db:"status"`
+	RequestedBy   *string    `json:"requested_by,omitempty" db:"requested_by"`
+	RequestedAt   time.Time  `json:"requested_at" db:"requested_at"`
+	ReviewedBy    *string    `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewComment string     `json:"review_comment,omitempty" db:"review_comment"`
+}
+
+// GradeReview — комментарий менеджера при утверждении, возврате ведомости или рассмотрении запроса
+type GradeReview struct {
+	Comment string `json:"comment"`
+}
+
+// MarkChange — запись журнала изменений оценок. OldValue пуст, если оценки ещё не было.
+type MarkChange struct {
+	ID              string    `json:"id" db:"id"`
+	StudentID       string    `json:"student_id" db:"student_id"`
+	OfferingID      string    `json:"offering_id" db:"offering_id"`
+	MarkType        string    `json:"mark_type" db:"mark_type"`
+	OldValue        *float64  `json:"old_value" db:"old_value"`
+	NewValue        float64   `json:"new_value" db:"new_value"`
+	ChangedBy       *string   `json:"changed_by,omitempty" db:"changed_by"`
+	ChangeRequestID *string   `json:"change_request_id,omitempty" db:"change_request_id"`
+	ChangedAt       time.Time `json:"changed_at" db:"changed_at"`
+}
+
+var (
+	ErrGradesLocked          = errors.New("grades for this course are submitted or locked")
+	ErrGradesNotLocked       = errors.New("grades are not locked, change the mark directly")
+	ErrGradeSheetState       = errors.New("grade sheet is not in the required state")
+	ErrGradeChangeNotFound   = errors.New("grade change request not found")
+	ErrGradeChangeNotPending = errors.New("grade change request has already been reviewed")
+)
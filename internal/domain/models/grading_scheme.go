@@ -0,0 +1,90 @@
+package models
+
+import "errors"
+
+// GradingScheme — схема оценивания: максимум баллов и вес каждой части оценки,
+// границы буквенных оценок и баллы GPA. Схема задаётся для курса, периода,
+// курса в конкретном периоде или для всех курсов (course_id и term_id пустые).
+type GradingScheme struct {
+	ID                      string        `json:"id" db:"id"`
+	Name                    string        `json:"name" db:"name" binding:"required"`
+	CourseID                *string       `json:"course_id,omitempty" db:"course_id"`
+	TermID                  *string       `json:"term_id,omitempty" db:"term_id"`
+	FirstAttestationMax     float64       `json:"first_attestation_max" db:"first_attestation_max" binding:"required,gt=0"`
+	SecondAttestationMax    float64       `json:"second_attestation_max" db:"second_attestation_max" binding:"required,gt=0"`
+	FinalMax                float64       `json:"final_max" db:"final_max" binding:"required,gt=0"`
+	FirstAttestationWeight  float64       `json:"first_attestation_weight" db:"first_attestation_weight" binding:"min=0"`
+	SecondAttestationWeight float64       `json:"second_attestation_weight" db:"second_attestation_weight" binding:"min=0"`
+	FinalWeight             float64       `json:"final_weight" db:"final_weight" binding:"min=0"`
+	Letters                 []LetterGrade `json:"letters" db:"-" binding:"required,min=1,dive"`
+	CreatedAt               string        `json:"created_at" db:"created_at"`
+	UpdatedAt               string        `json:"updated_at" db:"updated_at"`
+}
+
+// LetterGrade — буквенная оценка, которую получает итоговый балл не ниже MinTotal
+type LetterGrade struct {
+	Letter    string  `json:"letter" db:"letter" binding:"required"`
+	MinTotal  float64 `json:"min_total" db:"min_total" binding:"min=0,max=100"`
+	GPAPoints float64 `json:"gpa_points" db:"gpa_points" binding:"min=0"`
+}
+
+// DefaultGradingScheme — схема, которая действует, если для курса не задана своя:
+// 30 + 30 + 40 баллов без перевзвешивания и стандартная буквенная шкала
+func DefaultGradingScheme() *GradingScheme {
+	return &GradingScheme{
+		Name:                    "Default",
+		FirstAttestationMax:     30,
+		SecondAttestationMax:    30,
+		FinalMax:                40,
+		FirstAttestationWeight:  30,
+		SecondAttestationWeight: 30,
+		FinalWeight:             40,
+		Letters: []LetterGrade{
+			{Letter: "A", MinTotal: 95, GPAPoints: 4.0},
+			{Letter: "A-", MinTotal: 90, GPAPoints: 3.67},
+			{Letter: "B+", MinTotal: 85, GPAPoints: 3.33},
+			{Letter: "B", MinTotal: 80, GPAPoints: 3.0},
+			{Letter: "B-", MinTotal: 75, GPAPoints: 2.67},
+			{Letter: "C+", MinTotal: 70, GPAPoints: 2.33},
+			{Letter: "C", MinTotal: 65, GPAPoints: 2.0},
+			{Letter: "C-", MinTotal: 60, GPAPoints: 1.67},
+			{Letter: "D+", MinTotal: 55, GPAPoints: 1.33},
+			{Letter: "D", MinTotal: PassingTotal, GPAPoints: 1.0},
+			{Letter: "F", MinTotal: 0, GPAPoints: 0},
+		},
+	}
+}
+
+// ComponentMax возвращает максимум баллов для типа оценки
+func (s *GradingScheme) ComponentMax(markType string) (float64, error) {
+	switch markType {
+	case MarkTypeFirstAttestation:
+		return s.FirstAttestationMax, nil
+	case MarkTypeSecondAttestation:
+		return s.SecondAttestationMax, nil
+	case MarkTypeFinal:
+		return s.FinalMax, nil
+	}
+	return 0, ErrInvalidMarkType
+}
+
+// PassingTotal возвращает проходной итоговый балл схемы — нижнюю границу младшей буквенной оценки
+// с ненулевыми баллами GPA. Если таких оценок нет, действует порог по умолчанию.
+func (s *GradingScheme) PassingTotal() float64 {
+	passing, found := 0.0, false
+	for _, l := range s.Letters {
+		if l.GPAPoints > 0 && (!found || l.MinTotal < passing) {
+			passing, found = l.MinTotal, true
+		}
+	}
+	if !found {
+		return PassingTotal
+	}
+	return passing
+}
+
+var (
+	ErrGradingSchemeNotFound = errors.New("grading scheme not found")
+	ErrGradingSchemeExists   = errors.New("grading scheme for this course and term already exists")
+	ErrInvalidGradingScheme  = errors.New("invalid grading scheme")
+)
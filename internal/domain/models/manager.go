@@ -2,8 +2,10 @@ package models
 
 type Manager struct {
 	User
-	Department string  `json:"department" db:"department"`
-	CreatedAt  string  `json:"created_at" db:"created_at"`
-	UpdatedAt  string  `json:"updated_at" db:"updated_at"`
-	DeletedAt  *string `json:"deleted_at,omitempty" db:"deleted_at"`
+	Department string `json:"department" db:"department"`
+	// DepartmentID — кафедра, в пределах которой менеджер управляет людьми и курсами
+	DepartmentID *string `json:"department_id,omitempty" db:"department_id"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+	UpdatedAt    string  `json:"updated_at" db:"updated_at"`
+	DeletedAt    *string `json:"deleted_at,omitempty" db:"deleted_at"`
 }
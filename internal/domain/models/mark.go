@@ -6,6 +6,7 @@ type Mark struct {
 	ID                uint    `json:"id" db:"id"`
 	StudentID         uint    `json:"student_id" db:"student_id"`
 	CourseID          uint    `json:"course_id" db:"course_id"`
+	OfferingID        uint    `json:"offering_id" db:"offering_id"`
 	FirstAttestation  float64 `json:"first_attestation" db:"first_attestation"`
 	SecondAttestation float64 `json:"second_attestation" db:"second_attestation"`
 	FinalMark         float64 `json:"final_mark" db:"final_mark"`
@@ -13,7 +14,46 @@ type Mark struct {
 	UpdatedAt         string  `json:"updated_at" db:"updated_at"`
 }
 
+// Типы оценок, которые выставляет преподаватель
+const (
+	MarkTypeFirstAttestation  = "first_attestation"
+	MarkTypeSecondAttestation = "second_attestation"
+	MarkTypeFinal             = "final"
+)
+
+// PassingTotal — минимальный итоговый балл (из 100), при котором курс считается сданным
+// по схеме оценивания по умолчанию; для своей схемы см. GradingScheme.PassingTotal
+const PassingTotal = 50
+
+// GradedMark — оценки по курсу с итоговым баллом и буквенной оценкой по схеме оценивания;
+// Passed — итоговый балл не ниже проходного по этой схеме
+type GradedMark struct {
+	Mark
+	Total     float64 `json:"total"`
+	Letter    string  `json:"letter"`
+	GPAPoints float64 `json:"gpa_points"`
+	Passed    bool    `json:"passed"`
+}
+
+// CourseResult — оценки студента по курсу вместе с кодом курса и периодом.
+// Total и PassingTotal считаются по схеме оценивания курса в периоде.
+type CourseResult struct {
+	Mark
+	Code         string  `json:"code" db:"code"`
+	Credits      int     `json:"credits" db:"credits"`
+	TermID       string  `json:"term_id" db:"term_id"`
+	Total        float64 `json:"total" db:"total"`
+	PassingTotal float64 `json:"passing_total" db:"passing_total"`
+}
+
+// Passed сообщает, сдан ли курс по схеме оценивания, действовавшей в периоде
+func (r CourseResult) Passed() bool {
+	return r.Total >= r.PassingTotal
+}
+
 // Определение ошибок
 var (
-	ErrInvalidMarkType = errors.New("invalid mark type")
+	ErrInvalidMarkType  = errors.New("invalid mark type")
+	ErrMarkOutOfRange   = errors.New("mark is out of the allowed range")
+	ErrNotCourseTeacher = errors.New("teacher is not assigned to this course")
 )
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Статусы записи на консультацию
+const (
+	BookingStatusBooked    = "booked"
+	BookingStatusCancelled = "cancelled"
+)
+
+// OfficeHour — еженедельные часы консультаций преподавателя: день недели (0 — воскресенье), время "15:04"
+// и длительность одной записи. Часы делятся на слоты по SlotMinutes от начала; ValidFrom/ValidUntil
+// ограничивают даты, в которые часы действуют.
+type OfficeHour struct {
+	ID          string     `json:"id" db:"id"`
+	TeacherID   string     `json:"teacher_id" db:"teacher_id"`
+	TeacherName string     `json:"teacher_name" db:"teacher_name"`
+	Weekday     int        `json:"weekday" db:"weekday" binding:"min=0,max=6"`
+	StartTime   string     `json:"start_time" db:"start_time" binding:"required"`
+	EndTime     string     `json:"end_time" db:"end_time" binding:"required"`
+	SlotMinutes int        `json:"slot_minutes" db:"slot_minutes" binding:"required,min=5,max=240"`
+	Location    string     `json:"location" db:"location"`
+	ValidFrom   *time.Time `json:"valid_from,omitempty" db:"valid_from"`
+	ValidUntil  *time.Time `json:"valid_until,omitempty" db:"valid_until"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// OfficeHourSlot — слот консультации на конкретную дату; Available — слот свободен
+type OfficeHourSlot struct {
+	OfficeHourID string    `json:"office_hour_id"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	Location     string    `json:"location"`
+	Available    bool      `json:"available"`
+}
+
+// OfficeHourBooking — запись студента на слот консультации. Слот занимает только запись со статусом booked,
+// отменённая запись его освобождает.
+type OfficeHourBooking struct {
+	ID           string     `json:"id" db:"id"`
+	OfficeHourID string     `json:"office_hour_id" db:"office_hour_id"`
+	TeacherID    string     `json:"teacher_id" db:"teacher_id"`
+	TeacherName  string     `json:"teacher_name" db:"teacher_name"`
+	StudentID    string     `json:"student_id" db:"student_id"`
+	StudentName  string     `json:"student_name" db:"student_name"`
+	StartsAt     time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at" db:"ends_at"`
+	Location     string     `json:"location" db:"location"`
+	Topic        string     `json:"topic" db:"topic"`
+	Status       string     `json:"status" db:"status"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CancelledBy  *string    `json:"cancelled_by,omitempty" db:"cancelled_by"`
+}
+
+// BookSlotRequest — запись на слот: начало слота и тема консультации
+type BookSlotRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	Topic    string    `json:"topic" binding:"max=500"`
+}
+
+var (
+	ErrTeacherNotFound       = errors.New("teacher not found")
+	ErrOfficeHourNotFound    = errors.New("office hour not found")
+	ErrInvalidOfficeHour     = errors.New("invalid office hour")
+	ErrOfficeHourHasBookings = errors.New("office hour has upcoming bookings")
+	ErrOfficeHourOverlap     = errors.New("office hour overlaps another office hour of the teacher")
+	ErrOfficeHourForbidden   = errors.New("not allowed to manage this office hour")
+	ErrInvalidSlot           = errors.New("invalid office hour slot")
+	ErrSlotTaken             = errors.New("office hour slot is already booked")
+	ErrBookingOverlap        = errors.New("student already has a booking at this time")
+	ErrNotTeacherStudent     = errors.New("student is not enrolled in any course of this teacher")
+	ErrBookingNotFound       = errors.New("booking not found")
+	ErrBookingForbidden      = errors.New("not allowed to access this booking")
+	ErrBookingNotCancellable = errors.New("booking is already cancelled or has started")
+)
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Faculty — факультет. Студенты, программы и кафедры относятся к факультету; строковое название
+// факультета в профиле студента хранится для совместимости и всегда совпадает с Name
+type Faculty struct {
+	ID   string `json:"id" db:"id"`
+	Code string `json:"code" db:"code" binding:"required"`
+	Name string `json:"name" db:"name" binding:"required"`
+	// Aliases — прежние и сокращённые названия ("IT", "FIT"), по которым факультет находится при вводе строкой
+	Aliases   pq.StringArray `json:"aliases" db:"aliases" swaggertype:"array,string"`
+	CreatedAt string         `json:"created_at" db:"created_at"`
+	UpdatedAt string         `json:"updated_at" db:"updated_at"`
+}
+
+// Department — кафедра (отдел). К ней относятся преподаватели, менеджеры и курсы; менеджер
+// управляет только людьми и курсами своей кафедры и студентами её факультета
+type Department struct {
+	ID          string         `json:"id" db:"id"`
+	FacultyID   *string        `json:"faculty_id,omitempty" db:"faculty_id"`
+	FacultyName *string        `json:"faculty_name,omitempty" db:"faculty_name"`
+	Code        string         `json:"code" db:"code" binding:"required"`
+	Name        string         `json:"name" db:"name" binding:"required"`
+	Aliases     pq.StringArray `json:"aliases" db:"aliases" swaggertype:"array,string"`
+	CreatedAt   string         `json:"created_at" db:"created_at"`
+	UpdatedAt   string         `json:"updated_at" db:"updated_at"`
+}
+
+// MergeRequest — слияние дубликата SourceID в запись из пути запроса: ссылки переносятся,
+// код, название и псевдонимы дубликата становятся псевдонимами, дубликат удаляется
+type MergeRequest struct {
+	SourceID string `json:"source_id" binding:"required"`
+}
+
+var (
+	ErrFacultyNotFound          = errors.New("faculty not found")
+	ErrFacultyCodeTaken         = errors.New("faculty code is already taken")
+	ErrFacultyInUse             = errors.New("faculty has students or departments")
+	ErrDepartmentNotFound       = errors.New("department not found")
+	ErrDepartmentCodeTaken      = errors.New("department code is already taken")
+	ErrDepartmentInUse          = errors.New("department has staff or courses")
+	ErrMergeIntoItself          = errors.New("cannot merge a record into itself")
+	ErrManagerWithoutDepartment = errors.New("manager is not assigned to a department")
+	ErrOutsideDepartment        = errors.New("forbidden: outside of the manager's department")
+)
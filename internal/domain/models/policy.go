@@ -0,0 +1,21 @@
+package models
+
+// Policy — правило доступа Casbin: роль может выполнять Method на маршруте Path.
+// Path — шаблон маршрута gin (например /students/:id), Method — HTTP-метод или *.
+type Policy struct {
+	Role   string `json:"role" binding:"required"`
+	Path   string `json:"path" binding:"required"`
+	Method string `json:"method" binding:"required"`
+}
+
+// RoleInheritance — роль Role получает все права роли Parent
+type RoleInheritance struct {
+	Role   string `json:"role" binding:"required"`
+	Parent string `json:"parent" binding:"required"`
+}
+
+// RolesResponse — список ролей и их наследование
+type RolesResponse struct {
+	Roles       []string          `json:"roles"`
+	Inheritance []RoleInheritance `json:"inheritance"`
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Program — образовательная программа (направление подготовки), например "Computer Science, BSc"
+type Program struct {
+	ID          string `json:"id" db:"id"`
+	Code        string `json:"code" db:"code" binding:"required"`
+	Name        string `json:"name" db:"name" binding:"required"`
+	Degree      string `json:"degree" db:"degree" binding:"required,oneof=bachelor master doctorate"`
+	Faculty     string `json:"faculty" db:"faculty"`
+	Description string `json:"description" db:"description"`
+	// AdvisorApproval — запись студентов программы на курсы согласует их консультант
+	AdvisorApproval bool   `json:"advisor_approval" db:"advisor_approval"`
+	CreatedAt       string `json:"created_at" db:"created_at"`
+	UpdatedAt       string `json:"updated_at" db:"updated_at"`
+}
+
+// Curriculum — учебный план программы для набора определённого года: обязательные курсы,
+// группы курсов по выбору с минимумом кредитов и общее число кредитов для выпуска
+type Curriculum struct {
+	ID                string             `json:"id" db:"id"`
+	ProgramID         string             `json:"program_id" db:"program_id"`
+	Name              string             `json:"name" db:"name" binding:"required"`
+	CatalogYear       int                `json:"catalog_year" db:"catalog_year" binding:"required"`
+	TotalCredits      int                `json:"total_credits" db:"total_credits" binding:"required,min=1"`
+	RequiredCourseIDs pq.StringArray     `json:"required_course_ids" db:"required_course_ids" swaggertype:"array,string"`
+	RequiredCourses   []CurriculumCourse `json:"required_courses" db:"-"`
+	ElectiveGroups    []ElectiveGroup    `json:"elective_groups" db:"-"`
+	CreatedAt         string             `json:"created_at" db:"created_at"`
+	UpdatedAt         string             `json:"updated_at" db:"updated_at"`
+}
+
+// CurriculumCourse — курс учебного плана
+type CurriculumCourse struct {
+	CourseID string `json:"course_id" db:"course_id"`
+	Code     string `json:"code" db:"code"`
+	Name     string `json:"name" db:"name"`
+	Credits  int    `json:"credits" db:"credits"`
+}
+
+// ElectiveGroup — группа курсов по выбору: студент набирает в ней не меньше MinCredits кредитов
+type ElectiveGroup struct {
+	ID           string             `json:"id" db:"id"`
+	CurriculumID string             `json:"curriculum_id" db:"curriculum_id"`
+	Name         string             `json:"name" db:"name" binding:"required"`
+	MinCredits   int                `json:"min_credits" db:"min_credits" binding:"required,min=1"`
+	CourseIDs    pq.StringArray     `json:"course_ids" db:"course_ids" binding:"required,min=1" swaggertype:"array,string"`
+	Courses      []CurriculumCourse `json:"courses" db:"-"`
+}
+
+// StudentProgram — назначение студента на программу и учебный план
+type StudentProgram struct {
+	StudentID    string `json:"student_id"`
+	ProgramID    string `json:"program_id" binding:"required"`
+	CurriculumID string `json:"curriculum_id"`
+}
+
+// Состояние курса учебного плана в аудите
+const (
+	AuditCourseCompleted  = "completed"
+	AuditCourseInProgress = "in_progress"
+	AuditCourseFailed     = "failed"
+	AuditCourseNotTaken   = "not_taken"
+)
+
+// AuditCourse — курс учебного плана с лучшим результатом студента. Counted — курс засчитан в это
+// требование (каждый сданный курс засчитывается только в одно требование).
+type AuditCourse struct {
+	CurriculumCourse
+	Status  string   `json:"status"`
+	TermID  string   `json:"term_id,omitempty"`
+	Total   *float64 `json:"total,omitempty"`
+	Letter  string   `json:"letter,omitempty"`
+	Counted bool     `json:"counted"`
+}
+
+// AuditElectiveGroup — выполнение группы курсов по выбору
+type AuditElectiveGroup struct {
+	ID                string        `json:"id"`
+	Name              string        `json:"name"`
+	MinCredits        int           `json:"min_credits"`
+	EarnedCredits     int           `json:"earned_credits"`
+	InProgressCredits int           `json:"in_progress_credits"`
+	RemainingCredits  int           `json:"remaining_credits"`
+	Satisfied         bool          `json:"satisfied"`
+	Courses           []AuditCourse `json:"courses"`
+}
+
+// DegreeAudit — сравнение пройденных студентом курсов с учебным планом его программы
+type DegreeAudit struct {
+	StudentID         string               `json:"student_id"`
+	ProgramID         string               `json:"program_id"`
+	ProgramName       string               `json:"program_name"`
+	CurriculumID      string               `json:"curriculum_id"`
+	CurriculumName    string               `json:"curriculum_name"`
+	TotalCredits      int                  `json:"total_credits"`
+	EarnedCredits     int                  `json:"earned_credits"`
+	InProgressCredits int                  `json:"in_progress_credits"`
+	RemainingCredits  int                  `json:"remaining_credits"`
+	CumulativeGPA     float64              `json:"cumulative_gpa"`
+	RequiredCourses   []AuditCourse        `json:"required_courses"`
+	MissingRequired   []AuditCourse        `json:"missing_required"`
+	ElectiveGroups    []AuditElectiveGroup `json:"elective_groups"`
+	Complete          bool                 `json:"complete"`
+}
+
+var (
+	ErrProgramNotFound           = errors.New("program not found")
+	ErrProgramCodeTaken          = errors.New("program code is already taken")
+	ErrProgramInUse              = errors.New("program has assigned students")
+	ErrCurriculumNotFound        = errors.New("curriculum not found")
+	ErrCurriculumInUse           = errors.New("curriculum has assigned students")
+	ErrInvalidCurriculum         = errors.New("invalid curriculum")
+	ErrCurriculumCourseNotFound  = errors.New("curriculum references an unknown course")
+	ErrStudentProgramNotAssigned = errors.New("student is not assigned to a program")
+)
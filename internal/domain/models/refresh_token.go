@@ -0,0 +1,27 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// RefreshToken — серверная запись о выданном refresh-токене.
+// Все токены, полученные ротацией от одного логина, имеют общий FamilyID.
+type RefreshToken struct {
+	ID         uint       `json:"id" db:"id"`
+	JTI        string     `json:"jti" db:"jti"`
+	FamilyID   string     `json:"family_id" db:"family_id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	UserAgent  string     `json:"user_agent" db:"user_agent"`
+	IPAddress  string     `json:"ip_address" db:"ip_address"`
+	IssuedAt   time.Time  `json:"issued_at" db:"issued_at"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	ReplacedBy *string    `json:"replaced_by,omitempty" db:"replaced_by"`
+}
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenRevoked  = errors.New("refresh token revoked")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected")
+)
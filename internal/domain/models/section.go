@@ -0,0 +1,44 @@
+package models
+
+import "errors"
+
+// CourseSection — группа курса в периоде со своим преподавателем, аудиторией и лимитом мест
+type CourseSection struct {
+	ID         string  `json:"id" db:"id"`
+	OfferingID string  `json:"offering_id" db:"offering_id"`
+	Name       string  `json:"name" db:"name" binding:"required"`
+	TeacherID  *string `json:"teacher_id,omitempty" db:"teacher_id"`
+	Capacity   int     `json:"capacity" db:"capacity" binding:"required,min=1"`
+	Room       string  `json:"room" db:"room"`
+	Enrolled   int     `json:"enrolled" db:"enrolled"`
+	Waitlisted int     `json:"waitlisted" db:"waitlisted"`
+	CreatedAt  string  `json:"created_at" db:"created_at"`
+	UpdatedAt  string  `json:"updated_at" db:"updated_at"`
+}
+
+// EnrollmentResult — итог записи: студент записан, поставлен в лист ожидания или ждёт согласования консультанта
+type EnrollmentResult struct {
+	Status           string `json:"status"`
+	SectionID        string `json:"section_id,omitempty"`
+	WaitlistPosition int    `json:"waitlist_position,omitempty"`
+	ApprovalID       string `json:"approval_id,omitempty"`
+}
+
+// WaitlistEntry — место студента в листе ожидания секции (позиция начинается с 1)
+type WaitlistEntry struct {
+	SectionID   string `json:"section_id" db:"section_id"`
+	SectionName string `json:"section_name" db:"section_name"`
+	StudentID   string `json:"student_id" db:"student_id"`
+	CourseID    string `json:"course_id" db:"course_id"`
+	TermID      string `json:"term_id" db:"term_id"`
+	Position    int    `json:"position" db:"position"`
+	CreatedAt   string `json:"created_at" db:"created_at"`
+}
+
+var (
+	ErrSectionNotFound  = errors.New("course section not found")
+	ErrSectionRequired  = errors.New("course has several sections, section_id is required")
+	ErrAlreadyEnrolled  = errors.New("student is already enrolled in this course")
+	ErrNotOnWaitlist    = errors.New("student is not on the waitlist of this section")
+	ErrCapacityTooSmall = errors.New("capacity is less than the number of enrolled students")
+)
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Положение студента по итогам периода: к good и probation добавляются предупреждение
+// (GPA за период ниже порога, но меньше StandingRules.ProbationTerms периодов подряд) и список отличников
+const (
+	StandingWarning = "warning"
+	StandingHonors  = "honors"
+)
+
+// StandingRules — правила положения по итогам периода. Испытательный срок — GPA за период ниже ProbationGPA
+// ProbationTerms периодов с оценками подряд. Список отличников — верхние HonorsPercent процентов студентов
+// факультета, набравших за период не меньше HonorsMinCredits кредитов, при GPA за период не ниже HonorsMinGPA.
+type StandingRules struct {
+	ProbationGPA     float64 `json:"probation_gpa" db:"probation_gpa" binding:"min=0,max=4"`
+	ProbationTerms   int     `json:"probation_terms" db:"probation_terms" binding:"min=1"`
+	HonorsPercent    float64 `json:"honors_percent" db:"honors_percent" binding:"min=0,max=100"`
+	HonorsMinCredits int     `json:"honors_min_credits" db:"honors_min_credits" binding:"min=0"`
+	HonorsMinGPA     float64 `json:"honors_min_gpa" db:"honors_min_gpa" binding:"min=0,max=4"`
+}
+
+// StandingCandidate — студент с итоговыми оценками в периоде; отличники отбираются внутри факультета
+type StandingCandidate struct {
+	StudentID string  `db:"student_id"`
+	FacultyID *string `db:"faculty_id"`
+}
+
+// AcademicStanding — сохранённое положение студента по итогам периода
+type AcademicStanding struct {
+	ID               string    `json:"id" db:"id"`
+	StudentID        string    `json:"student_id" db:"student_id"`
+	Firstname        string    `json:"firstname,omitempty" db:"firstname"`
+	Lastname         string    `json:"lastname,omitempty" db:"lastname"`
+	FacultyID        *string   `json:"faculty_id,omitempty" db:"faculty_id"`
+	TermID           string    `json:"term_id" db:"term_id"`
+	Year             int       `json:"year" db:"year"`
+	Season           string    `json:"season" db:"season"`
+	Status           string    `json:"status" db:"status"`
+	TermGPA          float64   `json:"term_gpa" db:"term_gpa"`
+	CumulativeGPA    float64   `json:"cumulative_gpa" db:"cumulative_gpa"`
+	AttemptedCredits int       `json:"attempted_credits" db:"attempted_credits"`
+	EarnedCredits    int       `json:"earned_credits" db:"earned_credits"`
+	ComputedAt       time.Time `json:"computed_at" db:"computed_at"`
+}
+
+var ErrTermNotClosed = errors.New("academic standing is computed only for closed terms")
@@ -2,9 +2,17 @@ package models
 
 type Student struct {
 	User
-	StudentYear int     `json:"student_year" db:"student_year"`
-	Faculty     string  `json:"faculty" db:"faculty"`
-	CreatedAt   string  `json:"created_at" db:"created_at"`
-	UpdatedAt   string  `json:"updated_at" db:"updated_at"`
-	DeletedAt   *string `json:"deleted_at,omitempty" db:"deleted_at"`
+	StudentYear int    `json:"student_year" db:"student_year"`
+	Faculty     string `json:"faculty" db:"faculty"`
+	// FacultyID — ссылка на факультет; при сохранении Faculty заменяется его названием
+	FacultyID *string `json:"faculty_id,omitempty" db:"faculty_id"`
+	// Программа и учебный план назначаются отдельно, см. StudentProgram
+	ProgramID    *string `json:"program_id,omitempty" db:"program_id"`
+	CurriculumID *string `json:"curriculum_id,omitempty" db:"curriculum_id"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+	UpdatedAt    string  `json:"updated_at" db:"updated_at"`
+	DeletedAt    *string `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Standing — положение по последнему рассчитанному периоду, заполняется только в карточке студента
+	Standing string `json:"standing,omitempty" db:"standing"`
 }
@@ -2,9 +2,11 @@ package models
 
 type Teacher struct {
 	User
-	Department string  `json:"department" db:"department"`
-	Position   string  `json:"position" db:"position"`
-	CreatedAt  string  `json:"created_at" db:"created_at"`
-	UpdatedAt  string  `json:"updated_at" db:"updated_at"`
-	DeletedAt  *string `json:"deleted_at,omitempty" db:"deleted_at"`
+	Department string `json:"department" db:"department"`
+	// DepartmentID — ссылка на кафедру; при сохранении Department заменяется её названием
+	DepartmentID *string `json:"department_id,omitempty" db:"department_id"`
+	Position     string  `json:"position" db:"position"`
+	CreatedAt    string  `json:"created_at" db:"created_at"`
+	UpdatedAt    string  `json:"updated_at" db:"updated_at"`
+	DeletedAt    *string `json:"deleted_at,omitempty" db:"deleted_at"`
 }
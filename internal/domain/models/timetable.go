@@ -0,0 +1,102 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Виды конфликтов расписания
+const (
+	ConflictRoom     = "room"
+	ConflictTeacher  = "teacher"
+	ConflictStudent  = "student"
+	ConflictCapacity = "capacity"
+)
+
+// Room — аудитория с вместимостью и оборудованием (например, "projector", "computers")
+type Room struct {
+	ID        string         `json:"id" db:"id"`
+	Building  string         `json:"building" db:"building" binding:"required"`
+	Name      string         `json:"name" db:"name" binding:"required"`
+	Capacity  int            `json:"capacity" db:"capacity" binding:"required,min=1"`
+	Equipment pq.StringArray `json:"equipment" db:"equipment" swaggertype:"array,string"`
+	CreatedAt string         `json:"created_at" db:"created_at"`
+	UpdatedAt string         `json:"updated_at" db:"updated_at"`
+}
+
+// RoomFilter — отбор аудиторий: не меньше MinCapacity мест и всё оборудование из Equipment
+type RoomFilter struct {
+	MinCapacity int      `form:"min_capacity" binding:"min=0"`
+	Equipment   []string `form:"equipment"`
+}
+
+// SectionMeeting — еженедельное занятие секции: день недели (0 — воскресенье) и время "15:04".
+// Building и RoomName заполняются при чтении.
+type SectionMeeting struct {
+	ID        string  `json:"id" db:"id"`
+	SectionID string  `json:"section_id" db:"section_id"`
+	RoomID    *string `json:"room_id,omitempty" db:"room_id"`
+	Weekday   int     `json:"weekday" db:"weekday" binding:"min=0,max=6"`
+	StartTime string  `json:"start_time" db:"start_time" binding:"required"`
+	EndTime   string  `json:"end_time" db:"end_time" binding:"required"`
+	Building  string  `json:"building,omitempty" db:"building"`
+	RoomName  string  `json:"room_name,omitempty" db:"room_name"`
+}
+
+// TimetableEntry — строка недельного расписания студента, преподавателя или аудитории
+type TimetableEntry struct {
+	MeetingID   string  `json:"meeting_id" db:"meeting_id"`
+	SectionID   string  `json:"section_id" db:"section_id"`
+	SectionName string  `json:"section_name" db:"section_name"`
+	OfferingID  string  `json:"offering_id" db:"offering_id"`
+	CourseID    string  `json:"course_id" db:"course_id"`
+	Code        string  `json:"code" db:"code"`
+	Name        string  `json:"name" db:"name"`
+	Weekday     int     `json:"weekday" db:"weekday"`
+	StartTime   string  `json:"start_time" db:"start_time"`
+	EndTime     string  `json:"end_time" db:"end_time"`
+	RoomID      *string `json:"room_id,omitempty" db:"room_id"`
+	Building    string  `json:"building,omitempty" db:"building"`
+	RoomName    string  `json:"room_name,omitempty" db:"room_name"`
+}
+
+// ScheduleConflict — пересечение занятия MeetingID секции SectionID с другим занятием того же периода.
+// SubjectID — общая аудитория, преподаватель или студент; для нового занятия MeetingID пуст.
+// Конфликт вместимости означает, что лимит мест секции больше вместимости аудитории.
+type ScheduleConflict struct {
+	Kind                string `json:"kind" db:"kind"`
+	SubjectID           string `json:"subject_id" db:"subject_id"`
+	MeetingID           string `json:"meeting_id,omitempty" db:"meeting_id"`
+	SectionID           string `json:"section_id" db:"section_id"`
+	ConflictMeetingID   string `json:"conflict_meeting_id,omitempty" db:"conflict_meeting_id"`
+	ConflictSectionID   string `json:"conflict_section_id,omitempty" db:"conflict_section_id"`
+	ConflictSectionName string `json:"conflict_section_name,omitempty" db:"conflict_section_name"`
+	ConflictCourseCode  string `json:"conflict_course_code,omitempty" db:"conflict_course_code"`
+	Weekday             int    `json:"weekday" db:"weekday"`
+	StartTime           string `json:"start_time,omitempty" db:"start_time"`
+	EndTime             string `json:"end_time,omitempty" db:"end_time"`
+}
+
+// ScheduleConflictError возвращается, когда занятие нельзя поставить из-за конфликтов
+type ScheduleConflictError struct {
+	Conflicts []ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("%s: %d conflict(s)", ErrScheduleConflict, len(e.Conflicts))
+}
+
+func (e *ScheduleConflictError) Unwrap() error {
+	return ErrScheduleConflict
+}
+
+var (
+	ErrRoomNotFound       = errors.New("room not found")
+	ErrRoomExists         = errors.New("room with this name already exists in the building")
+	ErrRoomInUse          = errors.New("room is used in the timetable")
+	ErrMeetingNotFound    = errors.New("section meeting not found")
+	ErrInvalidMeetingTime = errors.New("invalid meeting time")
+	ErrScheduleConflict   = errors.New("schedule conflict")
+)
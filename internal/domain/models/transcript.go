@@ -0,0 +1,58 @@
+package models
+
+// Академическое положение студента, правила — см. StandingRules
+const (
+	StandingGood      = "good"
+	StandingProbation = "probation"
+)
+
+// CourseRecord — запись студента на курс в периоде вместе с оценками (нули, если оценок нет)
+type CourseRecord struct {
+	Mark
+	TermID     string `db:"term_id"`
+	TermYear   int    `db:"term_year"`
+	TermSeason string `db:"term_season"`
+	Code       string `db:"code"`
+	Name       string `db:"name"`
+	Credits    int    `db:"credits"`
+	Status     string `db:"status"`
+}
+
+// TranscriptCourse — строка транскрипта. Буквенная оценка есть только у завершённых курсов,
+// отозванные курсы отмечаются буквой W и в GPA не входят.
+type TranscriptCourse struct {
+	CourseID          uint     `json:"course_id"`
+	Code              string   `json:"code"`
+	Name              string   `json:"name"`
+	Credits           int      `json:"credits"`
+	Status            string   `json:"status"`
+	FirstAttestation  float64  `json:"first_attestation"`
+	SecondAttestation float64  `json:"second_attestation"`
+	FinalMark         float64  `json:"final_mark"`
+	Total             *float64 `json:"total,omitempty"`
+	Letter            string   `json:"letter,omitempty"`
+	GPAPoints         *float64 `json:"gpa_points,omitempty"`
+	CreditsEarned     int      `json:"credits_earned"`
+}
+
+// TranscriptTerm — курсы одного периода с GPA за период и накопленным GPA на его конец
+type TranscriptTerm struct {
+	TermID           string             `json:"term_id"`
+	Year             int                `json:"year"`
+	Season           string             `json:"season"`
+	Courses          []TranscriptCourse `json:"courses"`
+	AttemptedCredits int                `json:"attempted_credits"`
+	EarnedCredits    int                `json:"earned_credits"`
+	TermGPA          float64            `json:"term_gpa"`
+	CumulativeGPA    float64            `json:"cumulative_gpa"`
+	Standing         string             `json:"standing"`
+}
+
+// Transcript — академическая справка студента по периодам в хронологическом порядке
+type Transcript struct {
+	StudentID     string           `json:"student_id"`
+	Terms         []TranscriptTerm `json:"terms"`
+	EarnedCredits int              `json:"earned_credits"`
+	CumulativeGPA float64          `json:"cumulative_gpa"`
+	Standing      string           `json:"standing"`
+}
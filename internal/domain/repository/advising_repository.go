@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type AdvisingRepository interface {
+	GetStudentAdvisors(ctx context.Context, studentID string) ([]models.AdvisorAssignment, error)
+	// AssignAdvisor назначает консультанта студенту по программе, заменяя прежнего
+	AssignAdvisor(ctx context.Context, assignment *models.AdvisorAssignment) (*models.AdvisorAssignment, error)
+	RemoveAdvisor(ctx context.Context, studentID, programID string) error
+	// IsAdvisor — teacherID консультирует студента по его текущей программе
+	IsAdvisor(ctx context.Context, teacherID, studentID string) (bool, error)
+	GetAdvisees(ctx context.Context, teacherID string) ([]models.Advisee, error)
+
+	GetNotes(ctx context.Context, studentID string) ([]models.AdvisingNote, error)
+	GetNoteByID(ctx context.Context, id string) (*models.AdvisingNote, error)
+	CreateNote(ctx context.Context, note *models.AdvisingNote) (*models.AdvisingNote, error)
+	UpdateNote(ctx context.Context, note models.AdvisingNote) (*models.AdvisingNote, error)
+	DeleteNote(ctx context.Context, id string) error
+
+	// RequiresApproval — программа студента требует согласования записи и у студента есть консультант
+	RequiresApproval(ctx context.Context, studentID string) (bool, error)
+	CreateApproval(ctx context.Context, approval *models.EnrollmentApproval) (*models.EnrollmentApproval, error)
+	GetApprovalByID(ctx context.Context, id string) (*models.EnrollmentApproval, error)
+	// GetApprovals возвращает заявки со статусом status (пустой — все); с teacherID — только
+	// консультируемых им студентов, со studentID — только заявки этого студента,
+	// с facultyID — только студентов этого факультета
+	GetApprovals(ctx context.Context, status, teacherID, studentID, facultyID string) ([]models.EnrollmentApproval, error)
+	// DecideApproval закрывает заявку, если она ещё ожидает решения, иначе возвращает ErrApprovalDecided
+	DecideApproval(ctx context.Context, id, status, decidedBy, comment string) (*models.EnrollmentApproval, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type AnnouncementRepository interface {
+	CreateAnnouncement(ctx context.Context, announcement *models.Announcement) (*models.Announcement, error)
+	GetAnnouncementByID(ctx context.Context, id string) (*models.Announcement, error)
+	UpdateAnnouncement(ctx context.Context, announcement models.Announcement) (*models.Announcement, error)
+	DeleteAnnouncement(ctx context.Context, id string) error
+	// GetFeed возвращает страницу объявлений, адресованных пользователю с ролью role, с отметкой о прочтении
+	// и числом всех и непрочитанных объявлений ленты
+	GetFeed(ctx context.Context, userID, role string, unreadOnly bool, limit, offset int) (*models.AnnouncementFeed, error)
+	// IsVisible — объявление попадает в ленту пользователя
+	IsVisible(ctx context.Context, id, userID, role string) (bool, error)
+	MarkRead(ctx context.Context, id, userID string) error
+	// MarkAllRead отмечает прочитанными все объявления ленты пользователя и возвращает их число
+	MarkAllRead(ctx context.Context, userID, role string) (int, error)
+}
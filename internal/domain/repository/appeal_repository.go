@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type AppealRepository interface {
+	CreateAppeal(ctx context.Context, appeal *models.GradeAppeal, attachment *models.AppealAttachment) (*models.GradeAppeal, error)
+	GetAppealByID(ctx context.Context, id string) (*models.GradeAppeal, error)
+	GetAppeals(ctx context.Context, status, teacherID string) ([]models.GradeAppeal, error)
+	GetStudentAppeals(ctx context.Context, studentID string) ([]models.GradeAppeal, error)
+	GetAttachment(ctx context.Context, id string) (*models.AppealAttachment, error)
+	UpdateAppealStatus(ctx context.Context, id string, from []string, to, actorID string, resolvedValue *float64) error
+	ResolveAppeal(ctx context.Context, appeal *models.GradeAppeal, outcome, actorID string, resolvedValue float64, overrideSheet bool) error
+	AddComment(ctx context.Context, comment *models.AppealComment) (*models.AppealComment, error)
+	GetComments(ctx context.Context, appealID string) ([]models.AppealComment, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type AssignmentRepository interface {
+	GetOfferingAssignments(ctx context.Context, offeringID string) ([]models.Assignment, error)
+	GetAssignmentByID(ctx context.Context, id string) (*models.Assignment, error)
+	CreateAssignment(ctx context.Context, assignment *models.Assignment) (*models.Assignment, error)
+	UpdateAssignment(ctx context.Context, assignment models.Assignment) (*models.Assignment, error)
+	DeleteAssignment(ctx context.Context, id string) error
+	GetOfferingStudents(ctx context.Context, offeringID string) ([]string, error)
+	IsEnrolled(ctx context.Context, offeringID, studentID string) (bool, error)
+	GetSubmissions(ctx context.Context, assignmentID string) ([]models.Submission, error)
+	GetSubmission(ctx context.Context, assignmentID, studentID string) (*models.Submission, error)
+	GetSubmissionByID(ctx context.Context, id string) (*models.Submission, error)
+	GetStudentSubmissions(ctx context.Context, studentID string) ([]models.Submission, error)
+	SaveSubmission(ctx context.Context, submission *models.Submission) (*models.Submission, error)
+	GradeSubmission(ctx context.Context, id string, score, finalScore float64, feedback, gradedBy string) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type AttendanceRepository interface {
+	CreateSessions(ctx context.Context, sessions []models.ClassSession) ([]models.ClassSession, error)
+	GetOfferingSessions(ctx context.Context, offeringID string) ([]models.ClassSession, error)
+	GetSessionByID(ctx context.Context, id string) (*models.ClassSession, error)
+	DeleteSession(ctx context.Context, id string) error
+	GetSessionRoster(ctx context.Context, sessionID string) ([]models.AttendanceRecord, error)
+	MarkAttendance(ctx context.Context, records []models.AttendanceRecord) error
+	GetStudentAttendance(ctx context.Context, studentID string) ([]models.AttendanceSummary, error)
+	GetTermAttendance(ctx context.Context, termID string) ([]models.AttendanceSummary, error)
+	GetSettings(ctx context.Context) (*models.AttendanceSettings, error)
+	UpdateSettings(ctx context.Context, settings models.AttendanceSettings) (*models.AttendanceSettings, error)
+}
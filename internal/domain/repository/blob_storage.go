@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStorage хранит файлы по ключу вида "assignments/12/34/abcd". Реализация выбирается
+// при запуске: локальный диск или внешнее хранилище с тем же интерфейсом.
+type BlobStorage interface {
+	Put(ctx context.Context, key string, data io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
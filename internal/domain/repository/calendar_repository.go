@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type CalendarRepository interface {
+	GetFeedByUser(ctx context.Context, userID string) (*models.CalendarFeed, error)
+	GetFeedByToken(ctx context.Context, token string) (*models.CalendarFeed, error)
+	SaveFeed(ctx context.Context, userID, token string) (*models.CalendarFeed, error)
+	GetTermHolidays(ctx context.Context, termID string) ([]models.Holiday, error)
+	CreateHoliday(ctx context.Context, holiday *models.Holiday) (*models.Holiday, error)
+	DeleteHoliday(ctx context.Context, termID, id string) error
+}
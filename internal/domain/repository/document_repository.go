@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type DocumentRepository interface {
+	CreateDocument(ctx context.Context, document *models.IssuedDocument) (*models.IssuedDocument, error)
+	GetDocumentByCode(ctx context.Context, code string) (*models.IssuedDocument, error)
+	GetStudentDocuments(ctx context.Context, studentID string) ([]models.IssuedDocument, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"university_system/internal/domain/models"
+)
+
+type EvaluationRepository interface {
+	GetTemplates(ctx context.Context) ([]models.EvaluationTemplate, error)
+	GetTemplateByID(ctx context.Context, id string) (*models.EvaluationTemplate, error)
+	CreateTemplate(ctx context.Context, template *models.EvaluationTemplate) (*models.EvaluationTemplate, error)
+	DeleteTemplate(ctx context.Context, id string) error
+	// OpenEvaluations открывает анкету для каждого курса периода, у которого её ещё нет
+	OpenEvaluations(ctx context.Context, termID, templateID string, closesAt time.Time) error
+	GetTermEvaluations(ctx context.Context, termID string) ([]models.CourseEvaluation, error)
+	GetEvaluationByID(ctx context.Context, id string) (*models.CourseEvaluation, error)
+	// GetStudentEvaluations — анкеты курсов, на которые записан студент, с отметкой о заполнении
+	GetStudentEvaluations(ctx context.Context, studentID string) ([]models.CourseEvaluation, error)
+	GetTeacherEvaluations(ctx context.Context, teacherID string) ([]models.CourseEvaluation, error)
+	// IsParticipant — студент записан на курс анкеты (не выбыл и не отозвал запись)
+	IsParticipant(ctx context.Context, evaluationID, studentID string) (bool, error)
+	// SubmitResponse отмечает участие студента и сохраняет ответы отдельно от него
+	SubmitResponse(ctx context.Context, evaluationID, studentID string, answers []models.EvaluationAnswer) error
+	GetAnswers(ctx context.Context, evaluationID string) ([]models.EvaluationAnswer, error)
+}
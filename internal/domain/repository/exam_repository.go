@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type ExamRepository interface {
+	GetOfferingExams(ctx context.Context, offeringID string) ([]models.Exam, error)
+	GetTermExams(ctx context.Context, termID string) ([]models.Exam, error)
+	GetExamByID(ctx context.Context, id string) (*models.Exam, error)
+	CreateExam(ctx context.Context, exam *models.Exam) (*models.Exam, error)
+	UpdateExam(ctx context.Context, exam models.Exam) (*models.Exam, error)
+	DeleteExam(ctx context.Context, id string) error
+	CountOfferingStudents(ctx context.Context, offeringID string) (int, error)
+	FindConflicts(ctx context.Context, exam models.Exam) ([]models.ExamConflict, error)
+	GetTermConflicts(ctx context.Context, termID string) ([]models.ExamConflict, error)
+	GetExamRooms(ctx context.Context, examID string) ([]models.ExamRoom, error)
+	GetExamStudents(ctx context.Context, examID string) ([]models.ExamSeat, error)
+	SaveSeating(ctx context.Context, examID string, seats []models.ExamSeat) error
+	GetSeating(ctx context.Context, examID string) ([]models.ExamSeat, error)
+	GetStudentExams(ctx context.Context, studentID, termID string) ([]models.StudentExam, error)
+	GetTeacherExams(ctx context.Context, teacherID, termID string) ([]models.Exam, error)
+}
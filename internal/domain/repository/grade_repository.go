@@ -8,6 +8,18 @@ import (
 type GradeRepository interface {
 	GetStudentMarks(ctx context.Context, studentID string) ([]models.Mark, error)
 	GetCourseMarks(ctx context.Context, courseID string) ([]models.Mark, error)
-	AddMark(ctx context.Context, mark *models.Mark, markType string) error
+	AddMark(ctx context.Context, mark *models.Mark, markType string, changedBy string) error
+	AddMarks(ctx context.Context, offeringID string, marks []models.Mark, markType string, changedBy string) error
 	IsTeacherOfCourse(ctx context.Context, teacherID string, courseID string) (bool, error)
+	GetEnrollmentOfferingID(ctx context.Context, studentID string, courseID string) (uint, error)
+	GetStudentRecords(ctx context.Context, studentID string) ([]models.CourseRecord, error)
+	GetOfferingMarks(ctx context.Context, offeringID string) ([]models.Mark, error)
+	GetGradeSheet(ctx context.Context, offeringID string) (*models.GradeSheet, error)
+	UpdateGradeSheetStatus(ctx context.Context, offeringID, from, to, actorID, comment string) error
+	CreateGradeChangeRequest(ctx context.Context, request *models.GradeChangeRequest) (*models.GradeChangeRequest, error)
+	GetGradeChangeRequests(ctx context.Context, status string) ([]models.GradeChangeRequest, error)
+	GetGradeChangeRequest(ctx context.Context, id string) (*models.GradeChangeRequest, error)
+	ApproveGradeChange(ctx context.Context, id, reviewerID, comment string) error
+	RejectGradeChange(ctx context.Context, id, reviewerID, comment string) error
+	GetMarkChanges(ctx context.Context, offeringID string) ([]models.MarkChange, error)
 }
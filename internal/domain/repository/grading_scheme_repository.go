@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type GradingSchemeRepository interface {
+	GetGradingSchemes(ctx context.Context) ([]models.GradingScheme, error)
+	GetGradingSchemeByID(ctx context.Context, id string) (*models.GradingScheme, error)
+	CreateGradingScheme(ctx context.Context, scheme *models.GradingScheme) (*models.GradingScheme, error)
+	UpdateGradingScheme(ctx context.Context, scheme models.GradingScheme) (*models.GradingScheme, error)
+	DeleteGradingScheme(ctx context.Context, id string) error
+	// GetOfferingScheme подбирает схему для курса в периоде; ErrGradingSchemeNotFound, если подходящей нет
+	GetOfferingScheme(ctx context.Context, offeringID string) (*models.GradingScheme, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"university_system/internal/domain/models"
+)
+
+type OfficeHourRepository interface {
+	GetTeacherOfficeHours(ctx context.Context, teacherID string) ([]models.OfficeHour, error)
+	GetOfficeHourByID(ctx context.Context, id string) (*models.OfficeHour, error)
+	// CreateOfficeHour и UpdateOfficeHour проверяют пересечение с другими часами преподавателя
+	// в одной транзакции с записью: пересечение — ErrOfficeHourOverlap
+	CreateOfficeHour(ctx context.Context, hour *models.OfficeHour) (*models.OfficeHour, error)
+	// UpdateOfficeHour и DeleteOfficeHour не меняют часы с действующими записями, начинающимися
+	// не раньше now: ErrOfficeHourHasBookings
+	UpdateOfficeHour(ctx context.Context, hour *models.OfficeHour, now time.Time) (*models.OfficeHour, error)
+	DeleteOfficeHour(ctx context.Context, id string, now time.Time) error
+	// GetBookedSlots — начала занятых слотов часов в интервале [from, to)
+	GetBookedSlots(ctx context.Context, officeHourID string, from, to time.Time) ([]time.Time, error)
+	// CreateBooking занимает слот; занятый слот — ErrSlotTaken, пересекающаяся по времени запись студента —
+	// ErrBookingOverlap, слот, которого после изменения часов в них уже нет, — ErrInvalidSlot
+	CreateBooking(ctx context.Context, booking *models.OfficeHourBooking) (*models.OfficeHourBooking, error)
+	GetBookingByID(ctx context.Context, id string) (*models.OfficeHourBooking, error)
+	// GetStudentBookings и GetTeacherBookings возвращают записи по времени начала; from == nil — все записи
+	GetStudentBookings(ctx context.Context, studentID string, from *time.Time) ([]models.OfficeHourBooking, error)
+	GetTeacherBookings(ctx context.Context, teacherID string, from *time.Time) ([]models.OfficeHourBooking, error)
+	// CancelBooking отменяет действующую запись; отменённая запись — ErrBookingNotCancellable
+	CancelBooking(ctx context.Context, id, cancelledBy string, at time.Time) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type OrganizationRepository interface {
+	GetFaculties(ctx context.Context) ([]models.Faculty, error)
+	GetFacultyByID(ctx context.Context, id string) (*models.Faculty, error)
+	// FindFaculty ищет факультет по коду, названию или псевдониму без учёта регистра
+	FindFaculty(ctx context.Context, name string) (*models.Faculty, error)
+	CreateFaculty(ctx context.Context, faculty *models.Faculty) (*models.Faculty, error)
+	UpdateFaculty(ctx context.Context, faculty models.Faculty) (*models.Faculty, error)
+	DeleteFaculty(ctx context.Context, id string) error
+	// MergeFaculties переносит ссылки с дубликата sourceID на targetID и удаляет дубликат;
+	// aliases — новые псевдонимы targetID
+	MergeFaculties(ctx context.Context, targetID, sourceID string, aliases []string) error
+
+	// GetDepartments возвращает кафедры факультета facultyID или все кафедры, если он пуст
+	GetDepartments(ctx context.Context, facultyID string) ([]models.Department, error)
+	GetDepartmentByID(ctx context.Context, id string) (*models.Department, error)
+	FindDepartment(ctx context.Context, name string) (*models.Department, error)
+	CreateDepartment(ctx context.Context, department *models.Department) (*models.Department, error)
+	UpdateDepartment(ctx context.Context, department models.Department) (*models.Department, error)
+	DeleteDepartment(ctx context.Context, id string) error
+	MergeDepartments(ctx context.Context, targetID, sourceID string, aliases []string) error
+
+	// GetManagerDepartment возвращает кафедру менеджера или ErrManagerWithoutDepartment
+	GetManagerDepartment(ctx context.Context, managerID string) (*models.Department, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type ProgramRepository interface {
+	GetPrograms(ctx context.Context) ([]models.Program, error)
+	GetProgramByID(ctx context.Context, id string) (*models.Program, error)
+	CreateProgram(ctx context.Context, program *models.Program) (*models.Program, error)
+	UpdateProgram(ctx context.Context, program models.Program) (*models.Program, error)
+	DeleteProgram(ctx context.Context, id string) error
+	GetProgramCurricula(ctx context.Context, programID string) ([]models.Curriculum, error)
+	GetCurriculumByID(ctx context.Context, id string) (*models.Curriculum, error)
+	CreateCurriculum(ctx context.Context, curriculum *models.Curriculum) (*models.Curriculum, error)
+	UpdateCurriculum(ctx context.Context, curriculum models.Curriculum) (*models.Curriculum, error)
+	DeleteCurriculum(ctx context.Context, id string) error
+	AssignStudent(ctx context.Context, assignment models.StudentProgram) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshTokenByJTI(ctx context.Context, jti string) (*models.RefreshToken, error)
+	// MarkRefreshTokenReplaced отзывает токен и запоминает его преемника.
+	// Возвращает false, если токен уже был отозван (повторное использование).
+	MarkRefreshTokenReplaced(ctx context.Context, jti string, replacedBy string) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type RoomRepository interface {
+	GetRooms(ctx context.Context, filter models.RoomFilter) ([]models.Room, error)
+	GetRoomByID(ctx context.Context, id string) (*models.Room, error)
+	CreateRoom(ctx context.Context, room *models.Room) (*models.Room, error)
+	UpdateRoom(ctx context.Context, room models.Room) (*models.Room, error)
+	DeleteRoom(ctx context.Context, id string) error
+}
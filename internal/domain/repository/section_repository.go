@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type SectionRepository interface {
+	GetOfferingSections(ctx context.Context, offeringID string) ([]models.CourseSection, error)
+	GetSectionByID(ctx context.Context, id string) (*models.CourseSection, error)
+	CreateSection(ctx context.Context, section *models.CourseSection) (*models.CourseSection, error)
+	UpdateSection(ctx context.Context, section models.CourseSection, changedBy string) (*models.CourseSection, error)
+	DeleteSection(ctx context.Context, id string) error
+	EnrollInSection(ctx context.Context, studentID, sectionID, changedBy string) (*models.EnrollmentResult, error)
+	DropEnrollment(ctx context.Context, studentID, offeringID, changedBy string) error
+	GetSectionWaitlist(ctx context.Context, sectionID string) ([]models.WaitlistEntry, error)
+	GetStudentWaitlist(ctx context.Context, studentID string) ([]models.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, studentID, sectionID string) error
+}
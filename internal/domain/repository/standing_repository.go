@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type StandingRepository interface {
+	GetRules(ctx context.Context) (*models.StandingRules, error)
+	UpdateRules(ctx context.Context, rules models.StandingRules) (*models.StandingRules, error)
+	// GetTermCandidates возвращает студентов с завершёнными (completed или failed) курсами в периоде
+	GetTermCandidates(ctx context.Context, termID string) ([]models.StandingCandidate, error)
+	// SaveTermStandings заменяет положение студентов за период результатом нового расчёта
+	SaveTermStandings(ctx context.Context, termID string, standings []models.AcademicStanding) error
+	GetStudentStandings(ctx context.Context, studentID string) ([]models.AcademicStanding, error)
+	// GetTermStandings — положение за период с фильтрами по статусу и факультету (пустая строка — без фильтра)
+	GetTermStandings(ctx context.Context, termID, status, facultyID string) ([]models.AcademicStanding, error)
+}
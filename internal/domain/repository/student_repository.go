@@ -11,7 +11,13 @@ type StudentRepository interface {
 	CreateStudent(ctx context.Context, student *models.Student) (*models.Student, error)
 	UpdateStudent(ctx context.Context, student models.Student) (*models.Student, error)
 	DeleteStudent(ctx context.Context, id string) error
-	EnrollStudentToCourse(ctx context.Context, studentID, courseID string) error
+	EnrollStudentToCourse(ctx context.Context, studentID, courseID, offeringID, changedBy string) error
 	GetStudentCourses(ctx context.Context, studentID string) ([]models.Course, error)
+	GetTermCourses(ctx context.Context, studentID, termID string) ([]models.Course, error)
+	GetTermWaitlistCourses(ctx context.Context, studentID, termID string) ([]models.Course, error)
+	GetCompletedCourses(ctx context.Context, studentID, beforeTermID string) ([]models.CourseResult, error)
+	GetStudentEnrollments(ctx context.Context, studentID string) ([]models.Enrollment, error)
+	GetEnrollmentEvents(ctx context.Context, studentID string) ([]models.EnrollmentEvent, error)
+	WithdrawEnrollment(ctx context.Context, studentID, offeringID, changedBy string) error
 	CreateUserWithRole(ctx context.Context, user models.User, role string) (string, error)
 }
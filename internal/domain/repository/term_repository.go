@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type TermRepository interface {
+	GetTerms(ctx context.Context) ([]models.AcademicTerm, error)
+	GetTermByID(ctx context.Context, id string) (*models.AcademicTerm, error)
+	CreateTerm(ctx context.Context, term *models.AcademicTerm) (*models.AcademicTerm, error)
+	UpdateTerm(ctx context.Context, term models.AcademicTerm) (*models.AcademicTerm, error)
+	DeleteTerm(ctx context.Context, id string) error
+	CompleteTermEnrollments(ctx context.Context, termID string) error
+	GetTermOfferings(ctx context.Context, termID string) ([]models.CourseOffering, error)
+	GetOffering(ctx context.Context, termID, courseID string) (*models.CourseOffering, error)
+	GetOfferingByID(ctx context.Context, termID, offeringID string) (*models.CourseOffering, error)
+	CreateOffering(ctx context.Context, offering *models.CourseOffering) (*models.CourseOffering, error)
+	DeleteOffering(ctx context.Context, termID, offeringID string) error
+}
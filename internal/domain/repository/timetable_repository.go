@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"university_system/internal/domain/models"
+)
+
+type TimetableRepository interface {
+	GetSectionMeetings(ctx context.Context, sectionID string) ([]models.SectionMeeting, error)
+	GetMeetingByID(ctx context.Context, id string) (*models.SectionMeeting, error)
+	CreateMeeting(ctx context.Context, meeting *models.SectionMeeting) (*models.SectionMeeting, error)
+	UpdateMeeting(ctx context.Context, meeting models.SectionMeeting) (*models.SectionMeeting, error)
+	DeleteMeeting(ctx context.Context, id string) error
+	FindConflicts(ctx context.Context, meeting models.SectionMeeting) ([]models.ScheduleConflict, error)
+	GetTermConflicts(ctx context.Context, termID string) ([]models.ScheduleConflict, error)
+	GetStudentTimetable(ctx context.Context, studentID, termID string) ([]models.TimetableEntry, error)
+	GetTeacherTimetable(ctx context.Context, teacherID, termID string) ([]models.TimetableEntry, error)
+	GetRoomTimetable(ctx context.Context, roomID, termID string) ([]models.TimetableEntry, error)
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+// advisorSelect — назначения консультантов с именем преподавателя и названием программы
+const advisorSelect = `SELECT a.id, a.student_id, a.teacher_id, u.firstname || ' ' || u.lastname AS teacher_name,
+	a.program_id, p.name AS program_name, COALESCE(a.program_id = s.program_id, FALSE) AS is_current,
+	a.assigned_by, a.assigned_at
+FROM advisor_assignments a
+JOIN users u ON u.id = a.teacher_id
+JOIN programs p ON p.id = a.program_id
+JOIN students s ON s.id = a.student_id`
+
+// approvalSelect — заявки на согласование записи с кодом курса и периодом
+const approvalSelect = `SELECT ea.id, ea.student_id, ea.course_id, c.code AS course_code, c.name AS course_name,
+	ea.offering_id, o.term_id, ea.section_id, ea.status, ea.comment, ea.decided_by, ea.decided_at, ea.requested_at
+FROM enrollment_approvals ea
+JOIN courses c ON c.id = ea.course_id
+JOIN course_offerings o ON o.id = ea.offering_id`
+
+// currentAdvisor — условие "teacher_id консультирует студента student_id по его текущей программе"
+const currentAdvisor = `SELECT 1 FROM advisor_assignments a JOIN students s ON s.id = a.student_id AND s.program_id = a.program_id`
+
+type AdvisingRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewAdvisingRepository(db *sqlx.DB) domainRepo.AdvisingRepository {
+	return &AdvisingRepositoryImpl{DB: db}
+}
+
+func (r *AdvisingRepositoryImpl) GetStudentAdvisors(ctx context.Context, studentID string) ([]domainModels.AdvisorAssignment, error) {
+	advisors := []domainModels.AdvisorAssignment{}
+	err := r.DB.SelectContext(ctx, &advisors, advisorSelect+" WHERE a.student_id = $1 ORDER BY is_current DESC, a.assigned_at DESC", studentID)
+	if err != nil {
+		return nil, err
+	}
+	return advisors, nil
+}
+
+func (r *AdvisingRepositoryImpl) AssignAdvisor(ctx context.Context, assignment *domainModels.AdvisorAssignment) (*domainModels.AdvisorAssignment, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `
+		INSERT INTO advisor_assignments (student_id, teacher_id, program_id, assigned_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (student_id, program_id) DO UPDATE
+			SET teacher_id = EXCLUDED.teacher_id, assigned_by = EXCLUDED.assigned_by, assigned_at = CURRENT_TIMESTAMP
+		RETURNING id`,
+		assignment.StudentID, assignment.TeacherID, assignment.ProgramID, assignment.AssignedBy,
+	).Scan(&id)
+	if err != nil {
+		// Консультант ссылается на teachers: пользователь другой роли не проходит внешний ключ
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return nil, domainModels.ErrAdvisorNotTeacher
+		}
+		return nil, err
+	}
+	var created domainModels.AdvisorAssignment
+	if err := r.DB.GetContext(ctx, &created, advisorSelect+" WHERE a.id = $1", id); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *AdvisingRepositoryImpl) RemoveAdvisor(ctx context.Context, studentID, programID string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM advisor_assignments WHERE student_id = $1 AND program_id = $2", studentID, programID)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrAdvisorAssignmentNotFound
+	}
+	return nil
+}
+
+func (r *AdvisingRepositoryImpl) IsAdvisor(ctx context.Context, teacherID, studentID string) (bool, error) {
+	var exists bool
+	err := r.DB.GetContext(ctx, &exists, "SELECT EXISTS ("+currentAdvisor+" WHERE a.teacher_id = $1 AND a.student_id = $2)", teacherID, studentID)
+	return exists, err
+}
+
+// GetAdvisees возвращает студентов, которых teacherID консультирует по их текущей программе
+func (r *AdvisingRepositoryImpl) GetAdvisees(ctx context.Context, teacherID string) ([]domainModels.Advisee, error) {
+	advisees := []domainModels.Advisee{}
+	err := r.DB.SelectContext(ctx, &advisees, `
+		SELECT s.id AS student_id, u.firstname, u.lastname, u.email, s.student_year, a.program_id, p.name AS program_name,
+			(SELECT COUNT(*) FROM enrollment_approvals ea WHERE ea.student_id = s.id AND ea.status = 'pending') AS pending_approvals
+		FROM advisor_assignments a
+		JOIN students s ON s.id = a.student_id AND s.program_id = a.program_id
+		JOIN users u ON u.id = s.id
+		JOIN programs p ON p.id = a.program_id
+		WHERE a.teacher_id = $1
+		ORDER BY u.lastname, u.firstname, s.id`, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return advisees, nil
+}
+
+func (r *AdvisingRepositoryImpl) GetNotes(ctx context.Context, studentID string) ([]domainModels.AdvisingNote, error) {
+	notes := []domainModels.AdvisingNote{}
+	err := r.DB.SelectContext(ctx, &notes, "SELECT * FROM advising_notes WHERE student_id = $1 ORDER BY created_at DESC, id DESC", studentID)
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+func (r *AdvisingRepositoryImpl) GetNoteByID(ctx context.Context, id string) (*domainModels.AdvisingNote, error) {
+	var note domainModels.AdvisingNote
+	err := r.DB.GetContext(ctx, &note, "SELECT * FROM advising_notes WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrAdvisingNoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (r *AdvisingRepositoryImpl) CreateNote(ctx context.Context, note *domainModels.AdvisingNote) (*domainModels.AdvisingNote, error) {
+	err := r.DB.QueryRowxContext(ctx, `
+		INSERT INTO advising_notes (student_id, author_id, author_role, visibility, body)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`,
+		note.StudentID, note.AuthorID, note.AuthorRole, note.Visibility, note.Body,
+	).Scan(&note.ID, &note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (r *AdvisingRepositoryImpl) UpdateNote(ctx context.Context, note domainModels.AdvisingNote) (*domainModels.AdvisingNote, error) {
+	_, err := r.DB.ExecContext(ctx, `UPDATE advising_notes SET visibility = $1, body = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3`, note.Visibility, note.Body, note.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetNoteByID(ctx, note.ID)
+}
+
+func (r *AdvisingRepositoryImpl) DeleteNote(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM advising_notes WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrAdvisingNoteNotFound
+	}
+	return nil
+}
+
+func (r *AdvisingRepositoryImpl) RequiresApproval(ctx context.Context, studentID string) (bool, error) {
+	var required bool
+	err := r.DB.GetContext(ctx, &required, `SELECT EXISTS (
+		`+currentAdvisor+` JOIN programs p ON p.id = s.program_id
+		WHERE a.student_id = $1 AND p.advisor_approval)`, studentID)
+	return required, err
+}
+
+func (r *AdvisingRepositoryImpl) CreateApproval(ctx context.Context, approval *domainModels.EnrollmentApproval) (*domainModels.EnrollmentApproval, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `
+		INSERT INTO enrollment_approvals (student_id, course_id, offering_id, section_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		approval.StudentID, approval.CourseID, approval.OfferingID, approval.SectionID,
+	).Scan(&id)
+	if err != nil {
+		// Ожидающая заявка на курс в периоде может быть только одна (частичный уникальный индекс)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, domainModels.ErrApprovalPending
+		}
+		return nil, err
+	}
+	return r.GetApprovalByID(ctx, id)
+}
+
+func (r *AdvisingRepositoryImpl) GetApprovalByID(ctx context.Context, id string) (*domainModels.EnrollmentApproval, error) {
+	var approval domainModels.EnrollmentApproval
+	err := r.DB.GetContext(ctx, &approval, approvalSelect+" WHERE ea.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrApprovalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &approval, nil
+}
+
+func (r *AdvisingRepositoryImpl) GetApprovals(ctx context.Context, status, teacherID, studentID, facultyID string) ([]domainModels.EnrollmentApproval, error) {
+	approvals := []domainModels.EnrollmentApproval{}
+	err := r.DB.SelectContext(ctx, &approvals, approvalSelect+`
+		WHERE ($1 = '' OR ea.status = $1)
+			AND ($2 = '' OR EXISTS (`+currentAdvisor+` WHERE a.student_id = ea.student_id AND a.teacher_id::text = $2))
+			AND ($3 = '' OR ea.student_id::text = $3)
+			AND ($4 = '' OR EXISTS (SELECT 1 FROM students fs WHERE fs.id = ea.student_id AND fs.faculty_id::text = $4))
+		ORDER BY ea.requested_at, ea.id`, status, teacherID, studentID, facultyID)
+	if err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
+
+func (r *AdvisingRepositoryImpl) DecideApproval(ctx context.Context, id, status, decidedBy, comment string) (*domainModels.EnrollmentApproval, error) {
+	result, err := r.DB.ExecContext(ctx, `UPDATE enrollment_approvals
+		SET status = $1, comment = $2, decided_by = $3, decided_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND status = 'pending'`, status, comment, nullableID(decidedBy), id)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrApprovalDecided
+	}
+	return r.GetApprovalByID(ctx, id)
+}
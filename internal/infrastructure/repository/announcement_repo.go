@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+// announcementSelect — объявление с кафедрой, курсом и автором; $1 — пользователь, для которого отмечается прочтение
+const announcementSelect = `SELECT a.id, a.scope, a.department_id, d.name AS department_name, a.course_id, c.code AS course_code,
+	a.audience, a.title, a.body, a.author_id, COALESCE(u.firstname || ' ' || u.lastname, '') AS author_name,
+	EXISTS (SELECT 1 FROM announcement_reads r WHERE r.announcement_id = a.id AND r.user_id = $1) AS is_read,
+	a.created_at, a.updated_at
+FROM announcements a
+LEFT JOIN departments d ON d.id = a.department_id
+LEFT JOIN courses c ON c.id = a.course_id
+LEFT JOIN users u ON u.id = a.author_id`
+
+// announcementVisible — объявление адресовано пользователю $1 с ролью $2: администратор видит все объявления,
+// автор — свои; остальным объявление адресовано по роли и охвату. Кафедральное объявление получают
+// преподаватели и менеджер кафедры и студенты её факультета, объявление курса — записанные на курс
+// студенты, преподаватели курса и менеджер кафедры курса.
+const announcementVisible = `($2 = 'admin' OR a.author_id = $1 OR (a.audience IN ('all', $2) AND (
+	a.scope = 'university'
+	OR a.scope = 'department' AND (
+		EXISTS (SELECT 1 FROM teachers t WHERE t.id = $1 AND t.department_id = a.department_id)
+		OR EXISTS (SELECT 1 FROM managers m WHERE m.id = $1 AND m.department_id = a.department_id)
+		OR EXISTS (SELECT 1 FROM students s JOIN departments sd ON sd.faculty_id = s.faculty_id
+			WHERE s.id = $1 AND sd.id = a.department_id))
+	OR a.scope = 'course' AND (
+		EXISTS (SELECT 1 FROM student_courses sc WHERE sc.student_id = $1 AND sc.course_id = a.course_id AND sc.status = 'enrolled')
+		OR EXISTS (SELECT 1 FROM teacher_courses tc WHERE tc.teacher_id = $1 AND tc.course_id = a.course_id)
+		OR EXISTS (SELECT 1 FROM managers m JOIN courses mc ON mc.department_id = m.department_id
+			WHERE m.id = $1 AND mc.id = a.course_id)))))`
+
+type AnnouncementRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewAnnouncementRepository(db *sqlx.DB) domainRepo.AnnouncementRepository {
+	return &AnnouncementRepositoryImpl{DB: db}
+}
+
+func (r *AnnouncementRepositoryImpl) CreateAnnouncement(ctx context.Context, announcement *domainModels.Announcement) (*domainModels.Announcement, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `INSERT INTO announcements (scope, department_id, course_id, audience, title, body, author_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		announcement.Scope, announcement.DepartmentID, announcement.CourseID, announcement.Audience,
+		announcement.Title, announcement.Body, announcement.AuthorID).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return nil, domainModels.ErrInvalidAnnouncement
+		}
+		return nil, err
+	}
+	return r.GetAnnouncementByID(ctx, id)
+}
+
+func (r *AnnouncementRepositoryImpl) GetAnnouncementByID(ctx context.Context, id string) (*domainModels.Announcement, error) {
+	var announcement domainModels.Announcement
+	// Объявление читается без пользователя, поэтому отметка о прочтении всегда false
+	err := r.DB.GetContext(ctx, &announcement, announcementSelect+" WHERE a.id = $2", nil, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (r *AnnouncementRepositoryImpl) UpdateAnnouncement(ctx context.Context, announcement domainModels.Announcement) (*domainModels.Announcement, error) {
+	result, err := r.DB.ExecContext(ctx, `UPDATE announcements SET audience = $2, title = $3, body = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`, announcement.ID, announcement.Audience, announcement.Title, announcement.Body)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrAnnouncementNotFound
+	}
+	return r.GetAnnouncementByID(ctx, announcement.ID)
+}
+
+func (r *AnnouncementRepositoryImpl) DeleteAnnouncement(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM announcements WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+func (r *AnnouncementRepositoryImpl) GetFeed(ctx context.Context, userID, role string, unreadOnly bool, limit, offset int) (*domainModels.AnnouncementFeed, error) {
+	feed := domainModels.AnnouncementFeed{Items: []domainModels.Announcement{}}
+	err := r.DB.QueryRowxContext(ctx, `SELECT COUNT(*),
+		COUNT(*) FILTER (WHERE NOT EXISTS (SELECT 1 FROM announcement_reads r WHERE r.announcement_id = a.id AND r.user_id = $1))
+		FROM announcements a WHERE `+announcementVisible, userID, role).Scan(&feed.Total, &feed.Unread)
+	if err != nil {
+		return nil, err
+	}
+	if unreadOnly {
+		feed.Total = feed.Unread
+	}
+	err = r.DB.SelectContext(ctx, &feed.Items, announcementSelect+" WHERE "+announcementVisible+`
+		AND (NOT $3 OR NOT EXISTS (SELECT 1 FROM announcement_reads r WHERE r.announcement_id = a.id AND r.user_id = $1))
+		ORDER BY a.created_at DESC, a.id DESC LIMIT $4 OFFSET $5`, userID, role, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *AnnouncementRepositoryImpl) IsVisible(ctx context.Context, id, userID, role string) (bool, error) {
+	var visible bool
+	err := r.DB.GetContext(ctx, &visible, "SELECT EXISTS (SELECT 1 FROM announcements a WHERE a.id = $3 AND "+announcementVisible+")",
+		userID, role, id)
+	return visible, err
+}
+
+func (r *AnnouncementRepositoryImpl) MarkRead(ctx context.Context, id, userID string) error {
+	_, err := r.DB.ExecContext(ctx, `INSERT INTO announcement_reads (announcement_id, user_id) VALUES ($1, $2)
+		ON CONFLICT (announcement_id, user_id) DO NOTHING`, id, userID)
+	return err
+}
+
+func (r *AnnouncementRepositoryImpl) MarkAllRead(ctx context.Context, userID, role string) (int, error) {
+	result, err := r.DB.ExecContext(ctx, `INSERT INTO announcement_reads (announcement_id, user_id)
+		SELECT a.id, CAST($1 AS INTEGER) FROM announcements a WHERE `+announcementVisible+`
+		ON CONFLICT (announcement_id, user_id) DO NOTHING`, userID, role)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+type AppealRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewAppealRepository(db *sqlx.DB) domainRepo.AppealRepository {
+	return &AppealRepositoryImpl{DB: db}
+}
+
+// appealColumns — все колонки апелляции, кроме содержимого вложения
+const appealColumns = `a.id, a.student_id, a.offering_id, a.course_id, a.mark_type, a.reason, a.original_value, a.resolved_value,
+	a.status, a.attachment_name, a.attachment_type, a.resolved_by, a.resolved_at, a.filed_at, a.updated_at`
+
+func (r *AppealRepositoryImpl) CreateAppeal(ctx context.Context, appeal *domainModels.GradeAppeal, attachment *domainModels.AppealAttachment) (*domainModels.GradeAppeal, error) {
+	var data []byte
+	if attachment != nil {
+		appeal.AttachmentName = &attachment.Name
+		appeal.AttachmentType = &attachment.ContentType
+		data = attachment.Data
+	}
+	err := r.DB.QueryRowxContext(ctx, `
+		INSERT INTO grade_appeals (student_id, offering_id, course_id, mark_type, reason, original_value, status,
+			attachment_name, attachment_type, attachment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, filed_at, updated_at`,
+		appeal.StudentID, appeal.OfferingID, appeal.CourseID, appeal.MarkType, appeal.Reason, appeal.OriginalValue, appeal.Status,
+		appeal.AttachmentName, appeal.AttachmentType, data,
+	).Scan(&appeal.ID, &appeal.FiledAt, &appeal.UpdatedAt)
+	if err != nil {
+		// Открытая апелляция на оценку может быть только одна (частичный уникальный индекс)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, domainModels.ErrAppealExists
+		}
+		return nil, err
+	}
+	return appeal, nil
+}
+
+func (r *AppealRepositoryImpl) GetAppealByID(ctx context.Context, id string) (*domainModels.GradeAppeal, error) {
+	var appeal domainModels.GradeAppeal
+	err := r.DB.GetContext(ctx, &appeal, "SELECT "+appealColumns+" FROM grade_appeals a WHERE a.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrAppealNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &appeal, nil
+}
+
+// GetAppeals возвращает апелляции с указанным статусом (пустой — все); если задан teacherID,
+// только по курсам этого преподавателя
+func (r *AppealRepositoryImpl) GetAppeals(ctx context.Context, status, teacherID string) ([]domainModels.GradeAppeal, error) {
+	appeals := []domainModels.GradeAppeal{}
+	err := r.DB.SelectContext(ctx, &appeals, `
+		SELECT `+appealColumns+` FROM grade_appeals a
+		WHERE ($1 = '' OR a.status = $1)
+			AND ($2 = '' OR EXISTS (SELECT 1 FROM teacher_courses tc WHERE tc.course_id = a.course_id AND tc.teacher_id::text = $2))
+		ORDER BY a.filed_at, a.id`, status, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return appeals, nil
+}
+
+func (r *AppealRepositoryImpl) GetStudentAppeals(ctx context.Context, studentID string) ([]domainModels.GradeAppeal, error) {
+	appeals := []domainModels.GradeAppeal{}
+	err := r.DB.SelectContext(ctx, &appeals,
+		"SELECT "+appealColumns+" FROM grade_appeals a WHERE a.student_id = $1 ORDER BY a.filed_at DESC, a.id DESC", studentID)
+	if err != nil {
+		return nil, err
+	}
+	return appeals, nil
+}
+
+func (r *AppealRepositoryImpl) GetAttachment(ctx context.Context, id string) (*domainModels.AppealAttachment, error) {
+	var attachment domainModels.AppealAttachment
+	err := r.DB.GetContext(ctx, &attachment, `
+		SELECT attachment_name, attachment_type, attachment FROM grade_appeals
+		WHERE id = $1 AND attachment IS NOT NULL`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrAppealAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// UpdateAppealStatus переводит апелляцию в статус to, если её текущий статус входит в from.
+// Для итоговых статусов запоминаются автор решения и итоговое значение оценки.
+func (r *AppealRepositoryImpl) UpdateAppealStatus(ctx context.Context, id string, from []string, to, actorID string, resolvedValue *float64) error {
+	query := "UPDATE grade_appeals SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 AND status = ANY($3)"
+	args := []interface{}{to, id, pq.Array(from)}
+	if to == domainModels.AppealStatusUpheld || to == domainModels.AppealStatusChanged {
+		query = `UPDATE grade_appeals SET status = $1, resolved_by = $4, resolved_at = CURRENT_TIMESTAMP, resolved_value = $5,
+			updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2 AND status = ANY($3)`
+		args = append(args, nullableID(actorID), resolvedValue)
+	}
+
+	result, err := r.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domainModels.ErrAppealState
+	}
+	return nil
+}
+
+// ResolveAppeal закрывает открытую апелляцию решением outcome и, если оценка изменена, записывает
+// новое значение в той же транзакции. В отправленной или утверждённой ведомости оценку можно
+// изменить только с overrideSheet; иначе возвращается ErrAppealSheetLocked.
+func (r *AppealRepositoryImpl) ResolveAppeal(ctx context.Context, appeal *domainModels.GradeAppeal, outcome, actorID string, resolvedValue float64, overrideSheet bool) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE grade_appeals SET status = $1, resolved_by = $4, resolved_at = CURRENT_TIMESTAMP,
+			resolved_value = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = ANY($3)`,
+		outcome, appeal.ID, pq.Array([]string{domainModels.AppealStatusSubmitted, domainModels.AppealStatusUnderReview}),
+		nullableID(actorID), resolvedValue)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domainModels.ErrAppealState
+	}
+
+	if outcome == domainModels.AppealStatusChanged {
+		status, err := lockGradeSheet(ctx, tx, appeal.OfferingID)
+		if err != nil {
+			return err
+		}
+		if status != domainModels.GradeSheetOpen && !overrideSheet {
+			return domainModels.ErrAppealSheetLocked
+		}
+		if err := setMark(ctx, tx, appeal.StudentID, appeal.OfferingID, appeal.MarkType, resolvedValue, actorID, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *AppealRepositoryImpl) AddComment(ctx context.Context, comment *domainModels.AppealComment) (*domainModels.AppealComment, error) {
+	err := r.DB.QueryRowxContext(ctx, `
+		INSERT INTO grade_appeal_comments (appeal_id, author_id, author_role, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		comment.AppealID, comment.AuthorID, comment.AuthorRole, comment.Body,
+	).Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (r *AppealRepositoryImpl) GetComments(ctx context.Context, appealID string) ([]domainModels.AppealComment, error) {
+	comments := []domainModels.AppealComment{}
+	err := r.DB.SelectContext(ctx, &comments,
+		"SELECT * FROM grade_appeal_comments WHERE appeal_id = $1 ORDER BY created_at, id", appealID)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
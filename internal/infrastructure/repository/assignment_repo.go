@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const assignmentSelect = `SELECT a.*, o.course_id FROM assignments a JOIN course_offerings o ON o.id = a.offering_id`
+
+const submissionSelect = `SELECT s.*, u.firstname, u.lastname FROM assignment_submissions s JOIN users u ON u.id = s.student_id`
+
+type AssignmentRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewAssignmentRepository(db *sqlx.DB) domainRepo.AssignmentRepository {
+	return &AssignmentRepositoryImpl{DB: db}
+}
+
+func (r *AssignmentRepositoryImpl) GetOfferingAssignments(ctx context.Context, offeringID string) ([]domainModels.Assignment, error) {
+	assignments := []domainModels.Assignment{}
+	err := r.DB.SelectContext(ctx, &assignments, assignmentSelect+" WHERE a.offering_id = $1 ORDER BY a.due_at, a.id", offeringID)
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *AssignmentRepositoryImpl) GetAssignmentByID(ctx context.Context, id string) (*domainModels.Assignment, error) {
+	var assignment domainModels.Assignment
+	err := r.DB.GetContext(ctx, &assignment, assignmentSelect+" WHERE a.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrAssignmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *AssignmentRepositoryImpl) CreateAssignment(ctx context.Context, assignment *domainModels.Assignment) (*domainModels.Assignment, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `
+		INSERT INTO assignments (offering_id, title, description, due_at, max_points, late_policy, late_penalty_percent, mark_type, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		assignment.OfferingID, assignment.Title, assignment.Description, assignment.DueAt, assignment.MaxPoints,
+		assignment.LatePolicy, assignment.LatePenaltyPercent, assignment.MarkType, assignment.CreatedBy).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetAssignmentByID(ctx, id)
+}
+
+func (r *AssignmentRepositoryImpl) UpdateAssignment(ctx context.Context, assignment domainModels.Assignment) (*domainModels.Assignment, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE assignments SET title = $1, description = $2, due_at = $3, max_points = $4, late_policy = $5,
+			late_penalty_percent = $6, mark_type = $7, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $8`,
+		assignment.Title, assignment.Description, assignment.DueAt, assignment.MaxPoints, assignment.LatePolicy,
+		assignment.LatePenaltyPercent, assignment.MarkType, assignment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrAssignmentNotFound
+	}
+	return r.GetAssignmentByID(ctx, assignment.ID)
+}
+
+func (r *AssignmentRepositoryImpl) DeleteAssignment(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM assignments WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrAssignmentNotFound
+	}
+	return nil
+}
+
+// GetOfferingStudents возвращает ID студентов, записанных на курс в периоде и не выбывших с него
+func (r *AssignmentRepositoryImpl) GetOfferingStudents(ctx context.Context, offeringID string) ([]string, error) {
+	students := []string{}
+	err := r.DB.SelectContext(ctx, &students,
+		"SELECT student_id FROM student_courses WHERE offering_id = $1 AND status = 'enrolled' ORDER BY student_id", offeringID)
+	if err != nil {
+		return nil, err
+	}
+	return students, nil
+}
+
+func (r *AssignmentRepositoryImpl) IsEnrolled(ctx context.Context, offeringID, studentID string) (bool, error) {
+	var enrolled bool
+	err := r.DB.GetContext(ctx, &enrolled, `SELECT EXISTS (
+		SELECT 1 FROM student_courses WHERE offering_id = $1 AND student_id = $2 AND status = 'enrolled')`, offeringID, studentID)
+	return enrolled, err
+}
+
+func (r *AssignmentRepositoryImpl) GetSubmissions(ctx context.Context, assignmentID string) ([]domainModels.Submission, error) {
+	submissions := []domainModels.Submission{}
+	err := r.DB.SelectContext(ctx, &submissions, submissionSelect+`
+		WHERE s.assignment_id = $1 ORDER BY u.lastname, u.firstname, s.student_id`, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+func (r *AssignmentRepositoryImpl) GetSubmission(ctx context.Context, assignmentID, studentID string) (*domainModels.Submission, error) {
+	return r.getSubmission(ctx, submissionSelect+" WHERE s.assignment_id = $1 AND s.student_id = $2", assignmentID, studentID)
+}
+
+func (r *AssignmentRepositoryImpl) GetSubmissionByID(ctx context.Context, id string) (*domainModels.Submission, error) {
+	return r.getSubmission(ctx, submissionSelect+" WHERE s.id = $1", id)
+}
+
+func (r *AssignmentRepositoryImpl) getSubmission(ctx context.Context, query string, args ...interface{}) (*domainModels.Submission, error) {
+	var submission domainModels.Submission
+	err := r.DB.GetContext(ctx, &submission, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrSubmissionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *AssignmentRepositoryImpl) GetStudentSubmissions(ctx context.Context, studentID string) ([]domainModels.Submission, error) {
+	submissions := []domainModels.Submission{}
+	err := r.DB.SelectContext(ctx, &submissions, submissionSelect+`
+		WHERE s.student_id = $1 ORDER BY s.submitted_at DESC, s.id DESC`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// SaveSubmission сохраняет работу или заменяет файл прежней. Оценённая работа не заменяется:
+// условие проверяется в том же запросе, чтобы замена не разошлась с одновременным оцениванием.
+func (r *AssignmentRepositoryImpl) SaveSubmission(ctx context.Context, submission *domainModels.Submission) (*domainModels.Submission, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `
+		INSERT INTO assignment_submissions (assignment_id, student_id, file_name, content_type, size, storage_key, comment, submitted_at, days_late)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (assignment_id, student_id) DO UPDATE
+		SET file_name = EXCLUDED.file_name, content_type = EXCLUDED.content_type, size = EXCLUDED.size,
+			storage_key = EXCLUDED.storage_key, comment = EXCLUDED.comment,
+			submitted_at = EXCLUDED.submitted_at, days_late = EXCLUDED.days_late
+		WHERE assignment_submissions.score IS NULL
+		RETURNING id`,
+		submission.AssignmentID, submission.StudentID, submission.FileName, submission.ContentType, submission.Size,
+		submission.StorageKey, submission.Comment, submission.SubmittedAt, submission.DaysLate).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrSubmissionGraded
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.GetSubmissionByID(ctx, id)
+}
+
+func (r *AssignmentRepositoryImpl) GradeSubmission(ctx context.Context, id string, score, finalScore float64, feedback, gradedBy string) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE assignment_submissions SET score = $1, final_score = $2, feedback = $3, graded_by = $4, graded_at = CURRENT_TIMESTAMP
+		WHERE id = $5`, score, finalScore, feedback, gradedBy, id)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrSubmissionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+type AttendanceRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewAttendanceRepository(db *sqlx.DB) domainRepo.AttendanceRepository {
+	return &AttendanceRepositoryImpl{DB: db}
+}
+
+// CreateSessions создаёт занятия одной транзакцией; уже существующие (то же время у того же курса
+// и секции) пропускаются, поэтому расписание можно генерировать повторно
+func (r *AttendanceRepositoryImpl) CreateSessions(ctx context.Context, sessions []domainModels.ClassSession) ([]domainModels.ClassSession, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	created := []domainModels.ClassSession{}
+	for _, session := range sessions {
+		err := tx.QueryRowxContext(ctx, `
+			INSERT INTO class_sessions (offering_id, section_id, starts_at, ends_at, topic)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT DO NOTHING
+			RETURNING id, created_at`,
+			session.OfferingID, session.SectionID, session.StartsAt, session.EndsAt, session.Topic,
+		).Scan(&session.ID, &session.CreatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, session)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *AttendanceRepositoryImpl) GetOfferingSessions(ctx context.Context, offeringID string) ([]domainModels.ClassSession, error) {
+	sessions := []domainModels.ClassSession{}
+	err := r.DB.SelectContext(ctx, &sessions, `
+		SELECT s.*, o.course_id FROM class_sessions s JOIN course_offerings o ON o.id = s.offering_id
+		WHERE s.offering_id = $1 ORDER BY s.starts_at, s.id`, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *AttendanceRepositoryImpl) GetSessionByID(ctx context.Context, id string) (*domainModels.ClassSession, error) {
+	var session domainModels.ClassSession
+	err := r.DB.GetContext(ctx, &session, `
+		SELECT s.*, o.course_id FROM class_sessions s JOIN course_offerings o ON o.id = s.offering_id
+		WHERE s.id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *AttendanceRepositoryImpl) DeleteSession(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM class_sessions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domainModels.ErrSessionNotFound
+	}
+	return nil
+}
+
+// GetSessionRoster возвращает студентов занятия (записанных на курс, а для занятия секции — в эту секцию)
+// с их отметками; студенты, бросившие или отозвавшиеся с курса, не входят в список
+func (r *AttendanceRepositoryImpl) GetSessionRoster(ctx context.Context, sessionID string) ([]domainModels.AttendanceRecord, error) {
+	roster := []domainModels.AttendanceRecord{}
+	err := r.DB.SelectContext(ctx, &roster, `
+		SELECT s.id AS session_id, sc.student_id, u.firstname, u.lastname,
+			COALESCE(a.status, '') AS status, COALESCE(a.note, '') AS note, a.marked_by, a.marked_at
+		FROM class_sessions s
+		JOIN student_courses sc ON sc.offering_id = s.offering_id
+			AND (s.section_id IS NULL OR sc.section_id = s.section_id)
+			AND sc.status NOT IN ('dropped', 'withdrawn')
+		JOIN users u ON u.id = sc.student_id
+		LEFT JOIN attendance_records a ON a.session_id = s.id AND a.student_id = sc.student_id
+		WHERE s.id = $1
+		ORDER BY u.lastname, u.firstname, sc.student_id`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return roster, nil
+}
+
+// MarkAttendance сохраняет отметки одной транзакцией, перезаписывая прежние
+func (r *AttendanceRepositoryImpl) MarkAttendance(ctx context.Context, records []domainModels.AttendanceRecord) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, record := range records {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO attendance_records (session_id, student_id, status, note, marked_by)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (session_id, student_id) DO UPDATE
+			SET status = EXCLUDED.status, note = EXCLUDED.note, marked_by = EXCLUDED.marked_by, marked_at = CURRENT_TIMESTAMP`,
+			record.SessionID, record.StudentID, record.Status, record.Note, record.MarkedBy); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// attendanceSummary — подсчёт отметок по студенту и курсу в периоде
+const attendanceSummary = `
+	SELECT sc.student_id, u.firstname, u.lastname, sc.offering_id, sc.course_id, c.code, c.name, o.term_id,
+		COUNT(*) FILTER (WHERE a.status = 'present') AS present,
+		COUNT(*) FILTER (WHERE a.status = 'late') AS late,
+		COUNT(*) FILTER (WHERE a.status = 'absent') AS absent,
+		COUNT(*) FILTER (WHERE a.status = 'excused') AS excused
+	FROM student_courses sc
+	JOIN users u ON u.id = sc.student_id
+	JOIN courses c ON c.id = sc.course_id
+	JOIN course_offerings o ON o.id = sc.offering_id
+	LEFT JOIN class_sessions s ON s.offering_id = sc.offering_id
+	LEFT JOIN attendance_records a ON a.session_id = s.id AND a.student_id = sc.student_id`
+
+const attendanceSummaryGroup = `
+	GROUP BY sc.student_id, u.firstname, u.lastname, sc.offering_id, sc.course_id, c.code, c.name, o.term_id
+	ORDER BY c.code, u.lastname, u.firstname`
+
+func (r *AttendanceRepositoryImpl) GetStudentAttendance(ctx context.Context, studentID string) ([]domainModels.AttendanceSummary, error) {
+	summaries := []domainModels.AttendanceSummary{}
+	err := r.DB.SelectContext(ctx, &summaries, attendanceSummary+`
+		WHERE sc.student_id = $1 AND sc.status NOT IN ('dropped', 'withdrawn')`+attendanceSummaryGroup, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (r *AttendanceRepositoryImpl) GetTermAttendance(ctx context.Context, termID string) ([]domainModels.AttendanceSummary, error) {
+	summaries := []domainModels.AttendanceSummary{}
+	err := r.DB.SelectContext(ctx, &summaries, attendanceSummary+`
+		WHERE o.term_id = $1 AND sc.status NOT IN ('dropped', 'withdrawn')`+attendanceSummaryGroup, termID)
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (r *AttendanceRepositoryImpl) GetSettings(ctx context.Context) (*domainModels.AttendanceSettings, error) {
+	var settings domainModels.AttendanceSettings
+	err := r.DB.GetContext(ctx, &settings, "SELECT min_percentage, min_sessions FROM attendance_settings WHERE id = 1")
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *AttendanceRepositoryImpl) UpdateSettings(ctx context.Context, settings domainModels.AttendanceSettings) (*domainModels.AttendanceSettings, error) {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO attendance_settings (id, min_percentage, min_sessions) VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET min_percentage = EXCLUDED.min_percentage, min_sessions = EXCLUDED.min_sessions,
+			updated_at = CURRENT_TIMESTAMP`,
+		settings.MinPercentage, settings.MinSessions)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
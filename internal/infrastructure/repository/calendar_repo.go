@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const feedSelect = `SELECT f.user_id, u.role, f.token, f.created_at FROM calendar_feeds f JOIN users u ON u.id = f.user_id`
+
+type CalendarRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewCalendarRepository(db *sqlx.DB) domainRepo.CalendarRepository {
+	return &CalendarRepositoryImpl{DB: db}
+}
+
+func (r *CalendarRepositoryImpl) GetFeedByUser(ctx context.Context, userID string) (*domainModels.CalendarFeed, error) {
+	return r.getFeed(ctx, feedSelect+" WHERE f.user_id = $1", userID)
+}
+
+func (r *CalendarRepositoryImpl) GetFeedByToken(ctx context.Context, token string) (*domainModels.CalendarFeed, error) {
+	return r.getFeed(ctx, feedSelect+" WHERE f.token = $1", token)
+}
+
+// SaveFeed создаёт ленту пользователя или заменяет её токен
+func (r *CalendarRepositoryImpl) SaveFeed(ctx context.Context, userID, token string) (*domainModels.CalendarFeed, error) {
+	_, err := r.DB.ExecContext(ctx, `INSERT INTO calendar_feeds (user_id, token) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = CURRENT_TIMESTAMP`, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetFeedByUser(ctx, userID)
+}
+
+func (r *CalendarRepositoryImpl) getFeed(ctx context.Context, query string, arg string) (*domainModels.CalendarFeed, error) {
+	var feed domainModels.CalendarFeed
+	err := r.DB.GetContext(ctx, &feed, query, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *CalendarRepositoryImpl) GetTermHolidays(ctx context.Context, termID string) ([]domainModels.Holiday, error) {
+	holidays := []domainModels.Holiday{}
+	err := r.DB.SelectContext(ctx, &holidays, "SELECT * FROM term_holidays WHERE term_id = $1 ORDER BY date", termID)
+	if err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (r *CalendarRepositoryImpl) CreateHoliday(ctx context.Context, holiday *domainModels.Holiday) (*domainModels.Holiday, error) {
+	err := r.DB.QueryRowxContext(ctx, "INSERT INTO term_holidays (term_id, date, name) VALUES ($1, $2, $3) RETURNING id",
+		holiday.TermID, holiday.Date, holiday.Name).Scan(&holiday.ID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, domainModels.ErrHolidayExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (r *CalendarRepositoryImpl) DeleteHoliday(ctx context.Context, termID, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM term_holidays WHERE id = $1 AND term_id = $2", id, termID)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrHolidayNotFound
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/jmoiron/sqlx"
+)
+
+// CasbinAdapter хранит политики Casbin в таблице casbin_rule
+type CasbinAdapter struct {
+	DB *sqlx.DB
+}
+
+func NewCasbinAdapter(db *sqlx.DB) persist.Adapter {
+	return &CasbinAdapter{DB: db}
+}
+
+type casbinRule struct {
+	PType string `db:"ptype"`
+	V0    string `db:"v0"`
+	V1    string `db:"v1"`
+	V2    string `db:"v2"`
+	V3    string `db:"v3"`
+	V4    string `db:"v4"`
+	V5    string `db:"v5"`
+}
+
+const casbinRuleFields = 6
+
+func newCasbinRule(ptype string, rule []string) casbinRule {
+	values := make([]string, casbinRuleFields)
+	copy(values, rule)
+	return casbinRule{PType: ptype, V0: values[0], V1: values[1], V2: values[2], V3: values[3], V4: values[4], V5: values[5]}
+}
+
+func (r casbinRule) toArray() []string {
+	values := []string{r.PType, r.V0, r.V1, r.V2, r.V3, r.V4, r.V5}
+	for len(values) > 1 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	return values
+}
+
+func (a *CasbinAdapter) LoadPolicy(m model.Model) error {
+	var rules []casbinRule
+	if err := a.DB.Select(&rules, "SELECT ptype, v0, v1, v2, v3, v4, v5 FROM casbin_rule ORDER BY id"); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if err := persist.LoadPolicyArray(rule.toArray(), m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *CasbinAdapter) SavePolicy(m model.Model) error {
+	ctx := context.Background()
+	tx, err := a.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM casbin_rule"); err != nil {
+		return err
+	}
+	for _, sec := range []string{"p", "g"} {
+		for ptype, assertion := range m[sec] {
+			for _, rule := range assertion.Policy {
+				if err := insertCasbinRule(ctx, tx, newCasbinRule(ptype, rule)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func (a *CasbinAdapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return insertCasbinRule(context.Background(), a.DB, newCasbinRule(ptype, rule))
+}
+
+func (a *CasbinAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	r := newCasbinRule(ptype, rule)
+	_, err := a.DB.Exec(`DELETE FROM casbin_rule
+		WHERE ptype = $1 AND v0 = $2 AND v1 = $3 AND v2 = $4 AND v3 = $5 AND v4 = $6 AND v5 = $7`,
+		r.PType, r.V0, r.V1, r.V2, r.V3, r.V4, r.V5)
+	return err
+}
+
+func (a *CasbinAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	conditions := []string{"ptype = $1"}
+	args := []interface{}{ptype}
+	for i, value := range fieldValues {
+		if value == "" {
+			continue
+		}
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("v%d = $%d", fieldIndex+i, len(args)))
+	}
+	_, err := a.DB.Exec("DELETE FROM casbin_rule WHERE "+strings.Join(conditions, " AND "), args...)
+	return err
+}
+
+func insertCasbinRule(ctx context.Context, db sqlx.ExtContext, r casbinRule) error {
+	_, err := db.ExecContext(ctx, `INSERT INTO casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`,
+		r.PType, r.V0, r.V1, r.V2, r.V3, r.V4, r.V5)
+	return err
+}
@@ -16,7 +16,8 @@ func NewCourseRepository(db *sqlx.DB) domainRepo.CourseRepository {
 }
 
 func (r *CourseRepositoryImpl) CreateCourse(ctx context.Context, course *domainModels.Course) (*domainModels.Course, error) {
-	query := `INSERT INTO courses (name, code, description, teacher_id, credits) VALUES (:name, :code, :description, :teacher_id, :credits) RETURNING id`
+	query := `INSERT INTO courses (name, code, description, teacher_id, credits, department_id, prerequisites, corequisites, min_student_year, allowed_faculties)
+		VALUES (:name, :code, :description, :teacher_id, :credits, :department_id, :prerequisites, :corequisites, :min_student_year, :allowed_faculties) RETURNING id`
 	stmt, err := r.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -50,7 +51,8 @@ func (r *CourseRepositoryImpl) GetAllCourses(ctx context.Context) ([]domainModel
 }
 
 func (r *CourseRepositoryImpl) UpdateCourse(ctx context.Context, course domainModels.Course) (*domainModels.Course, error) {
-	_, err := r.DB.NamedExecContext(ctx, `UPDATE courses SET name=:name, code=:code, description=:description, teacher_id=:teacher_id, credits=:credits WHERE id=:id`, &course)
+	_, err := r.DB.NamedExecContext(ctx, `UPDATE courses SET name=:name, code=:code, description=:description, teacher_id=:teacher_id, credits=:credits, department_id=:department_id,
+		prerequisites=:prerequisites, corequisites=:corequisites, min_student_year=:min_student_year, allowed_faculties=:allowed_faculties WHERE id=:id`, &course)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +66,8 @@ func (r *CourseRepositoryImpl) DeleteCourse(ctx context.Context, ID string) erro
 
 func (r *CourseRepositoryImpl) GetCourseStudents(ctx context.Context, courseID string) ([]domainModels.Student, error) {
 	var students []domainModels.Student
-	err := r.DB.SelectContext(ctx, &students, "SELECT s.* FROM students s JOIN student_courses sc ON s.id = sc.student_id WHERE sc.course_id = $1", courseID)
+	err := r.DB.SelectContext(ctx, &students, `SELECT s.* FROM students s JOIN student_courses sc ON s.id = sc.student_id
+		WHERE sc.course_id = $1 AND sc.status NOT IN ('dropped', 'withdrawn')`, courseID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+type DocumentRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewDocumentRepository(db *sqlx.DB) domainRepo.DocumentRepository {
+	return &DocumentRepositoryImpl{DB: db}
+}
+
+func (r *DocumentRepositoryImpl) CreateDocument(ctx context.Context, document *domainModels.IssuedDocument) (*domainModels.IssuedDocument, error) {
+	query := `INSERT INTO issued_documents (code, type, student_id, student_name, term_id, content_hash, issued_by, issued_at)
+		VALUES (:code, :type, :student_id, :student_name, :term_id, :content_hash, :issued_by, :issued_at) RETURNING id`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var id string
+	err = stmt.QueryRowxContext(ctx, document).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	document.ID = id
+	return document, nil
+}
+
+func (r *DocumentRepositoryImpl) GetDocumentByCode(ctx context.Context, code string) (*domainModels.IssuedDocument, error) {
+	var document domainModels.IssuedDocument
+	err := r.DB.GetContext(ctx, &document, "SELECT * FROM issued_documents WHERE code = $1", code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (r *DocumentRepositoryImpl) GetStudentDocuments(ctx context.Context, studentID string) ([]domainModels.IssuedDocument, error) {
+	var documents []domainModels.IssuedDocument
+	err := r.DB.SelectContext(ctx, &documents, "SELECT * FROM issued_documents WHERE student_id = $1 ORDER BY issued_at DESC", studentID)
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+// evaluationParticipants — статусы записи, при которых студент участвует в анкете курса
+const evaluationParticipants = "('enrolled', 'completed', 'failed')"
+
+// evaluationSelect — анкеты курсов с шаблоном, курсом, числом ответов и записанных студентов
+const evaluationSelect = `SELECT e.id, e.template_id, tp.name AS template_name, e.offering_id, o.course_id,
+	c.code AS course_code, c.name AS course_name, o.term_id, e.closes_at, e.created_at,
+	(SELECT COUNT(*) FROM evaluation_participants p WHERE p.evaluation_id = e.id) AS response_count,
+	(SELECT COUNT(*) FROM student_courses sc WHERE sc.offering_id = e.offering_id
+		AND sc.status IN ` + evaluationParticipants + `) AS enrolled_count`
+
+const evaluationFrom = `
+FROM course_evaluations e
+JOIN evaluation_templates tp ON tp.id = e.template_id
+JOIN course_offerings o ON o.id = e.offering_id
+JOIN courses c ON c.id = o.course_id`
+
+type EvaluationRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewEvaluationRepository(db *sqlx.DB) domainRepo.EvaluationRepository {
+	return &EvaluationRepositoryImpl{DB: db}
+}
+
+func (r *EvaluationRepositoryImpl) GetTemplates(ctx context.Context) ([]domainModels.EvaluationTemplate, error) {
+	templates := []domainModels.EvaluationTemplate{}
+	if err := r.DB.SelectContext(ctx, &templates, "SELECT * FROM evaluation_templates ORDER BY name, id"); err != nil {
+		return nil, err
+	}
+	for i := range templates {
+		if err := r.loadQuestions(ctx, &templates[i]); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+func (r *EvaluationRepositoryImpl) GetTemplateByID(ctx context.Context, id string) (*domainModels.EvaluationTemplate, error) {
+	var template domainModels.EvaluationTemplate
+	err := r.DB.GetContext(ctx, &template, "SELECT * FROM evaluation_templates WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrEvaluationTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadQuestions(ctx, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *EvaluationRepositoryImpl) loadQuestions(ctx context.Context, template *domainModels.EvaluationTemplate) error {
+	template.Questions = []domainModels.EvaluationQuestion{}
+	return r.DB.SelectContext(ctx, &template.Questions, `SELECT id, position, kind, text, required
+		FROM evaluation_questions WHERE template_id = $1 ORDER BY position, id`, template.ID)
+}
+
+// CreateTemplate сохраняет шаблон с вопросами одной транзакцией
+func (r *EvaluationRepositoryImpl) CreateTemplate(ctx context.Context, template *domainModels.EvaluationTemplate) (*domainModels.EvaluationTemplate, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowxContext(ctx, `INSERT INTO evaluation_templates (name, description, min_responses, created_by)
+		VALUES ($1, $2, $3, $4) RETURNING id`,
+		template.Name, template.Description, template.MinResponses, template.CreatedBy).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range template.Questions {
+		_, err := tx.ExecContext(ctx, `INSERT INTO evaluation_questions (template_id, position, kind, text, required)
+			VALUES ($1, $2, $3, $4, $5)`, id, question.Position, question.Kind, question.Text, question.Required)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetTemplateByID(ctx, id)
+}
+
+func (r *EvaluationRepositoryImpl) DeleteTemplate(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM evaluation_templates WHERE id = $1", id)
+	if err != nil {
+		// Анкеты курсов ссылаются на шаблон с ON DELETE RESTRICT
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return domainModels.ErrEvaluationTemplateInUse
+		}
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrEvaluationTemplateNotFound
+	}
+	return nil
+}
+
+func (r *EvaluationRepositoryImpl) OpenEvaluations(ctx context.Context, termID, templateID string, closesAt time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO course_evaluations (offering_id, template_id, closes_at)
+		SELECT id, $2, $3 FROM course_offerings WHERE term_id = $1
+		ON CONFLICT (offering_id) DO NOTHING`, termID, templateID, closesAt)
+	return err
+}
+
+func (r *EvaluationRepositoryImpl) GetTermEvaluations(ctx context.Context, termID string) ([]domainModels.CourseEvaluation, error) {
+	evaluations := []domainModels.CourseEvaluation{}
+	err := r.DB.SelectContext(ctx, &evaluations, evaluationSelect+evaluationFrom+" WHERE o.term_id = $1 ORDER BY c.code", termID)
+	if err != nil {
+		return nil, err
+	}
+	return evaluations, nil
+}
+
+func (r *EvaluationRepositoryImpl) GetEvaluationByID(ctx context.Context, id string) (*domainModels.CourseEvaluation, error) {
+	var evaluation domainModels.CourseEvaluation
+	err := r.DB.GetContext(ctx, &evaluation, evaluationSelect+evaluationFrom+" WHERE e.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrEvaluationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &evaluation, nil
+}
+
+func (r *EvaluationRepositoryImpl) GetStudentEvaluations(ctx context.Context, studentID string) ([]domainModels.CourseEvaluation, error) {
+	evaluations := []domainModels.CourseEvaluation{}
+	err := r.DB.SelectContext(ctx, &evaluations, evaluationSelect+`,
+		EXISTS (SELECT 1 FROM evaluation_participants p WHERE p.evaluation_id = e.id AND p.student_id = $1) AS answered`+evaluationFrom+`
+		JOIN student_courses s ON s.offering_id = e.offering_id AND s.student_id = $1
+		WHERE s.status IN `+evaluationParticipants+`
+		ORDER BY e.closes_at DESC, c.code`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return evaluations, nil
+}
+
+func (r *EvaluationRepositoryImpl) GetTeacherEvaluations(ctx context.Context, teacherID string) ([]domainModels.CourseEvaluation, error) {
+	evaluations := []domainModels.CourseEvaluation{}
+	err := r.DB.SelectContext(ctx, &evaluations, evaluationSelect+evaluationFrom+`
+		JOIN teacher_courses tc ON tc.course_id = o.course_id AND tc.teacher_id = $1
+		ORDER BY e.closes_at DESC, c.code`, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return evaluations, nil
+}
+
+func (r *EvaluationRepositoryImpl) IsParticipant(ctx context.Context, evaluationID, studentID string) (bool, error) {
+	var exists bool
+	err := r.DB.GetContext(ctx, &exists, `SELECT EXISTS (
+		SELECT 1 FROM course_evaluations e
+		JOIN student_courses sc ON sc.offering_id = e.offering_id
+		WHERE e.id = $1 AND sc.student_id = $2 AND sc.status IN `+evaluationParticipants+`)`, evaluationID, studentID)
+	return exists, err
+}
+
+// SubmitResponse записывает участие студента и его ответы одной транзакцией. Ответ не связан
+// со студентом и не хранит времени отправки, поэтому сопоставить их нельзя.
+func (r *EvaluationRepositoryImpl) SubmitResponse(ctx context.Context, evaluationID, studentID string, answers []domainModels.EvaluationAnswer) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO evaluation_participants (evaluation_id, student_id) VALUES ($1, $2)", evaluationID, studentID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domainModels.ErrEvaluationAnswered
+		}
+		return err
+	}
+	var responseID string
+	if err := tx.QueryRowxContext(ctx, "INSERT INTO evaluation_responses (evaluation_id) VALUES ($1) RETURNING id", evaluationID).Scan(&responseID); err != nil {
+		return err
+	}
+	for _, answer := range answers {
+		_, err := tx.ExecContext(ctx, "INSERT INTO evaluation_answers (response_id, question_id, rating, comment) VALUES ($1, $2, $3, $4)",
+			responseID, answer.QuestionID, answer.Rating, answer.Comment)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *EvaluationRepositoryImpl) GetAnswers(ctx context.Context, evaluationID string) ([]domainModels.EvaluationAnswer, error) {
+	answers := []domainModels.EvaluationAnswer{}
+	err := r.DB.SelectContext(ctx, &answers, `SELECT a.question_id, a.rating, a.comment
+		FROM evaluation_answers a
+		JOIN evaluation_responses resp ON resp.id = a.response_id
+		WHERE resp.evaluation_id = $1`, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const examSelect = `SELECT e.id, e.offering_id, o.term_id, o.course_id, c.code, c.name, e.title, e.starts_at, e.duration_minutes, e.ends_at,
+	ARRAY(SELECT er.room_id::text FROM exam_rooms er WHERE er.exam_id = e.id ORDER BY er.room_id) AS room_ids,
+	ARRAY(SELECT r.building || ', ' || r.name FROM exam_rooms er JOIN rooms r ON r.id = er.room_id
+		WHERE er.exam_id = e.id ORDER BY er.room_id) AS rooms,
+	ARRAY(SELECT ep.teacher_id::text FROM exam_proctors ep WHERE ep.exam_id = e.id ORDER BY ep.teacher_id) AS proctor_ids,
+	e.created_at
+FROM exams e
+JOIN course_offerings o ON o.id = e.offering_id
+JOIN courses c ON c.id = o.course_id`
+
+const examOrder = " ORDER BY e.starts_at, c.code, e.id"
+
+// examConflicts сопоставляет экзамены из candidates с пересекающимися по времени экзаменами и возвращает
+// общие аудитории, наблюдателей и студентов. candidates, candidate_rooms и candidate_proctors задаёт вызывающий.
+const examConflicts = `,
+existing AS (
+	SELECT e.id, e.offering_id, e.title, e.starts_at, e.ends_at, c.code
+	FROM exams e
+	JOIN course_offerings o ON o.id = e.offering_id
+	JOIN courses c ON c.id = o.course_id
+),
+pairs AS (
+	SELECT a.id AS exam_id, a.offering_id, b.id AS conflict_exam_id, b.offering_id AS conflict_offering_id,
+		b.code AS conflict_course_code, b.title AS conflict_title, b.starts_at, b.ends_at
+	FROM candidates a
+	JOIN existing b ON b.starts_at < a.ends_at AND b.ends_at > a.starts_at AND b.id IS DISTINCT FROM a.id
+),
+conflicts AS (
+	SELECT 'room' AS kind, ra.room_id::text AS subject_id, p.* FROM pairs p
+	JOIN candidate_rooms ra ON ra.exam_id IS NOT DISTINCT FROM p.exam_id
+	JOIN exam_rooms rb ON rb.exam_id = p.conflict_exam_id AND rb.room_id = ra.room_id
+	UNION ALL
+	SELECT 'teacher', pa.teacher_id::text, p.* FROM pairs p
+	JOIN candidate_proctors pa ON pa.exam_id IS NOT DISTINCT FROM p.exam_id
+	JOIN exam_proctors pb ON pb.exam_id = p.conflict_exam_id AND pb.teacher_id = pa.teacher_id
+	UNION ALL
+	SELECT 'student', sa.student_id::text, p.* FROM pairs p
+	JOIN student_courses sa ON sa.offering_id = p.offering_id AND sa.status = 'enrolled'
+	JOIN student_courses sb ON sb.offering_id = p.conflict_offering_id AND sb.status = 'enrolled' AND sb.student_id = sa.student_id
+)
+SELECT kind, subject_id, COALESCE(exam_id::text, '') AS exam_id, conflict_exam_id, conflict_course_code, conflict_title,
+	starts_at, ends_at
+FROM conflicts`
+
+type ExamRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewExamRepository(db *sqlx.DB) domainRepo.ExamRepository {
+	return &ExamRepositoryImpl{DB: db}
+}
+
+func (r *ExamRepositoryImpl) GetOfferingExams(ctx context.Context, offeringID string) ([]domainModels.Exam, error) {
+	return r.selectExams(ctx, examSelect+" WHERE e.offering_id = $1"+examOrder, offeringID)
+}
+
+func (r *ExamRepositoryImpl) GetTermExams(ctx context.Context, termID string) ([]domainModels.Exam, error) {
+	return r.selectExams(ctx, examSelect+" WHERE o.term_id = $1"+examOrder, termID)
+}
+
+func (r *ExamRepositoryImpl) GetExamByID(ctx context.Context, id string) (*domainModels.Exam, error) {
+	var exam domainModels.Exam
+	err := r.DB.GetContext(ctx, &exam, examSelect+" WHERE e.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrExamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &exam, nil
+}
+
+// CreateExam сохраняет экзамен с аудиториями и наблюдателями одной транзакцией
+func (r *ExamRepositoryImpl) CreateExam(ctx context.Context, exam *domainModels.Exam) (*domainModels.Exam, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowxContext(ctx, `INSERT INTO exams (offering_id, title, starts_at, duration_minutes, ends_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		exam.OfferingID, exam.Title, exam.StartsAt, exam.DurationMinutes, exam.EndsAt).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveExamStaff(ctx, tx, id, *exam); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetExamByID(ctx, id)
+}
+
+// UpdateExam переносит экзамен и заменяет аудитории и наблюдателей; прежняя рассадка удаляется,
+// потому что аудитории могли измениться
+func (r *ExamRepositoryImpl) UpdateExam(ctx context.Context, exam domainModels.Exam) (*domainModels.Exam, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE exams SET title = $1, starts_at = $2, duration_minutes = $3, ends_at = $4
+		WHERE id = $5`, exam.Title, exam.StartsAt, exam.DurationMinutes, exam.EndsAt, exam.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrExamNotFound
+	}
+	for _, table := range []string{"exam_seats", "exam_rooms", "exam_proctors"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE exam_id = $1", exam.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := saveExamStaff(ctx, tx, exam.ID, exam); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetExamByID(ctx, exam.ID)
+}
+
+func saveExamStaff(ctx context.Context, tx *sqlx.Tx, examID string, exam domainModels.Exam) error {
+	if _, err := tx.ExecContext(ctx, `INSERT INTO exam_rooms (exam_id, room_id)
+		SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING`, examID, exam.RoomIDs); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO exam_proctors (exam_id, teacher_id)
+		SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING`, examID, exam.ProctorIDs)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return domainModels.ErrProctorNotFound
+	}
+	return err
+}
+
+func (r *ExamRepositoryImpl) DeleteExam(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM exams WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrExamNotFound
+	}
+	return nil
+}
+
+func (r *ExamRepositoryImpl) CountOfferingStudents(ctx context.Context, offeringID string) (int, error) {
+	var count int
+	err := r.DB.GetContext(ctx, &count,
+		"SELECT COUNT(*) FROM student_courses WHERE offering_id = $1 AND status = 'enrolled'", offeringID)
+	return count, err
+}
+
+// FindConflicts проверяет экзамен перед сохранением; при изменении экзамена его текущая версия не учитывается
+func (r *ExamRepositoryImpl) FindConflicts(ctx context.Context, exam domainModels.Exam) ([]domainModels.ExamConflict, error) {
+	query := `WITH candidates AS (
+	SELECT $1::int AS id, $2::int AS offering_id, $3::timestamp AS starts_at, $4::timestamp AS ends_at
+),
+candidate_rooms AS (SELECT $1::int AS exam_id, unnest($5::int[]) AS room_id),
+candidate_proctors AS (SELECT $1::int AS exam_id, unnest($6::int[]) AS teacher_id)` + examConflicts + `
+ORDER BY kind, starts_at, subject_id`
+	conflicts := []domainModels.ExamConflict{}
+	err := r.DB.SelectContext(ctx, &conflicts, query, nullableID(exam.ID), exam.OfferingID,
+		exam.StartsAt, exam.EndsAt, exam.RoomIDs, exam.ProctorIDs)
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// GetTermConflicts возвращает конфликты между экзаменами периода, каждую пару экзаменов — один раз
+func (r *ExamRepositoryImpl) GetTermConflicts(ctx context.Context, termID string) ([]domainModels.ExamConflict, error) {
+	query := `WITH candidates AS (
+	SELECT e.id, e.offering_id, e.starts_at, e.ends_at
+	FROM exams e JOIN course_offerings o ON o.id = e.offering_id
+	WHERE o.term_id = $1
+),
+candidate_rooms AS (SELECT exam_id, room_id FROM exam_rooms),
+candidate_proctors AS (SELECT exam_id, teacher_id FROM exam_proctors)` + examConflicts + `
+WHERE exam_id < conflict_exam_id ORDER BY starts_at, kind, subject_id`
+	conflicts := []domainModels.ExamConflict{}
+	if err := r.DB.SelectContext(ctx, &conflicts, query, termID); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func (r *ExamRepositoryImpl) GetExamRooms(ctx context.Context, examID string) ([]domainModels.ExamRoom, error) {
+	rooms := []domainModels.ExamRoom{}
+	err := r.DB.SelectContext(ctx, &rooms, `
+		SELECT r.id AS room_id, r.building, r.name, r.capacity
+		FROM exam_rooms er JOIN rooms r ON r.id = er.room_id
+		WHERE er.exam_id = $1 ORDER BY r.id`, examID)
+	if err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+// GetExamStudents возвращает записанных на курс студентов экзамена в алфавитном порядке
+func (r *ExamRepositoryImpl) GetExamStudents(ctx context.Context, examID string) ([]domainModels.ExamSeat, error) {
+	students := []domainModels.ExamSeat{}
+	err := r.DB.SelectContext(ctx, &students, `
+		SELECT e.id AS exam_id, sc.student_id, u.firstname, u.lastname, '' AS room_id, '' AS building, '' AS room_name, 0 AS seat
+		FROM exams e
+		JOIN student_courses sc ON sc.offering_id = e.offering_id AND sc.status = 'enrolled'
+		JOIN users u ON u.id = sc.student_id
+		WHERE e.id = $1
+		ORDER BY u.lastname, u.firstname, sc.student_id`, examID)
+	if err != nil {
+		return nil, err
+	}
+	return students, nil
+}
+
+// SaveSeating заменяет рассадку экзамена одной транзакцией
+func (r *ExamRepositoryImpl) SaveSeating(ctx context.Context, examID string, seats []domainModels.ExamSeat) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM exam_seats WHERE exam_id = $1", examID); err != nil {
+		return err
+	}
+	for _, seat := range seats {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO exam_seats (exam_id, student_id, room_id, seat) VALUES ($1, $2, $3, $4)",
+			examID, seat.StudentID, seat.RoomID, seat.Seat); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *ExamRepositoryImpl) GetSeating(ctx context.Context, examID string) ([]domainModels.ExamSeat, error) {
+	seats := []domainModels.ExamSeat{}
+	err := r.DB.SelectContext(ctx, &seats, `
+		SELECT s.exam_id, s.student_id, u.firstname, u.lastname, s.room_id, r.building, r.name AS room_name, s.seat
+		FROM exam_seats s
+		JOIN users u ON u.id = s.student_id
+		JOIN rooms r ON r.id = s.room_id
+		WHERE s.exam_id = $1
+		ORDER BY r.id, s.seat`, examID)
+	if err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+func (r *ExamRepositoryImpl) GetStudentExams(ctx context.Context, studentID, termID string) ([]domainModels.StudentExam, error) {
+	query := `SELECT x.*, s.room_id::text AS seat_room_id, COALESCE(r.building, '') AS building,
+		COALESCE(r.name, '') AS room_name, s.seat
+	FROM (` + examSelect + `
+		JOIN student_courses sc ON sc.offering_id = e.offering_id AND sc.status = 'enrolled'
+		WHERE sc.student_id = $1 AND o.term_id = $2
+	) x
+	LEFT JOIN exam_seats s ON s.exam_id = x.id AND s.student_id = $1
+	LEFT JOIN rooms r ON r.id = s.room_id
+	ORDER BY x.starts_at, x.code, x.id`
+	exams := []domainModels.StudentExam{}
+	if err := r.DB.SelectContext(ctx, &exams, query, studentID, termID); err != nil {
+		return nil, err
+	}
+	return exams, nil
+}
+
+// GetTeacherExams возвращает экзамены, на которых преподаватель наблюдает, и экзамены его курсов
+func (r *ExamRepositoryImpl) GetTeacherExams(ctx context.Context, teacherID, termID string) ([]domainModels.Exam, error) {
+	return r.selectExams(ctx, examSelect+` WHERE o.term_id = $2 AND (
+		EXISTS (SELECT 1 FROM exam_proctors ep WHERE ep.exam_id = e.id AND ep.teacher_id = $1)
+		OR EXISTS (SELECT 1 FROM teacher_courses tc WHERE tc.course_id = o.course_id AND tc.teacher_id = $1))`+examOrder,
+		teacherID, termID)
+}
+
+func (r *ExamRepositoryImpl) selectExams(ctx context.Context, query string, args ...interface{}) ([]domainModels.Exam, error) {
+	exams := []domainModels.Exam{}
+	if err := r.DB.SelectContext(ctx, &exams, query, args...); err != nil {
+		return nil, err
+	}
+	return exams, nil
+}
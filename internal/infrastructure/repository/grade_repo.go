@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/jmoiron/sqlx"
+	"strconv"
 	domainModels "university_system/internal/domain/models"
 	domainRepo "university_system/internal/domain/repository"
 )
@@ -33,60 +37,113 @@ func (r *GradeRepositoryImpl) GetCourseMarks(ctx context.Context, courseID strin
 	return marks, nil
 }
 
-// AddMark добавляет оценку указанного типа
-func (r *GradeRepositoryImpl) AddMark(ctx context.Context, mark *domainModels.Mark, markType string) error {
-	var query string
-	var value float64
-
-	switch markType {
-	case "first_attestation":
-		query = "UPDATE course_marks SET first_attestation = $1 WHERE student_id = $2 AND course_id = $3"
-		value = mark.FirstAttestation
-	case "second_attestation":
-		query = "UPDATE course_marks SET second_attestation = $1 WHERE student_id = $2 AND course_id = $3"
-		value = mark.SecondAttestation
-	case "final":
-		query = "UPDATE course_marks SET final_mark = $1 WHERE student_id = $2 AND course_id = $3"
-		value = mark.FinalMark
-	default:
+// markColumns — колонки course_marks для каждого типа оценки
+var markColumns = map[string]string{
+	domainModels.MarkTypeFirstAttestation:  "first_attestation",
+	domainModels.MarkTypeSecondAttestation: "second_attestation",
+	domainModels.MarkTypeFinal:             "final_mark",
+}
+
+// AddMark выставляет оценку указанного типа, пока ведомость курса в периоде открыта,
+// и записывает изменение в журнал от имени changedBy
+func (r *GradeRepositoryImpl) AddMark(ctx context.Context, mark *domainModels.Mark, markType string, changedBy string) error {
+	return r.AddMarks(ctx, strconv.FormatUint(uint64(mark.OfferingID), 10), []domainModels.Mark{*mark}, markType, changedBy)
+}
+
+// AddMarks выставляет оценки указанного типа нескольким студентам курса в периоде одной транзакцией:
+// либо записываются все, либо ни одной
+func (r *GradeRepositoryImpl) AddMarks(ctx context.Context, offeringID string, marks []domainModels.Mark, markType string, changedBy string) error {
+	if _, ok := markColumns[markType]; !ok {
 		return domainModels.ErrInvalidMarkType
 	}
 
-	result, err := r.DB.ExecContext(ctx, query, value, mark.StudentID, mark.CourseID)
+	tx, err := r.DB.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	// Если не обновили ни одной записи, создаем новую запись
-	rows, err := result.RowsAffected()
+	// Строка ведомости блокируется до конца транзакции: отправка ведомости не пройдёт,
+	// пока оценки не записаны, а оценки не запишутся в отправленную ведомость
+	status, err := lockGradeSheet(ctx, tx, offeringID)
 	if err != nil {
 		return err
 	}
+	if status != domainModels.GradeSheetOpen {
+		return domainModels.ErrGradesLocked
+	}
 
-	if rows == 0 {
-		// Создаем новую запись с оценкой
-		query := "INSERT INTO course_marks (student_id, course_id, first_attestation, second_attestation, final_mark) VALUES ($1, $2, $3, $4, $5)"
-		
-		firstAtt := 0.0
-		secondAtt := 0.0
-		finalMark := 0.0
-		
+	for _, mark := range marks {
+		value := mark.FinalMark
 		switch markType {
-		case "first_attestation":
-			firstAtt = value
-		case "second_attestation":
-			secondAtt = value
-		case "final":
-			finalMark = value
+		case domainModels.MarkTypeFirstAttestation:
+			value = mark.FirstAttestation
+		case domainModels.MarkTypeSecondAttestation:
+			value = mark.SecondAttestation
 		}
-		
-		_, err = r.DB.ExecContext(ctx, query, mark.StudentID, mark.CourseID, firstAtt, secondAtt, finalMark)
-		if err != nil {
+		studentID := strconv.FormatUint(uint64(mark.StudentID), 10)
+		if err := setMark(ctx, tx, studentID, offeringID, markType, value, changedBy, nil); err != nil {
 			return err
 		}
 	}
+	return tx.Commit()
+}
 
-	return nil
+// lockGradeSheet создаёт ведомость курса в периоде, если её ещё нет, блокирует её строку
+// до конца транзакции и возвращает статус
+func lockGradeSheet(ctx context.Context, tx *sqlx.Tx, offeringID string) (string, error) {
+	if _, err := tx.ExecContext(ctx, "INSERT INTO grade_sheets (offering_id) VALUES ($1) ON CONFLICT (offering_id) DO NOTHING", offeringID); err != nil {
+		return "", err
+	}
+	var status string
+	err := tx.GetContext(ctx, &status, "SELECT status FROM grade_sheets WHERE offering_id = $1 FOR UPDATE", offeringID)
+	return status, err
+}
+
+// setMark обновляет или создаёт оценку и записывает старое и новое значение в mark_changes
+func setMark(ctx context.Context, tx *sqlx.Tx, studentID, offeringID, markType string, value float64, changedBy string, requestID *string) error {
+	column, ok := markColumns[markType]
+	if !ok {
+		return domainModels.ErrInvalidMarkType
+	}
+
+	var old sql.NullFloat64
+	err := tx.GetContext(ctx, &old,
+		fmt.Sprintf("SELECT %s FROM course_marks WHERE student_id = $1 AND offering_id = $2 FOR UPDATE", column),
+		studentID, offeringID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// Создаем новую запись с оценкой, остальные части оценки остаются нулевыми
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+			INSERT INTO course_marks (student_id, course_id, offering_id, %s)
+			SELECT $1, course_id, id, $3 FROM course_offerings WHERE id = $2`, column),
+			studentID, offeringID, value)
+	case err == nil:
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf("UPDATE course_marks SET %s = $1, updated_at = CURRENT_TIMESTAMP WHERE student_id = $2 AND offering_id = $3", column),
+			value, studentID, offeringID)
+	}
+	if err != nil {
+		return err
+	}
+
+	var oldValue *float64
+	if old.Valid {
+		oldValue = &old.Float64
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO mark_changes (student_id, offering_id, mark_type, old_value, new_value, changed_by, change_request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		studentID, offeringID, markType, oldValue, value, nullableID(changedBy), requestID)
+	return err
+}
+
+// nullableID превращает пустой ID в NULL
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
 }
 
 // IsTeacherOfCourse проверяет, является ли преподаватель ведущим данного курса
@@ -102,3 +159,201 @@ func (r *GradeRepositoryImpl) IsTeacherOfCourse(ctx context.Context, teacherID s
 	
 	return count > 0, nil
 }
+
+// GetEnrollmentOfferingID возвращает offering, в рамках которого студент проходит курс
+// (при повторном прохождении — самый поздний по дате начала периода)
+func (r *GradeRepositoryImpl) GetEnrollmentOfferingID(ctx context.Context, studentID string, courseID string) (uint, error) {
+	var offeringID uint
+	err := r.DB.GetContext(ctx, &offeringID, `
+		SELECT sc.offering_id
+		FROM student_courses sc
+		JOIN course_offerings co ON co.id = sc.offering_id
+		JOIN academic_terms t ON t.id = co.term_id
+		WHERE sc.student_id = $1 AND sc.course_id = $2 AND sc.status NOT IN ('dropped', 'withdrawn')
+		ORDER BY t.start_date DESC
+		LIMIT 1`, studentID, courseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domainModels.ErrStudentNotEnrolled
+	}
+	return offeringID, err
+}
+
+// GetStudentRecords возвращает все записи студента на курсы, кроме брошенных в период add/drop,
+// в порядке периодов
+func (r *GradeRepositoryImpl) GetStudentRecords(ctx context.Context, studentID string) ([]domainModels.CourseRecord, error) {
+	var records []domainModels.CourseRecord
+	err := r.DB.SelectContext(ctx, &records, `
+		SELECT sc.student_id, sc.course_id, sc.offering_id, sc.status,
+			COALESCE(m.first_attestation, 0) AS first_attestation,
+			COALESCE(m.second_attestation, 0) AS second_attestation,
+			COALESCE(m.final_mark, 0) AS final_mark,
+			o.term_id, t.year AS term_year, t.season AS term_season, c.code, c.name, c.credits
+		FROM student_courses sc
+		JOIN courses c ON c.id = sc.course_id
+		JOIN course_offerings o ON o.id = sc.offering_id
+		JOIN academic_terms t ON t.id = o.term_id
+		LEFT JOIN course_marks m ON m.student_id = sc.student_id AND m.offering_id = sc.offering_id
+		WHERE sc.student_id = $1 AND sc.status <> 'dropped'
+		ORDER BY t.start_date, c.code`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetOfferingMarks возвращает оценки всех студентов курса в периоде
+func (r *GradeRepositoryImpl) GetOfferingMarks(ctx context.Context, offeringID string) ([]domainModels.Mark, error) {
+	var marks []domainModels.Mark
+	err := r.DB.SelectContext(ctx, &marks, "SELECT * FROM course_marks WHERE offering_id = $1 ORDER BY student_id", offeringID)
+	if err != nil {
+		return nil, err
+	}
+	return marks, nil
+}
+
+// GetGradeSheet возвращает ведомость курса в периоде; пока оценок не было, ведомость открыта
+func (r *GradeRepositoryImpl) GetGradeSheet(ctx context.Context, offeringID string) (*domainModels.GradeSheet, error) {
+	var sheet domainModels.GradeSheet
+	err := r.DB.GetContext(ctx, &sheet, `
+		SELECT o.id AS offering_id, o.course_id, o.term_id, COALESCE(g.status, 'open') AS status,
+			g.submitted_by, g.submitted_at, g.reviewed_by, g.reviewed_at, COALESCE(g.review_comment, '') AS review_comment
+		FROM course_offerings o
+		LEFT JOIN grade_sheets g ON g.offering_id = o.id
+		WHERE o.id = $1`, offeringID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrOfferingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sheet, nil
+}
+
+// UpdateGradeSheetStatus переводит ведомость из состояния from в to. При отправке запоминается
+// отправивший преподаватель, при утверждении или возврате — менеджер и его комментарий.
+func (r *GradeRepositoryImpl) UpdateGradeSheetStatus(ctx context.Context, offeringID, from, to, actorID, comment string) error {
+	query := `UPDATE grade_sheets SET status = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP, review_comment = $3
+		WHERE offering_id = $4 AND status = $5`
+	if to == domainModels.GradeSheetSubmitted {
+		if _, err := r.DB.ExecContext(ctx, "INSERT INTO grade_sheets (offering_id) VALUES ($1) ON CONFLICT (offering_id) DO NOTHING", offeringID); err != nil {
+			return err
+		}
+		query = `UPDATE grade_sheets SET status = $1, submitted_by = $2, submitted_at = CURRENT_TIMESTAMP, review_comment = $3
+			WHERE offering_id = $4 AND status = $5`
+	}
+
+	result, err := r.DB.ExecContext(ctx, query, to, nullableID(actorID), comment, offeringID, from)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domainModels.ErrGradeSheetState
+	}
+	return nil
+}
+
+func (r *GradeRepositoryImpl) CreateGradeChangeRequest(ctx context.Context, request *domainModels.GradeChangeRequest) (*domainModels.GradeChangeRequest, error) {
+	query := `INSERT INTO grade_change_requests (offering_id, student_id, mark_type, old_value, new_value, reason, status, requested_by)
+		VALUES (:offering_id, :student_id, :mark_type, :old_value, :new_value, :reason, :status, :requested_by)
+		RETURNING id, requested_at`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	if err := stmt.QueryRowxContext(ctx, request).Scan(&request.ID, &request.RequestedAt); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetGradeChangeRequests возвращает запросы на изменение оценок; пустой status — все запросы
+func (r *GradeRepositoryImpl) GetGradeChangeRequests(ctx context.Context, status string) ([]domainModels.GradeChangeRequest, error) {
+	requests := []domainModels.GradeChangeRequest{}
+	err := r.DB.SelectContext(ctx, &requests,
+		"SELECT * FROM grade_change_requests WHERE $1 = '' OR status = $1 ORDER BY requested_at, id", status)
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *GradeRepositoryImpl) GetGradeChangeRequest(ctx context.Context, id string) (*domainModels.GradeChangeRequest, error) {
+	var request domainModels.GradeChangeRequest
+	err := r.DB.GetContext(ctx, &request, "SELECT * FROM grade_change_requests WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrGradeChangeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ApproveGradeChange применяет запрошенную оценку в утверждённой ведомости и закрывает запрос.
+// В журнал изменение попадает от имени утвердившего менеджера со ссылкой на запрос.
+func (r *GradeRepositoryImpl) ApproveGradeChange(ctx context.Context, id, reviewerID, comment string) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var request domainModels.GradeChangeRequest
+	err = tx.GetContext(ctx, &request, "SELECT * FROM grade_change_requests WHERE id = $1 FOR UPDATE", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainModels.ErrGradeChangeNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if request.Status != domainModels.GradeChangePending {
+		return domainModels.ErrGradeChangeNotPending
+	}
+
+	if err := setMark(ctx, tx, request.StudentID, request.OfferingID, request.MarkType, request.NewValue, reviewerID, &request.ID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE grade_change_requests SET status = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP, review_comment = $3
+		WHERE id = $4`, domainModels.GradeChangeApproved, nullableID(reviewerID), comment, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *GradeRepositoryImpl) RejectGradeChange(ctx context.Context, id, reviewerID, comment string) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE grade_change_requests SET status = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP, review_comment = $3
+		WHERE id = $4 AND status = $5`,
+		domainModels.GradeChangeRejected, nullableID(reviewerID), comment, id, domainModels.GradeChangePending)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		// Либо запроса нет, либо он уже рассмотрен
+		if _, err := r.GetGradeChangeRequest(ctx, id); err != nil {
+			return err
+		}
+		return domainModels.ErrGradeChangeNotPending
+	}
+	return nil
+}
+
+// GetMarkChanges возвращает журнал изменений оценок курса в периоде в хронологическом порядке
+func (r *GradeRepositoryImpl) GetMarkChanges(ctx context.Context, offeringID string) ([]domainModels.MarkChange, error) {
+	changes := []domainModels.MarkChange{}
+	err := r.DB.SelectContext(ctx, &changes, "SELECT * FROM mark_changes WHERE offering_id = $1 ORDER BY changed_at, id", offeringID)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+// offeringSchemeJoin подбирает схему оценивания для курса в периоде o: сначала схему курса
+// в этом периоде, затем схему курса, схему периода и общую схему
+const offeringSchemeJoin = `LEFT JOIN LATERAL (
+	SELECT * FROM grading_schemes gs
+	WHERE (gs.course_id = o.course_id OR gs.course_id IS NULL) AND (gs.term_id = o.term_id OR gs.term_id IS NULL)
+	ORDER BY gs.course_id IS NULL, gs.term_id IS NULL
+	LIMIT 1
+) gs ON true`
+
+// weightedTotal — итоговый балл оценок m по схеме gs с округлением до сотых, как в GradeService;
+// без схемы баллы просто складываются, как в models.DefaultGradingScheme
+const weightedTotal = `ROUND(COALESCE(
+	m.first_attestation / gs.first_attestation_max * gs.first_attestation_weight
+		+ m.second_attestation / gs.second_attestation_max * gs.second_attestation_weight
+		+ m.final_mark / gs.final_max * gs.final_weight,
+	m.first_attestation + m.second_attestation + m.final_mark)::numeric, 2)::float8`
+
+// schemePassingTotal — проходной итоговый балл схемы gs: нижняя граница младшей буквенной оценки
+// с ненулевыми баллами GPA, как в GradingScheme.PassingTotal; без схемы — models.PassingTotal
+var schemePassingTotal = fmt.Sprintf(`COALESCE((SELECT MIN(b.min_total) FROM grade_boundaries b
+	WHERE b.scheme_id = gs.id AND b.gpa_points > 0), %v)`, domainModels.PassingTotal)
+
+type GradingSchemeRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewGradingSchemeRepository(db *sqlx.DB) domainRepo.GradingSchemeRepository {
+	return &GradingSchemeRepositoryImpl{DB: db}
+}
+
+func (r *GradingSchemeRepositoryImpl) GetGradingSchemes(ctx context.Context) ([]domainModels.GradingScheme, error) {
+	var schemes []domainModels.GradingScheme
+	err := r.DB.SelectContext(ctx, &schemes, "SELECT * FROM grading_schemes ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	for i := range schemes {
+		if schemes[i].Letters, err = r.getLetters(ctx, schemes[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return schemes, nil
+}
+
+func (r *GradingSchemeRepositoryImpl) GetGradingSchemeByID(ctx context.Context, id string) (*domainModels.GradingScheme, error) {
+	var scheme domainModels.GradingScheme
+	err := r.DB.GetContext(ctx, &scheme, "SELECT * FROM grading_schemes WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrGradingSchemeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if scheme.Letters, err = r.getLetters(ctx, scheme.ID); err != nil {
+		return nil, err
+	}
+	return &scheme, nil
+}
+
+func (r *GradingSchemeRepositoryImpl) CreateGradingScheme(ctx context.Context, scheme *domainModels.GradingScheme) (*domainModels.GradingScheme, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareNamedContext(ctx, `INSERT INTO grading_schemes (name, course_id, term_id,
+			first_attestation_max, second_attestation_max, final_max,
+			first_attestation_weight, second_attestation_weight, final_weight)
+		VALUES (:name, :course_id, :term_id, :first_attestation_max, :second_attestation_max, :final_max,
+			:first_attestation_weight, :second_attestation_weight, :final_weight) RETURNING id`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var id string
+	if err := stmt.QueryRowxContext(ctx, scheme).Scan(&id); err != nil {
+		return nil, schemeError(err)
+	}
+
+	if err := replaceLetters(ctx, tx, id, scheme.Letters); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	scheme.ID = id
+	return scheme, nil
+}
+
+func (r *GradingSchemeRepositoryImpl) UpdateGradingScheme(ctx context.Context, scheme domainModels.GradingScheme) (*domainModels.GradingScheme, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.NamedExecContext(ctx, `UPDATE grading_schemes SET name=:name, course_id=:course_id, term_id=:term_id,
+		first_attestation_max=:first_attestation_max, second_attestation_max=:second_attestation_max, final_max=:final_max,
+		first_attestation_weight=:first_attestation_weight, second_attestation_weight=:second_attestation_weight,
+		final_weight=:final_weight, updated_at=CURRENT_TIMESTAMP WHERE id=:id`, &scheme)
+	if err != nil {
+		return nil, schemeError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrGradingSchemeNotFound
+	}
+	if err := replaceLetters(ctx, tx, scheme.ID, scheme.Letters); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &scheme, nil
+}
+
+func (r *GradingSchemeRepositoryImpl) DeleteGradingScheme(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx, "DELETE FROM grading_schemes WHERE id = $1", id)
+	return err
+}
+
+func (r *GradingSchemeRepositoryImpl) GetOfferingScheme(ctx context.Context, offeringID string) (*domainModels.GradingScheme, error) {
+	var schemeID sql.NullString
+	err := r.DB.GetContext(ctx, &schemeID, "SELECT gs.id FROM course_offerings o "+offeringSchemeJoin+" WHERE o.id = $1", offeringID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrOfferingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !schemeID.Valid {
+		return nil, domainModels.ErrGradingSchemeNotFound
+	}
+	return r.GetGradingSchemeByID(ctx, schemeID.String)
+}
+
+func (r *GradingSchemeRepositoryImpl) getLetters(ctx context.Context, schemeID string) ([]domainModels.LetterGrade, error) {
+	letters := []domainModels.LetterGrade{}
+	err := r.DB.SelectContext(ctx, &letters,
+		"SELECT letter, min_total, gpa_points FROM grade_boundaries WHERE scheme_id = $1 ORDER BY min_total DESC", schemeID)
+	if err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+func replaceLetters(ctx context.Context, tx *sqlx.Tx, schemeID string, letters []domainModels.LetterGrade) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM grade_boundaries WHERE scheme_id = $1", schemeID); err != nil {
+		return err
+	}
+	for _, letter := range letters {
+		_, err := tx.ExecContext(ctx, "INSERT INTO grade_boundaries (scheme_id, letter, min_total, gpa_points) VALUES ($1, $2, $3, $4)",
+			schemeID, letter.Letter, letter.MinTotal, letter.GPAPoints)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemeError переводит нарушение уникальности (курс, период) в доменную ошибку
+func schemeError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return domainModels.ErrGradingSchemeExists
+	}
+	return err
+}
@@ -20,7 +20,7 @@ func (r *ManagerRepositoryImpl) GetManagers(ctx context.Context) ([]domainModels
 	query := `SELECT 
 		m.id, 
 		u.username, u.firstname, u.lastname, u.email, u.role, u.birthdate, 
-		m.created_at, m.updated_at, m.deleted_at
+		m.department, m.department_id, m.created_at, m.updated_at, m.deleted_at
 	FROM managers m
 	JOIN users u ON m.id = u.id`
 	err := r.DB.SelectContext(ctx, &managers, query)
@@ -35,7 +35,7 @@ func (r *ManagerRepositoryImpl) GetManagerById(ctx context.Context, id string) (
 	query := `SELECT 
 		m.id, 
 		u.username, u.firstname, u.lastname, u.email, u.role, u.birthdate, 
-		m.created_at, m.updated_at, m.deleted_at
+		m.department, m.department_id, m.created_at, m.updated_at, m.deleted_at
 	FROM managers m
 	JOIN users u ON m.id = u.id
 	WHERE m.id = $1`
@@ -47,7 +47,7 @@ func (r *ManagerRepositoryImpl) GetManagerById(ctx context.Context, id string) (
 }
 
 func (r *ManagerRepositoryImpl) CreateManager(ctx context.Context, manager *domainModels.Manager) (*domainModels.Manager, error) {
-	query := `INSERT INTO managers (id, department) VALUES (:id, :department) RETURNING id`
+	query := `INSERT INTO managers (id, department, department_id) VALUES (:id, :department, :department_id) RETURNING id`
 	stmt, err := r.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -63,7 +63,7 @@ func (r *ManagerRepositoryImpl) CreateManager(ctx context.Context, manager *doma
 }
 
 func (r *ManagerRepositoryImpl) UpdateManager(ctx context.Context, manager domainModels.Manager) (*domainModels.Manager, error) {
-	_, err := r.DB.NamedExecContext(ctx, `UPDATE managers SET id=:id WHERE id=:id`, &manager)
+	_, err := r.DB.NamedExecContext(ctx, `UPDATE managers SET department=:department, department_id=:department_id WHERE id=:id`, &manager)
 	if err != nil {
 		return nil, err
 	}
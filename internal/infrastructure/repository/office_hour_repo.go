@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const officeHourSelect = `SELECT h.id, h.teacher_id, u.firstname || ' ' || u.lastname AS teacher_name, h.weekday,
+	to_char(h.start_time, 'HH24:MI') AS start_time, to_char(h.end_time, 'HH24:MI') AS end_time,
+	h.slot_minutes, h.location, h.valid_from, h.valid_until, h.created_at
+FROM office_hours h
+JOIN users u ON u.id = h.teacher_id`
+
+const bookingSelect = `SELECT b.id, b.office_hour_id, h.teacher_id, tu.firstname || ' ' || tu.lastname AS teacher_name,
+	b.student_id, su.firstname || ' ' || su.lastname AS student_name, b.starts_at, b.ends_at, h.location,
+	b.topic, b.status, b.created_at, b.cancelled_at, b.cancelled_by
+FROM office_hour_bookings b
+JOIN office_hours h ON h.id = b.office_hour_id
+JOIN users tu ON tu.id = h.teacher_id
+JOIN users su ON su.id = b.student_id`
+
+type OfficeHourRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewOfficeHourRepository(db *sqlx.DB) domainRepo.OfficeHourRepository {
+	return &OfficeHourRepositoryImpl{DB: db}
+}
+
+func (r *OfficeHourRepositoryImpl) GetTeacherOfficeHours(ctx context.Context, teacherID string) ([]domainModels.OfficeHour, error) {
+	hours := []domainModels.OfficeHour{}
+	err := r.DB.SelectContext(ctx, &hours, officeHourSelect+" WHERE h.teacher_id = $1 ORDER BY h.weekday, h.start_time", teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+func (r *OfficeHourRepositoryImpl) GetOfficeHourByID(ctx context.Context, id string) (*domainModels.OfficeHour, error) {
+	var hour domainModels.OfficeHour
+	err := r.DB.GetContext(ctx, &hour, officeHourSelect+" WHERE h.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrOfficeHourNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &hour, nil
+}
+
+// CreateOfficeHour добавляет часы под блокировкой преподавателя, чтобы одновременно созданные
+// часы не прошли проверку пересечения каждые по отдельности
+func (r *OfficeHourRepositoryImpl) CreateOfficeHour(ctx context.Context, hour *domainModels.OfficeHour) (*domainModels.OfficeHour, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockOfficeHourTeacher(ctx, tx, hour.TeacherID); err != nil {
+		return nil, err
+	}
+	if err := ensureNoOfficeHourOverlap(ctx, tx, hour); err != nil {
+		return nil, err
+	}
+	var id string
+	err = tx.QueryRowxContext(ctx, `INSERT INTO office_hours
+		(teacher_id, weekday, start_time, end_time, slot_minutes, location, valid_from, valid_until)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		hour.TeacherID, hour.Weekday, hour.StartTime, hour.EndTime, hour.SlotMinutes, hour.Location,
+		hour.ValidFrom, hour.ValidUntil).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetOfficeHourByID(ctx, id)
+}
+
+// UpdateOfficeHour меняет часы под блокировкой преподавателя и самих часов: блокировка часов
+// задерживает новые записи на них до конца транзакции (см. CreateBooking)
+func (r *OfficeHourRepositoryImpl) UpdateOfficeHour(ctx context.Context, hour *domainModels.OfficeHour, now time.Time) (*domainModels.OfficeHour, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockOfficeHourTeacher(ctx, tx, hour.TeacherID); err != nil {
+		return nil, err
+	}
+	if err := lockOfficeHour(ctx, tx, hour.ID); err != nil {
+		return nil, err
+	}
+	if err := ensureNoUpcomingBookings(ctx, tx, hour.ID, now); err != nil {
+		return nil, err
+	}
+	if err := ensureNoOfficeHourOverlap(ctx, tx, hour); err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE office_hours SET weekday = $2, start_time = $3, end_time = $4,
+		slot_minutes = $5, location = $6, valid_from = $7, valid_until = $8 WHERE id = $1`,
+		hour.ID, hour.Weekday, hour.StartTime, hour.EndTime, hour.SlotMinutes, hour.Location, hour.ValidFrom, hour.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetOfficeHourByID(ctx, hour.ID)
+}
+
+func (r *OfficeHourRepositoryImpl) DeleteOfficeHour(ctx context.Context, id string, now time.Time) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockOfficeHour(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := ensureNoUpcomingBookings(ctx, tx, id, now); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM office_hours WHERE id = $1", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockOfficeHourTeacher блокирует строку преподавателя: изменения часов одного преподавателя
+// выполняются по очереди
+func lockOfficeHourTeacher(ctx context.Context, tx *sqlx.Tx, teacherID string) error {
+	var id string
+	err := tx.GetContext(ctx, &id, "SELECT id FROM teachers WHERE id = $1 FOR UPDATE", teacherID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainModels.ErrTeacherNotFound
+	}
+	return err
+}
+
+func lockOfficeHour(ctx context.Context, tx *sqlx.Tx, id string) error {
+	var locked string
+	err := tx.GetContext(ctx, &locked, "SELECT id FROM office_hours WHERE id = $1 FOR UPDATE", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainModels.ErrOfficeHourNotFound
+	}
+	return err
+}
+
+// ensureNoUpcomingBookings — у часов нет действующих записей, начинающихся не раньше from
+func ensureNoUpcomingBookings(ctx context.Context, tx *sqlx.Tx, officeHourID string, from time.Time) error {
+	var booked bool
+	err := tx.GetContext(ctx, &booked, `SELECT EXISTS (SELECT 1 FROM office_hour_bookings
+		WHERE office_hour_id = $1 AND status = 'booked' AND starts_at >= $2)`, officeHourID, from)
+	if err != nil {
+		return err
+	}
+	if booked {
+		return domainModels.ErrOfficeHourHasBookings
+	}
+	return nil
+}
+
+// ensureNoOfficeHourOverlap проверяет, что у преподавателя нет других часов в тот же день недели,
+// которые пересекаются с hour по времени и действуют хотя бы в одну общую дату; пустая дата — открытая граница
+func ensureNoOfficeHourOverlap(ctx context.Context, tx *sqlx.Tx, hour *domainModels.OfficeHour) error {
+	var other domainModels.OfficeHour
+	err := tx.GetContext(ctx, &other, `SELECT h.id, to_char(h.start_time, 'HH24:MI') AS start_time,
+			to_char(h.end_time, 'HH24:MI') AS end_time
+		FROM office_hours h
+		WHERE h.teacher_id = $1 AND h.weekday = $2 AND ($3 = '' OR h.id::text <> $3)
+			AND h.start_time < $5::time AND $4::time < h.end_time
+			AND ($6::date IS NULL OR h.valid_until IS NULL OR h.valid_until >= $6::date)
+			AND ($7::date IS NULL OR h.valid_from IS NULL OR h.valid_from <= $7::date)
+		ORDER BY h.start_time LIMIT 1`,
+		hour.TeacherID, hour.Weekday, hour.ID, hour.StartTime, hour.EndTime, hour.ValidFrom, hour.ValidUntil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s-%s on the same weekday (office hour %s)", domainModels.ErrOfficeHourOverlap,
+		other.StartTime, other.EndTime, other.ID)
+}
+
+func (r *OfficeHourRepositoryImpl) GetBookedSlots(ctx context.Context, officeHourID string, from, to time.Time) ([]time.Time, error) {
+	slots := []time.Time{}
+	err := r.DB.SelectContext(ctx, &slots, `SELECT starts_at FROM office_hour_bookings
+		WHERE office_hour_id = $1 AND status = 'booked' AND starts_at >= $2 AND starts_at < $3
+		ORDER BY starts_at`, officeHourID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// CreateBooking полагается на ограничения по действующим записям: частичный уникальный индекс
+// пропускает только одну из одновременных записей на слот, а ограничение-исключение
+// office_hour_bookings_student_overlap — только одну из пересекающихся по времени записей студента.
+// Слот проверяется заново по часам, заблокированным на время вставки: если часы успели изменить
+// или удалить, запись на слот прежнего расписания не создаётся.
+func (r *OfficeHourRepositoryImpl) CreateBooking(ctx context.Context, booking *domainModels.OfficeHourBooking) (*domainModels.OfficeHourBooking, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var offered bool
+	err = tx.GetContext(ctx, &offered, `SELECT weekday = EXTRACT(DOW FROM $2::timestamp)
+			AND ($2::timestamp)::time >= start_time AND ($3::timestamp)::time <= end_time
+			AND $3::timestamp - $2::timestamp = slot_minutes * interval '1 minute'
+			AND EXTRACT(EPOCH FROM ($2::timestamp)::time - start_time)::integer % (slot_minutes * 60) = 0
+			AND (valid_from IS NULL OR valid_from <= ($2::timestamp)::date)
+			AND (valid_until IS NULL OR valid_until >= ($2::timestamp)::date)
+		FROM office_hours WHERE id = $1 FOR SHARE`, booking.OfficeHourID, booking.StartsAt, booking.EndsAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrOfficeHourNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !offered {
+		return nil, fmt.Errorf("%w: office hour has changed", domainModels.ErrInvalidSlot)
+	}
+
+	var id string
+	err = tx.QueryRowxContext(ctx, `INSERT INTO office_hour_bookings (office_hour_id, student_id, starts_at, ends_at, topic)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		booking.OfficeHourID, booking.StudentID, booking.StartsAt, booking.EndsAt, booking.Topic).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch {
+			case pqErr.Code == "23P01":
+				return nil, domainModels.ErrBookingOverlap
+			case pqErr.Code == "23505":
+				return nil, domainModels.ErrSlotTaken
+			}
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetBookingByID(ctx, id)
+}
+
+func (r *OfficeHourRepositoryImpl) GetBookingByID(ctx context.Context, id string) (*domainModels.OfficeHourBooking, error) {
+	var booking domainModels.OfficeHourBooking
+	err := r.DB.GetContext(ctx, &booking, bookingSelect+" WHERE b.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+func (r *OfficeHourRepositoryImpl) GetStudentBookings(ctx context.Context, studentID string, from *time.Time) ([]domainModels.OfficeHourBooking, error) {
+	bookings := []domainModels.OfficeHourBooking{}
+	err := r.DB.SelectContext(ctx, &bookings, bookingSelect+`
+		WHERE b.student_id = $1 AND ($2::timestamp IS NULL OR b.starts_at >= $2)
+		ORDER BY b.starts_at, b.id`, studentID, from)
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (r *OfficeHourRepositoryImpl) GetTeacherBookings(ctx context.Context, teacherID string, from *time.Time) ([]domainModels.OfficeHourBooking, error) {
+	bookings := []domainModels.OfficeHourBooking{}
+	err := r.DB.SelectContext(ctx, &bookings, bookingSelect+`
+		WHERE h.teacher_id = $1 AND ($2::timestamp IS NULL OR b.starts_at >= $2)
+		ORDER BY b.starts_at, b.id`, teacherID, from)
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (r *OfficeHourRepositoryImpl) CancelBooking(ctx context.Context, id, cancelledBy string, at time.Time) error {
+	result, err := r.DB.ExecContext(ctx, `UPDATE office_hour_bookings SET status = 'cancelled', cancelled_at = $3, cancelled_by = $2
+		WHERE id = $1 AND status = 'booked'`, id, cancelledBy, at)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrBookingNotCancellable
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const departmentSelect = `SELECT d.*, f.name AS faculty_name FROM departments d LEFT JOIN faculties f ON f.id = d.faculty_id`
+
+type OrganizationRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewOrganizationRepository(db *sqlx.DB) domainRepo.OrganizationRepository {
+	return &OrganizationRepositoryImpl{DB: db}
+}
+
+// nameMatch — условие совпадения кода, названия или псевдонима записи alias с $1
+// без учёта регистра и крайних пробелов
+func nameMatch(alias string) string {
+	return `(lower(` + alias + `.code) = lower(btrim($1)) OR lower(` + alias + `.name) = lower(btrim($1))
+		OR EXISTS (SELECT 1 FROM unnest(` + alias + `.aliases) a WHERE lower(a) = lower(btrim($1))))`
+}
+
+func (r *OrganizationRepositoryImpl) GetFaculties(ctx context.Context) ([]domainModels.Faculty, error) {
+	faculties := []domainModels.Faculty{}
+	if err := r.DB.SelectContext(ctx, &faculties, "SELECT * FROM faculties ORDER BY name"); err != nil {
+		return nil, err
+	}
+	return faculties, nil
+}
+
+func (r *OrganizationRepositoryImpl) GetFacultyByID(ctx context.Context, id string) (*domainModels.Faculty, error) {
+	var faculty domainModels.Faculty
+	err := r.DB.GetContext(ctx, &faculty, "SELECT * FROM faculties WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrFacultyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &faculty, nil
+}
+
+// FindFaculty предпочитает совпадение по коду: код уникален, а псевдонимы разных факультетов могут пересекаться
+func (r *OrganizationRepositoryImpl) FindFaculty(ctx context.Context, name string) (*domainModels.Faculty, error) {
+	var faculty domainModels.Faculty
+	err := r.DB.GetContext(ctx, &faculty, `SELECT f.* FROM faculties f WHERE `+nameMatch("f")+`
+		ORDER BY lower(f.code) = lower(btrim($1)) DESC, f.id LIMIT 1`, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrFacultyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &faculty, nil
+}
+
+func (r *OrganizationRepositoryImpl) CreateFaculty(ctx context.Context, faculty *domainModels.Faculty) (*domainModels.Faculty, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `INSERT INTO faculties (code, name, aliases) VALUES ($1, $2, $3) RETURNING id`,
+		faculty.Code, faculty.Name, faculty.Aliases).Scan(&id)
+	if err != nil {
+		return nil, facultyError(err)
+	}
+	return r.GetFacultyByID(ctx, id)
+}
+
+// UpdateFaculty меняет факультет и переписывает его прежнее название у студентов, программ
+// и в списках допущенных факультетов курсов
+func (r *OrganizationRepositoryImpl) UpdateFaculty(ctx context.Context, faculty domainModels.Faculty) (*domainModels.Faculty, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var previous string
+	err = tx.GetContext(ctx, &previous, "SELECT name FROM faculties WHERE id = $1 FOR UPDATE", faculty.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrFacultyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE faculties SET code = $1, name = $2, aliases = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4`, faculty.Code, faculty.Name, faculty.Aliases, faculty.ID)
+	if err != nil {
+		return nil, facultyError(err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE students SET faculty = $1 WHERE faculty_id = $2", faculty.Name, faculty.ID); err != nil {
+		return nil, err
+	}
+	if err := relabelFaculty(ctx, tx, []string{previous}, faculty.Name); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetFacultyByID(ctx, faculty.ID)
+}
+
+func (r *OrganizationRepositoryImpl) DeleteFaculty(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM faculties WHERE id = $1", id)
+	if err != nil {
+		return facultyError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrFacultyNotFound
+	}
+	return nil
+}
+
+// MergeFaculties переносит студентов и кафедры факультета sourceID в targetID, заменяет строковые
+// ссылки на дубликат названием targetID, сохраняет псевдонимы aliases и удаляет дубликат
+func (r *OrganizationRepositoryImpl) MergeFaculties(ctx context.Context, targetID, sourceID string, aliases []string) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var target, source domainModels.Faculty
+	if err := tx.GetContext(ctx, &target, "SELECT * FROM faculties WHERE id = $1 FOR UPDATE", targetID); err != nil {
+		return notFound(err, domainModels.ErrFacultyNotFound)
+	}
+	if err := tx.GetContext(ctx, &source, "SELECT * FROM faculties WHERE id = $1 FOR UPDATE", sourceID); err != nil {
+		return notFound(err, domainModels.ErrFacultyNotFound)
+	}
+
+	statements := []string{
+		"UPDATE students SET faculty_id = $1, faculty = $3, updated_at = CURRENT_TIMESTAMP WHERE faculty_id = $2",
+		"UPDATE departments SET faculty_id = $1, updated_at = CURRENT_TIMESTAMP WHERE faculty_id = $2",
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, targetID, sourceID, target.Name); err != nil {
+			return err
+		}
+	}
+	names := append([]string{source.Code, source.Name}, source.Aliases...)
+	if err := relabelFaculty(ctx, tx, names, target.Name); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM faculties WHERE id = $1", sourceID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE faculties SET aliases = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2",
+		pq.StringArray(aliases), targetID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// relabelFaculty заменяет названия names (без учёта регистра) на name в списках допущенных
+// факультетов курсов и у программ — там факультет по-прежнему хранится строкой
+func relabelFaculty(ctx context.Context, tx *sqlx.Tx, names []string, name string) error {
+	lowered := make([]string, 0, len(names))
+	for _, n := range names {
+		lowered = append(lowered, strings.ToLower(strings.TrimSpace(n)))
+	}
+	_, err := tx.ExecContext(ctx, `UPDATE courses SET allowed_faculties = ARRAY(
+			SELECT DISTINCT CASE WHEN lower(btrim(a)) = ANY($1) THEN $2 ELSE a END FROM unnest(allowed_faculties) a)
+		WHERE EXISTS (SELECT 1 FROM unnest(allowed_faculties) a WHERE lower(btrim(a)) = ANY($1))`, pq.Array(lowered), name)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE programs SET faculty = $2 WHERE lower(btrim(faculty)) = ANY($1)", pq.Array(lowered), name)
+	return err
+}
+
+func (r *OrganizationRepositoryImpl) GetDepartments(ctx context.Context, facultyID string) ([]domainModels.Department, error) {
+	departments := []domainModels.Department{}
+	err := r.DB.SelectContext(ctx, &departments, departmentSelect+` WHERE $1 = '' OR d.faculty_id::text = $1 ORDER BY d.name`, facultyID)
+	if err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
+
+func (r *OrganizationRepositoryImpl) GetDepartmentByID(ctx context.Context, id string) (*domainModels.Department, error) {
+	var department domainModels.Department
+	err := r.DB.GetContext(ctx, &department, departmentSelect+" WHERE d.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrDepartmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *OrganizationRepositoryImpl) FindDepartment(ctx context.Context, name string) (*domainModels.Department, error) {
+	var department domainModels.Department
+	err := r.DB.GetContext(ctx, &department, departmentSelect+` WHERE `+nameMatch("d")+`
+		ORDER BY lower(d.code) = lower(btrim($1)) DESC, d.id LIMIT 1`, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrDepartmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *OrganizationRepositoryImpl) CreateDepartment(ctx context.Context, department *domainModels.Department) (*domainModels.Department, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `INSERT INTO departments (faculty_id, code, name, aliases) VALUES ($1, $2, $3, $4) RETURNING id`,
+		department.FacultyID, department.Code, department.Name, department.Aliases).Scan(&id)
+	if err != nil {
+		return nil, departmentError(err)
+	}
+	return r.GetDepartmentByID(ctx, id)
+}
+
+// UpdateDepartment меняет кафедру и переписывает её название у преподавателей и менеджеров
+func (r *OrganizationRepositoryImpl) UpdateDepartment(ctx context.Context, department domainModels.Department) (*domainModels.Department, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE departments SET faculty_id = $1, code = $2, name = $3, aliases = $4,
+		updated_at = CURRENT_TIMESTAMP WHERE id = $5`,
+		department.FacultyID, department.Code, department.Name, department.Aliases, department.ID)
+	if err != nil {
+		return nil, departmentError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrDepartmentNotFound
+	}
+	for _, table := range []string{"teachers", "managers"} {
+		if _, err := tx.ExecContext(ctx, "UPDATE "+table+" SET department = $1 WHERE department_id = $2", department.Name, department.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetDepartmentByID(ctx, department.ID)
+}
+
+func (r *OrganizationRepositoryImpl) DeleteDepartment(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM departments WHERE id = $1", id)
+	if err != nil {
+		return departmentError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+// MergeDepartments переносит преподавателей, менеджеров и курсы кафедры sourceID в targetID,
+// сохраняет псевдонимы aliases и удаляет дубликат
+func (r *OrganizationRepositoryImpl) MergeDepartments(ctx context.Context, targetID, sourceID string, aliases []string) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var target, source domainModels.Department
+	if err := tx.GetContext(ctx, &target, "SELECT * FROM departments WHERE id = $1 FOR UPDATE", targetID); err != nil {
+		return notFound(err, domainModels.ErrDepartmentNotFound)
+	}
+	if err := tx.GetContext(ctx, &source, "SELECT * FROM departments WHERE id = $1 FOR UPDATE", sourceID); err != nil {
+		return notFound(err, domainModels.ErrDepartmentNotFound)
+	}
+
+	statements := []string{
+		"UPDATE teachers SET department_id = $1, department = $3, updated_at = CURRENT_TIMESTAMP WHERE department_id = $2",
+		"UPDATE managers SET department_id = $1, department = $3, updated_at = CURRENT_TIMESTAMP WHERE department_id = $2",
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, targetID, sourceID, target.Name); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE courses SET department_id = $1, updated_at = CURRENT_TIMESTAMP WHERE department_id = $2",
+		targetID, sourceID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM departments WHERE id = $1", sourceID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE departments SET aliases = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2",
+		pq.StringArray(aliases), targetID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *OrganizationRepositoryImpl) GetManagerDepartment(ctx context.Context, managerID string) (*domainModels.Department, error) {
+	var department domainModels.Department
+	err := r.DB.GetContext(ctx, &department, `SELECT d.*, f.name AS faculty_name
+		FROM managers m JOIN departments d ON d.id = m.department_id LEFT JOIN faculties f ON f.id = d.faculty_id
+		WHERE m.id = $1`, managerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrManagerWithoutDepartment
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func notFound(err error, notFoundErr error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFoundErr
+	}
+	return err
+}
+
+// facultyError: 23505 — занятый код, 23503 — на факультет ссылаются студенты или кафедры
+func facultyError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return domainModels.ErrFacultyCodeTaken
+		case "23503":
+			return domainModels.ErrFacultyInUse
+		}
+	}
+	return err
+}
+
+// departmentError: 23505 — занятый код; 23503 при сохранении — неизвестный факультет,
+// при удалении — на кафедру ссылаются преподаватели, менеджеры или курсы
+func departmentError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "23505":
+			return domainModels.ErrDepartmentCodeTaken
+		case pqErr.Code == "23503" && pqErr.Table == "departments":
+			return domainModels.ErrFacultyNotFound
+		case pqErr.Code == "23503":
+			return domainModels.ErrDepartmentInUse
+		}
+	}
+	return err
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const curriculumSelect = `SELECT cu.id, cu.program_id, cu.name, cu.catalog_year, cu.total_credits,
+	ARRAY(SELECT cc.course_id::text FROM curriculum_courses cc WHERE cc.curriculum_id = cu.id ORDER BY cc.course_id) AS required_course_ids,
+	cu.created_at, cu.updated_at
+FROM curricula cu`
+
+type ProgramRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewProgramRepository(db *sqlx.DB) domainRepo.ProgramRepository {
+	return &ProgramRepositoryImpl{DB: db}
+}
+
+func (r *ProgramRepositoryImpl) GetPrograms(ctx context.Context) ([]domainModels.Program, error) {
+	programs := []domainModels.Program{}
+	if err := r.DB.SelectContext(ctx, &programs, "SELECT * FROM programs ORDER BY code"); err != nil {
+		return nil, err
+	}
+	return programs, nil
+}
+
+func (r *ProgramRepositoryImpl) GetProgramByID(ctx context.Context, id string) (*domainModels.Program, error) {
+	var program domainModels.Program
+	err := r.DB.GetContext(ctx, &program, "SELECT * FROM programs WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrProgramNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &program, nil
+}
+
+func (r *ProgramRepositoryImpl) CreateProgram(ctx context.Context, program *domainModels.Program) (*domainModels.Program, error) {
+	query := `INSERT INTO programs (code, name, degree, faculty, description, advisor_approval)
+		VALUES (:code, :name, :degree, :faculty, :description, :advisor_approval) RETURNING id`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	if err := stmt.QueryRowxContext(ctx, program).Scan(&program.ID); err != nil {
+		return nil, programError(err)
+	}
+	return r.GetProgramByID(ctx, program.ID)
+}
+
+func (r *ProgramRepositoryImpl) UpdateProgram(ctx context.Context, program domainModels.Program) (*domainModels.Program, error) {
+	result, err := r.DB.NamedExecContext(ctx, `UPDATE programs SET code=:code, name=:name, degree=:degree, faculty=:faculty,
+		description=:description, advisor_approval=:advisor_approval, updated_at=CURRENT_TIMESTAMP WHERE id=:id`, &program)
+	if err != nil {
+		return nil, programError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrProgramNotFound
+	}
+	return r.GetProgramByID(ctx, program.ID)
+}
+
+func (r *ProgramRepositoryImpl) DeleteProgram(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM programs WHERE id = $1", id)
+	if err != nil {
+		return programError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrProgramNotFound
+	}
+	return nil
+}
+
+// GetProgramCurricula возвращает учебные планы программы, начиная с самого нового набора;
+// курсы и группы по выбору загружаются только в GetCurriculumByID
+func (r *ProgramRepositoryImpl) GetProgramCurricula(ctx context.Context, programID string) ([]domainModels.Curriculum, error) {
+	curricula := []domainModels.Curriculum{}
+	err := r.DB.SelectContext(ctx, &curricula, curriculumSelect+" WHERE cu.program_id = $1 ORDER BY cu.catalog_year DESC, cu.id DESC", programID)
+	if err != nil {
+		return nil, err
+	}
+	return curricula, nil
+}
+
+func (r *ProgramRepositoryImpl) GetCurriculumByID(ctx context.Context, id string) (*domainModels.Curriculum, error) {
+	var curriculum domainModels.Curriculum
+	err := r.DB.GetContext(ctx, &curriculum, curriculumSelect+" WHERE cu.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrCurriculumNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	curriculum.RequiredCourses = []domainModels.CurriculumCourse{}
+	err = r.DB.SelectContext(ctx, &curriculum.RequiredCourses, `
+		SELECT c.id AS course_id, c.code, c.name, c.credits
+		FROM curriculum_courses cc JOIN courses c ON c.id = cc.course_id
+		WHERE cc.curriculum_id = $1 ORDER BY c.code`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	curriculum.ElectiveGroups = []domainModels.ElectiveGroup{}
+	err = r.DB.SelectContext(ctx, &curriculum.ElectiveGroups, `
+		SELECT g.id, g.curriculum_id, g.name, g.min_credits,
+			ARRAY(SELECT gc.course_id::text FROM elective_group_courses gc WHERE gc.group_id = g.id ORDER BY gc.course_id) AS course_ids
+		FROM elective_groups g WHERE g.curriculum_id = $1 ORDER BY g.position, g.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range curriculum.ElectiveGroups {
+		group := &curriculum.ElectiveGroups[i]
+		group.Courses = []domainModels.CurriculumCourse{}
+		err = r.DB.SelectContext(ctx, &group.Courses, `
+			SELECT c.id AS course_id, c.code, c.name, c.credits
+			FROM elective_group_courses gc JOIN courses c ON c.id = gc.course_id
+			WHERE gc.group_id = $1 ORDER BY c.code`, group.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &curriculum, nil
+}
+
+// CreateCurriculum сохраняет учебный план с обязательными курсами и группами по выбору одной транзакцией
+func (r *ProgramRepositoryImpl) CreateCurriculum(ctx context.Context, curriculum *domainModels.Curriculum) (*domainModels.Curriculum, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowxContext(ctx, `INSERT INTO curricula (program_id, name, catalog_year, total_credits)
+		VALUES ($1, $2, $3, $4) RETURNING id`,
+		curriculum.ProgramID, curriculum.Name, curriculum.CatalogYear, curriculum.TotalCredits).Scan(&id)
+	if err != nil {
+		return nil, curriculumError(err)
+	}
+	if err := saveCurriculumCourses(ctx, tx, id, *curriculum); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetCurriculumByID(ctx, id)
+}
+
+// UpdateCurriculum меняет учебный план и заменяет его курсы и группы по выбору
+func (r *ProgramRepositoryImpl) UpdateCurriculum(ctx context.Context, curriculum domainModels.Curriculum) (*domainModels.Curriculum, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE curricula SET name = $1, catalog_year = $2, total_credits = $3,
+		updated_at = CURRENT_TIMESTAMP WHERE id = $4`,
+		curriculum.Name, curriculum.CatalogYear, curriculum.TotalCredits, curriculum.ID)
+	if err != nil {
+		return nil, curriculumError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrCurriculumNotFound
+	}
+	for _, table := range []string{"curriculum_courses", "elective_groups"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE curriculum_id = $1", curriculum.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := saveCurriculumCourses(ctx, tx, curriculum.ID, curriculum); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetCurriculumByID(ctx, curriculum.ID)
+}
+
+func saveCurriculumCourses(ctx context.Context, tx *sqlx.Tx, curriculumID string, curriculum domainModels.Curriculum) error {
+	if _, err := tx.ExecContext(ctx, `INSERT INTO curriculum_courses (curriculum_id, course_id)
+		SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING`, curriculumID, curriculum.RequiredCourseIDs); err != nil {
+		return curriculumError(err)
+	}
+	for position, group := range curriculum.ElectiveGroups {
+		var groupID string
+		err := tx.QueryRowxContext(ctx, `INSERT INTO elective_groups (curriculum_id, name, min_credits, position)
+			VALUES ($1, $2, $3, $4) RETURNING id`, curriculumID, group.Name, group.MinCredits, position).Scan(&groupID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO elective_group_courses (group_id, course_id)
+			SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING`, groupID, group.CourseIDs); err != nil {
+			return curriculumError(err)
+		}
+	}
+	return nil
+}
+
+func (r *ProgramRepositoryImpl) DeleteCurriculum(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM curricula WHERE id = $1", id)
+	if err != nil {
+		return curriculumError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrCurriculumNotFound
+	}
+	return nil
+}
+
+func (r *ProgramRepositoryImpl) AssignStudent(ctx context.Context, assignment domainModels.StudentProgram) error {
+	result, err := r.DB.ExecContext(ctx, `UPDATE students SET program_id = $1, curriculum_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3`, assignment.ProgramID, assignment.CurriculumID, assignment.StudentID)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// programError переводит нарушения ограничений таблицы программ в доменные ошибки
+func programError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return domainModels.ErrProgramCodeTaken
+		case "23503":
+			return domainModels.ErrProgramInUse
+		}
+	}
+	return err
+}
+
+// curriculumError: 23505 — повтор названия плана в программе, 23503 — неизвестный курс при сохранении
+// или назначенные студенты при удалении
+func curriculumError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "23505":
+			return fmt.Errorf("%w: name is already used in this program", domainModels.ErrInvalidCurriculum)
+		case pqErr.Code == "23503" && pqErr.Table == "students":
+			return domainModels.ErrCurriculumInUse
+		case pqErr.Code == "23503":
+			return domainModels.ErrCurriculumCourseNotFound
+		}
+	}
+	return err
+}
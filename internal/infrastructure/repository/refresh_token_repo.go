@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewRefreshTokenRepository(db *sqlx.DB) domainRepo.RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{DB: db}
+}
+
+func (r *RefreshTokenRepositoryImpl) CreateRefreshToken(ctx context.Context, token *domainModels.RefreshToken) error {
+	query := `INSERT INTO refresh_tokens (jti, family_id, user_id, user_agent, ip_address, issued_at, expires_at)
+		VALUES (:jti, :family_id, :user_id, :user_agent, :ip_address, :issued_at, :expires_at) RETURNING id`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	return stmt.QueryRowxContext(ctx, token).Scan(&token.ID)
+}
+
+func (r *RefreshTokenRepositoryImpl) GetRefreshTokenByJTI(ctx context.Context, jti string) (*domainModels.RefreshToken, error) {
+	var token domainModels.RefreshToken
+	err := r.DB.GetContext(ctx, &token, "SELECT * FROM refresh_tokens WHERE jti = $1", jti)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenReplaced атомарно отзывает токен: из двух параллельных запросов
+// с одним и тем же токеном успешным будет только один.
+func (r *RefreshTokenRepositoryImpl) MarkRefreshTokenReplaced(ctx context.Context, jti string, replacedBy string) (bool, error) {
+	result, err := r.DB.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP, replaced_by = $2 WHERE jti = $1 AND revoked_at IS NULL",
+		jti, replacedBy)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := r.DB.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = $1 AND revoked_at IS NULL",
+		familyID)
+	return err
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	_, err := r.DB.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL",
+		userID)
+	return err
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+type RoomRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewRoomRepository(db *sqlx.DB) domainRepo.RoomRepository {
+	return &RoomRepositoryImpl{DB: db}
+}
+
+func (r *RoomRepositoryImpl) GetRooms(ctx context.Context, filter domainModels.RoomFilter) ([]domainModels.Room, error) {
+	rooms := []domainModels.Room{}
+	err := r.DB.SelectContext(ctx, &rooms, `SELECT * FROM rooms WHERE capacity >= $1 AND equipment @> $2
+		ORDER BY building, name`, filter.MinCapacity, pq.StringArray(filter.Equipment))
+	if err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *RoomRepositoryImpl) GetRoomByID(ctx context.Context, id string) (*domainModels.Room, error) {
+	var room domainModels.Room
+	err := r.DB.GetContext(ctx, &room, "SELECT * FROM rooms WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+func (r *RoomRepositoryImpl) CreateRoom(ctx context.Context, room *domainModels.Room) (*domainModels.Room, error) {
+	if room.Equipment == nil {
+		room.Equipment = pq.StringArray{}
+	}
+	query := `INSERT INTO rooms (building, name, capacity, equipment)
+		VALUES (:building, :name, :capacity, :equipment) RETURNING id`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	if err := stmt.QueryRowxContext(ctx, room).Scan(&room.ID); err != nil {
+		return nil, roomError(err)
+	}
+	return r.GetRoomByID(ctx, room.ID)
+}
+
+func (r *RoomRepositoryImpl) UpdateRoom(ctx context.Context, room domainModels.Room) (*domainModels.Room, error) {
+	if room.Equipment == nil {
+		room.Equipment = pq.StringArray{}
+	}
+	result, err := r.DB.NamedExecContext(ctx, `UPDATE rooms SET building=:building, name=:name, capacity=:capacity,
+		equipment=:equipment, updated_at=CURRENT_TIMESTAMP WHERE id=:id`, &room)
+	if err != nil {
+		return nil, roomError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrRoomNotFound
+	}
+	return r.GetRoomByID(ctx, room.ID)
+}
+
+func (r *RoomRepositoryImpl) DeleteRoom(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx, "DELETE FROM rooms WHERE id = $1", id)
+	return roomError(err)
+}
+
+// roomError переводит нарушения ограничений таблицы аудиторий в доменные ошибки
+func roomError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return domainModels.ErrRoomExists
+		case "23503":
+			return domainModels.ErrRoomInUse
+		}
+	}
+	return err
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const sectionSelect = `SELECT s.id, s.offering_id, s.name, s.teacher_id, s.capacity, s.room, s.created_at, s.updated_at,
+	(SELECT COUNT(*) FROM student_courses sc WHERE sc.section_id = s.id AND sc.status = 'enrolled') AS enrolled,
+	(SELECT COUNT(*) FROM section_waitlist w WHERE w.section_id = s.id) AS waitlisted
+FROM course_sections s`
+
+// Позиция считается по порядку постановки в очередь внутри секции
+const waitlistSelect = `SELECT * FROM (
+	SELECT w.section_id, s.name AS section_name, w.student_id, o.course_id, o.term_id, w.created_at,
+		ROW_NUMBER() OVER (PARTITION BY w.section_id ORDER BY w.id) AS position
+	FROM section_waitlist w
+	JOIN course_sections s ON s.id = w.section_id
+	JOIN course_offerings o ON o.id = s.offering_id
+) q`
+
+type SectionRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewSectionRepository(db *sqlx.DB) domainRepo.SectionRepository {
+	return &SectionRepositoryImpl{DB: db}
+}
+
+func (r *SectionRepositoryImpl) GetOfferingSections(ctx context.Context, offeringID string) ([]domainModels.CourseSection, error) {
+	var sections []domainModels.CourseSection
+	err := r.DB.SelectContext(ctx, &sections, sectionSelect+" WHERE s.offering_id = $1 ORDER BY s.name", offeringID)
+	if err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+func (r *SectionRepositoryImpl) GetSectionByID(ctx context.Context, id string) (*domainModels.CourseSection, error) {
+	var section domainModels.CourseSection
+	err := r.DB.GetContext(ctx, &section, sectionSelect+" WHERE s.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrSectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+func (r *SectionRepositoryImpl) CreateSection(ctx context.Context, section *domainModels.CourseSection) (*domainModels.CourseSection, error) {
+	query := `INSERT INTO course_sections (offering_id, name, teacher_id, capacity, room)
+		VALUES (:offering_id, :name, :teacher_id, :capacity, :room) RETURNING id`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var id string
+	err = stmt.QueryRowxContext(ctx, section).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	section.ID = id
+	return section, nil
+}
+
+// UpdateSection меняет данные секции. Если лимит мест вырос, освободившиеся места
+// сразу занимают студенты из листа ожидания.
+func (r *SectionRepositoryImpl) UpdateSection(ctx context.Context, section domainModels.CourseSection, changedBy string) (*domainModels.CourseSection, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockSection(ctx, tx, section.ID); err != nil {
+		return nil, err
+	}
+	taken, err := countSectionStudents(ctx, tx, section.ID)
+	if err != nil {
+		return nil, err
+	}
+	if section.Capacity < taken {
+		return nil, domainModels.ErrCapacityTooSmall
+	}
+
+	_, err = tx.NamedExecContext(ctx, `UPDATE course_sections SET name=:name, teacher_id=:teacher_id, capacity=:capacity,
+		room=:room, updated_at=CURRENT_TIMESTAMP WHERE id=:id`, &section)
+	if err != nil {
+		return nil, err
+	}
+	if err := promoteWaitlist(ctx, tx, section.ID, section.Capacity, changedBy); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetSectionByID(ctx, section.ID)
+}
+
+func (r *SectionRepositoryImpl) DeleteSection(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx, "DELETE FROM course_sections WHERE id = $1", id)
+	return err
+}
+
+// EnrollInSection записывает студента в секцию, если есть свободные места и очередь пуста,
+// иначе ставит его в конец листа ожидания. Строка секции блокируется до конца транзакции,
+// поэтому параллельные записи не могут превысить лимит.
+func (r *SectionRepositoryImpl) EnrollInSection(ctx context.Context, studentID, sectionID, changedBy string) (*domainModels.EnrollmentResult, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	section, err := lockSection(ctx, tx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var enrolled bool
+	err = tx.GetContext(ctx, &enrolled,
+		"SELECT EXISTS (SELECT 1 FROM student_courses WHERE student_id = $1 AND offering_id = $2 AND status <> 'dropped')",
+		studentID, section.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+	if enrolled {
+		return nil, domainModels.ErrAlreadyEnrolled
+	}
+
+	taken, err := countSectionStudents(ctx, tx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	var waiting int
+	if err := tx.GetContext(ctx, &waiting, "SELECT COUNT(*) FROM section_waitlist WHERE section_id = $1", sectionID); err != nil {
+		return nil, err
+	}
+
+	if taken < section.Capacity && waiting == 0 {
+		activated, err := activateEnrollment(ctx, tx, studentID, section.CourseID, section.OfferingID, &sectionID, changedBy)
+		if err != nil {
+			return nil, err
+		}
+		if !activated {
+			return nil, domainModels.ErrAlreadyEnrolled
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return &domainModels.EnrollmentResult{Status: domainModels.EnrollmentStatusEnrolled, SectionID: sectionID}, nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO section_waitlist (section_id, student_id) VALUES ($1, $2) ON CONFLICT (section_id, student_id) DO NOTHING",
+		sectionID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	var entry domainModels.WaitlistEntry
+	err = tx.GetContext(ctx, &entry, waitlistSelect+" WHERE q.section_id = $1 AND q.student_id = $2", sectionID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &domainModels.EnrollmentResult{
+		Status:           domainModels.EnrollmentStatusWaitlisted,
+		SectionID:        sectionID,
+		WaitlistPosition: entry.Position,
+	}, nil
+}
+
+// DropEnrollment отмечает запись как брошенную и отдаёт освободившееся место
+// первому студенту из листа ожидания секции
+func (r *SectionRepositoryImpl) DropEnrollment(ctx context.Context, studentID, offeringID, changedBy string) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sectionID sql.NullString
+	err = tx.GetContext(ctx, &sectionID,
+		"SELECT section_id FROM student_courses WHERE student_id = $1 AND offering_id = $2", studentID, offeringID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainModels.ErrStudentNotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+
+	var section *lockedSection
+	if sectionID.Valid {
+		if section, err = lockSection(ctx, tx, sectionID.String); err != nil {
+			return err
+		}
+	}
+
+	if err := changeEnrollmentStatus(ctx, tx, studentID, offeringID, domainModels.EnrollmentStatusDropped, changedBy); err != nil {
+		return err
+	}
+	if section != nil {
+		if err := promoteWaitlist(ctx, tx, sectionID.String, section.Capacity, changedBy); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *SectionRepositoryImpl) GetSectionWaitlist(ctx context.Context, sectionID string) ([]domainModels.WaitlistEntry, error) {
+	var entries []domainModels.WaitlistEntry
+	err := r.DB.SelectContext(ctx, &entries, waitlistSelect+" WHERE q.section_id = $1 ORDER BY q.position", sectionID)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *SectionRepositoryImpl) GetStudentWaitlist(ctx context.Context, studentID string) ([]domainModels.WaitlistEntry, error) {
+	var entries []domainModels.WaitlistEntry
+	err := r.DB.SelectContext(ctx, &entries, waitlistSelect+" WHERE q.student_id = $1 ORDER BY q.created_at", studentID)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *SectionRepositoryImpl) LeaveWaitlist(ctx context.Context, studentID, sectionID string) error {
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM section_waitlist WHERE student_id = $1 AND section_id = $2", studentID, sectionID)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return domainModels.ErrNotOnWaitlist
+	}
+	return nil
+}
+
+type lockedSection struct {
+	OfferingID string `db:"offering_id"`
+	CourseID   string `db:"course_id"`
+	Capacity   int    `db:"capacity"`
+}
+
+// lockSection блокирует строку секции до конца транзакции
+func lockSection(ctx context.Context, tx *sqlx.Tx, sectionID string) (*lockedSection, error) {
+	var section lockedSection
+	err := tx.GetContext(ctx, &section, `SELECT s.offering_id, o.course_id, s.capacity
+		FROM course_sections s JOIN course_offerings o ON o.id = s.offering_id
+		WHERE s.id = $1 FOR UPDATE OF s`, sectionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrSectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+func countSectionStudents(ctx context.Context, tx *sqlx.Tx, sectionID string) (int, error) {
+	var taken int
+	err := tx.GetContext(ctx, &taken, "SELECT COUNT(*) FROM student_courses WHERE section_id = $1 AND status = 'enrolled'", sectionID)
+	return taken, err
+}
+
+// promoteWaitlist переводит студентов из листа ожидания в секцию в порядке очереди,
+// пока есть свободные места. Секция должна быть заблокирована вызывающим.
+// Правила записи заново не проверяются: курс из листа ожидания уже учтён в нагрузке студента.
+// В журнале записей перевод приписывается changedBy — тому, чьё действие освободило место.
+func promoteWaitlist(ctx context.Context, tx *sqlx.Tx, sectionID string, capacity int, changedBy string) error {
+	for {
+		taken, err := countSectionStudents(ctx, tx, sectionID)
+		if err != nil {
+			return err
+		}
+		if taken >= capacity {
+			return nil
+		}
+
+		var next struct {
+			StudentID  string `db:"student_id"`
+			OfferingID string `db:"offering_id"`
+			CourseID   string `db:"course_id"`
+		}
+		err = tx.GetContext(ctx, &next, `SELECT w.student_id, s.offering_id, o.course_id
+			FROM section_waitlist w
+			JOIN course_sections s ON s.id = w.section_id
+			JOIN course_offerings o ON o.id = s.offering_id
+			WHERE w.section_id = $1 ORDER BY w.id LIMIT 1`, sectionID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Студент мог уже попасть в другую секцию курса — тогда место достаётся следующему
+		if _, err := activateEnrollment(ctx, tx, next.StudentID, next.CourseID, next.OfferingID, &sectionID, changedBy); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM section_waitlist
+			WHERE student_id = $1 AND section_id IN (SELECT id FROM course_sections WHERE offering_id = $2)`,
+			next.StudentID, next.OfferingID)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+// standingSelect — положение студентов с именем, факультетом и периодом
+const standingSelect = `SELECT st.id, st.student_id, u.firstname, u.lastname, s.faculty_id, st.term_id, t.year, t.season,
+	st.status, st.term_gpa, st.cumulative_gpa, st.attempted_credits, st.earned_credits, st.computed_at
+FROM academic_standings st
+JOIN students s ON s.id = st.student_id
+JOIN users u ON u.id = st.student_id
+JOIN academic_terms t ON t.id = st.term_id`
+
+type StandingRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewStandingRepository(db *sqlx.DB) domainRepo.StandingRepository {
+	return &StandingRepositoryImpl{DB: db}
+}
+
+func (r *StandingRepositoryImpl) GetRules(ctx context.Context) (*domainModels.StandingRules, error) {
+	var rules domainModels.StandingRules
+	err := r.DB.GetContext(ctx, &rules, `SELECT probation_gpa, probation_terms, honors_percent, honors_min_credits, honors_min_gpa
+		FROM standing_rules WHERE id = 1`)
+	if err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (r *StandingRepositoryImpl) UpdateRules(ctx context.Context, rules domainModels.StandingRules) (*domainModels.StandingRules, error) {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO standing_rules (id, probation_gpa, probation_terms, honors_percent, honors_min_credits, honors_min_gpa)
+		VALUES (1, $1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET probation_gpa = EXCLUDED.probation_gpa, probation_terms = EXCLUDED.probation_terms,
+			honors_percent = EXCLUDED.honors_percent, honors_min_credits = EXCLUDED.honors_min_credits,
+			honors_min_gpa = EXCLUDED.honors_min_gpa, updated_at = CURRENT_TIMESTAMP`,
+		rules.ProbationGPA, rules.ProbationTerms, rules.HonorsPercent, rules.HonorsMinCredits, rules.HonorsMinGPA)
+	if err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (r *StandingRepositoryImpl) GetTermCandidates(ctx context.Context, termID string) ([]domainModels.StandingCandidate, error) {
+	candidates := []domainModels.StandingCandidate{}
+	err := r.DB.SelectContext(ctx, &candidates, `
+		SELECT DISTINCT s.id AS student_id, s.faculty_id
+		FROM student_courses sc
+		JOIN course_offerings o ON o.id = sc.offering_id
+		JOIN students s ON s.id = sc.student_id
+		WHERE o.term_id = $1 AND sc.status IN ('completed', 'failed') AND s.deleted_at IS NULL
+		ORDER BY s.id`, termID)
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// SaveTermStandings пересчитывает период целиком одной транзакцией: прежние записи периода удаляются
+func (r *StandingRepositoryImpl) SaveTermStandings(ctx context.Context, termID string, standings []domainModels.AcademicStanding) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM academic_standings WHERE term_id = $1", termID); err != nil {
+		return err
+	}
+	for _, standing := range standings {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO academic_standings (student_id, term_id, status, term_gpa, cumulative_gpa, attempted_credits, earned_credits)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			standing.StudentID, termID, standing.Status, standing.TermGPA, standing.CumulativeGPA,
+			standing.AttemptedCredits, standing.EarnedCredits)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *StandingRepositoryImpl) GetStudentStandings(ctx context.Context, studentID string) ([]domainModels.AcademicStanding, error) {
+	standings := []domainModels.AcademicStanding{}
+	err := r.DB.SelectContext(ctx, &standings, standingSelect+" WHERE st.student_id = $1 ORDER BY t.start_date", studentID)
+	if err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
+
+func (r *StandingRepositoryImpl) GetTermStandings(ctx context.Context, termID, status, facultyID string) ([]domainModels.AcademicStanding, error) {
+	standings := []domainModels.AcademicStanding{}
+	err := r.DB.SelectContext(ctx, &standings, standingSelect+`
+		WHERE st.term_id = $1 AND ($2 = '' OR st.status = $2) AND ($3 = '' OR s.faculty_id::text = $3)
+		ORDER BY st.term_gpa DESC, u.lastname, u.firstname, st.student_id`, termID, status, facultyID)
+	if err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/jmoiron/sqlx"
 	domainModels "university_system/internal/domain/models"
 	domainRepo "university_system/internal/domain/repository"
@@ -20,7 +22,7 @@ func (r *StudentRepositoryImpl) GetStudents(ctx context.Context) ([]domainModels
 	query := `SELECT 
 		s.id, 
 		u.username, u.firstname, u.lastname, u.email, u.role, u.birthdate, 
-		s.student_year, s.faculty, s.created_at, s.updated_at, s.deleted_at
+		s.student_year, s.faculty, s.faculty_id, s.program_id, s.curriculum_id, s.created_at, s.updated_at, s.deleted_at
 	FROM students s
 	JOIN users u ON s.id = u.id`
 	err := r.DB.SelectContext(ctx, &students, query)
@@ -35,7 +37,11 @@ func (r *StudentRepositoryImpl) GetStudentById(ctx context.Context, id string) (
 	query := `SELECT 
 		s.id, 
 		u.username, u.firstname, u.lastname, u.email, u.role, u.birthdate, 
-		s.student_year, s.faculty, s.created_at, s.updated_at, s.deleted_at
+		s.student_year, s.faculty, s.faculty_id, s.program_id, s.curriculum_id, s.created_at, s.updated_at, s.deleted_at,
+		COALESCE((SELECT st.status FROM academic_standings st
+			JOIN academic_terms t ON t.id = st.term_id
+			WHERE st.student_id = s.id
+			ORDER BY t.start_date DESC LIMIT 1), 'good') AS standing
 	FROM students s
 	JOIN users u ON s.id = u.id
 	WHERE s.id = $1`
@@ -47,7 +53,7 @@ func (r *StudentRepositoryImpl) GetStudentById(ctx context.Context, id string) (
 }
 
 func (r *StudentRepositoryImpl) CreateStudent(ctx context.Context, student *domainModels.Student) (*domainModels.Student, error) {
-	query := `INSERT INTO students (id, student_year, faculty) VALUES (:id, :student_year, :faculty) RETURNING id`
+	query := `INSERT INTO students (id, student_year, faculty, faculty_id) VALUES (:id, :student_year, :faculty, :faculty_id) RETURNING id`
 	stmt, err := r.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -63,7 +69,7 @@ func (r *StudentRepositoryImpl) CreateStudent(ctx context.Context, student *doma
 }
 
 func (r *StudentRepositoryImpl) UpdateStudent(ctx context.Context, student domainModels.Student) (*domainModels.Student, error) {
-	_, err := r.DB.NamedExecContext(ctx, `UPDATE students SET student_year=:student_year, faculty=:faculty WHERE id=:id`, &student)
+	_, err := r.DB.NamedExecContext(ctx, `UPDATE students SET student_year=:student_year, faculty=:faculty, faculty_id=:faculty_id WHERE id=:id`, &student)
 	if err != nil {
 		return nil, err
 	}
@@ -75,20 +81,187 @@ func (r *StudentRepositoryImpl) DeleteStudent(ctx context.Context, id string) er
 	return err
 }
 
-func (r *StudentRepositoryImpl) EnrollStudentToCourse(ctx context.Context, studentID, courseID string) error {
-	_, err := r.DB.ExecContext(ctx, "INSERT INTO student_courses (student_id, course_id) VALUES ($1, $2)", studentID, courseID)
-	return err
+func (r *StudentRepositoryImpl) EnrollStudentToCourse(ctx context.Context, studentID, courseID, offeringID, changedBy string) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	activated, err := activateEnrollment(ctx, tx, studentID, courseID, offeringID, nil, changedBy)
+	if err != nil {
+		return err
+	}
+	if !activated {
+		return domainModels.ErrAlreadyEnrolled
+	}
+	return tx.Commit()
 }
 
+// GetStudentCourses возвращает курсы, которые студент проходит или прошёл (без брошенных и отозванных)
 func (r *StudentRepositoryImpl) GetStudentCourses(ctx context.Context, studentID string) ([]domainModels.Course, error) {
 	var courses []domainModels.Course
-	err := r.DB.SelectContext(ctx, &courses, "SELECT c.* FROM courses c JOIN student_courses sc ON c.id = sc.course_id WHERE sc.student_id = $1", studentID)
+	err := r.DB.SelectContext(ctx, &courses, `SELECT c.* FROM courses c JOIN student_courses sc ON c.id = sc.course_id
+		WHERE sc.student_id = $1 AND sc.status NOT IN ('dropped', 'withdrawn')`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// GetTermCourses возвращает курсы, на которые студент записан в периоде termID
+func (r *StudentRepositoryImpl) GetTermCourses(ctx context.Context, studentID, termID string) ([]domainModels.Course, error) {
+	var courses []domainModels.Course
+	err := r.DB.SelectContext(ctx, &courses, `SELECT c.* FROM courses c
+		JOIN student_courses sc ON c.id = sc.course_id
+		JOIN course_offerings o ON o.id = sc.offering_id
+		WHERE sc.student_id = $1 AND o.term_id = $2 AND sc.status = 'enrolled'`, studentID, termID)
 	if err != nil {
 		return nil, err
 	}
 	return courses, nil
 }
 
+// GetTermWaitlistCourses возвращает курсы периода termID, в листе ожидания секций которых стоит студент
+func (r *StudentRepositoryImpl) GetTermWaitlistCourses(ctx context.Context, studentID, termID string) ([]domainModels.Course, error) {
+	var courses []domainModels.Course
+	err := r.DB.SelectContext(ctx, &courses, `SELECT c.* FROM courses c WHERE c.id IN (
+		SELECT o.course_id FROM section_waitlist w
+		JOIN course_sections s ON s.id = w.section_id
+		JOIN course_offerings o ON o.id = s.offering_id
+		WHERE w.student_id = $1 AND o.term_id = $2)`, studentID, termID)
+	if err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// GetCompletedCourses возвращает оценки студента за периоды, закончившиеся до начала периода beforeTermID
+func (r *StudentRepositoryImpl) GetCompletedCourses(ctx context.Context, studentID, beforeTermID string) ([]domainModels.CourseResult, error) {
+	var results []domainModels.CourseResult
+	err := r.DB.SelectContext(ctx, &results, `SELECT m.*, c.code, c.credits, o.term_id, `+weightedTotal+` AS total,
+			`+schemePassingTotal+` AS passing_total
+		FROM course_marks m
+		JOIN courses c ON c.id = m.course_id
+		JOIN course_offerings o ON o.id = m.offering_id
+		JOIN academic_terms t ON t.id = o.term_id
+		`+offeringSchemeJoin+`
+		JOIN student_courses sc ON sc.student_id = m.student_id AND sc.offering_id = m.offering_id
+		WHERE m.student_id = $1 AND t.end_date < (SELECT start_date FROM academic_terms WHERE id = $2)
+			AND sc.status NOT IN ('dropped', 'withdrawn')`,
+		studentID, beforeTermID)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetStudentEnrollments возвращает все записи студента, включая брошенные и отозванные
+func (r *StudentRepositoryImpl) GetStudentEnrollments(ctx context.Context, studentID string) ([]domainModels.Enrollment, error) {
+	var enrollments []domainModels.Enrollment
+	err := r.DB.SelectContext(ctx, &enrollments, `SELECT sc.student_id, sc.course_id, c.code AS course_code, c.name AS course_name,
+			sc.offering_id, o.term_id, sc.section_id, sc.status, sc.enrolled_at, sc.status_changed_at
+		FROM student_courses sc
+		JOIN courses c ON c.id = sc.course_id
+		JOIN course_offerings o ON o.id = sc.offering_id
+		JOIN academic_terms t ON t.id = o.term_id
+		WHERE sc.student_id = $1
+		ORDER BY t.start_date, c.code`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return enrollments, nil
+}
+
+func (r *StudentRepositoryImpl) GetEnrollmentEvents(ctx context.Context, studentID string) ([]domainModels.EnrollmentEvent, error) {
+	var events []domainModels.EnrollmentEvent
+	err := r.DB.SelectContext(ctx, &events, "SELECT * FROM enrollment_events WHERE student_id = $1 ORDER BY id", studentID)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// WithdrawEnrollment отзывает студента с курса; место в секции не освобождается для листа ожидания,
+// так как запись на курсы к этому времени уже закрыта
+func (r *StudentRepositoryImpl) WithdrawEnrollment(ctx context.Context, studentID, offeringID, changedBy string) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := changeEnrollmentStatus(ctx, tx, studentID, offeringID, domainModels.EnrollmentStatusWithdrawn, changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// activateEnrollment создаёт запись на курс или возобновляет ранее брошенную; changedBy — кто записал.
+// Возвращает false, если студент уже записан либо курс в этом периоде отозван или завершён.
+func activateEnrollment(ctx context.Context, tx *sqlx.Tx, studentID, courseID, offeringID string, sectionID *string, changedBy string) (bool, error) {
+	var previous sql.NullString
+	err := tx.GetContext(ctx, &previous,
+		"SELECT status FROM student_courses WHERE student_id = $1 AND offering_id = $2 FOR UPDATE", studentID, offeringID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		result, err := tx.ExecContext(ctx, `INSERT INTO student_courses (student_id, course_id, offering_id, section_id, status)
+			VALUES ($1, $2, $3, $4, 'enrolled') ON CONFLICT DO NOTHING`, studentID, courseID, offeringID, sectionID)
+		if err != nil {
+			return false, err
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			return false, err
+		}
+	case err != nil:
+		return false, err
+	case previous.String != domainModels.EnrollmentStatusDropped:
+		return false, nil
+	default:
+		_, err := tx.ExecContext(ctx, `UPDATE student_courses SET status = 'enrolled', section_id = $3,
+			enrolled_at = CURRENT_TIMESTAMP, status_changed_at = CURRENT_TIMESTAMP
+			WHERE student_id = $1 AND offering_id = $2`, studentID, offeringID, sectionID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = recordEnrollmentEvent(ctx, tx, studentID, offeringID, previous, domainModels.EnrollmentStatusEnrolled, changedBy)
+	return err == nil, err
+}
+
+// changeEnrollmentStatus переводит активную запись в статус status и пишет журнал
+func changeEnrollmentStatus(ctx context.Context, tx *sqlx.Tx, studentID, offeringID, status, changedBy string) error {
+	result, err := tx.ExecContext(ctx, `UPDATE student_courses SET status = $3, status_changed_at = CURRENT_TIMESTAMP
+		WHERE student_id = $1 AND offering_id = $2 AND status = 'enrolled'`, studentID, offeringID, status)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		var exists bool
+		err := tx.GetContext(ctx, &exists,
+			"SELECT EXISTS (SELECT 1 FROM student_courses WHERE student_id = $1 AND offering_id = $2)", studentID, offeringID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domainModels.ErrNotActiveEnrollment
+		}
+		return domainModels.ErrStudentNotEnrolled
+	}
+	previous := sql.NullString{String: domainModels.EnrollmentStatusEnrolled, Valid: true}
+	return recordEnrollmentEvent(ctx, tx, studentID, offeringID, previous, status, changedBy)
+}
+
+func recordEnrollmentEvent(ctx context.Context, tx *sqlx.Tx, studentID, offeringID string, from sql.NullString, to, changedBy string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO enrollment_events (student_id, offering_id, course_id, from_status, to_status, changed_by)
+		SELECT $1, o.id, o.course_id, $3, $4, NULLIF($5, '')::INTEGER FROM course_offerings o WHERE o.id = $2`,
+		studentID, offeringID, from, to, changedBy)
+	return err
+}
+
 func (r *StudentRepositoryImpl) CreateUserWithRole(ctx context.Context, user domainModels.User, role string) (string, error) {
 	var id string
 	query := `INSERT INTO users (username, password, firstname, lastname, email, role, birthdate) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
@@ -20,7 +20,7 @@ func (r *TeacherRepositoryImpl) GetTeachers(ctx context.Context) ([]domainModels
 	query := `SELECT 
 		t.id, 
 		u.username, u.firstname, u.lastname, u.email, u.role, u.birthdate, 
-		t.department, t.position, t.created_at, t.updated_at, t.deleted_at
+		t.department, t.department_id, t.position, t.created_at, t.updated_at, t.deleted_at
 	FROM teachers t
 	JOIN users u ON t.id = u.id`
 	err := r.DB.SelectContext(ctx, &teachers, query)
@@ -35,7 +35,7 @@ func (r *TeacherRepositoryImpl) GetTeacherById(ctx context.Context, id string) (
 	query := `SELECT 
 		t.id, 
 		u.username, u.firstname, u.lastname, u.email, u.role, u.birthdate, 
-		t.department, t.position, t.created_at, t.updated_at, t.deleted_at
+		t.department, t.department_id, t.position, t.created_at, t.updated_at, t.deleted_at
 	FROM teachers t
 	JOIN users u ON t.id = u.id
 	WHERE t.id = $1`
@@ -47,7 +47,7 @@ func (r *TeacherRepositoryImpl) GetTeacherById(ctx context.Context, id string) (
 }
 
 func (r *TeacherRepositoryImpl) CreateTeacher(ctx context.Context, teacher *domainModels.Teacher) (*domainModels.Teacher, error) {
-	query := `INSERT INTO teachers (id, department, position) VALUES (:id, :department, :position) RETURNING id`
+	query := `INSERT INTO teachers (id, department, department_id, position) VALUES (:id, :department, :department_id, :position) RETURNING id`
 	stmt, err := r.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -63,7 +63,7 @@ func (r *TeacherRepositoryImpl) CreateTeacher(ctx context.Context, teacher *doma
 }
 
 func (r *TeacherRepositoryImpl) UpdateTeacher(ctx context.Context, teacher domainModels.Teacher) (*domainModels.Teacher, error) {
-	_, err := r.DB.NamedExecContext(ctx, `UPDATE teachers SET department=:department, position=:position WHERE id=:id`, &teacher)
+	_, err := r.DB.NamedExecContext(ctx, `UPDATE teachers SET department=:department, department_id=:department_id, position=:position WHERE id=:id`, &teacher)
 	if err != nil {
 		return nil, err
 	}
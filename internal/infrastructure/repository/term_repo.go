@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+type TermRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewTermRepository(db *sqlx.DB) domainRepo.TermRepository {
+	return &TermRepositoryImpl{DB: db}
+}
+
+func (r *TermRepositoryImpl) GetTerms(ctx context.Context) ([]domainModels.AcademicTerm, error) {
+	var terms []domainModels.AcademicTerm
+	err := r.DB.SelectContext(ctx, &terms, "SELECT * FROM academic_terms ORDER BY start_date")
+	if err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+func (r *TermRepositoryImpl) GetTermByID(ctx context.Context, id string) (*domainModels.AcademicTerm, error) {
+	var term domainModels.AcademicTerm
+	err := r.DB.GetContext(ctx, &term, "SELECT * FROM academic_terms WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrTermNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *TermRepositoryImpl) CreateTerm(ctx context.Context, term *domainModels.AcademicTerm) (*domainModels.AcademicTerm, error) {
+	query := `INSERT INTO academic_terms (year, season, start_date, end_date, enrollment_start, enrollment_end, grading_deadline,
+		add_drop_deadline, withdrawal_deadline, status, max_credits)
+		VALUES (:year, :season, :start_date, :end_date, :enrollment_start, :enrollment_end, :grading_deadline,
+		:add_drop_deadline, :withdrawal_deadline, :status, :max_credits) RETURNING id`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var id string
+	err = stmt.QueryRowxContext(ctx, term).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	term.ID = id
+	return term, nil
+}
+
+func (r *TermRepositoryImpl) UpdateTerm(ctx context.Context, term domainModels.AcademicTerm) (*domainModels.AcademicTerm, error) {
+	result, err := r.DB.NamedExecContext(ctx, `UPDATE academic_terms SET year=:year, season=:season, start_date=:start_date,
+		end_date=:end_date, enrollment_start=:enrollment_start, enrollment_end=:enrollment_end,
+		grading_deadline=:grading_deadline, add_drop_deadline=:add_drop_deadline, withdrawal_deadline=:withdrawal_deadline, status=:status, max_credits=:max_credits, updated_at=CURRENT_TIMESTAMP WHERE id=:id`, &term)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrTermNotFound
+	}
+	return &term, nil
+}
+
+func (r *TermRepositoryImpl) DeleteTerm(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx, "DELETE FROM academic_terms WHERE id = $1", id)
+	return historyError(err, domainModels.ErrTermHasHistory)
+}
+
+// CompleteTermEnrollments переводит активные записи периода в completed или failed
+// по итоговому баллу схемы оценивания и записывает смену статуса в журнал
+func (r *TermRepositoryImpl) CompleteTermEnrollments(ctx context.Context, termID string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		WITH finished AS (
+			UPDATE student_courses sc
+			SET status = CASE
+					WHEN COALESCE((SELECT `+weightedTotal+`
+						FROM course_marks m
+						WHERE m.student_id = sc.student_id AND m.offering_id = sc.offering_id), 0) >= `+schemePassingTotal+` THEN 'completed'
+					ELSE 'failed'
+				END,
+				status_changed_at = CURRENT_TIMESTAMP
+			FROM course_offerings o
+			`+offeringSchemeJoin+`
+			WHERE o.id = sc.offering_id AND o.term_id = $1 AND sc.status = 'enrolled'
+			RETURNING sc.student_id, sc.offering_id, sc.course_id, sc.status
+		)
+		INSERT INTO enrollment_events (student_id, offering_id, course_id, from_status, to_status)
+		SELECT student_id, offering_id, course_id, 'enrolled', status FROM finished`,
+		termID)
+	return err
+}
+
+func (r *TermRepositoryImpl) GetTermOfferings(ctx context.Context, termID string) ([]domainModels.CourseOffering, error) {
+	var offerings []domainModels.CourseOffering
+	err := r.DB.SelectContext(ctx, &offerings, "SELECT * FROM course_offerings WHERE term_id = $1 ORDER BY id", termID)
+	if err != nil {
+		return nil, err
+	}
+	return offerings, nil
+}
+
+func (r *TermRepositoryImpl) GetOffering(ctx context.Context, termID, courseID string) (*domainModels.CourseOffering, error) {
+	var offering domainModels.CourseOffering
+	err := r.DB.GetContext(ctx, &offering, "SELECT * FROM course_offerings WHERE term_id = $1 AND course_id = $2", termID, courseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrOfferingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &offering, nil
+}
+
+func (r *TermRepositoryImpl) GetOfferingByID(ctx context.Context, termID, offeringID string) (*domainModels.CourseOffering, error) {
+	var offering domainModels.CourseOffering
+	err := r.DB.GetContext(ctx, &offering, "SELECT * FROM course_offerings WHERE term_id = $1 AND id = $2", termID, offeringID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrOfferingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &offering, nil
+}
+
+func (r *TermRepositoryImpl) CreateOffering(ctx context.Context, offering *domainModels.CourseOffering) (*domainModels.CourseOffering, error) {
+	query := `INSERT INTO course_offerings (course_id, term_id) VALUES (:course_id, :term_id) RETURNING id`
+	stmt, err := r.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	var id string
+	err = stmt.QueryRowxContext(ctx, offering).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	offering.ID = id
+	return offering, nil
+}
+
+func (r *TermRepositoryImpl) DeleteOffering(ctx context.Context, termID, offeringID string) error {
+	_, err := r.DB.ExecContext(ctx, "DELETE FROM course_offerings WHERE id = $1 AND term_id = $2", offeringID, termID)
+	return historyError(err, domainModels.ErrOfferingHasHistory)
+}
+
+// historyError: 23503 при удалении — на курс в периоде ссылаются записи студентов или оценки
+func historyError(err error, historyErr error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return historyErr
+	}
+	return err
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	domainModels "university_system/internal/domain/models"
+	domainRepo "university_system/internal/domain/repository"
+)
+
+const meetingSelect = `SELECT m.id, m.section_id, m.room_id, m.weekday,
+	to_char(m.start_time, 'HH24:MI') AS start_time, to_char(m.end_time, 'HH24:MI') AS end_time,
+	COALESCE(r.building, '') AS building, COALESCE(r.name, '') AS room_name
+FROM section_meetings m
+LEFT JOIN rooms r ON r.id = m.room_id`
+
+const timetableSelect = `SELECT m.id AS meeting_id, s.id AS section_id, s.name AS section_name, o.id AS offering_id,
+	c.id AS course_id, c.code, c.name, m.weekday,
+	to_char(m.start_time, 'HH24:MI') AS start_time, to_char(m.end_time, 'HH24:MI') AS end_time,
+	m.room_id, COALESCE(r.building, '') AS building, COALESCE(r.name, '') AS room_name
+FROM section_meetings m
+JOIN course_sections s ON s.id = m.section_id
+JOIN course_offerings o ON o.id = s.offering_id
+JOIN courses c ON c.id = o.course_id
+LEFT JOIN rooms r ON r.id = m.room_id`
+
+const timetableOrder = " ORDER BY m.weekday, m.start_time, c.code"
+
+// Преподаватели секции — назначенный ей преподаватель, а если его нет, все преподаватели курса
+// из teacher_courses. Студенты секции — записанные в неё или в курс без секции.
+const sectionPeople = `section_teachers AS (
+	SELECT s.id AS section_id, s.teacher_id FROM course_sections s WHERE s.teacher_id IS NOT NULL
+	UNION
+	SELECT s.id, tc.teacher_id FROM course_sections s
+	JOIN course_offerings o ON o.id = s.offering_id
+	JOIN teacher_courses tc ON tc.course_id = o.course_id
+	WHERE s.teacher_id IS NULL
+),
+section_students AS (
+	SELECT s.id AS section_id, sc.student_id FROM course_sections s
+	JOIN student_courses sc ON sc.offering_id = s.offering_id AND sc.status = 'enrolled'
+		AND (sc.section_id = s.id OR sc.section_id IS NULL)
+)`
+
+// scheduleConflicts сопоставляет занятия из candidates с пересекающимися по времени занятиями того же
+// периода и возвращает общие аудитории, преподавателей и студентов. candidates задаётся вызывающим.
+const scheduleConflicts = `,
+scheduled AS (
+	SELECT m.id, m.section_id, m.room_id, m.weekday, m.start_time, m.end_time, o.term_id,
+		s.name AS section_name, c.code AS course_code
+	FROM section_meetings m
+	JOIN course_sections s ON s.id = m.section_id
+	JOIN course_offerings o ON o.id = s.offering_id
+	JOIN courses c ON c.id = o.course_id
+),
+pairs AS (
+	SELECT a.id AS meeting_id, a.section_id, a.room_id, b.id AS conflict_meeting_id, b.section_id AS conflict_section_id,
+		b.room_id AS conflict_room_id, b.section_name AS conflict_section_name, b.course_code AS conflict_course_code,
+		b.weekday, b.start_time, b.end_time
+	FROM candidates a
+	JOIN scheduled b ON b.term_id = a.term_id AND b.weekday = a.weekday
+		AND b.start_time < a.end_time AND b.end_time > a.start_time AND b.id IS DISTINCT FROM a.id
+),
+conflicts AS (
+	SELECT 'room' AS kind, p.room_id::text AS subject_id, p.* FROM pairs p
+	WHERE p.room_id = p.conflict_room_id
+	UNION ALL
+	SELECT 'teacher', ta.teacher_id::text, p.* FROM pairs p
+	JOIN section_teachers ta ON ta.section_id = p.section_id
+	JOIN section_teachers tb ON tb.section_id = p.conflict_section_id AND tb.teacher_id = ta.teacher_id
+	WHERE p.section_id <> p.conflict_section_id
+	UNION ALL
+	SELECT 'student', sa.student_id::text, p.* FROM pairs p
+	JOIN section_students sa ON sa.section_id = p.section_id
+	JOIN section_students sb ON sb.section_id = p.conflict_section_id AND sb.student_id = sa.student_id
+	WHERE p.section_id <> p.conflict_section_id
+)
+SELECT kind, subject_id, COALESCE(meeting_id::text, '') AS meeting_id, section_id, conflict_meeting_id,
+	conflict_section_id, conflict_section_name, conflict_course_code, weekday,
+	to_char(start_time, 'HH24:MI') AS start_time, to_char(end_time, 'HH24:MI') AS end_time
+FROM conflicts`
+
+type TimetableRepositoryImpl struct {
+	DB *sqlx.DB
+}
+
+func NewTimetableRepository(db *sqlx.DB) domainRepo.TimetableRepository {
+	return &TimetableRepositoryImpl{DB: db}
+}
+
+func (r *TimetableRepositoryImpl) GetSectionMeetings(ctx context.Context, sectionID string) ([]domainModels.SectionMeeting, error) {
+	meetings := []domainModels.SectionMeeting{}
+	err := r.DB.SelectContext(ctx, &meetings, meetingSelect+" WHERE m.section_id = $1 ORDER BY m.weekday, m.start_time", sectionID)
+	if err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+func (r *TimetableRepositoryImpl) GetMeetingByID(ctx context.Context, id string) (*domainModels.SectionMeeting, error) {
+	var meeting domainModels.SectionMeeting
+	err := r.DB.GetContext(ctx, &meeting, meetingSelect+" WHERE m.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainModels.ErrMeetingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &meeting, nil
+}
+
+func (r *TimetableRepositoryImpl) CreateMeeting(ctx context.Context, meeting *domainModels.SectionMeeting) (*domainModels.SectionMeeting, error) {
+	var id string
+	err := r.DB.QueryRowxContext(ctx, `INSERT INTO section_meetings (section_id, room_id, weekday, start_time, end_time)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		meeting.SectionID, meeting.RoomID, meeting.Weekday, meeting.StartTime, meeting.EndTime).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetMeetingByID(ctx, id)
+}
+
+func (r *TimetableRepositoryImpl) UpdateMeeting(ctx context.Context, meeting domainModels.SectionMeeting) (*domainModels.SectionMeeting, error) {
+	result, err := r.DB.ExecContext(ctx, `UPDATE section_meetings SET room_id = $1, weekday = $2, start_time = $3, end_time = $4
+		WHERE id = $5`, meeting.RoomID, meeting.Weekday, meeting.StartTime, meeting.EndTime, meeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return nil, domainModels.ErrMeetingNotFound
+	}
+	return r.GetMeetingByID(ctx, meeting.ID)
+}
+
+func (r *TimetableRepositoryImpl) DeleteMeeting(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx, "DELETE FROM section_meetings WHERE id = $1", id)
+	return err
+}
+
+// FindConflicts проверяет занятие перед сохранением; при изменении занятия его текущая версия не учитывается
+func (r *TimetableRepositoryImpl) FindConflicts(ctx context.Context, meeting domainModels.SectionMeeting) ([]domainModels.ScheduleConflict, error) {
+	query := `WITH ` + sectionPeople + `,
+candidates AS (
+	SELECT $1::int AS id, s.id AS section_id, $2::int AS room_id, $3::int AS weekday,
+		$4::time AS start_time, $5::time AS end_time, o.term_id
+	FROM course_sections s JOIN course_offerings o ON o.id = s.offering_id
+	WHERE s.id = $6
+)` + scheduleConflicts + " ORDER BY kind, weekday, start_time, subject_id"
+	conflicts := []domainModels.ScheduleConflict{}
+	err := r.DB.SelectContext(ctx, &conflicts, query, nullableID(meeting.ID), meeting.RoomID,
+		meeting.Weekday, meeting.StartTime, meeting.EndTime, meeting.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// GetTermConflicts возвращает все конфликты расписания периода, каждую пару занятий — один раз
+func (r *TimetableRepositoryImpl) GetTermConflicts(ctx context.Context, termID string) ([]domainModels.ScheduleConflict, error) {
+	query := `WITH ` + sectionPeople + `,
+candidates AS (
+	SELECT m.id, m.section_id, m.room_id, m.weekday, m.start_time, m.end_time, o.term_id
+	FROM section_meetings m
+	JOIN course_sections s ON s.id = m.section_id
+	JOIN course_offerings o ON o.id = s.offering_id
+	WHERE o.term_id = $1
+)` + scheduleConflicts + " WHERE meeting_id < conflict_meeting_id ORDER BY weekday, start_time, kind, subject_id"
+	conflicts := []domainModels.ScheduleConflict{}
+	if err := r.DB.SelectContext(ctx, &conflicts, query, termID); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func (r *TimetableRepositoryImpl) GetStudentTimetable(ctx context.Context, studentID, termID string) ([]domainModels.TimetableEntry, error) {
+	query := timetableSelect + `
+		JOIN student_courses sc ON sc.offering_id = o.id AND sc.status = 'enrolled'
+			AND (sc.section_id = s.id OR sc.section_id IS NULL)
+		WHERE sc.student_id = $1 AND o.term_id = $2` + timetableOrder
+	return r.selectTimetable(ctx, query, studentID, termID)
+}
+
+func (r *TimetableRepositoryImpl) GetTeacherTimetable(ctx context.Context, teacherID, termID string) ([]domainModels.TimetableEntry, error) {
+	query := `WITH ` + sectionPeople + " " + timetableSelect + `
+		JOIN section_teachers st ON st.section_id = s.id
+		WHERE st.teacher_id = $1 AND o.term_id = $2` + timetableOrder
+	return r.selectTimetable(ctx, query, teacherID, termID)
+}
+
+func (r *TimetableRepositoryImpl) GetRoomTimetable(ctx context.Context, roomID, termID string) ([]domainModels.TimetableEntry, error) {
+	query := timetableSelect + " WHERE m.room_id = $1 AND o.term_id = $2" + timetableOrder
+	return r.selectTimetable(ctx, query, roomID, termID)
+}
+
+func (r *TimetableRepositoryImpl) selectTimetable(ctx context.Context, query string, args ...interface{}) ([]domainModels.TimetableEntry, error) {
+	entries := []domainModels.TimetableEntry{}
+	if err := r.DB.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -3,8 +3,10 @@ package routes
 import (
 	"university_system/internal/auth"
 	infraRepo "university_system/internal/infrastructure/repository"
+	"university_system/internal/infrastructure/storage"
 	controller "university_system/internal/university/controllers"
 	"university_system/internal/university/services"
+	"university_system/pkg/config"
 	"university_system/pkg/databases"
 	"university_system/pkg/middleware"
 
@@ -13,7 +15,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func RegisterUserRoutes(router *gin.Engine) {
+func RegisterUserRoutes(router *gin.Engine, cfg *config.Config) {
 	userRepo := infraRepo.NewUserRepository(databases.Instance)
 	userService := services.NewUserService(userRepo)
 	studentRepo := infraRepo.NewStudentRepository(databases.Instance)
@@ -21,80 +23,444 @@ func RegisterUserRoutes(router *gin.Engine) {
 	teacherRepo := infraRepo.NewTeacherRepository(databases.Instance)
 	managerRepo := infraRepo.NewManagerRepository(databases.Instance)
 	markRepo := infraRepo.NewGradeRepository(databases.Instance)
-	studentController := controller.NewStudentController(studentRepo)
+	termRepo := infraRepo.NewTermRepository(databases.Instance)
+	sectionRepo := infraRepo.NewSectionRepository(databases.Instance)
+	schemeRepo := infraRepo.NewGradingSchemeRepository(databases.Instance)
+	documentRepo := infraRepo.NewDocumentRepository(databases.Instance)
+	appealRepo := infraRepo.NewAppealRepository(databases.Instance)
+	attendanceRepo := infraRepo.NewAttendanceRepository(databases.Instance)
+	roomRepo := infraRepo.NewRoomRepository(databases.Instance)
+	timetableRepo := infraRepo.NewTimetableRepository(databases.Instance)
+	calendarRepo := infraRepo.NewCalendarRepository(databases.Instance)
+	assignmentRepo := infraRepo.NewAssignmentRepository(databases.Instance)
+	examRepo := infraRepo.NewExamRepository(databases.Instance)
+	programRepo := infraRepo.NewProgramRepository(databases.Instance)
+	organizationRepo := infraRepo.NewOrganizationRepository(databases.Instance)
+	advisingRepo := infraRepo.NewAdvisingRepository(databases.Instance)
+	standingRepo := infraRepo.NewStandingRepository(databases.Instance)
+	evaluationRepo := infraRepo.NewEvaluationRepository(databases.Instance)
+	officeHourRepo := infraRepo.NewOfficeHourRepository(databases.Instance)
+	announcementRepo := infraRepo.NewAnnouncementRepository(databases.Instance)
+	blobStorage := storage.NewLocalStorage(cfg.StorageDir)
+	studentService := services.NewStudentService(studentRepo, termRepo, sectionRepo, courseRepo, organizationRepo, advisingRepo)
+	courseService := services.NewCourseService(courseRepo, organizationRepo)
+	teacherService := services.NewTeacherService(teacherRepo, organizationRepo)
+	managerService := services.NewManagerService(managerRepo, organizationRepo, teacherRepo, courseRepo)
+	gradeService := services.NewGradeService(markRepo, schemeRepo, termRepo, standingRepo)
+	standingService := services.NewStandingService(standingRepo, termRepo, organizationRepo, gradeService)
+	termService := services.NewTermService(termRepo, standingService)
+	sectionService := services.NewSectionService(sectionRepo, termRepo)
+	schemeService := services.NewGradingSchemeService(schemeRepo)
+	documentService := services.NewDocumentService(documentRepo, studentRepo, termRepo, gradeService, cfg.PublicURL+"/verify/")
+	appealService := services.NewAppealService(appealRepo, markRepo, schemeRepo)
+	attendanceService := services.NewAttendanceService(attendanceRepo, termRepo, sectionRepo, markRepo)
+	roomService := services.NewRoomService(roomRepo)
+	timetableService := services.NewTimetableService(timetableRepo, roomRepo, sectionRepo, termRepo)
+	calendarService := services.NewCalendarService(calendarRepo, termRepo, timetableRepo, examRepo, officeHourRepo, cfg.PublicURL+"/calendar/feeds/")
+	assignmentService := services.NewAssignmentService(assignmentRepo, blobStorage, termRepo, markRepo, schemeRepo)
+	examService := services.NewExamService(examRepo, roomRepo, termRepo)
+	programService := services.NewProgramService(programRepo, studentRepo, gradeService)
+	organizationService := services.NewOrganizationService(organizationRepo)
+	evaluationService := services.NewEvaluationService(evaluationRepo, termRepo, markRepo)
+	officeHourService := services.NewOfficeHourService(officeHourRepo, teacherRepo, courseRepo)
+	announcementService := services.NewAnnouncementService(announcementRepo, courseRepo, organizationRepo, markRepo)
+	advisingService := services.NewAdvisingService(advisingRepo, studentRepo, organizationRepo, termRepo, studentService, gradeService, attendanceService)
+	studentController := controller.NewStudentController(studentService)
 	userController := controller.NewUserController(userService)
-	courseController := controller.NewCourseController(courseRepo)
-	teacherController := controller.NewTeacherController(teacherRepo)
-	managerController := controller.NewManagerController(managerRepo)
-	markController := controller.NewCourseMarkController(markRepo)
+	courseController := controller.NewCourseController(courseService)
+	teacherController := controller.NewTeacherController(teacherService)
+	managerController := controller.NewManagerController(managerService)
+	markController := controller.NewCourseMarkController(gradeService)
+	policyController := controller.NewPolicyController()
+	termController := controller.NewTermController(termService)
+	sectionController := controller.NewSectionController(sectionService)
+	schemeController := controller.NewGradingSchemeController(schemeService)
+	documentController := controller.NewDocumentController(documentService)
+	appealController := controller.NewAppealController(appealService)
+	attendanceController := controller.NewAttendanceController(attendanceService)
+	roomController := controller.NewRoomController(roomService)
+	timetableController := controller.NewTimetableController(timetableService)
+	calendarController := controller.NewCalendarController(calendarService)
+	assignmentController := controller.NewAssignmentController(assignmentService)
+	examController := controller.NewExamController(examService)
+	programController := controller.NewProgramController(programService)
+	organizationController := controller.NewOrganizationController(organizationService)
+	advisingController := controller.NewAdvisingController(advisingService)
+	standingController := controller.NewStandingController(standingService)
+	evaluationController := controller.NewEvaluationController(evaluationService)
+	officeHourController := controller.NewOfficeHourController(officeHourService)
+	announcementController := controller.NewAnnouncementController(announcementService)
 	protected := router.Group("/api")
-	protected.Use(middleware.AuthMiddleware())
+	protected.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
 	{
-		protected.GET("/users", middleware.RoleMiddleware("admin", "manager"), userController.GetUsers)
-		protected.POST("/users", middleware.RoleMiddleware("admin", "manager"), userController.CreateUser)
-		protected.GET("/users/:id", middleware.RoleMiddleware("admin", "manager"), userController.GetUserById)
-		protected.PUT("/users/:id", middleware.RoleMiddleware("admin", "manager"), userController.UpdateUser)
-		protected.DELETE("/users/:id", middleware.RoleMiddleware("admin"), userController.DeleteUser)
+		protected.GET("/users", userController.GetUsers)
+		protected.POST("/users", userController.CreateUser)
+		protected.GET("/users/:id", userController.GetUserById)
+		protected.PUT("/users/:id", userController.UpdateUser)
+		protected.DELETE("/users/:id", userController.DeleteUser)
+
+		protected.GET("/policies", policyController.GetPolicies)
+		protected.POST("/policies", policyController.AddPolicy)
+		protected.DELETE("/policies", policyController.RemovePolicy)
+		protected.POST("/policies/reload", policyController.ReloadPolicies)
+		protected.GET("/roles", policyController.GetRoles)
+		protected.POST("/roles", policyController.AddRoleInheritance)
+		protected.DELETE("/roles", policyController.RemoveRoleInheritance)
 	}
 
 	studentRoutes := router.Group("/students")
-	studentRoutes.Use(middleware.AuthMiddleware())
+	studentRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
 	{
-		studentRoutes.GET("/", middleware.RoleMiddleware("admin", "manager", "teacher"), studentController.GetStudents)
-		studentRoutes.POST("", middleware.RoleMiddleware("admin", "manager"), studentController.CreateStudent)
-		studentRoutes.GET("/:id", middleware.RoleMiddleware("admin", "manager", "teacher", "student"), studentController.GetStudentById)
-		studentRoutes.PUT("/:id", middleware.RoleMiddleware("admin", "manager", "student"), studentController.UpdateStudent)
-		studentRoutes.DELETE("/:id", middleware.RoleMiddleware("admin", "manager"), studentController.DeleteStudent)
-		studentRoutes.POST("/:student_id/courses/:course_id", middleware.RoleMiddleware("admin", "manager", "student"), studentController.EnrollStudentToCourse)
-		studentRoutes.GET("/:id/courses", middleware.RoleMiddleware("admin", "manager", "teacher", "student"), studentController.GetStudentCourses)
+		studentRoutes.GET("/", studentController.GetStudents)
+		studentRoutes.POST("", studentController.CreateStudent)
+		studentRoutes.GET("/:id", middleware.SelfOrRoles("id", "admin", "manager", "teacher"), studentController.GetStudentById)
+		studentRoutes.PUT("/:id", middleware.SelfOrRoles("id", "admin", "manager"), studentController.UpdateStudent)
+		studentRoutes.DELETE("/:id", studentController.DeleteStudent)
+		studentRoutes.POST("/:student_id/courses/:course_id", middleware.SelfOrRoles("student_id", "admin", "manager"), studentController.EnrollStudentToCourse)
+		studentRoutes.DELETE("/:id/courses/:course_id", middleware.SelfOrRoles("id", "admin", "manager"), studentController.DropStudentFromCourse)
+		studentRoutes.POST("/:student_id/courses/:course_id/withdraw", middleware.SelfOrRoles("student_id", "admin", "manager"), studentController.WithdrawStudentFromCourse)
+		studentRoutes.GET("/:id/courses", middleware.SelfOrRoles("id", "admin", "manager", "teacher"), studentController.GetStudentCourses)
+		studentRoutes.GET("/:id/enrollments", middleware.SelfOrRoles("id", "admin", "manager"), studentController.GetStudentEnrollments)
+		studentRoutes.GET("/:id/enrollments/history", middleware.SelfOrRoles("id", "admin", "manager"), studentController.GetEnrollmentHistory)
+		studentRoutes.GET("/:id/transcript", middleware.SelfOrRoles("id", "admin", "manager"), markController.GetStudentTranscript)
+		studentRoutes.POST("/:student_id/documents/transcript", middleware.SelfOrRoles("student_id", "admin", "manager"), documentController.IssueTranscript)
+		studentRoutes.POST("/:student_id/documents/enrollment-certificate", middleware.SelfOrRoles("student_id", "admin", "manager"), documentController.IssueEnrollmentCertificate)
+		studentRoutes.GET("/:id/documents", middleware.SelfOrRoles("id", "admin", "manager"), documentController.GetStudentDocuments)
+		studentRoutes.POST("/:student_id/appeals", middleware.SelfOrRoles("student_id", "admin"), appealController.FileAppeal)
+		studentRoutes.GET("/:id/appeals", middleware.SelfOrRoles("id", "admin", "manager"), appealController.GetStudentAppeals)
+		studentRoutes.GET("/:id/attendance", middleware.SelfOrRoles("id", "admin", "manager"), attendanceController.GetStudentAttendance)
+		studentRoutes.GET("/:id/timetable", middleware.SelfOrRoles("id", "admin", "manager"), timetableController.GetStudentTimetable)
+		studentRoutes.GET("/:id/submissions", middleware.SelfOrRoles("id", "admin", "manager"), assignmentController.GetStudentSubmissions)
+		studentRoutes.GET("/:id/exams", middleware.SelfOrRoles("id", "admin", "manager"), examController.GetStudentExams)
+		studentRoutes.PUT("/:id/program", programController.AssignStudentProgram)
+		studentRoutes.GET("/:id/degree-audit", middleware.SelfOrRoles("id", "admin", "manager"), programController.GetDegreeAudit)
+		studentRoutes.GET("/:id/standings", middleware.SelfOrRoles("id", "admin", "manager"), standingController.GetStudentStandings)
+		studentRoutes.GET("/:id/evaluations", middleware.SelfOrRoles("id", "admin", "manager"), evaluationController.GetStudentEvaluations)
+		studentRoutes.GET("/:id/bookings", middleware.SelfOrRoles("id", "admin", "manager"), officeHourController.GetStudentBookings)
+		studentRoutes.GET("/:id/advisors", middleware.SelfOrRoles("id", "admin", "manager", "teacher"), advisingController.GetStudentAdvisors)
+		studentRoutes.PUT("/:id/advisors", advisingController.AssignAdvisor)
+		studentRoutes.DELETE("/:id/advisors/:program_id", advisingController.RemoveAdvisor)
+		studentRoutes.GET("/:id/advising-notes", middleware.SelfOrRoles("id", "admin", "manager", "teacher"), advisingController.GetAdvisingNotes)
+		studentRoutes.POST("/:student_id/advising-notes", advisingController.CreateAdvisingNote)
+		studentRoutes.GET("/:id/waitlist", middleware.SelfOrRoles("id", "admin", "manager"), studentController.GetStudentWaitlist)
+		studentRoutes.DELETE("/:id/waitlist/:section_id", middleware.SelfOrRoles("id", "admin", "manager"), studentController.LeaveWaitlist)
 	}
 
 	courseRoutes := router.Group("/courses")
-	courseRoutes.Use(middleware.AuthMiddleware())
+	courseRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
 	{
-		courseRoutes.POST("/", middleware.RoleMiddleware("admin", "manager", "teacher"), courseController.CreateCourse)
-		courseRoutes.GET("/:id", middleware.RoleMiddleware("admin", "manager", "teacher", "student"), courseController.GetCourseByID)
-		courseRoutes.GET("/", middleware.RoleMiddleware("admin", "manager", "teacher", "student"), courseController.GetAllCourses)
-		courseRoutes.PUT("/:id", middleware.RoleMiddleware("admin", "manager", "teacher"), courseController.UpdateCourse)
-		courseRoutes.DELETE("/:id", middleware.RoleMiddleware("admin", "manager"), courseController.DeleteCourse)
-		courseRoutes.GET("/:id/students", middleware.RoleMiddleware("admin", "manager", "teacher"), courseController.GetCourseStudents)
-		courseRoutes.GET("/:id/teachers", middleware.RoleMiddleware("admin", "manager", "teacher"), courseController.GetCourseTeachers)
+		courseRoutes.POST("/", courseController.CreateCourse)
+		courseRoutes.GET("/:id", courseController.GetCourseByID)
+		courseRoutes.GET("/", courseController.GetAllCourses)
+		courseRoutes.PUT("/:id", courseController.UpdateCourse)
+		courseRoutes.DELETE("/:id", courseController.DeleteCourse)
+		courseRoutes.GET("/:id/students", courseController.GetCourseStudents)
+		courseRoutes.GET("/:id/teachers", courseController.GetCourseTeachers)
 	}
 
 	teacherRoutes := router.Group("/teachers")
-	teacherRoutes.Use(middleware.AuthMiddleware())
+	teacherRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
 	{
-		teacherRoutes.GET("/", middleware.RoleMiddleware("admin", "manager", "teacher"), teacherController.GetTeachers)
-		teacherRoutes.GET("/:id", middleware.RoleMiddleware("admin", "manager", "teacher"), teacherController.GetTeacherByID)
-		teacherRoutes.PUT("/:id", middleware.RoleMiddleware("admin", "manager", "teacher"), teacherController.UpdateTeacher)
-		teacherRoutes.DELETE("/:id", middleware.RoleMiddleware("admin", "manager"), teacherController.DeleteTeacher)
-		teacherRoutes.POST("/", middleware.RoleMiddleware("admin", "manager"), teacherController.CreateTeacher)
-		teacherRoutes.GET("/:id/courses", middleware.RoleMiddleware("admin", "manager", "teacher"), teacherController.GetTeacherCourses)
-		teacherRoutes.POST("/:id/courses/:course_id/students/:student_id/PutFirstAtt", middleware.RoleMiddleware("admin", "teacher"), markController.AddFirstAttestation)
-		teacherRoutes.POST("/:id/courses/:course_id/students/:student_id/PutSecondAtt", middleware.RoleMiddleware("admin", "teacher"), markController.AddSecondAttestation)
-		teacherRoutes.POST("/:id/courses/:course_id/students/:student_id/PutFinalMark", middleware.RoleMiddleware("admin", "teacher"), markController.AddFinalExamMark)
+		teacherRoutes.GET("/", teacherController.GetTeachers)
+		teacherRoutes.GET("/:id", teacherController.GetTeacherByID)
+		teacherRoutes.PUT("/:id", middleware.SelfOrRoles("id", "admin", "manager"), teacherController.UpdateTeacher)
+		teacherRoutes.DELETE("/:id", teacherController.DeleteTeacher)
+		teacherRoutes.POST("/", teacherController.CreateTeacher)
+		teacherRoutes.GET("/:id/courses", teacherController.GetTeacherCourses)
+		teacherRoutes.GET("/:id/timetable", middleware.SelfOrRoles("id", "admin", "manager"), timetableController.GetTeacherTimetable)
+		teacherRoutes.GET("/:id/exams", middleware.SelfOrRoles("id", "admin", "manager"), examController.GetTeacherExams)
+		teacherRoutes.GET("/:id/advisees", middleware.SelfOrRoles("id", "admin", "manager"), advisingController.GetAdvisees)
+		teacherRoutes.GET("/:id/evaluations", middleware.SelfOrRoles("id", "admin", "manager"), evaluationController.GetTeacherEvaluations)
+		teacherRoutes.GET("/:id/office-hours", officeHourController.GetTeacherOfficeHours)
+		teacherRoutes.POST("/:id/office-hours", middleware.SelfOrRoles("id", "admin"), officeHourController.CreateOfficeHour)
+		teacherRoutes.GET("/:id/bookings", middleware.SelfOrRoles("id", "admin", "manager"), officeHourController.GetTeacherBookings)
+		teacherRoutes.POST("/:id/courses/:course_id/students/:student_id/PutFirstAtt", middleware.SelfOrRoles("id", "admin"), markController.AddFirstAttestation)
+		teacherRoutes.POST("/:id/courses/:course_id/students/:student_id/PutSecondAtt", middleware.SelfOrRoles("id", "admin"), markController.AddSecondAttestation)
+		teacherRoutes.POST("/:id/courses/:course_id/students/:student_id/PutFinalMark", middleware.SelfOrRoles("id", "admin"), markController.AddFinalExamMark)
 
 	}
 
 	managerRoutes := router.Group("/managers")
-	managerRoutes.Use(middleware.AuthMiddleware())
+	managerRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
 	{
-		managerRoutes.GET("/", middleware.RoleMiddleware("admin", "manager"), managerController.GetManagers)
-		managerRoutes.GET("/:id", middleware.RoleMiddleware("admin", "manager"), managerController.GetManagerById)
-		managerRoutes.PUT("/:id", middleware.RoleMiddleware("admin", "manager"), managerController.UpdateManager)
-		managerRoutes.DELETE("/:id", middleware.RoleMiddleware("admin"), managerController.DeleteManager)
-		managerRoutes.POST("/", middleware.RoleMiddleware("admin", "manager"), managerController.CreateManager)
-		managerRoutes.POST("/:id/teachers/:teacher_id/courses/:course_id", middleware.RoleMiddleware("admin", "manager"), managerController.AssignTeacherToCourse)
+		managerRoutes.GET("/", managerController.GetManagers)
+		managerRoutes.GET("/:id", managerController.GetManagerById)
+		managerRoutes.PUT("/:id", middleware.SelfOrRoles("id", "admin"), managerController.UpdateManager)
+		managerRoutes.DELETE("/:id", managerController.DeleteManager)
+		managerRoutes.POST("/", managerController.CreateManager)
+		managerRoutes.POST("/:id/teachers/:teacher_id/courses/:course_id", managerController.AssignTeacherToCourse)
 	}
 
 	marksRoutes := router.Group("/marks")
-	marksRoutes.Use(middleware.AuthMiddleware())
+	marksRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		marksRoutes.GET("/student/:student_id", middleware.SelfOrRoles("student_id", "admin", "manager", "teacher"), markController.GetStudentMarks)
+		marksRoutes.GET("/course/:course_id", markController.GetCourseMarks)
+	}
+
+	termRoutes := router.Group("/terms")
+	termRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		termRoutes.GET("", termController.GetTerms)
+		termRoutes.POST("", termController.CreateTerm)
+		termRoutes.GET("/:id", termController.GetTermByID)
+		termRoutes.PUT("/:id", termController.UpdateTerm)
+		termRoutes.DELETE("/:id", termController.DeleteTerm)
+		termRoutes.GET("/:id/standings", standingController.GetTermStandings)
+		termRoutes.POST("/:id/standings", standingController.ComputeTermStandings)
+		termRoutes.GET("/:id/evaluations", evaluationController.GetTermEvaluations)
+		termRoutes.POST("/:id/evaluations", evaluationController.OpenTermEvaluations)
+		termRoutes.GET("/:id/offerings", termController.GetTermOfferings)
+		termRoutes.POST("/:id/offerings", termController.CreateOffering)
+		termRoutes.DELETE("/:id/offerings/:offering_id", termController.DeleteOffering)
+		termRoutes.GET("/:id/offerings/:offering_id/sections", sectionController.GetOfferingSections)
+		termRoutes.POST("/:id/offerings/:offering_id/sections", sectionController.CreateSection)
+		termRoutes.GET("/:id/offerings/:offering_id/grades", markController.GetGradeSheet)
+		termRoutes.POST("/:id/offerings/:offering_id/grades/submit", markController.SubmitGrades)
+		termRoutes.POST("/:id/offerings/:offering_id/grades/approve", markController.ApproveGrades)
+		termRoutes.POST("/:id/offerings/:offering_id/grades/return", markController.ReturnGrades)
+		termRoutes.GET("/:id/offerings/:offering_id/grades/history", markController.GetMarkHistory)
+		termRoutes.POST("/:id/offerings/:offering_id/grade-changes", markController.RequestGradeChange)
+		termRoutes.GET("/:id/offerings/:offering_id/sessions", attendanceController.GetOfferingSessions)
+		termRoutes.POST("/:id/offerings/:offering_id/sessions", attendanceController.GenerateSessions)
+		termRoutes.GET("/:id/offerings/:offering_id/assignments", assignmentController.GetOfferingAssignments)
+		termRoutes.POST("/:id/offerings/:offering_id/assignments", assignmentController.CreateAssignment)
+		termRoutes.POST("/:id/offerings/:offering_id/assignments/apply-marks", assignmentController.ApplyAssignmentMarks)
+		termRoutes.GET("/:id/offerings/:offering_id/exams", examController.GetOfferingExams)
+		termRoutes.POST("/:id/offerings/:offering_id/exams", examController.CreateExam)
+		termRoutes.GET("/:id/exams", examController.GetTermExams)
+		termRoutes.GET("/:id/exams/conflicts", examController.GetTermConflicts)
+		termRoutes.GET("/:id/attendance/flags", attendanceController.GetAttendanceFlags)
+		termRoutes.GET("/:id/timetable/conflicts", timetableController.GetTermConflicts)
+		termRoutes.GET("/:id/holidays", calendarController.GetTermHolidays)
+		termRoutes.POST("/:id/holidays", calendarController.CreateHoliday)
+		termRoutes.DELETE("/:id/holidays/:holiday_id", calendarController.DeleteHoliday)
+	}
+
+	gradeChangeRoutes := router.Group("/grade-changes")
+	gradeChangeRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		gradeChangeRoutes.GET("", markController.GetGradeChangeRequests)
+		gradeChangeRoutes.POST("/:id/approve", markController.ApproveGradeChange)
+		gradeChangeRoutes.POST("/:id/reject", markController.RejectGradeChange)
+	}
+
+	sectionRoutes := router.Group("/sections")
+	sectionRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		sectionRoutes.GET("/:id", sectionController.GetSectionByID)
+		sectionRoutes.PUT("/:id", sectionController.UpdateSection)
+		sectionRoutes.DELETE("/:id", sectionController.DeleteSection)
+		sectionRoutes.GET("/:id/waitlist", sectionController.GetSectionWaitlist)
+		sectionRoutes.GET("/:id/meetings", timetableController.GetSectionMeetings)
+		sectionRoutes.POST("/:id/meetings", timetableController.CreateMeeting)
+	}
+
+	sessionRoutes := router.Group("/sessions")
+	sessionRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		sessionRoutes.DELETE("/:id", attendanceController.DeleteSession)
+		sessionRoutes.GET("/:id/attendance", attendanceController.GetSessionAttendance)
+		sessionRoutes.PUT("/:id/attendance", attendanceController.MarkSessionAttendance)
+		sessionRoutes.PUT("/:id/attendance/:student_id", attendanceController.MarkStudentAttendance)
+	}
+
+	attendanceRoutes := router.Group("/attendance")
+	attendanceRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		attendanceRoutes.GET("/settings", attendanceController.GetAttendanceSettings)
+		attendanceRoutes.PUT("/settings", attendanceController.UpdateAttendanceSettings)
+	}
+
+	standingRuleRoutes := router.Group("/standing-rules")
+	standingRuleRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		standingRuleRoutes.GET("", standingController.GetStandingRules)
+		standingRuleRoutes.PUT("", standingController.UpdateStandingRules)
+	}
+
+	evaluationTemplateRoutes := router.Group("/evaluation-templates")
+	evaluationTemplateRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		evaluationTemplateRoutes.GET("", evaluationController.GetEvaluationTemplates)
+		evaluationTemplateRoutes.POST("", evaluationController.CreateEvaluationTemplate)
+		evaluationTemplateRoutes.GET("/:id", evaluationController.GetEvaluationTemplateByID)
+		evaluationTemplateRoutes.DELETE("/:id", evaluationController.DeleteEvaluationTemplate)
+	}
+
+	evaluationRoutes := router.Group("/evaluations")
+	evaluationRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		evaluationRoutes.POST("/:id/responses", evaluationController.SubmitEvaluationResponse)
+		evaluationRoutes.GET("/:id/results", evaluationController.GetEvaluationResults)
+	}
+
+	officeHourRoutes := router.Group("/office-hours")
+	officeHourRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		officeHourRoutes.PUT("/:id", officeHourController.UpdateOfficeHour)
+		officeHourRoutes.DELETE("/:id", officeHourController.DeleteOfficeHour)
+		officeHourRoutes.GET("/:id/slots", officeHourController.GetOfficeHourSlots)
+		officeHourRoutes.POST("/:id/bookings", officeHourController.BookOfficeHourSlot)
+	}
+
+	bookingRoutes := router.Group("/bookings")
+	bookingRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		bookingRoutes.POST("/:id/cancel", officeHourController.CancelBooking)
+	}
+
+	announcementRoutes := router.Group("/announcements")
+	announcementRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		announcementRoutes.GET("/feed", announcementController.GetAnnouncementFeed)
+		announcementRoutes.POST("", announcementController.CreateAnnouncement)
+		announcementRoutes.POST("/read-all", announcementController.MarkAllAnnouncementsRead)
+		announcementRoutes.PUT("/:id", announcementController.UpdateAnnouncement)
+		announcementRoutes.DELETE("/:id", announcementController.DeleteAnnouncement)
+		announcementRoutes.POST("/:id/read", announcementController.MarkAnnouncementRead)
+	}
+
+	roomRoutes := router.Group("/rooms")
+	roomRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		roomRoutes.GET("", roomController.GetRooms)
+		roomRoutes.POST("", roomController.CreateRoom)
+		roomRoutes.GET("/:id", roomController.GetRoomByID)
+		roomRoutes.PUT("/:id", roomController.UpdateRoom)
+		roomRoutes.DELETE("/:id", roomController.DeleteRoom)
+		roomRoutes.GET("/:id/timetable", timetableController.GetRoomTimetable)
+	}
+
+	meetingRoutes := router.Group("/meetings")
+	meetingRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		meetingRoutes.PUT("/:id", timetableController.UpdateMeeting)
+		meetingRoutes.DELETE("/:id", timetableController.DeleteMeeting)
+	}
+
+	assignmentRoutes := router.Group("/assignments")
+	assignmentRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		assignmentRoutes.GET("/:id", assignmentController.GetAssignment)
+		assignmentRoutes.PUT("/:id", assignmentController.UpdateAssignment)
+		assignmentRoutes.DELETE("/:id", assignmentController.DeleteAssignment)
+		assignmentRoutes.GET("/:id/submissions", assignmentController.GetAssignmentSubmissions)
+		assignmentRoutes.POST("/:id/submissions", assignmentController.SubmitAssignment)
+	}
+
+	submissionRoutes := router.Group("/submissions")
+	submissionRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		submissionRoutes.GET("/:id", assignmentController.GetSubmission)
+		submissionRoutes.GET("/:id/file", assignmentController.GetSubmissionFile)
+		submissionRoutes.PUT("/:id/grade", assignmentController.GradeSubmission)
+	}
+
+	examRoutes := router.Group("/exams")
+	examRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		examRoutes.GET("/:id", examController.GetExam)
+		examRoutes.PUT("/:id", examController.UpdateExam)
+		examRoutes.DELETE("/:id", examController.DeleteExam)
+		examRoutes.GET("/:id/seating", examController.GetSeating)
+		examRoutes.POST("/:id/seating", examController.AllocateSeating)
+		examRoutes.GET("/:id/rooms/:room_id/seating", examController.GetRoomSeatingList)
+	}
+
+	programRoutes := router.Group("/programs")
+	programRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		programRoutes.GET("", programController.GetPrograms)
+		programRoutes.POST("", programController.CreateProgram)
+		programRoutes.GET("/:id", programController.GetProgram)
+		programRoutes.PUT("/:id", programController.UpdateProgram)
+		programRoutes.DELETE("/:id", programController.DeleteProgram)
+		programRoutes.GET("/:id/curricula", programController.GetProgramCurricula)
+		programRoutes.POST("/:id/curricula", programController.CreateCurriculum)
+	}
+
+	curriculumRoutes := router.Group("/curricula")
+	curriculumRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		curriculumRoutes.GET("/:id", programController.GetCurriculum)
+		curriculumRoutes.PUT("/:id", programController.UpdateCurriculum)
+		curriculumRoutes.DELETE("/:id", programController.DeleteCurriculum)
+	}
+
+	facultyRoutes := router.Group("/faculties")
+	facultyRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		facultyRoutes.GET("", organizationController.GetFaculties)
+		facultyRoutes.POST("", organizationController.CreateFaculty)
+		facultyRoutes.GET("/:id", organizationController.GetFaculty)
+		facultyRoutes.PUT("/:id", organizationController.UpdateFaculty)
+		facultyRoutes.DELETE("/:id", organizationController.DeleteFaculty)
+		facultyRoutes.POST("/:id/merge", organizationController.MergeFaculties)
+		facultyRoutes.GET("/:id/departments", organizationController.GetFacultyDepartments)
+	}
+
+	departmentRoutes := router.Group("/departments")
+	departmentRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		departmentRoutes.GET("", organizationController.GetDepartments)
+		departmentRoutes.POST("", organizationController.CreateDepartment)
+		departmentRoutes.GET("/:id", organizationController.GetDepartment)
+		departmentRoutes.PUT("/:id", organizationController.UpdateDepartment)
+		departmentRoutes.DELETE("/:id", organizationController.DeleteDepartment)
+		departmentRoutes.POST("/:id/merge", organizationController.MergeDepartments)
+	}
+
+	advisingNoteRoutes := router.Group("/advising-notes")
+	advisingNoteRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		advisingNoteRoutes.PUT("/:id", advisingController.UpdateAdvisingNote)
+		advisingNoteRoutes.DELETE("/:id", advisingController.DeleteAdvisingNote)
+	}
+
+	approvalRoutes := router.Group("/enrollment-approvals")
+	approvalRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		approvalRoutes.GET("", advisingController.GetEnrollmentApprovals)
+		approvalRoutes.POST("/:id/approve", advisingController.ApproveEnrollment)
+		approvalRoutes.POST("/:id/reject", advisingController.RejectEnrollment)
+	}
+
+	calendarRoutes := router.Group("/calendar")
+	calendarRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		calendarRoutes.GET("/feed", calendarController.GetCalendarFeed)
+		calendarRoutes.POST("/feed/rotate", calendarController.RotateCalendarFeed)
+	}
+
+	schemeRoutes := router.Group("/grading-schemes")
+	schemeRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
+	{
+		schemeRoutes.GET("", schemeController.GetGradingSchemes)
+		schemeRoutes.POST("", schemeController.CreateGradingScheme)
+		schemeRoutes.GET("/:id", schemeController.GetGradingSchemeByID)
+		schemeRoutes.PUT("/:id", schemeController.UpdateGradingScheme)
+		schemeRoutes.DELETE("/:id", schemeController.DeleteGradingScheme)
+	}
+
+	appealRoutes := router.Group("/appeals")
+	appealRoutes.Use(middleware.AuthMiddleware(), auth.CasbinMiddleware())
 	{
-		marksRoutes.GET("/student/:student_id", middleware.RoleMiddleware("admin", "manager", "teacher", "student"), markController.GetStudentMarks)
-		marksRoutes.GET("/course/:course_id", middleware.RoleMiddleware("admin", "manager", "teacher", "student"), markController.GetCourseMarks)
+		appealRoutes.GET("", appealController.GetAppeals)
+		appealRoutes.GET("/:id", appealController.GetAppeal)
+		appealRoutes.GET("/:id/attachment", appealController.GetAppealAttachment)
+		appealRoutes.POST("/:id/comments", appealController.AddAppealComment)
+		appealRoutes.POST("/:id/review", appealController.StartAppealReview)
+		appealRoutes.POST("/:id/resolve", appealController.ResolveAppeal)
+		appealRoutes.POST("/:id/withdraw", appealController.WithdrawAppeal)
 	}
 
 	router.POST("/login", auth.Login)
 	router.POST("/refresh", auth.Refresh)
+	router.POST("/logout", auth.Logout)
+	router.POST("/logout-all", auth.LogoutAll)
+	router.GET("/.well-known/jwks.json", auth.JWKS)
+	router.GET("/verify/:code", documentController.VerifyDocument)
+	router.GET("/calendar/feeds/:token", calendarController.GetCalendarFeedICS)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(files.Handler))
 }
@@ -0,0 +1,328 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdvisingController struct {
+	advisingService services.AdvisingService
+}
+
+func NewAdvisingController(service services.AdvisingService) *AdvisingController {
+	return &AdvisingController{advisingService: service}
+}
+
+// GetStudentAdvisors godoc
+// @Summary Консультанты студента
+// @Description Консультанты по всем программам студента; консультант текущей программы идёт первым (current = true)
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.AdvisorAssignment
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/advisors [get]
+func (ac *AdvisingController) GetStudentAdvisors(c *gin.Context) {
+	advisors, err := ac.advisingService.GetStudentAdvisors(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to fetch advisors")
+		return
+	}
+	c.JSON(http.StatusOK, advisors)
+}
+
+// AssignAdvisor godoc
+// @Summary Назначить консультанта
+// @Description Назначает преподавателя консультантом студента по его текущей программе, заменяя прежнего консультанта этой программы.
+// @Description Менеджер назначает консультантов только студентам факультета своей кафедры.
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param input body models.AdvisorAssignment true "ID преподавателя"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.AdvisorAssignment
+// @Failure 400 {object} gin.H "Студенту не назначена программа или пользователь не преподаватель"
+// @Failure 403 {object} gin.H "Студент другого факультета"
+// @Failure 404 {object} gin.H "Студент не найден"
+// @Router /students/{id}/advisors [put]
+func (ac *AdvisingController) AssignAdvisor(c *gin.Context) {
+	var assignment models.AdvisorAssignment
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	assignment.StudentID = c.Param("id")
+	assigned, err := ac.advisingService.AssignAdvisor(c.Request.Context(), assignment, auth.CurrentUserID(c), managerScope(c))
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to assign advisor")
+		return
+	}
+	c.JSON(http.StatusOK, assigned)
+}
+
+// RemoveAdvisor godoc
+// @Summary Снять консультанта
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param program_id path string true "ID программы"
+// @Success 204 "Консультант снят"
+// @Failure 403 {object} gin.H "Студент другого факультета"
+// @Failure 404 {object} gin.H "Назначение не найдено"
+// @Router /students/{id}/advisors/{program_id} [delete]
+func (ac *AdvisingController) RemoveAdvisor(c *gin.Context) {
+	if err := ac.advisingService.RemoveAdvisor(c.Request.Context(), c.Param("id"), c.Param("program_id"), managerScope(c)); err != nil {
+		respondAdvisingError(c, err, "Unable to remove advisor")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetAdvisees godoc
+// @Summary Панель консультанта
+// @Description Студенты, которых преподаватель консультирует по их текущей программе, с накопленным GPA,
+// @Description академическим положением, числом ожидающих заявок на запись и признаками риска:
+// @Description probation, failed_course (несданный курс в последнем периоде с оценками), low_attendance (посещаемость ниже порога в идущем периоде)
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID преподавателя"
+// @Produce json
+// @Success 200 {array} models.Advisee
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /teachers/{id}/advisees [get]
+func (ac *AdvisingController) GetAdvisees(c *gin.Context) {
+	advisees, err := ac.advisingService.GetAdvisees(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to fetch advisees")
+		return
+	}
+	c.JSON(http.StatusOK, advisees)
+}
+
+// GetAdvisingNotes godoc
+// @Summary Заметки о консультациях студента
+// @Description Автор видит свои заметки всегда. Заметки private видит только автор, advisors — консультанты студента,
+// @Description менеджеры и администраторы, student — ещё и сам студент. Преподавателю, не консультирующему студента, доступ закрыт,
+// @Description менеджер видит заметки только о студентах факультета своей кафедры.
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.AdvisingNote
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Router /students/{id}/advising-notes [get]
+func (ac *AdvisingController) GetAdvisingNotes(c *gin.Context) {
+	notes, err := ac.advisingService.GetNotes(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to fetch advising notes")
+		return
+	}
+	c.JSON(http.StatusOK, notes)
+}
+
+// CreateAdvisingNote godoc
+// @Summary Добавить заметку о консультации
+// @Description Преподаватель пишет заметки только о консультируемых студентах. Видимость по умолчанию — advisors.
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param student_id path string true "ID студента"
+// @Param input body models.AdvisingNote true "Текст и видимость: private, advisors или student"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.AdvisingNote
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Failure 404 {object} gin.H "Студент не найден"
+// @Router /students/{student_id}/advising-notes [post]
+func (ac *AdvisingController) CreateAdvisingNote(c *gin.Context) {
+	var note models.AdvisingNote
+	if err := c.ShouldBindJSON(&note); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	note.StudentID = c.Param("student_id")
+	created, err := ac.advisingService.CreateNote(c.Request.Context(), &note, auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to create advising note")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateAdvisingNote godoc
+// @Summary Изменить заметку о консультации
+// @Description Изменять заметку может только её автор; без visibility видимость не меняется
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID заметки"
+// @Param input body models.AdvisingNote true "Текст и видимость"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.AdvisingNote
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 403 {object} gin.H "Заметка другого автора"
+// @Failure 404 {object} gin.H "Заметка не найдена"
+// @Router /advising-notes/{id} [put]
+func (ac *AdvisingController) UpdateAdvisingNote(c *gin.Context) {
+	var note models.AdvisingNote
+	if err := c.ShouldBindJSON(&note); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	note.ID = c.Param("id")
+	updated, err := ac.advisingService.UpdateNote(c.Request.Context(), note, auth.CurrentUserID(c))
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to update advising note")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteAdvisingNote godoc
+// @Summary Удалить заметку о консультации
+// @Description Удалить заметку может её автор или администратор
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID заметки"
+// @Success 204 "Заметка удалена"
+// @Failure 403 {object} gin.H "Заметка другого автора"
+// @Failure 404 {object} gin.H "Заметка не найдена"
+// @Router /advising-notes/{id} [delete]
+func (ac *AdvisingController) DeleteAdvisingNote(c *gin.Context) {
+	if err := ac.advisingService.DeleteNote(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c)); err != nil {
+		respondAdvisingError(c, err, "Unable to delete advising note")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetEnrollmentApprovals godoc
+// @Summary Заявки на согласование записи
+// @Description Преподаватель видит заявки консультируемых студентов, студент — свои, менеджер — студентов факультета своей кафедры, администратор — все
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param status query string false "pending, approved или rejected"
+// @Produce json
+// @Success 200 {array} models.EnrollmentApproval
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /enrollment-approvals [get]
+func (ac *AdvisingController) GetEnrollmentApprovals(c *gin.Context) {
+	approvals, err := ac.advisingService.GetApprovals(c.Request.Context(), c.Query("status"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to fetch enrollment approvals")
+		return
+	}
+	c.JSON(http.StatusOK, approvals)
+}
+
+// ApproveEnrollment godoc
+// @Summary Одобрить запись на курс
+// @Description Консультант студента, менеджер кафедры факультета студента или администратор одобряет заявку, и студент записывается на курс.
+// @Description Период, правила курса и секция проверяются заново; если запись не удалась, заявка остаётся ожидающей.
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID заявки"
+// @Param input body models.ApprovalDecision false "Комментарий"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.EnrollmentApproval
+// @Failure 403 {object} gin.H "Не консультант студента или студент другого факультета"
+// @Failure 404 {object} gin.H "Заявка не найдена"
+// @Failure 409 {object} gin.H "Заявка уже рассмотрена или запись закрыта"
+// @Failure 422 {object} models.EnrollmentRuleError "Нарушены пререквизиты или ограничения курса"
+// @Router /enrollment-approvals/{id}/approve [post]
+func (ac *AdvisingController) ApproveEnrollment(c *gin.Context) {
+	decision, ok := bindApprovalDecision(c)
+	if !ok {
+		return
+	}
+	approval, err := ac.advisingService.ApproveEnrollment(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c), decision.Comment)
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to approve enrollment")
+		return
+	}
+	c.JSON(http.StatusOK, approval)
+}
+
+// RejectEnrollment godoc
+// @Summary Отклонить запись на курс
+// @Tags advising
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID заявки"
+// @Param input body models.ApprovalDecision false "Комментарий"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.EnrollmentApproval
+// @Failure 403 {object} gin.H "Не консультант студента или студент другого факультета"
+// @Failure 404 {object} gin.H "Заявка не найдена"
+// @Failure 409 {object} gin.H "Заявка уже рассмотрена"
+// @Router /enrollment-approvals/{id}/reject [post]
+func (ac *AdvisingController) RejectEnrollment(c *gin.Context) {
+	decision, ok := bindApprovalDecision(c)
+	if !ok {
+		return
+	}
+	approval, err := ac.advisingService.RejectEnrollment(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c), decision.Comment)
+	if err != nil {
+		respondAdvisingError(c, err, "Unable to reject enrollment")
+		return
+	}
+	c.JSON(http.StatusOK, approval)
+}
+
+// bindApprovalDecision читает необязательный комментарий; пустое тело допустимо
+func bindApprovalDecision(c *gin.Context) (models.ApprovalDecision, bool) {
+	var decision models.ApprovalDecision
+	if c.Request.ContentLength == 0 {
+		return decision, true
+	}
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return decision, false
+	}
+	return decision, true
+}
+
+func respondAdvisingError(c *gin.Context, err error, message string) {
+	if respondScopeError(c, err) {
+		return
+	}
+	var ruleErr *models.EnrollmentRuleError
+	switch {
+	case errors.Is(err, models.ErrAdvisorAssignmentNotFound), errors.Is(err, models.ErrAdvisingNoteNotFound),
+		errors.Is(err, models.ErrApprovalNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAdvisingForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrStudentProgramNotAssigned), errors.Is(err, models.ErrAdvisorNotTeacher):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrApprovalDecided):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.As(err, &ruleErr), errors.Is(err, models.ErrEnrollmentClosed), errors.Is(err, models.ErrAlreadyEnrolled),
+		errors.Is(err, models.ErrCourseNotOffered), errors.Is(err, models.ErrSectionNotFound), errors.Is(err, models.ErrTermNotFound):
+		// Одобрение заявки повторяет запись на курс и отвечает на её ошибки так же
+		respondEnrollmentError(c, err, message)
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnnouncementController struct {
+	announcementService services.AnnouncementService
+}
+
+func NewAnnouncementController(service services.AnnouncementService) *AnnouncementController {
+	return &AnnouncementController{announcementService: service}
+}
+
+// GetAnnouncementFeed godoc
+// @Summary Лента объявлений пользователя
+// @Description Объявления университета, кафедр и курсов, адресованные пользователю, от новых к старым,
+// @Description с отметкой read, числом всех (total) и непрочитанных (unread) объявлений
+// @Tags announcements
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param page query int false "Номер страницы (с 1)"
+// @Param page_size query int false "Размер страницы (по умолчанию 20, не больше 100)"
+// @Param unread query bool false "Только непрочитанные"
+// @Produce json
+// @Success 200 {object} models.AnnouncementFeed
+// @Failure 400 {object} gin.H "Неверные параметры страницы"
+// @Router /announcements/feed [get]
+func (ac *AnnouncementController) GetAnnouncementFeed(c *gin.Context) {
+	page, err := queryInt(c, "page")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "page must be a number"})
+		return
+	}
+	pageSize, err := queryInt(c, "page_size")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "page_size must be a number"})
+		return
+	}
+	feed, err := ac.announcementService.GetFeed(c.Request.Context(), auth.CurrentUserID(c), auth.CurrentUserRole(c),
+		c.Query("unread") == "true", page, pageSize)
+	if err != nil {
+		respondAnnouncementError(c, err, "Unable to fetch announcements")
+		return
+	}
+	c.JSON(http.StatusOK, feed)
+}
+
+// queryInt читает необязательный числовой параметр запроса; без параметра возвращает 0
+func queryInt(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// CreateAnnouncement godoc
+// @Summary Опубликовать объявление
+// @Description scope: university — для всего университета, department — для кафедры (department_id),
+// @Description course — для курса (course_id). audience: all (по умолчанию), student или teacher.
+// @Description Университетские и кафедральные объявления публикует менеджер (кафедральные — своей кафедры),
+// @Description объявления курса — ещё и преподаватель курса.
+// @Tags announcements
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.Announcement true "Объявление"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Announcement
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 403 {object} gin.H "Нет права публиковать объявление в этом охвате"
+// @Failure 404 {object} gin.H "Кафедра или курс не найдены"
+// @Router /announcements [post]
+func (ac *AnnouncementController) CreateAnnouncement(c *gin.Context) {
+	var announcement models.Announcement
+	if err := c.ShouldBindJSON(&announcement); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := ac.announcementService.CreateAnnouncement(c.Request.Context(), &announcement, auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAnnouncementError(c, err, "Unable to create announcement")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateAnnouncement godoc
+// @Summary Изменить объявление
+// @Description Меняются заголовок, текст и адресаты; охват объявления не меняется. Изменяет автор или администратор.
+// @Tags announcements
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID объявления"
+// @Param input body models.Announcement true "Объявление"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Announcement
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 403 {object} gin.H "Не автор объявления"
+// @Failure 404 {object} gin.H "Объявление не найдено"
+// @Router /announcements/{id} [put]
+func (ac *AnnouncementController) UpdateAnnouncement(c *gin.Context) {
+	var announcement models.Announcement
+	if err := c.ShouldBindJSON(&announcement); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	announcement.ID = c.Param("id")
+	updated, err := ac.announcementService.UpdateAnnouncement(c.Request.Context(), announcement, auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAnnouncementError(c, err, "Unable to update announcement")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteAnnouncement godoc
+// @Summary Удалить объявление
+// @Tags announcements
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID объявления"
+// @Success 204
+// @Failure 403 {object} gin.H "Не автор объявления"
+// @Failure 404 {object} gin.H "Объявление не найдено"
+// @Router /announcements/{id} [delete]
+func (ac *AnnouncementController) DeleteAnnouncement(c *gin.Context) {
+	if err := ac.announcementService.DeleteAnnouncement(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c)); err != nil {
+		respondAnnouncementError(c, err, "Unable to delete announcement")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAnnouncementRead godoc
+// @Summary Отметить объявление прочитанным
+// @Tags announcements
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID объявления"
+// @Success 204
+// @Failure 404 {object} gin.H "Объявление не найдено в ленте пользователя"
+// @Router /announcements/{id}/read [post]
+func (ac *AnnouncementController) MarkAnnouncementRead(c *gin.Context) {
+	if err := ac.announcementService.MarkRead(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c)); err != nil {
+		respondAnnouncementError(c, err, "Unable to mark announcement as read")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllAnnouncementsRead godoc
+// @Summary Отметить всю ленту прочитанной
+// @Tags announcements
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {object} gin.H "marked — число отмеченных объявлений"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /announcements/read-all [post]
+func (ac *AnnouncementController) MarkAllAnnouncementsRead(c *gin.Context) {
+	marked, err := ac.announcementService.MarkAllRead(c.Request.Context(), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAnnouncementError(c, err, "Unable to mark announcements as read")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marked": marked})
+}
+
+func respondAnnouncementError(c *gin.Context, err error, message string) {
+	if respondScopeError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, models.ErrAnnouncementNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAnnouncementForbidden), errors.Is(err, models.ErrNotCourseTeacher):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidAnnouncement):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,326 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AppealController struct {
+	appealService services.AppealService
+}
+
+func NewAppealController(service services.AppealService) *AppealController {
+	return &AppealController{appealService: service}
+}
+
+// FileAppeal godoc
+// @Summary Подать апелляцию на оценку
+// @Description Студент оспаривает одну часть оценки курса в периоде, указывает причину и может приложить файл (до 5 МБ)
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param student_id path string true "ID студента"
+// @Param offering_id formData string true "ID курса в периоде"
+// @Param mark_type formData string true "first_attestation, second_attestation или final"
+// @Param reason formData string true "Причина"
+// @Param attachment formData file false "Вложение"
+// @Accept multipart/form-data
+// @Produce json
+// @Success 201 {object} models.GradeAppeal
+// @Failure 400 {object} gin.H "Ошибка валидации или оценка не выставлена"
+// @Failure 409 {object} gin.H "Открытая апелляция на эту оценку уже есть"
+// @Failure 413 {object} gin.H "Вложение слишком большое"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{student_id}/appeals [post]
+func (ac *AppealController) FileAppeal(c *gin.Context) {
+	var appeal models.GradeAppeal
+	if err := c.ShouldBind(&appeal); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	attachment, err := readAppealAttachment(c)
+	if err != nil {
+		respondAppealError(c, err, "Unable to read attachment")
+		return
+	}
+	created, err := ac.appealService.FileAppeal(c.Request.Context(), c.Param("student_id"), &appeal, attachment)
+	if err != nil {
+		respondAppealError(c, err, "Unable to file appeal")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetStudentAppeals godoc
+// @Summary Апелляции студента
+// @Description Возвращает апелляции студента, начиная с последней
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.GradeAppeal
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/appeals [get]
+func (ac *AppealController) GetStudentAppeals(c *gin.Context) {
+	appeals, err := ac.appealService.GetStudentAppeals(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAppealError(c, err, "Unable to fetch appeals")
+		return
+	}
+	c.JSON(http.StatusOK, appeals)
+}
+
+// GetAppeals godoc
+// @Summary Апелляции на рассмотрение
+// @Description Преподаватель видит апелляции по своим курсам, менеджер — все
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param status query string false "submitted, under_review, upheld, changed или withdrawn"
+// @Produce json
+// @Success 200 {array} models.GradeAppeal
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /appeals [get]
+func (ac *AppealController) GetAppeals(c *gin.Context) {
+	appeals, err := ac.appealService.GetAppeals(c.Request.Context(), c.Query("status"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAppealError(c, err, "Unable to fetch appeals")
+		return
+	}
+	c.JSON(http.StatusOK, appeals)
+}
+
+// GetAppeal godoc
+// @Summary Апелляция
+// @Description Возвращает апелляцию с комментариями
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID апелляции"
+// @Produce json
+// @Success 200 {object} models.GradeAppeal
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Failure 404 {object} gin.H "Апелляция не найдена"
+// @Router /appeals/{id} [get]
+func (ac *AppealController) GetAppeal(c *gin.Context) {
+	appeal, err := ac.appealService.GetAppeal(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAppealError(c, err, "Unable to fetch appeal")
+		return
+	}
+	c.JSON(http.StatusOK, appeal)
+}
+
+// GetAppealAttachment godoc
+// @Summary Вложение апелляции
+// @Description Отдаёт файл, приложенный студентом к апелляции
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID апелляции"
+// @Produce octet-stream
+// @Success 200 {file} file "Вложение"
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Failure 404 {object} gin.H "Апелляция или вложение не найдены"
+// @Router /appeals/{id}/attachment [get]
+func (ac *AppealController) GetAppealAttachment(c *gin.Context) {
+	attachment, err := ac.appealService.GetAttachment(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAppealError(c, err, "Unable to fetch attachment")
+		return
+	}
+	setAttachmentHeaders(c, attachment.Name)
+	c.Data(http.StatusOK, downloadContentType(attachment.ContentType), attachment.Data)
+}
+
+// downloadableTypes — типы загруженных файлов, которые отдаются как есть. Остальные, в том числе HTML и SVG,
+// отдаются как application/octet-stream, чтобы браузер не исполнил присланный пользователем файл.
+var downloadableTypes = map[string]bool{
+	"application/pdf":    true,
+	"application/zip":    true,
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"text/plain": true,
+}
+
+// downloadContentType возвращает тип для отдачи загруженного файла: заявленный загрузчиком тип
+// из downloadableTypes без параметров или application/octet-stream
+func downloadContentType(declared string) string {
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil || !downloadableTypes[mediaType] {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+// setAttachmentHeaders отдаёт файл на скачивание под именем name и запрещает браузеру угадывать тип.
+// Имя кодируется по RFC 2231, поэтому кавычки, переводы строк и не-ASCII символы не ломают заголовок.
+func setAttachmentHeaders(c *gin.Context, name string) {
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": name})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	c.Header("Content-Disposition", disposition)
+	c.Header("X-Content-Type-Options", "nosniff")
+}
+
+// AddAppealComment godoc
+// @Summary Прокомментировать апелляцию
+// @Description Комментарий студента, преподавателя курса или менеджера, пока по апелляции нет решения
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID апелляции"
+// @Param input body models.AppealComment true "Текст комментария"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.AppealComment
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Failure 409 {object} gin.H "Апелляция закрыта"
+// @Router /appeals/{id}/comments [post]
+func (ac *AppealController) AddAppealComment(c *gin.Context) {
+	var comment models.AppealComment
+	if err := c.ShouldBindJSON(&comment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := ac.appealService.AddComment(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c), comment.Body)
+	if err != nil {
+		respondAppealError(c, err, "Unable to add comment")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// StartAppealReview godoc
+// @Summary Взять апелляцию на рассмотрение
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID апелляции"
+// @Produce json
+// @Success 200 {object} models.GradeAppeal
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Failure 409 {object} gin.H "Апелляция уже рассматривается или закрыта"
+// @Router /appeals/{id}/review [post]
+func (ac *AppealController) StartAppealReview(c *gin.Context) {
+	appeal, err := ac.appealService.StartReview(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAppealError(c, err, "Unable to start review")
+		return
+	}
+	c.JSON(http.StatusOK, appeal)
+}
+
+// ResolveAppeal godoc
+// @Summary Вынести решение по апелляции
+// @Description Оценка оставлена (upheld) или изменена (changed, с новым значением); изменение попадает в журнал оценок
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID апелляции"
+// @Param input body models.AppealResolution true "Решение"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.GradeAppeal
+// @Failure 400 {object} gin.H "Некорректное решение или оценка вне диапазона"
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Failure 409 {object} gin.H "Апелляция закрыта или ведомость отправлена (оценку меняет только менеджер)"
+// @Router /appeals/{id}/resolve [post]
+func (ac *AppealController) ResolveAppeal(c *gin.Context) {
+	var resolution models.AppealResolution
+	if err := c.ShouldBindJSON(&resolution); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	appeal, err := ac.appealService.ResolveAppeal(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c), resolution)
+	if err != nil {
+		respondAppealError(c, err, "Unable to resolve appeal")
+		return
+	}
+	c.JSON(http.StatusOK, appeal)
+}
+
+// WithdrawAppeal godoc
+// @Summary Отозвать апелляцию
+// @Tags appeals
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID апелляции"
+// @Produce json
+// @Success 200 {object} models.GradeAppeal
+// @Failure 403 {object} gin.H "Нет доступа"
+// @Failure 409 {object} gin.H "Апелляция закрыта"
+// @Router /appeals/{id}/withdraw [post]
+func (ac *AppealController) WithdrawAppeal(c *gin.Context) {
+	appeal, err := ac.appealService.WithdrawAppeal(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c))
+	if err != nil {
+		respondAppealError(c, err, "Unable to withdraw appeal")
+		return
+	}
+	c.JSON(http.StatusOK, appeal)
+}
+
+// readAppealAttachment читает необязательный файл из поля attachment
+func readAppealAttachment(c *gin.Context) (*models.AppealAttachment, error) {
+	header, err := c.FormFile("attachment")
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if header.Size > models.MaxAppealAttachmentSize {
+		return nil, models.ErrAttachmentTooLarge
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, models.MaxAppealAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return &models.AppealAttachment{Name: header.Filename, ContentType: contentType, Data: data}, nil
+}
+
+func respondAppealError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrAppealNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Appeal not found"})
+	case errors.Is(err, models.ErrAppealAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAppealForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAppealExists), errors.Is(err, models.ErrAppealState), errors.Is(err, models.ErrAppealSheetLocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrNoMarkToAppeal),
+		errors.Is(err, models.ErrInvalidAppealResolution),
+		errors.Is(err, models.ErrMarkOutOfRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
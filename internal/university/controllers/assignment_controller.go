@@ -0,0 +1,367 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssignmentController struct {
+	assignmentService services.AssignmentService
+}
+
+func NewAssignmentController(service services.AssignmentService) *AssignmentController {
+	return &AssignmentController{assignmentService: service}
+}
+
+// GetOfferingAssignments godoc
+// @Summary Задания курса в периоде
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Produce json
+// @Success 200 {array} models.Assignment
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Router /terms/{id}/offerings/{offering_id}/assignments [get]
+func (ac *AssignmentController) GetOfferingAssignments(c *gin.Context) {
+	assignments, err := ac.assignmentService.GetOfferingAssignments(c.Request.Context(), c.Param("id"), c.Param("offering_id"))
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to fetch assignments")
+		return
+	}
+	c.JSON(http.StatusOK, assignments)
+}
+
+// CreateAssignment godoc
+// @Summary Создать задание
+// @Description Срок сдачи, максимальный балл и правило для опоздавших работ: accept, reject или penalty (штраф в процентах за каждый начатый день). mark_type привязывает задание к аттестации.
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param input body models.Assignment true "Задание"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Assignment
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Router /terms/{id}/offerings/{offering_id}/assignments [post]
+func (ac *AssignmentController) CreateAssignment(c *gin.Context) {
+	var assignment models.Assignment
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	created, err := ac.assignmentService.CreateAssignment(c.Request.Context(), c.Param("id"), c.Param("offering_id"), auth.CurrentUserID(c), asTeacher, &assignment)
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to create assignment")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// ApplyAssignmentMarks godoc
+// @Summary Перенести баллы за задания в аттестацию
+// @Description Оценка студента — доля набранных баллов по заданиям, привязанным к аттестации, от максимума аттестации по схеме оценивания
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param input body models.AssignmentMarks true "Аттестация"
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.AssignmentMark
+// @Failure 400 {object} gin.H "К аттестации не привязано ни одного задания"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 409 {object} gin.H "Ведомость отправлена или закрыта"
+// @Router /terms/{id}/offerings/{offering_id}/assignments/apply-marks [post]
+func (ac *AssignmentController) ApplyAssignmentMarks(c *gin.Context) {
+	var request models.AssignmentMarks
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	marks, err := ac.assignmentService.ApplyMarks(c.Request.Context(), c.Param("id"), c.Param("offering_id"), auth.CurrentUserID(c), asTeacher, request.MarkType)
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to apply assignment marks")
+		return
+	}
+	c.JSON(http.StatusOK, marks)
+}
+
+// GetAssignment godoc
+// @Summary Задание
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID задания"
+// @Produce json
+// @Success 200 {object} models.Assignment
+// @Failure 404 {object} gin.H "Задание не найдено"
+// @Router /assignments/{id} [get]
+func (ac *AssignmentController) GetAssignment(c *gin.Context) {
+	assignment, err := ac.assignmentService.GetAssignment(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to fetch assignment")
+		return
+	}
+	c.JSON(http.StatusOK, assignment)
+}
+
+// UpdateAssignment godoc
+// @Summary Изменить задание
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID задания"
+// @Param input body models.Assignment true "Задание"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Assignment
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Задание не найдено"
+// @Router /assignments/{id} [put]
+func (ac *AssignmentController) UpdateAssignment(c *gin.Context) {
+	var assignment models.Assignment
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	assignment.ID = c.Param("id")
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	updated, err := ac.assignmentService.UpdateAssignment(c.Request.Context(), auth.CurrentUserID(c), asTeacher, assignment)
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to update assignment")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteAssignment godoc
+// @Summary Удалить задание
+// @Description Удаляет задание вместе со сданными работами и их файлами
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID задания"
+// @Success 204 "Задание удалено"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Задание не найдено"
+// @Router /assignments/{id} [delete]
+func (ac *AssignmentController) DeleteAssignment(c *gin.Context) {
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	if err := ac.assignmentService.DeleteAssignment(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), asTeacher); err != nil {
+		respondAssignmentError(c, err, "Unable to delete assignment")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SubmitAssignment godoc
+// @Summary Сдать работу
+// @Description Студент загружает файл работы (до 10 МБ). Пока работа не оценена, повторная загрузка заменяет файл.
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID задания"
+// @Param file formData file true "Файл работы"
+// @Param comment formData string false "Комментарий"
+// @Accept multipart/form-data
+// @Produce json
+// @Success 201 {object} models.Submission
+// @Failure 400 {object} gin.H "Файл не передан или студент не записан на курс"
+// @Failure 404 {object} gin.H "Задание не найдено"
+// @Failure 409 {object} gin.H "Срок сдачи прошёл или работа уже оценена"
+// @Failure 413 {object} gin.H "Файл слишком большой"
+// @Router /assignments/{id}/submissions [post]
+func (ac *AssignmentController) SubmitAssignment(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if header.Size > models.MaxSubmissionSize {
+		respondAssignmentError(c, models.ErrSubmissionTooLarge, "Unable to submit assignment")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to read submission")
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	submission, err := ac.assignmentService.Submit(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), models.SubmissionUpload{
+		FileName:    header.Filename,
+		ContentType: contentType,
+		Size:        header.Size,
+		Data:        file,
+		Comment:     c.PostForm("comment"),
+	})
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to submit assignment")
+		return
+	}
+	c.JSON(http.StatusCreated, submission)
+}
+
+// GetAssignmentSubmissions godoc
+// @Summary Работы по заданию
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID задания"
+// @Produce json
+// @Success 200 {array} models.Submission
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Задание не найдено"
+// @Router /assignments/{id}/submissions [get]
+func (ac *AssignmentController) GetAssignmentSubmissions(c *gin.Context) {
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	submissions, err := ac.assignmentService.GetSubmissions(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), asTeacher)
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to fetch submissions")
+		return
+	}
+	c.JSON(http.StatusOK, submissions)
+}
+
+// GetSubmission godoc
+// @Summary Работа студента
+// @Description Доступна автору, преподавателю курса и менеджеру
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID работы"
+// @Produce json
+// @Success 200 {object} models.Submission
+// @Failure 403 {object} gin.H "Нет доступа к работе"
+// @Failure 404 {object} gin.H "Работа не найдена"
+// @Router /submissions/{id} [get]
+func (ac *AssignmentController) GetSubmission(c *gin.Context) {
+	submission, err := ac.assignmentService.GetSubmission(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to fetch submission")
+		return
+	}
+	c.JSON(http.StatusOK, submission)
+}
+
+// GetSubmissionFile godoc
+// @Summary Скачать файл работы
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID работы"
+// @Produce octet-stream
+// @Success 200 {file} file "Файл работы"
+// @Failure 403 {object} gin.H "Нет доступа к работе"
+// @Failure 404 {object} gin.H "Работа или файл не найдены"
+// @Router /submissions/{id}/file [get]
+func (ac *AssignmentController) GetSubmissionFile(c *gin.Context) {
+	file, err := ac.assignmentService.GetSubmissionFile(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to fetch submission file")
+		return
+	}
+	defer file.Data.Close()
+	setAttachmentHeaders(c, file.Name)
+	c.DataFromReader(http.StatusOK, file.Size, downloadContentType(file.ContentType), file.Data, nil)
+}
+
+// GradeSubmission godoc
+// @Summary Оценить работу
+// @Description Балл не больше максимального за задание; при правиле penalty итоговый балл уменьшается за опоздание. Оценённую работу можно переоценить.
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID работы"
+// @Param input body models.SubmissionGrade true "Балл и отзыв"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Submission
+// @Failure 400 {object} gin.H "Балл вне диапазона"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Работа не найдена"
+// @Router /submissions/{id}/grade [put]
+func (ac *AssignmentController) GradeSubmission(c *gin.Context) {
+	var grade models.SubmissionGrade
+	if err := c.ShouldBindJSON(&grade); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	submission, err := ac.assignmentService.GradeSubmission(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), asTeacher, grade)
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to grade submission")
+		return
+	}
+	c.JSON(http.StatusOK, submission)
+}
+
+// GetStudentSubmissions godoc
+// @Summary Работы студента
+// @Description Все сданные студентом работы, начиная с последней
+// @Tags assignments
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.Submission
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/submissions [get]
+func (ac *AssignmentController) GetStudentSubmissions(c *gin.Context) {
+	submissions, err := ac.assignmentService.GetStudentSubmissions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAssignmentError(c, err, "Unable to fetch submissions")
+		return
+	}
+	c.JSON(http.StatusOK, submissions)
+}
+
+func respondAssignmentError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrAssignmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Assignment not found"})
+	case errors.Is(err, models.ErrSubmissionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+	case errors.Is(err, models.ErrOfferingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Offering not found"})
+	case errors.Is(err, models.ErrBlobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrNotCourseTeacher), errors.Is(err, models.ErrSubmissionForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrSubmissionClosed),
+		errors.Is(err, models.ErrSubmissionGraded),
+		errors.Is(err, models.ErrGradesLocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrSubmissionTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidAssignment),
+		errors.Is(err, models.ErrStudentNotEnrolled),
+		errors.Is(err, models.ErrScoreOutOfRange),
+		errors.Is(err, models.ErrNoLinkedAssignments):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,277 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceController struct {
+	attendanceService services.AttendanceService
+}
+
+func NewAttendanceController(service services.AttendanceService) *AttendanceController {
+	return &AttendanceController{attendanceService: service}
+}
+
+// GenerateSessions godoc
+// @Summary Создать занятия по расписанию
+// @Description Создаёт занятия курса по дням недели в пределах периода (или диапазона from–to внутри него). Уже существующие занятия пропускаются.
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param input body models.SessionSchedule true "Расписание"
+// @Accept json
+// @Produce json
+// @Success 201 {array} models.ClassSession
+// @Failure 400 {object} gin.H "Некорректное расписание"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Период, курс или секция не найдены"
+// @Router /terms/{id}/offerings/{offering_id}/sessions [post]
+func (ac *AttendanceController) GenerateSessions(c *gin.Context) {
+	var schedule models.SessionSchedule
+	if err := c.ShouldBindJSON(&schedule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	sessions, err := ac.attendanceService.GenerateSessions(c.Request.Context(), c.Param("id"), c.Param("offering_id"), auth.CurrentUserID(c), asTeacher, schedule)
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to create sessions")
+		return
+	}
+	c.JSON(http.StatusCreated, sessions)
+}
+
+// GetOfferingSessions godoc
+// @Summary Занятия курса в периоде
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Produce json
+// @Success 200 {array} models.ClassSession
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Router /terms/{id}/offerings/{offering_id}/sessions [get]
+func (ac *AttendanceController) GetOfferingSessions(c *gin.Context) {
+	sessions, err := ac.attendanceService.GetOfferingSessions(c.Request.Context(), c.Param("id"), c.Param("offering_id"))
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to fetch sessions")
+		return
+	}
+	c.JSON(http.StatusOK, sessions)
+}
+
+// DeleteSession godoc
+// @Summary Удалить занятие
+// @Description Удаляет занятие вместе с отметками посещаемости
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID занятия"
+// @Success 204 "Занятие удалено"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Занятие не найдено"
+// @Router /sessions/{id} [delete]
+func (ac *AttendanceController) DeleteSession(c *gin.Context) {
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	if err := ac.attendanceService.DeleteSession(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), asTeacher); err != nil {
+		respondAttendanceError(c, err, "Unable to delete session")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetSessionAttendance godoc
+// @Summary Посещаемость занятия
+// @Description Список студентов занятия с отметками; у неотмеченных студентов status пуст
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID занятия"
+// @Produce json
+// @Success 200 {array} models.AttendanceRecord
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Занятие не найдено"
+// @Router /sessions/{id}/attendance [get]
+func (ac *AttendanceController) GetSessionAttendance(c *gin.Context) {
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	records, err := ac.attendanceService.GetSessionAttendance(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), asTeacher)
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to fetch attendance")
+		return
+	}
+	c.JSON(http.StatusOK, records)
+}
+
+// MarkSessionAttendance godoc
+// @Summary Отметить посещаемость занятия
+// @Description Сохраняет отметки перечисленных студентов; default ставится остальным студентам занятия
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID занятия"
+// @Param input body models.BulkAttendance true "Отметки"
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.AttendanceRecord
+// @Failure 400 {object} gin.H "Студент не относится к занятию"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Занятие не найдено"
+// @Router /sessions/{id}/attendance [put]
+func (ac *AttendanceController) MarkSessionAttendance(c *gin.Context) {
+	var bulk models.BulkAttendance
+	if err := c.ShouldBindJSON(&bulk); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ac.markAttendance(c, bulk)
+}
+
+// MarkStudentAttendance godoc
+// @Summary Отметить посещаемость студента
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID занятия"
+// @Param student_id path string true "ID студента"
+// @Param input body models.AttendanceMark true "Отметка"
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.AttendanceRecord
+// @Failure 400 {object} gin.H "Студент не относится к занятию"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт этот курс"
+// @Failure 404 {object} gin.H "Занятие не найдено"
+// @Router /sessions/{id}/attendance/{student_id} [put]
+func (ac *AttendanceController) MarkStudentAttendance(c *gin.Context) {
+	var mark models.AttendanceMark
+	if err := c.ShouldBindJSON(&mark); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mark.StudentID = c.Param("student_id")
+	ac.markAttendance(c, models.BulkAttendance{Records: []models.AttendanceMark{mark}})
+}
+
+func (ac *AttendanceController) markAttendance(c *gin.Context, bulk models.BulkAttendance) {
+	asTeacher := auth.CurrentUserRole(c) == "teacher"
+	records, err := ac.attendanceService.MarkAttendance(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), asTeacher, bulk)
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to mark attendance")
+		return
+	}
+	c.JSON(http.StatusOK, records)
+}
+
+// GetStudentAttendance godoc
+// @Summary Посещаемость студента
+// @Description Процент посещаемости по каждому курсу студента
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.AttendanceSummary
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/attendance [get]
+func (ac *AttendanceController) GetStudentAttendance(c *gin.Context) {
+	summaries, err := ac.attendanceService.GetStudentAttendance(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to fetch attendance")
+		return
+	}
+	c.JSON(http.StatusOK, summaries)
+}
+
+// GetAttendanceFlags godoc
+// @Summary Студенты с низкой посещаемостью
+// @Description Студенты периода, чья посещаемость курса ниже порога из настроек
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.AttendanceSummary
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/attendance/flags [get]
+func (ac *AttendanceController) GetAttendanceFlags(c *gin.Context) {
+	summaries, err := ac.attendanceService.GetAttendanceFlags(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to fetch attendance flags")
+		return
+	}
+	c.JSON(http.StatusOK, summaries)
+}
+
+// GetAttendanceSettings godoc
+// @Summary Правило посещаемости
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {object} models.AttendanceSettings
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /attendance/settings [get]
+func (ac *AttendanceController) GetAttendanceSettings(c *gin.Context) {
+	settings, err := ac.attendanceService.GetSettings(c.Request.Context())
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to fetch attendance settings")
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateAttendanceSettings godoc
+// @Summary Изменить правило посещаемости
+// @Tags attendance
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.AttendanceSettings true "Порог и минимум занятий"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.AttendanceSettings
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Router /attendance/settings [put]
+func (ac *AttendanceController) UpdateAttendanceSettings(c *gin.Context) {
+	var settings models.AttendanceSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := ac.attendanceService.UpdateSettings(c.Request.Context(), settings)
+	if err != nil {
+		respondAttendanceError(c, err, "Unable to update attendance settings")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func respondAttendanceError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrOfferingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Course offering not found"})
+	case errors.Is(err, models.ErrSectionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case errors.Is(err, models.ErrNotCourseTeacher):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidSchedule),
+		errors.Is(err, models.ErrNotInSession),
+		errors.Is(err, models.ErrSectionNotInOffering):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
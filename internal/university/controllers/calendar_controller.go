@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarController struct {
+	calendarService services.CalendarService
+}
+
+func NewCalendarController(service services.CalendarService) *CalendarController {
+	return &CalendarController{calendarService: service}
+}
+
+// GetCalendarFeed godoc
+// @Summary Ссылка на личную календарную ленту
+// @Description Возвращает ссылку на .ics-ленту текущего пользователя (занятия, сроки и праздники); при первом запросе лента создаётся
+// @Tags calendar
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {object} models.CalendarFeed
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /calendar/feed [get]
+func (cc *CalendarController) GetCalendarFeed(c *gin.Context) {
+	feed, err := cc.calendarService.GetFeed(c.Request.Context(), auth.CurrentUserID(c))
+	if err != nil {
+		respondCalendarError(c, err, "Unable to fetch calendar feed")
+		return
+	}
+	c.JSON(http.StatusOK, feed)
+}
+
+// RotateCalendarFeed godoc
+// @Summary Перевыпустить ссылку на календарную ленту
+// @Description Создаёт новую ссылку; прежняя перестаёт работать
+// @Tags calendar
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {object} models.CalendarFeed
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /calendar/feed/rotate [post]
+func (cc *CalendarController) RotateCalendarFeed(c *gin.Context) {
+	feed, err := cc.calendarService.RotateFeed(c.Request.Context(), auth.CurrentUserID(c))
+	if err != nil {
+		respondCalendarError(c, err, "Unable to rotate calendar feed")
+		return
+	}
+	c.JSON(http.StatusOK, feed)
+}
+
+// GetCalendarFeedICS godoc
+// @Summary Календарная лента (iCalendar)
+// @Description Публичная лента по токену из ссылки для подписки в календаре
+// @Tags calendar
+// @Param token path string true "Токен ленты (можно с суффиксом .ics)"
+// @Produce text/calendar
+// @Success 200 {string} string "Лента в формате RFC 5545"
+// @Failure 404 {object} gin.H "Лента не найдена"
+// @Router /calendar/feeds/{token} [get]
+func (cc *CalendarController) GetCalendarFeedICS(c *gin.Context) {
+	ics, err := cc.calendarService.RenderFeed(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondCalendarError(c, err, "Unable to render calendar feed")
+		return
+	}
+	c.Header("Content-Disposition", `inline; filename="schedule.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
+}
+
+// GetTermHolidays godoc
+// @Summary Праздники периода
+// @Tags calendar
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.Holiday
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/holidays [get]
+func (cc *CalendarController) GetTermHolidays(c *gin.Context) {
+	holidays, err := cc.calendarService.GetTermHolidays(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCalendarError(c, err, "Unable to fetch holidays")
+		return
+	}
+	c.JSON(http.StatusOK, holidays)
+}
+
+// CreateHoliday godoc
+// @Summary Добавить праздник
+// @Description Нерабочий день периода; занятия в этот день исключаются из календарных лент
+// @Tags calendar
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param input body models.Holiday true "Дата и название"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Holiday
+// @Failure 400 {object} gin.H "Дата вне периода"
+// @Failure 404 {object} gin.H "Период не найден"
+// @Failure 409 {object} gin.H "На эту дату праздник уже есть"
+// @Router /terms/{id}/holidays [post]
+func (cc *CalendarController) CreateHoliday(c *gin.Context) {
+	var holiday models.Holiday
+	if err := c.ShouldBindJSON(&holiday); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := cc.calendarService.CreateHoliday(c.Request.Context(), c.Param("id"), &holiday)
+	if err != nil {
+		respondCalendarError(c, err, "Unable to create holiday")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// DeleteHoliday godoc
+// @Summary Удалить праздник
+// @Tags calendar
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param holiday_id path string true "ID праздника"
+// @Success 204 "Праздник удалён"
+// @Failure 404 {object} gin.H "Праздник не найден"
+// @Router /terms/{id}/holidays/{holiday_id} [delete]
+func (cc *CalendarController) DeleteHoliday(c *gin.Context) {
+	if err := cc.calendarService.DeleteHoliday(c.Request.Context(), c.Param("id"), c.Param("holiday_id")); err != nil {
+		respondCalendarError(c, err, "Unable to delete holiday")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondCalendarError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrCalendarFeedNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+	case errors.Is(err, models.ErrHolidayNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Holiday not found"})
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrHolidayOutsideTerm):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrHolidayExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
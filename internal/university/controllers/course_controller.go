@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -38,7 +39,14 @@ func (c *CourseController) CreateCourse(ctx *gin.Context) {
 		return
 	}
 	// teacher_id может быть пустым (null) или uint, не забываем обработать
-	createdCourse, err := c.courseService.CreateCourse(ctx.Request.Context(), &course)
+	createdCourse, err := c.courseService.CreateCourse(ctx.Request.Context(), &course, managerScope(ctx))
+	if respondScopeError(ctx, err) {
+		return
+	}
+	if errors.Is(err, models.ErrInvalidRequisite) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create course: " + err.Error()})
 		return
@@ -118,8 +126,7 @@ func (c *CourseController) GetCourseByID(ctx *gin.Context) {
 // @Security BearerAuth
 func (c *CourseController) UpdateCourse(ctx *gin.Context) {
 	idParam := ctx.Param("id")
-	id, err := strconv.ParseUint(idParam, 10, 64)
-	if err != nil {
+	if _, err := strconv.ParseUint(idParam, 10, 64); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
 		return
 	}
@@ -130,8 +137,15 @@ func (c *CourseController) UpdateCourse(ctx *gin.Context) {
 		return
 	}
 
-	course.ID = string(id)
-	updatedCourse, err := c.courseService.UpdateCourse(ctx.Request.Context(), course)
+	course.ID = idParam
+	updatedCourse, err := c.courseService.UpdateCourse(ctx.Request.Context(), course, managerScope(ctx))
+	if respondScopeError(ctx, err) {
+		return
+	}
+	if errors.Is(err, models.ErrInvalidRequisite) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		log.Println("Error updating course:", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update course"})
@@ -160,7 +174,10 @@ func (c *CourseController) DeleteCourse(ctx *gin.Context) {
 		return
 	}
 
-	err := c.courseService.DeleteCourse(ctx.Request.Context(), idParam)
+	err := c.courseService.DeleteCourse(ctx.Request.Context(), idParam, managerScope(ctx))
+	if respondScopeError(ctx, err) {
+		return
+	}
 	if err != nil {
 		log.Println("Error deleting course:", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete course"})
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentController struct {
+	documentService services.DocumentService
+}
+
+func NewDocumentController(service services.DocumentService) *DocumentController {
+	return &DocumentController{documentService: service}
+}
+
+// IssueTranscript godoc
+// @Summary Выдать официальный транскрипт
+// @Description Формирует PDF-транскрипт с кодом проверки и QR-кодом. Код также возвращается в заголовке X-Verification-Code.
+// @Tags documents
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param student_id path string true "ID студента"
+// @Produce application/pdf
+// @Success 201 {file} file "PDF-документ"
+// @Failure 404 {object} gin.H "Студент не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{student_id}/documents/transcript [post]
+func (dc *DocumentController) IssueTranscript(c *gin.Context) {
+	document, pdf, err := dc.documentService.IssueTranscript(c.Request.Context(), c.Param("student_id"), auth.CurrentUserID(c))
+	if err != nil {
+		respondDocumentError(c, err, "Unable to issue transcript")
+		return
+	}
+	sendDocument(c, document, pdf)
+}
+
+// IssueEnrollmentCertificate godoc
+// @Summary Выдать справку о записи на обучение
+// @Description Формирует PDF-справку о записи на курсы идущего периода с кодом проверки и QR-кодом
+// @Tags documents
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param student_id path string true "ID студента"
+// @Produce application/pdf
+// @Success 201 {file} file "PDF-документ"
+// @Failure 404 {object} gin.H "Студент не найден"
+// @Failure 409 {object} gin.H "Студент не записан на курсы идущего периода"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{student_id}/documents/enrollment-certificate [post]
+func (dc *DocumentController) IssueEnrollmentCertificate(c *gin.Context) {
+	document, pdf, err := dc.documentService.IssueEnrollmentCertificate(c.Request.Context(), c.Param("student_id"), auth.CurrentUserID(c))
+	if err != nil {
+		respondDocumentError(c, err, "Unable to issue enrollment certificate")
+		return
+	}
+	sendDocument(c, document, pdf)
+}
+
+// GetStudentDocuments godoc
+// @Summary Выданные документы студента
+// @Description Возвращает список выданных студенту документов с кодами проверки
+// @Tags documents
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.IssuedDocument
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/documents [get]
+func (dc *DocumentController) GetStudentDocuments(c *gin.Context) {
+	documents, err := dc.documentService.GetStudentDocuments(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondDocumentError(c, err, "Unable to fetch documents")
+		return
+	}
+	c.JSON(http.StatusOK, documents)
+}
+
+// VerifyDocument godoc
+// @Summary Проверить подлинность документа
+// @Description Публичная проверка документа по коду. Если передан SHA-256 файла, ответ сообщает, совпадает ли он с выданным.
+// @Tags documents
+// @Param code path string true "Код проверки"
+// @Param hash query string false "SHA-256 содержимого PDF в hex"
+// @Produce json
+// @Success 200 {object} models.DocumentVerification
+// @Failure 404 {object} gin.H "Документ не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /verify/{code} [get]
+func (dc *DocumentController) VerifyDocument(c *gin.Context) {
+	verification, err := dc.documentService.VerifyDocument(c.Request.Context(), c.Param("code"), c.Query("hash"))
+	if err != nil {
+		respondDocumentError(c, err, "Unable to verify document")
+		return
+	}
+	c.JSON(http.StatusOK, verification)
+}
+
+func sendDocument(c *gin.Context, document *models.IssuedDocument, pdf []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.pdf"`, document.Type, document.Code))
+	c.Header("X-Verification-Code", document.Code)
+	c.Header("X-Content-SHA256", document.ContentHash)
+	c.Data(http.StatusCreated, "application/pdf", pdf)
+}
+
+func respondDocumentError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found", "valid": false})
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+	case errors.Is(err, models.ErrNotEnrolledInTerm):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EvaluationController struct {
+	evaluationService services.EvaluationService
+}
+
+func NewEvaluationController(service services.EvaluationService) *EvaluationController {
+	return &EvaluationController{evaluationService: service}
+}
+
+// GetEvaluationTemplates godoc
+// @Summary Шаблоны анкет оценки преподавания
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {array} models.EvaluationTemplate
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /evaluation-templates [get]
+func (ec *EvaluationController) GetEvaluationTemplates(c *gin.Context) {
+	templates, err := ec.evaluationService.GetTemplates(c.Request.Context())
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to fetch evaluation templates")
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetEvaluationTemplateByID godoc
+// @Summary Шаблон анкеты
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID шаблона"
+// @Produce json
+// @Success 200 {object} models.EvaluationTemplate
+// @Failure 404 {object} gin.H "Шаблон не найден"
+// @Router /evaluation-templates/{id} [get]
+func (ec *EvaluationController) GetEvaluationTemplateByID(c *gin.Context) {
+	template, err := ec.evaluationService.GetTemplateByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to fetch evaluation template")
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateEvaluationTemplate godoc
+// @Summary Создать шаблон анкеты
+// @Description Вопросы likert оцениваются по шкале 1–5, text — свободный ответ. min_responses (не меньше 3, по умолчанию 5) —
+// @Description порог ответов, ниже которого результаты не показываются.
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.EvaluationTemplate true "Название, порог ответов и вопросы"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.EvaluationTemplate
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Router /evaluation-templates [post]
+func (ec *EvaluationController) CreateEvaluationTemplate(c *gin.Context) {
+	var template models.EvaluationTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := ec.evaluationService.CreateTemplate(c.Request.Context(), &template, auth.CurrentUserID(c))
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to create evaluation template")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// DeleteEvaluationTemplate godoc
+// @Summary Удалить шаблон анкеты
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID шаблона"
+// @Success 204
+// @Failure 404 {object} gin.H "Шаблон не найден"
+// @Failure 409 {object} gin.H "По шаблону открыты анкеты"
+// @Router /evaluation-templates/{id} [delete]
+func (ec *EvaluationController) DeleteEvaluationTemplate(c *gin.Context) {
+	if err := ec.evaluationService.DeleteTemplate(c.Request.Context(), c.Param("id")); err != nil {
+		respondEvaluationError(c, err, "Unable to delete evaluation template")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// OpenTermEvaluations godoc
+// @Summary Открыть анкеты для курсов периода
+// @Description Открывает анкету по шаблону для каждого курса периода, у которого её ещё нет. Возвращает все анкеты периода.
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param input body models.OpenEvaluationsRequest true "Шаблон и срок закрытия"
+// @Accept json
+// @Produce json
+// @Success 201 {array} models.CourseEvaluation
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Период или шаблон не найден"
+// @Router /terms/{id}/evaluations [post]
+func (ec *EvaluationController) OpenTermEvaluations(c *gin.Context) {
+	var request models.OpenEvaluationsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	evaluations, err := ec.evaluationService.OpenEvaluations(c.Request.Context(), c.Param("id"), request)
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to open course evaluations")
+		return
+	}
+	c.JSON(http.StatusCreated, evaluations)
+}
+
+// GetTermEvaluations godoc
+// @Summary Анкеты курсов периода
+// @Description Анкеты с числом ответов и записанных студентов
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.CourseEvaluation
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/evaluations [get]
+func (ec *EvaluationController) GetTermEvaluations(c *gin.Context) {
+	evaluations, err := ec.evaluationService.GetTermEvaluations(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to fetch course evaluations")
+		return
+	}
+	c.JSON(http.StatusOK, evaluations)
+}
+
+// GetStudentEvaluations godoc
+// @Summary Анкеты студента
+// @Description Анкеты курсов, на которые записан студент; answered — анкета уже заполнена
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.CourseEvaluation
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/evaluations [get]
+func (ec *EvaluationController) GetStudentEvaluations(c *gin.Context) {
+	evaluations, err := ec.evaluationService.GetStudentEvaluations(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to fetch course evaluations")
+		return
+	}
+	c.JSON(http.StatusOK, evaluations)
+}
+
+// GetTeacherEvaluations godoc
+// @Summary Анкеты курсов преподавателя
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID преподавателя"
+// @Produce json
+// @Success 200 {array} models.CourseEvaluation
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /teachers/{id}/evaluations [get]
+func (ec *EvaluationController) GetTeacherEvaluations(c *gin.Context) {
+	evaluations, err := ec.evaluationService.GetTeacherEvaluations(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to fetch course evaluations")
+		return
+	}
+	c.JSON(http.StatusOK, evaluations)
+}
+
+// SubmitEvaluationResponse godoc
+// @Summary Заполнить анкету
+// @Description Анкету заполняет студент, записанный на курс, один раз до её закрытия. Ответы сохраняются без ссылки на студента.
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID анкеты"
+// @Param input body models.EvaluationResponse true "Ответы на вопросы"
+// @Accept json
+// @Success 204
+// @Failure 400 {object} gin.H "Ответы не соответствуют вопросам или студент не записан на курс"
+// @Failure 404 {object} gin.H "Анкета не найдена"
+// @Failure 409 {object} gin.H "Анкета закрыта или уже заполнена"
+// @Router /evaluations/{id}/responses [post]
+func (ec *EvaluationController) SubmitEvaluationResponse(c *gin.Context) {
+	var response models.EvaluationResponse
+	if err := c.ShouldBindJSON(&response); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := ec.evaluationService.SubmitResponse(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), response); err != nil {
+		respondEvaluationError(c, err, "Unable to submit evaluation response")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetEvaluationResults godoc
+// @Summary Результаты анкеты
+// @Description Обезличенная сводка: средние и распределения оценок, комментарии по алфавиту. Показывается, если ответов
+// @Description не меньше порога шаблона; преподаватель видит результаты своих курсов после утверждения ведомости.
+// @Tags evaluations
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID анкеты"
+// @Produce json
+// @Success 200 {object} models.EvaluationResults
+// @Failure 403 {object} gin.H "Не преподаватель курса, ведомость не утверждена или мало ответов"
+// @Failure 404 {object} gin.H "Анкета не найдена"
+// @Router /evaluations/{id}/results [get]
+func (ec *EvaluationController) GetEvaluationResults(c *gin.Context) {
+	results, err := ec.evaluationService.GetResults(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondEvaluationError(c, err, "Unable to fetch evaluation results")
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+func respondEvaluationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrEvaluationTemplateNotFound),
+		errors.Is(err, models.ErrEvaluationNotFound),
+		errors.Is(err, models.ErrOfferingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrNotCourseTeacher),
+		errors.Is(err, models.ErrEvaluationGradesNotLocked),
+		errors.Is(err, models.ErrNotEnoughResponses):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrEvaluationClosed),
+		errors.Is(err, models.ErrEvaluationAnswered),
+		errors.Is(err, models.ErrEvaluationTemplateInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidEvaluation),
+		errors.Is(err, models.ErrInvalidEvaluationAnswer),
+		errors.Is(err, models.ErrStudentNotEnrolled):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
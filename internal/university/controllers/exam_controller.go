@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExamController struct {
+	examService services.ExamService
+}
+
+func NewExamController(service services.ExamService) *ExamController {
+	return &ExamController{examService: service}
+}
+
+// GetOfferingExams godoc
+// @Summary Экзамены курса в периоде
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Produce json
+// @Success 200 {array} models.Exam
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Router /terms/{id}/offerings/{offering_id}/exams [get]
+func (ec *ExamController) GetOfferingExams(c *gin.Context) {
+	exams, err := ec.examService.GetOfferingExams(c.Request.Context(), c.Param("id"), c.Param("offering_id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to fetch exams")
+		return
+	}
+	c.JSON(http.StatusOK, exams)
+}
+
+// CreateExam godoc
+// @Summary Назначить экзамен курса
+// @Description Проверяет, что экзамен проходит в рамках периода, ищет пересечения по аудиториям, наблюдателям и записанным студентам и нехватку мест. С force=true допускаются все конфликты, кроме занятой аудитории.
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param force query bool false "Игнорировать конфликты наблюдателей, студентов и вместимости"
+// @Param input body models.Exam true "Время, длительность, аудитории и наблюдатели"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Exam
+// @Failure 400 {object} gin.H "Экзамен вне периода или наблюдатель не найден"
+// @Failure 404 {object} gin.H "Курс в периоде или аудитория не найдены"
+// @Failure 409 {object} gin.H "Конфликты экзаменов"
+// @Router /terms/{id}/offerings/{offering_id}/exams [post]
+func (ec *ExamController) CreateExam(c *gin.Context) {
+	var exam models.Exam
+	if err := c.ShouldBindJSON(&exam); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := ec.examService.CreateExam(c.Request.Context(), c.Param("id"), c.Param("offering_id"), &exam,
+		c.Query("force") == "true")
+	if err != nil {
+		respondExamError(c, err, "Unable to create exam")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetTermExams godoc
+// @Summary Расписание экзаменов периода
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.Exam
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/exams [get]
+func (ec *ExamController) GetTermExams(c *gin.Context) {
+	exams, err := ec.examService.GetTermExams(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to fetch exams")
+		return
+	}
+	c.JSON(http.StatusOK, exams)
+}
+
+// GetTermConflicts godoc
+// @Summary Конфликты экзаменов периода
+// @Description Пересечения экзаменов периода по аудиториям, наблюдателям и записанным студентам
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.ExamConflict
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/exams/conflicts [get]
+func (ec *ExamController) GetTermConflicts(c *gin.Context) {
+	conflicts, err := ec.examService.GetTermConflicts(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to fetch exam conflicts")
+		return
+	}
+	c.JSON(http.StatusOK, conflicts)
+}
+
+// GetExam godoc
+// @Summary Экзамен
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID экзамена"
+// @Produce json
+// @Success 200 {object} models.Exam
+// @Failure 404 {object} gin.H "Экзамен не найден"
+// @Router /exams/{id} [get]
+func (ec *ExamController) GetExam(c *gin.Context) {
+	exam, err := ec.examService.GetExam(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to fetch exam")
+		return
+	}
+	c.JSON(http.StatusOK, exam)
+}
+
+// UpdateExam godoc
+// @Summary Перенести экзамен
+// @Description Меняет время, аудитории или наблюдателей с теми же проверками конфликтов; составленная рассадка сбрасывается
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID экзамена"
+// @Param force query bool false "Игнорировать конфликты наблюдателей, студентов и вместимости"
+// @Param input body models.Exam true "Время, длительность, аудитории и наблюдатели"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Exam
+// @Failure 400 {object} gin.H "Экзамен вне периода или наблюдатель не найден"
+// @Failure 404 {object} gin.H "Экзамен или аудитория не найдены"
+// @Failure 409 {object} gin.H "Конфликты экзаменов"
+// @Router /exams/{id} [put]
+func (ec *ExamController) UpdateExam(c *gin.Context) {
+	var exam models.Exam
+	if err := c.ShouldBindJSON(&exam); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	exam.ID = c.Param("id")
+	updated, err := ec.examService.UpdateExam(c.Request.Context(), exam, c.Query("force") == "true")
+	if err != nil {
+		respondExamError(c, err, "Unable to update exam")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteExam godoc
+// @Summary Отменить экзамен
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID экзамена"
+// @Success 204 "Экзамен отменён"
+// @Failure 404 {object} gin.H "Экзамен не найден"
+// @Router /exams/{id} [delete]
+func (ec *ExamController) DeleteExam(c *gin.Context) {
+	if err := ec.examService.DeleteExam(c.Request.Context(), c.Param("id")); err != nil {
+		respondExamError(c, err, "Unable to delete exam")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AllocateSeating godoc
+// @Summary Рассадить студентов на экзамене
+// @Description Заново распределяет записанных на курс студентов по аудиториям экзамена пропорционально вместимости, в алфавитном порядке
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID экзамена"
+// @Produce json
+// @Success 200 {array} models.ExamSeat
+// @Failure 400 {object} gin.H "Мест в аудиториях меньше, чем студентов"
+// @Failure 404 {object} gin.H "Экзамен не найден"
+// @Router /exams/{id}/seating [post]
+func (ec *ExamController) AllocateSeating(c *gin.Context) {
+	seats, err := ec.examService.AllocateSeating(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to allocate seating")
+		return
+	}
+	c.JSON(http.StatusOK, seats)
+}
+
+// GetSeating godoc
+// @Summary Рассадка экзамена
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID экзамена"
+// @Produce json
+// @Success 200 {array} models.ExamSeat
+// @Failure 404 {object} gin.H "Экзамен не найден"
+// @Router /exams/{id}/seating [get]
+func (ec *ExamController) GetSeating(c *gin.Context) {
+	seats, err := ec.examService.GetSeating(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to fetch seating")
+		return
+	}
+	c.JSON(http.StatusOK, seats)
+}
+
+// GetRoomSeatingList godoc
+// @Summary Список рассадки аудитории для печати
+// @Description PDF со списком студентов аудитории по местам и колонкой для подписи
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID экзамена"
+// @Param room_id path string true "ID аудитории"
+// @Produce application/pdf
+// @Success 200 {file} file "PDF-список"
+// @Failure 404 {object} gin.H "Экзамен или аудитория не найдены, рассадка не составлена"
+// @Router /exams/{id}/rooms/{room_id}/seating [get]
+func (ec *ExamController) GetRoomSeatingList(c *gin.Context) {
+	pdf, err := ec.examService.RenderRoomSeating(c.Request.Context(), c.Param("id"), c.Param("room_id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to render seating list")
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="exam-%s-room-%s.pdf"`, c.Param("id"), c.Param("room_id")))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// GetStudentExams godoc
+// @Summary Экзамены студента
+// @Description Экзамены курсов студента с его аудиторией и местом, если рассадка составлена
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param term_id query string false "ID периода (по умолчанию — текущий)"
+// @Produce json
+// @Success 200 {array} models.StudentExam
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /students/{id}/exams [get]
+func (ec *ExamController) GetStudentExams(c *gin.Context) {
+	exams, err := ec.examService.GetStudentExams(c.Request.Context(), c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to fetch exams")
+		return
+	}
+	c.JSON(http.StatusOK, exams)
+}
+
+// GetTeacherExams godoc
+// @Summary Экзамены преподавателя
+// @Description Экзамены курсов преподавателя и экзамены, на которых он наблюдает
+// @Tags exams
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID преподавателя"
+// @Param term_id query string false "ID периода (по умолчанию — текущий)"
+// @Produce json
+// @Success 200 {array} models.Exam
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /teachers/{id}/exams [get]
+func (ec *ExamController) GetTeacherExams(c *gin.Context) {
+	exams, err := ec.examService.GetTeacherExams(c.Request.Context(), c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		respondExamError(c, err, "Unable to fetch exams")
+		return
+	}
+	c.JSON(http.StatusOK, exams)
+}
+
+func respondExamError(c *gin.Context, err error, message string) {
+	var conflictErr *models.ExamConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		c.JSON(http.StatusConflict, gin.H{"error": "Exam conflict", "conflicts": conflictErr.Conflicts})
+	case errors.Is(err, models.ErrExamNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+	case errors.Is(err, models.ErrOfferingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Course offering not found"})
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+	case errors.Is(err, models.ErrExamRoomNotFound),
+		errors.Is(err, models.ErrSeatingNotAllocated),
+		errors.Is(err, models.ErrNoActiveTerm):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrExamOutsideTerm),
+		errors.Is(err, models.ErrProctorNotFound),
+		errors.Is(err, models.ErrNotEnoughExamSeats):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
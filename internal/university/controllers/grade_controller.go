@@ -1,35 +1,31 @@
 package controller
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"log"
 	"net/http"
 	"strconv"
+	"university_system/internal/auth"
 	"university_system/internal/domain/models"
-	"university_system/internal/domain/repository"
+	"university_system/internal/university/services"
 )
 
 type CourseMarkController struct {
-	markRepo repository.GradeRepository
+	gradeService services.GradeService
 }
 
-func NewCourseMarkController(repo repository.GradeRepository) *CourseMarkController {
-	return &CourseMarkController{markRepo: repo}
+func NewCourseMarkController(service services.GradeService) *CourseMarkController {
+	return &CourseMarkController{gradeService: service}
 }
 
 func (c *CourseMarkController) addAttestationMark(ctx *gin.Context, markType string) {
 	studentID := ctx.Param("student_id")
 	courseID := ctx.Param("course_id")
 	teacherID := ctx.Param("id")
-
-	// Проверка, является ли преподаватель назначенным на данный курс
-	isTeacher, err := c.markRepo.IsTeacherOfCourse(ctx.Request.Context(), teacherID, courseID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при проверке преподавателя"})
-		return
-	}
-	if !isTeacher {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "Преподаватель не назначен на данный курс"})
-		return
+	// Преподаватель выставляет оценки только от своего имени
+	if auth.CurrentUserRole(ctx) == "teacher" {
+		teacherID = auth.CurrentUserID(ctx)
 	}
 
 	var markValue float64
@@ -56,21 +52,30 @@ func (c *CourseMarkController) addAttestationMark(ctx *gin.Context, markType str
 
 	// Установка соответствующего значения оценки в зависимости от типа
 	switch markType {
-	case "first_attestation":
+	case models.MarkTypeFirstAttestation:
 		mark.FirstAttestation = markValue
-	case "second_attestation":
+	case models.MarkTypeSecondAttestation:
 		mark.SecondAttestation = markValue
-	case "final":
+	case models.MarkTypeFinal:
 		mark.FinalMark = markValue
 	}
 
-	// Добавление оценки
-	if err := c.markRepo.AddMark(ctx.Request.Context(), mark, markType); err != nil {
+	// Добавление оценки: сервис проверяет преподавателя, запись на курс и диапазон баллов
+	err = c.gradeService.AddMark(ctx.Request.Context(), teacherID, auth.CurrentUserID(ctx), mark, markType)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusCreated, gin.H{"message": "Оценка успешно добавлена"})
+	case errors.Is(err, models.ErrNotCourseTeacher):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Преподаватель не назначен на данный курс"})
+	case errors.Is(err, models.ErrStudentNotEnrolled):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Студент не записан на данный курс"})
+	case errors.Is(err, models.ErrMarkOutOfRange):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Оценка вне допустимого диапазона", "details": err.Error()})
+	case errors.Is(err, models.ErrGradesLocked):
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Ведомость отправлена или утверждена, изменение возможно только через запрос"})
+	default:
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Не удалось добавить оценку", "details": err.Error()})
-		return
 	}
-
-	ctx.JSON(http.StatusCreated, gin.H{"message": "Оценка успешно добавлена"})
 }
 
 // AddFirstAttestation godoc
@@ -85,12 +90,13 @@ func (c *CourseMarkController) addAttestationMark(ctx *gin.Context, markType str
 // @Param course_id path string true "ID курса"
 // @Param mark body float64 true "Оценка"
 // @Success 201 {object} gin.H "Оценка добавлена"
-// @Failure 400 {object} gin.H "Ошибка ввода"
+// @Failure 400 {object} gin.H "Ошибка ввода или оценка вне диапазона схемы оценивания"
 // @Failure 403 {object} gin.H "Запрещено"
+// @Failure 409 {object} gin.H "Ведомость отправлена или утверждена"
 // @Router /teachers/{id}/courses/{course_id}/students/{student_id}/PutFirstAtt [post]
 // @Security BearerAuth
 func (c *CourseMarkController) AddFirstAttestation(ctx *gin.Context) {
-	c.addAttestationMark(ctx, "first_attestation")
+	c.addAttestationMark(ctx, models.MarkTypeFirstAttestation)
 }
 
 // AddSecondAttestation godoc
@@ -105,12 +111,13 @@ func (c *CourseMarkController) AddFirstAttestation(ctx *gin.Context) {
 // @Param course_id path string true "ID курса"
 // @Param mark body float64 true "Оценка"
 // @Success 201 {object} gin.H "Оценка добавлена"
-// @Failure 400 {object} gin.H "Ошибка ввода"
+// @Failure 400 {object} gin.H "Ошибка ввода или оценка вне диапазона схемы оценивания"
 // @Failure 403 {object} gin.H "Запрещено"
+// @Failure 409 {object} gin.H "Ведомость отправлена или утверждена"
 // @Router /teachers/{id}/courses/{course_id}/students/{student_id}/PutSecondAtt [post]
 // @Security BearerAuth
 func (c *CourseMarkController) AddSecondAttestation(ctx *gin.Context) {
-	c.addAttestationMark(ctx, "second_attestation")
+	c.addAttestationMark(ctx, models.MarkTypeSecondAttestation)
 }
 
 // AddFinalExamMark godoc
@@ -125,29 +132,30 @@ func (c *CourseMarkController) AddSecondAttestation(ctx *gin.Context) {
 // @Param course_id path string true "ID курса"
 // @Param mark body float64 true "Оценка"
 // @Success 201 {object} gin.H "Оценка добавлена"
-// @Failure 400 {object} gin.H "Ошибка ввода"
+// @Failure 400 {object} gin.H "Ошибка ввода или оценка вне диапазона схемы оценивания"
 // @Failure 403 {object} gin.H "Запрещено"
+// @Failure 409 {object} gin.H "Ведомость отправлена или утверждена"
 // @Router /teachers/{id}/courses/{course_id}/students/{student_id}/PutFinalMark [post]
 // @Security BearerAuth
 func (c *CourseMarkController) AddFinalExamMark(ctx *gin.Context) {
-	c.addAttestationMark(ctx, "final")
+	c.addAttestationMark(ctx, models.MarkTypeFinal)
 }
 
 // GetStudentMarks godoc
 // @Summary Получить оценки студента
-// @Description Возвращает все оценки студента по всем курсам.
+// @Description Возвращает все оценки студента по всем курсам с итоговым баллом и буквенной оценкой.
 // @Tags marks
 // @Produce json
 // @Param Authorization header string true "Bearer токен"
 // @Param student_id path string true "ID студента"
-// @Success 200 {object} models.Mark
+// @Success 200 {array} models.GradedMark
 // @Failure 500 {object} gin.H "Ошибка сервера"
 // @Router /marks/student/{student_id} [get]
 // @Security BearerAuth
 func (c *CourseMarkController) GetStudentMarks(ctx *gin.Context) {
 	studentID := ctx.Param("student_id")
 
-	marks, err := c.markRepo.GetStudentMarks(ctx.Request.Context(), studentID)
+	marks, err := c.gradeService.GetStudentMarks(ctx.Request.Context(), studentID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Не удалось получить оценки"})
 		return
@@ -158,23 +166,316 @@ func (c *CourseMarkController) GetStudentMarks(ctx *gin.Context) {
 
 // GetCourseMarks godoc
 // @Summary Получить оценки по курсу
-// @Description Возвращает все оценки студентов по заданному курсу.
+// @Description Возвращает все оценки студентов по заданному курсу с итоговым баллом и буквенной оценкой.
 // @Tags marks
 // @Produce json
 // @Param Authorization header string true "Bearer токен"
 // @Param course_id path string true "ID курса"
-// @Success 200 {object} models.Mark
+// @Success 200 {array} models.GradedMark
 // @Failure 500 {object} gin.H "Ошибка сервера"
 // @Router /marks/course/{course_id} [get]
 // @Security BearerAuth
 func (c *CourseMarkController) GetCourseMarks(ctx *gin.Context) {
 	courseID := ctx.Param("course_id")
 
-	marks, err := c.markRepo.GetCourseMarks(ctx.Request.Context(), courseID)
+	marks, err := c.gradeService.GetCourseMarks(ctx.Request.Context(), courseID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Не удалось получить оценки", "details": err.Error()})
 		return
 	}
 
+	// Студент видит в ведомости курса только свою строку
+	if auth.CurrentUserRole(ctx) == "student" {
+		own := []models.GradedMark{}
+		for _, mark := range marks {
+			if strconv.FormatUint(uint64(mark.StudentID), 10) == auth.CurrentUserID(ctx) {
+				own = append(own, mark)
+			}
+		}
+		marks = own
+	}
+
 	ctx.JSON(http.StatusOK, marks)
 }
+
+// GetStudentTranscript godoc
+// @Summary Транскрипт студента
+// @Description Возвращает курсы студента по периодам с оценками, полученными кредитами, GPA за период, накопленным GPA и академическим положением.
+// @Tags students
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Success 200 {object} models.Transcript
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/transcript [get]
+// @Security BearerAuth
+func (c *CourseMarkController) GetStudentTranscript(ctx *gin.Context) {
+	transcript, err := c.gradeService.GetTranscript(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Не удалось сформировать транскрипт", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transcript)
+}
+
+// GetGradeSheet godoc
+// @Summary Ведомость курса в периоде
+// @Description Возвращает состояние ведомости (open, submitted, locked) и оценки студентов курса в периоде.
+// @Tags grades
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Success 200 {object} models.GradeSheet
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id}/offerings/{offering_id}/grades [get]
+// @Security BearerAuth
+func (c *CourseMarkController) GetGradeSheet(ctx *gin.Context) {
+	sheet, err := c.gradeService.GetGradeSheet(ctx.Request.Context(), ctx.Param("id"), ctx.Param("offering_id"))
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось получить ведомость")
+		return
+	}
+	ctx.JSON(http.StatusOK, sheet)
+}
+
+// SubmitGrades godoc
+// @Summary Отправить ведомость на утверждение
+// @Description Преподаватель курса отправляет открытую ведомость менеджеру. После отправки оценки нельзя менять напрямую.
+// @Tags grades
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Success 200 {object} gin.H "Ведомость отправлена"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт курс"
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 409 {object} gin.H "Ведомость уже отправлена или утверждена"
+// @Router /terms/{id}/offerings/{offering_id}/grades/submit [post]
+// @Security BearerAuth
+func (c *CourseMarkController) SubmitGrades(ctx *gin.Context) {
+	// Администратор может отправить ведомость за преподавателя
+	asTeacher := auth.CurrentUserRole(ctx) == "teacher"
+	err := c.gradeService.SubmitGrades(ctx.Request.Context(), ctx.Param("id"), ctx.Param("offering_id"), auth.CurrentUserID(ctx), asTeacher)
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось отправить ведомость")
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Ведомость отправлена на утверждение"})
+}
+
+// ApproveGrades godoc
+// @Summary Утвердить ведомость
+// @Description Менеджер утверждает отправленную ведомость, после чего оценки блокируются.
+// @Tags grades
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param input body models.GradeReview false "Комментарий"
+// @Success 200 {object} gin.H "Ведомость утверждена"
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 409 {object} gin.H "Ведомость не отправлена"
+// @Router /terms/{id}/offerings/{offering_id}/grades/approve [post]
+// @Security BearerAuth
+func (c *CourseMarkController) ApproveGrades(ctx *gin.Context) {
+	review, ok := bindGradeReview(ctx)
+	if !ok {
+		return
+	}
+	err := c.gradeService.ApproveGrades(ctx.Request.Context(), ctx.Param("id"), ctx.Param("offering_id"), auth.CurrentUserID(ctx), review.Comment)
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось утвердить ведомость")
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Ведомость утверждена"})
+}
+
+// ReturnGrades godoc
+// @Summary Вернуть ведомость на доработку
+// @Description Менеджер возвращает отправленную ведомость преподавателю; оценки снова можно менять.
+// @Tags grades
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param input body models.GradeReview false "Комментарий"
+// @Success 200 {object} gin.H "Ведомость возвращена"
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 409 {object} gin.H "Ведомость не отправлена"
+// @Router /terms/{id}/offerings/{offering_id}/grades/return [post]
+// @Security BearerAuth
+func (c *CourseMarkController) ReturnGrades(ctx *gin.Context) {
+	review, ok := bindGradeReview(ctx)
+	if !ok {
+		return
+	}
+	err := c.gradeService.ReturnGrades(ctx.Request.Context(), ctx.Param("id"), ctx.Param("offering_id"), auth.CurrentUserID(ctx), review.Comment)
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось вернуть ведомость")
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Ведомость возвращена на доработку"})
+}
+
+// GetMarkHistory godoc
+// @Summary Журнал изменений оценок
+// @Description Возвращает все изменения оценок курса в периоде: старое и новое значение, автор и время.
+// @Tags grades
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Success 200 {array} models.MarkChange
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Router /terms/{id}/offerings/{offering_id}/grades/history [get]
+// @Security BearerAuth
+func (c *CourseMarkController) GetMarkHistory(ctx *gin.Context) {
+	changes, err := c.gradeService.GetMarkHistory(ctx.Request.Context(), ctx.Param("id"), ctx.Param("offering_id"))
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось получить журнал изменений")
+		return
+	}
+	ctx.JSON(http.StatusOK, changes)
+}
+
+// RequestGradeChange godoc
+// @Summary Запросить изменение утверждённой оценки
+// @Description Преподаватель курса запрашивает изменение оценки в утверждённой ведомости с указанием причины. Изменение вступает в силу после одобрения менеджером.
+// @Tags grades
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param input body models.GradeChangeRequest true "Студент, тип оценки, новое значение и причина"
+// @Success 201 {object} models.GradeChangeRequest
+// @Failure 400 {object} gin.H "Ошибка ввода или оценка вне диапазона"
+// @Failure 403 {object} gin.H "Преподаватель не ведёт курс"
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 409 {object} gin.H "Ведомость не утверждена"
+// @Router /terms/{id}/offerings/{offering_id}/grade-changes [post]
+// @Security BearerAuth
+func (c *CourseMarkController) RequestGradeChange(ctx *gin.Context) {
+	var request models.GradeChangeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.OfferingID = ctx.Param("offering_id")
+	asTeacher := auth.CurrentUserRole(ctx) == "teacher"
+	created, err := c.gradeService.RequestGradeChange(ctx.Request.Context(), ctx.Param("id"), auth.CurrentUserID(ctx), asTeacher, &request)
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось создать запрос на изменение оценки")
+		return
+	}
+	ctx.JSON(http.StatusCreated, created)
+}
+
+// GetGradeChangeRequests godoc
+// @Summary Запросы на изменение оценок
+// @Description Возвращает запросы на изменение утверждённых оценок, по умолчанию все.
+// @Tags grades
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param status query string false "pending, approved или rejected"
+// @Success 200 {array} models.GradeChangeRequest
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /grade-changes [get]
+// @Security BearerAuth
+func (c *CourseMarkController) GetGradeChangeRequests(ctx *gin.Context) {
+	requests, err := c.gradeService.GetGradeChangeRequests(ctx.Request.Context(), ctx.Query("status"))
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось получить запросы")
+		return
+	}
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// ApproveGradeChange godoc
+// @Summary Одобрить изменение оценки
+// @Description Менеджер одобряет запрос: оценка меняется, изменение записывается в журнал.
+// @Tags grades
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID запроса"
+// @Param input body models.GradeReview false "Комментарий"
+// @Success 200 {object} models.GradeChangeRequest
+// @Failure 404 {object} gin.H "Запрос не найден"
+// @Failure 409 {object} gin.H "Запрос уже рассмотрен"
+// @Router /grade-changes/{id}/approve [post]
+// @Security BearerAuth
+func (c *CourseMarkController) ApproveGradeChange(ctx *gin.Context) {
+	c.reviewGradeChange(ctx, true)
+}
+
+// RejectGradeChange godoc
+// @Summary Отклонить изменение оценки
+// @Description Менеджер отклоняет запрос на изменение оценки; оценка остаётся прежней.
+// @Tags grades
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID запроса"
+// @Param input body models.GradeReview false "Комментарий"
+// @Success 200 {object} models.GradeChangeRequest
+// @Failure 404 {object} gin.H "Запрос не найден"
+// @Failure 409 {object} gin.H "Запрос уже рассмотрен"
+// @Router /grade-changes/{id}/reject [post]
+// @Security BearerAuth
+func (c *CourseMarkController) RejectGradeChange(ctx *gin.Context) {
+	c.reviewGradeChange(ctx, false)
+}
+
+func (c *CourseMarkController) reviewGradeChange(ctx *gin.Context, approve bool) {
+	review, ok := bindGradeReview(ctx)
+	if !ok {
+		return
+	}
+	request, err := c.gradeService.ReviewGradeChange(ctx.Request.Context(), ctx.Param("id"), auth.CurrentUserID(ctx), approve, review.Comment)
+	if err != nil {
+		respondGradeError(ctx, err, "Не удалось рассмотреть запрос")
+		return
+	}
+	ctx.JSON(http.StatusOK, request)
+}
+
+// bindGradeReview читает необязательный комментарий; пустое тело допустимо
+func bindGradeReview(ctx *gin.Context) (models.GradeReview, bool) {
+	var review models.GradeReview
+	if ctx.Request.ContentLength == 0 {
+		return review, true
+	}
+	if err := ctx.ShouldBindJSON(&review); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return review, false
+	}
+	return review, true
+}
+
+func respondGradeError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrOfferingNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Курс в периоде не найден"})
+	case errors.Is(err, models.ErrGradeChangeNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Запрос на изменение оценки не найден"})
+	case errors.Is(err, models.ErrNotCourseTeacher):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Преподаватель не назначен на данный курс"})
+	case errors.Is(err, models.ErrStudentNotEnrolled):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Студент не записан на данный курс"})
+	case errors.Is(err, models.ErrMarkOutOfRange):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Оценка вне допустимого диапазона", "details": err.Error()})
+	case errors.Is(err, models.ErrGradeSheetState),
+		errors.Is(err, models.ErrGradesNotLocked),
+		errors.Is(err, models.ErrGradeChangeNotPending):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
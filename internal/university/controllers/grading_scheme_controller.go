@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GradingSchemeController struct {
+	schemeService services.GradingSchemeService
+}
+
+func NewGradingSchemeController(service services.GradingSchemeService) *GradingSchemeController {
+	return &GradingSchemeController{schemeService: service}
+}
+
+// GetGradingSchemes godoc
+// @Summary Получить схемы оценивания
+// @Description Возвращает все схемы оценивания с границами буквенных оценок
+// @Tags grading-schemes
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {array} models.GradingScheme
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /grading-schemes [get]
+func (gc *GradingSchemeController) GetGradingSchemes(c *gin.Context) {
+	schemes, err := gc.schemeService.GetGradingSchemes(c.Request.Context())
+	if err != nil {
+		respondGradingSchemeError(c, err, "Unable to fetch grading schemes")
+		return
+	}
+	c.JSON(http.StatusOK, schemes)
+}
+
+// CreateGradingScheme godoc
+// @Summary Создать схему оценивания
+// @Description Создаёт схему для курса, периода, курса в периоде или общую (без course_id и term_id). Веса частей оценки в сумме дают 100.
+// @Tags grading-schemes
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.GradingScheme true "Данные схемы"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.GradingScheme
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 409 {object} gin.H "Схема для этого курса и периода уже есть"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /grading-schemes [post]
+func (gc *GradingSchemeController) CreateGradingScheme(c *gin.Context) {
+	var scheme models.GradingScheme
+	if err := c.ShouldBindJSON(&scheme); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := gc.schemeService.CreateGradingScheme(c.Request.Context(), &scheme)
+	if err != nil {
+		respondGradingSchemeError(c, err, "Unable to create grading scheme")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetGradingSchemeByID godoc
+// @Summary Получить схему оценивания
+// @Description Возвращает схему оценивания с границами буквенных оценок
+// @Tags grading-schemes
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID схемы"
+// @Produce json
+// @Success 200 {object} models.GradingScheme
+// @Failure 404 {object} gin.H "Схема не найдена"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /grading-schemes/{id} [get]
+func (gc *GradingSchemeController) GetGradingSchemeByID(c *gin.Context) {
+	scheme, err := gc.schemeService.GetGradingSchemeByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondGradingSchemeError(c, err, "Unable to fetch grading scheme")
+		return
+	}
+	c.JSON(http.StatusOK, scheme)
+}
+
+// UpdateGradingScheme godoc
+// @Summary Обновить схему оценивания
+// @Description Обновляет схему целиком, включая буквенную шкалу. Итоги уже выставленных оценок пересчитываются при чтении.
+// @Tags grading-schemes
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID схемы"
+// @Param input body models.GradingScheme true "Данные схемы"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.GradingScheme
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Схема не найдена"
+// @Failure 409 {object} gin.H "Схема для этого курса и периода уже есть"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /grading-schemes/{id} [put]
+func (gc *GradingSchemeController) UpdateGradingScheme(c *gin.Context) {
+	var scheme models.GradingScheme
+	if err := c.ShouldBindJSON(&scheme); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scheme.ID = c.Param("id")
+	updated, err := gc.schemeService.UpdateGradingScheme(c.Request.Context(), scheme)
+	if err != nil {
+		respondGradingSchemeError(c, err, "Unable to update grading scheme")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteGradingScheme godoc
+// @Summary Удалить схему оценивания
+// @Description Удаляет схему; её курсы переходят на более общую схему или схему по умолчанию
+// @Tags grading-schemes
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID схемы"
+// @Success 204
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /grading-schemes/{id} [delete]
+func (gc *GradingSchemeController) DeleteGradingScheme(c *gin.Context) {
+	if err := gc.schemeService.DeleteGradingScheme(c.Request.Context(), c.Param("id")); err != nil {
+		respondGradingSchemeError(c, err, "Unable to delete grading scheme")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondGradingSchemeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrGradingSchemeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading scheme not found"})
+	case errors.Is(err, models.ErrInvalidGradingScheme):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrGradingSchemeExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -91,6 +91,9 @@ func (mc *ManagerController) CreateManager(c *gin.Context) {
 	manager.ID = userID
 	manager.User.ID = userID
 	createdManager, err := mc.managerService.CreateManager(c.Request.Context(), &manager)
+	if respondScopeError(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка создания профиля менеджера: " + err.Error()})
 		return
@@ -119,7 +122,12 @@ func (mc *ManagerController) UpdateManager(c *gin.Context) {
 		return
 	}
 
-	updatedManager, err := mc.managerService.UpdateManager(c.Request.Context(), manager)
+	manager.ID = c.Param("id")
+	manager.User.ID = manager.ID
+	updatedManager, err := mc.managerService.UpdateManager(c.Request.Context(), manager, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Println("Error updating manager:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update manager"})
@@ -169,7 +177,11 @@ func (mc *ManagerController) AssignTeacherToCourse(c *gin.Context) {
 
 	log.Printf("Assigning teacher %s to course %s", teacherID, courseID)
 
-	if err := mc.managerService.AssignTeacherToCourse(c.Request.Context(), teacherID, courseID); err != nil {
+	err := mc.managerService.AssignTeacherToCourse(c.Request.Context(), teacherID, courseID, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
+	if err != nil {
 		log.Println("Error assigning teacher to course:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to assign teacher to course",
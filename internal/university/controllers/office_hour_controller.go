@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OfficeHourController struct {
+	officeHourService services.OfficeHourService
+}
+
+func NewOfficeHourController(service services.OfficeHourService) *OfficeHourController {
+	return &OfficeHourController{officeHourService: service}
+}
+
+// GetTeacherOfficeHours godoc
+// @Summary Часы консультаций преподавателя
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID преподавателя"
+// @Produce json
+// @Success 200 {array} models.OfficeHour
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /teachers/{id}/office-hours [get]
+func (oc *OfficeHourController) GetTeacherOfficeHours(c *gin.Context) {
+	hours, err := oc.officeHourService.GetTeacherOfficeHours(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondOfficeHourError(c, err, "Unable to fetch office hours")
+		return
+	}
+	c.JSON(http.StatusOK, hours)
+}
+
+// CreateOfficeHour godoc
+// @Summary Опубликовать часы консультаций
+// @Description Еженедельные часы: день недели (0 — воскресенье), время "15:04" и длительность слота в минутах.
+// @Description valid_from и valid_until ограничивают даты, в которые часы действуют.
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID преподавателя"
+// @Param input body models.OfficeHour true "Часы консультаций"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.OfficeHour
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Преподаватель не найден"
+// @Failure 409 {object} gin.H "Пересекаются с другими часами преподавателя"
+// @Router /teachers/{id}/office-hours [post]
+func (oc *OfficeHourController) CreateOfficeHour(c *gin.Context) {
+	var hour models.OfficeHour
+	if err := c.ShouldBindJSON(&hour); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := oc.officeHourService.CreateOfficeHour(c.Request.Context(), c.Param("id"), &hour)
+	if err != nil {
+		respondOfficeHourError(c, err, "Unable to create office hour")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateOfficeHour godoc
+// @Summary Изменить часы консультаций
+// @Description Менять можно свои часы, пока на них нет предстоящих записей
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID часов консультаций"
+// @Param input body models.OfficeHour true "Часы консультаций"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.OfficeHour
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 403 {object} gin.H "Чужие часы консультаций"
+// @Failure 404 {object} gin.H "Часы не найдены"
+// @Failure 409 {object} gin.H "Есть предстоящие записи или часы пересекаются с другими часами преподавателя"
+// @Router /office-hours/{id} [put]
+func (oc *OfficeHourController) UpdateOfficeHour(c *gin.Context) {
+	var hour models.OfficeHour
+	if err := c.ShouldBindJSON(&hour); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	hour.ID = c.Param("id")
+	updated, err := oc.officeHourService.UpdateOfficeHour(c.Request.Context(), hour, auth.CurrentUserID(c), auth.CurrentUserRole(c))
+	if err != nil {
+		respondOfficeHourError(c, err, "Unable to update office hour")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteOfficeHour godoc
+// @Summary Удалить часы консультаций
+// @Description Удалить можно свои часы, пока на них нет предстоящих записей
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID часов консультаций"
+// @Success 204
+// @Failure 403 {object} gin.H "Чужие часы консультаций"
+// @Failure 404 {object} gin.H "Часы не найдены"
+// @Failure 409 {object} gin.H "Есть предстоящие записи"
+// @Router /office-hours/{id} [delete]
+func (oc *OfficeHourController) DeleteOfficeHour(c *gin.Context) {
+	if err := oc.officeHourService.DeleteOfficeHour(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c)); err != nil {
+		respondOfficeHourError(c, err, "Unable to delete office hour")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetOfficeHourSlots godoc
+// @Summary Слоты консультаций
+// @Description Слоты с отметкой available; прошедшие слоты не показываются. По умолчанию — на две недели вперёд,
+// @Description за один запрос — не больше чем на 56 дней.
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID часов консультаций"
+// @Param from query string false "Первый день (YYYY-MM-DD)"
+// @Param to query string false "Последний день включительно (YYYY-MM-DD)"
+// @Produce json
+// @Success 200 {array} models.OfficeHourSlot
+// @Failure 400 {object} gin.H "Неверный интервал"
+// @Failure 404 {object} gin.H "Часы не найдены"
+// @Router /office-hours/{id}/slots [get]
+func (oc *OfficeHourController) GetOfficeHourSlots(c *gin.Context) {
+	var from, to time.Time
+	var err error
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be YYYY-MM-DD"})
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be YYYY-MM-DD"})
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	slots, err := oc.officeHourService.GetSlots(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		respondOfficeHourError(c, err, "Unable to fetch office hour slots")
+		return
+	}
+	c.JSON(http.StatusOK, slots)
+}
+
+// BookOfficeHourSlot godoc
+// @Summary Записаться на консультацию
+// @Description Записаться может студент, записанный на один из курсов преподавателя. starts_at — начало свободного слота.
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID часов консультаций"
+// @Param input body models.BookSlotRequest true "Начало слота и тема"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.OfficeHourBooking
+// @Failure 400 {object} gin.H "Неверный слот"
+// @Failure 403 {object} gin.H "Студент не записан на курсы преподавателя"
+// @Failure 404 {object} gin.H "Часы не найдены"
+// @Failure 409 {object} gin.H "Слот занят или у студента уже есть запись на это время"
+// @Router /office-hours/{id}/bookings [post]
+func (oc *OfficeHourController) BookOfficeHourSlot(c *gin.Context) {
+	var request models.BookSlotRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	booking, err := oc.officeHourService.BookSlot(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), request)
+	if err != nil {
+		respondOfficeHourError(c, err, "Unable to book office hour slot")
+		return
+	}
+	c.JSON(http.StatusCreated, booking)
+}
+
+// GetStudentBookings godoc
+// @Summary Записи студента на консультации
+// @Description Предстоящие записи; с past=true — вместе с прошедшими
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param past query bool false "Включить прошедшие записи"
+// @Produce json
+// @Success 200 {array} models.OfficeHourBooking
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/bookings [get]
+func (oc *OfficeHourController) GetStudentBookings(c *gin.Context) {
+	bookings, err := oc.officeHourService.GetStudentBookings(c.Request.Context(), c.Param("id"), c.Query("past") == "true")
+	if err != nil {
+		respondOfficeHourError(c, err, "Unable to fetch bookings")
+		return
+	}
+	c.JSON(http.StatusOK, bookings)
+}
+
+// GetTeacherBookings godoc
+// @Summary Записи к преподавателю на консультации
+// @Description Предстоящие записи; с past=true — вместе с прошедшими
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID преподавателя"
+// @Param past query bool false "Включить прошедшие записи"
+// @Produce json
+// @Success 200 {array} models.OfficeHourBooking
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /teachers/{id}/bookings [get]
+func (oc *OfficeHourController) GetTeacherBookings(c *gin.Context) {
+	bookings, err := oc.officeHourService.GetTeacherBookings(c.Request.Context(), c.Param("id"), c.Query("past") == "true")
+	if err != nil {
+		respondOfficeHourError(c, err, "Unable to fetch bookings")
+		return
+	}
+	c.JSON(http.StatusOK, bookings)
+}
+
+// CancelBooking godoc
+// @Summary Отменить запись на консультацию
+// @Description Свою запись отменяет студент, записи к себе — преподаватель; только до начала консультации
+// @Tags office-hours
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID записи"
+// @Success 204
+// @Failure 403 {object} gin.H "Чужая запись"
+// @Failure 404 {object} gin.H "Запись не найдена"
+// @Failure 409 {object} gin.H "Запись уже отменена или консультация началась"
+// @Router /bookings/{id}/cancel [post]
+func (oc *OfficeHourController) CancelBooking(c *gin.Context) {
+	if err := oc.officeHourService.CancelBooking(c.Request.Context(), c.Param("id"), auth.CurrentUserID(c), auth.CurrentUserRole(c)); err != nil {
+		respondOfficeHourError(c, err, "Unable to cancel booking")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondOfficeHourError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrOfficeHourNotFound), errors.Is(err, models.ErrBookingNotFound),
+		errors.Is(err, models.ErrTeacherNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrOfficeHourForbidden), errors.Is(err, models.ErrBookingForbidden),
+		errors.Is(err, models.ErrNotTeacherStudent):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrOfficeHourHasBookings), errors.Is(err, models.ErrOfficeHourOverlap), errors.Is(err, models.ErrSlotTaken),
+		errors.Is(err, models.ErrBookingOverlap), errors.Is(err, models.ErrBookingNotCancellable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidOfficeHour), errors.Is(err, models.ErrInvalidSlot):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
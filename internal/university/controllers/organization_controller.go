@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationController struct {
+	organizationService services.OrganizationService
+}
+
+func NewOrganizationController(service services.OrganizationService) *OrganizationController {
+	return &OrganizationController{organizationService: service}
+}
+
+// GetFaculties godoc
+// @Summary Получить факультеты
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {array} models.Faculty
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /faculties [get]
+func (oc *OrganizationController) GetFaculties(c *gin.Context) {
+	faculties, err := oc.organizationService.GetFaculties(c.Request.Context())
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to fetch faculties")
+		return
+	}
+	c.JSON(http.StatusOK, faculties)
+}
+
+// GetFaculty godoc
+// @Summary Получить факультет
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID факультета"
+// @Produce json
+// @Success 200 {object} models.Faculty
+// @Failure 404 {object} gin.H "Факультет не найден"
+// @Router /faculties/{id} [get]
+func (oc *OrganizationController) GetFaculty(c *gin.Context) {
+	faculty, err := oc.organizationService.GetFaculty(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to fetch faculty")
+		return
+	}
+	c.JSON(http.StatusOK, faculty)
+}
+
+// CreateFaculty godoc
+// @Summary Создать факультет
+// @Description Псевдонимы — прежние и сокращённые названия, по которым факультет находится при вводе строкой
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.Faculty true "Код, название и псевдонимы"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Faculty
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 409 {object} gin.H "Код факультета уже занят"
+// @Router /faculties [post]
+func (oc *OrganizationController) CreateFaculty(c *gin.Context) {
+	var faculty models.Faculty
+	if err := c.ShouldBindJSON(&faculty); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := oc.organizationService.CreateFaculty(c.Request.Context(), &faculty)
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to create faculty")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateFaculty godoc
+// @Summary Изменить факультет
+// @Description Новое название сразу попадает в профили студентов, программы и условия записи на курсы
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID факультета"
+// @Param input body models.Faculty true "Код, название и псевдонимы"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Faculty
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Факультет не найден"
+// @Failure 409 {object} gin.H "Код факультета уже занят"
+// @Router /faculties/{id} [put]
+func (oc *OrganizationController) UpdateFaculty(c *gin.Context) {
+	var faculty models.Faculty
+	if err := c.ShouldBindJSON(&faculty); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	faculty.ID = c.Param("id")
+	updated, err := oc.organizationService.UpdateFaculty(c.Request.Context(), faculty)
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to update faculty")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteFaculty godoc
+// @Summary Удалить факультет
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID факультета"
+// @Success 204 "Факультет удалён"
+// @Failure 404 {object} gin.H "Факультет не найден"
+// @Failure 409 {object} gin.H "У факультета есть студенты или кафедры"
+// @Router /faculties/{id} [delete]
+func (oc *OrganizationController) DeleteFaculty(c *gin.Context) {
+	if err := oc.organizationService.DeleteFaculty(c.Request.Context(), c.Param("id")); err != nil {
+		respondOrganizationError(c, err, "Unable to delete faculty")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MergeFaculties godoc
+// @Summary Объединить факультет с дубликатом
+// @Description Студенты и кафедры дубликата переходят к факультету, код, название и псевдонимы дубликата становятся псевдонимами факультета, дубликат удаляется
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID факультета, который остаётся"
+// @Param input body models.MergeRequest true "ID дубликата"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Faculty
+// @Failure 400 {object} gin.H "Слияние факультета с самим собой"
+// @Failure 404 {object} gin.H "Факультет не найден"
+// @Router /faculties/{id}/merge [post]
+func (oc *OrganizationController) MergeFaculties(c *gin.Context) {
+	var request models.MergeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	merged, err := oc.organizationService.MergeFaculties(c.Request.Context(), c.Param("id"), request.SourceID)
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to merge faculties")
+		return
+	}
+	c.JSON(http.StatusOK, merged)
+}
+
+// GetFacultyDepartments godoc
+// @Summary Кафедры факультета
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID факультета"
+// @Produce json
+// @Success 200 {array} models.Department
+// @Failure 404 {object} gin.H "Факультет не найден"
+// @Router /faculties/{id}/departments [get]
+func (oc *OrganizationController) GetFacultyDepartments(c *gin.Context) {
+	departments, err := oc.organizationService.GetFacultyDepartments(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to fetch departments")
+		return
+	}
+	c.JSON(http.StatusOK, departments)
+}
+
+// GetDepartments godoc
+// @Summary Получить кафедры
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {array} models.Department
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /departments [get]
+func (oc *OrganizationController) GetDepartments(c *gin.Context) {
+	departments, err := oc.organizationService.GetDepartments(c.Request.Context())
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to fetch departments")
+		return
+	}
+	c.JSON(http.StatusOK, departments)
+}
+
+// GetDepartment godoc
+// @Summary Получить кафедру
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID кафедры"
+// @Produce json
+// @Success 200 {object} models.Department
+// @Failure 404 {object} gin.H "Кафедра не найдена"
+// @Router /departments/{id} [get]
+func (oc *OrganizationController) GetDepartment(c *gin.Context) {
+	department, err := oc.organizationService.GetDepartment(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to fetch department")
+		return
+	}
+	c.JSON(http.StatusOK, department)
+}
+
+// CreateDepartment godoc
+// @Summary Создать кафедру
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.Department true "Факультет, код, название и псевдонимы"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Department
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Факультет не найден"
+// @Failure 409 {object} gin.H "Код кафедры уже занят"
+// @Router /departments [post]
+func (oc *OrganizationController) CreateDepartment(c *gin.Context) {
+	var department models.Department
+	if err := c.ShouldBindJSON(&department); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := oc.organizationService.CreateDepartment(c.Request.Context(), &department)
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to create department")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateDepartment godoc
+// @Summary Изменить кафедру
+// @Description Новое название сразу попадает в профили преподавателей и менеджеров кафедры
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID кафедры"
+// @Param input body models.Department true "Факультет, код, название и псевдонимы"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Department
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Кафедра или факультет не найдены"
+// @Failure 409 {object} gin.H "Код кафедры уже занят"
+// @Router /departments/{id} [put]
+func (oc *OrganizationController) UpdateDepartment(c *gin.Context) {
+	var department models.Department
+	if err := c.ShouldBindJSON(&department); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	department.ID = c.Param("id")
+	updated, err := oc.organizationService.UpdateDepartment(c.Request.Context(), department)
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to update department")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteDepartment godoc
+// @Summary Удалить кафедру
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID кафедры"
+// @Success 204 "Кафедра удалена"
+// @Failure 404 {object} gin.H "Кафедра не найдена"
+// @Failure 409 {object} gin.H "На кафедре есть сотрудники или курсы"
+// @Router /departments/{id} [delete]
+func (oc *OrganizationController) DeleteDepartment(c *gin.Context) {
+	if err := oc.organizationService.DeleteDepartment(c.Request.Context(), c.Param("id")); err != nil {
+		respondOrganizationError(c, err, "Unable to delete department")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MergeDepartments godoc
+// @Summary Объединить кафедру с дубликатом
+// @Description Преподаватели, менеджеры и курсы дубликата переходят к кафедре, названия дубликата становятся её псевдонимами, дубликат удаляется
+// @Tags organization
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID кафедры, которая остаётся"
+// @Param input body models.MergeRequest true "ID дубликата"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Department
+// @Failure 400 {object} gin.H "Слияние кафедры с самой собой"
+// @Failure 404 {object} gin.H "Кафедра не найдена"
+// @Router /departments/{id}/merge [post]
+func (oc *OrganizationController) MergeDepartments(c *gin.Context) {
+	var request models.MergeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	merged, err := oc.organizationService.MergeDepartments(c.Request.Context(), c.Param("id"), request.SourceID)
+	if err != nil {
+		respondOrganizationError(c, err, "Unable to merge departments")
+		return
+	}
+	c.JSON(http.StatusOK, merged)
+}
+
+func respondOrganizationError(c *gin.Context, err error, message string) {
+	if respondScopeError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, models.ErrMergeIntoItself):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrFacultyCodeTaken), errors.Is(err, models.ErrFacultyInUse),
+		errors.Is(err, models.ErrDepartmentCodeTaken), errors.Is(err, models.ErrDepartmentInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// respondScopeError отвечает на ошибки справочника и ограничений кафедры, общие для профилей
+// студентов, преподавателей, менеджеров и курсов. Возвращает false, если ошибка к ним не относится.
+func respondScopeError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, models.ErrFacultyNotFound), errors.Is(err, models.ErrDepartmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrOutsideDepartment), errors.Is(err, models.ErrManagerWithoutDepartment):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	default:
+		return false
+	}
+	return true
+}
+
+// managerScope возвращает ID пользователя, если запрос выполняет менеджер: его действия с людьми
+// и курсами ограничены кафедрой. Для остальных ролей возвращает пустую строку.
+func managerScope(c *gin.Context) string {
+	if auth.CurrentUserRole(c) != "manager" {
+		return ""
+	}
+	return auth.CurrentUserID(c)
+}
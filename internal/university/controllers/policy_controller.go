@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PolicyController управляет правилами доступа Casbin.
+// Изменения сразу применяются enforcer'ом и сохраняются в таблице casbin_rule.
+type PolicyController struct{}
+
+func NewPolicyController() *PolicyController {
+	return &PolicyController{}
+}
+
+// GetPolicies godoc
+// @Summary Получить правила доступа
+// @Description Возвращает все правила доступа (роль, маршрут, метод)
+// @Tags policies
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {array} models.Policy
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /api/policies [get]
+func (pc *PolicyController) GetPolicies(c *gin.Context) {
+	rules, err := auth.GetPolicies()
+	if err != nil {
+		log.Println("Error fetching policies:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch policies"})
+		return
+	}
+	policies := make([]models.Policy, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) < 3 {
+			continue
+		}
+		policies = append(policies, models.Policy{Role: rule[0], Path: rule[1], Method: rule[2]})
+	}
+	c.JSON(http.StatusOK, policies)
+}
+
+// AddPolicy godoc
+// @Summary Добавить правило доступа
+// @Description Разрешает роли выполнять метод на маршруте. Действует сразу, без перезапуска
+// @Tags policies
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.Policy true "Правило"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Policy
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 409 {object} gin.H "Правило уже существует"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /api/policies [post]
+func (pc *PolicyController) AddPolicy(c *gin.Context) {
+	var policy models.Policy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	policy.Method = strings.ToUpper(policy.Method)
+
+	added, err := auth.AddPolicy(policy.Role, policy.Path, policy.Method)
+	if err != nil {
+		log.Println("Error adding policy:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to add policy"})
+		return
+	}
+	if !added {
+		c.JSON(http.StatusConflict, gin.H{"error": "Policy already exists"})
+		return
+	}
+	c.JSON(http.StatusCreated, policy)
+}
+
+// RemovePolicy godoc
+// @Summary Удалить правило доступа
+// @Description Отзывает у роли доступ к методу на маршруте. Действует сразу, без перезапуска
+// @Tags policies
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.Policy true "Правило"
+// @Accept json
+// @Success 204
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Правило не найдено"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /api/policies [delete]
+func (pc *PolicyController) RemovePolicy(c *gin.Context) {
+	var policy models.Policy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	policy.Method = strings.ToUpper(policy.Method)
+
+	removed, err := auth.RemovePolicy(policy.Role, policy.Path, policy.Method)
+	if err != nil {
+		log.Println("Error removing policy:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove policy"})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Policy not found"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ReloadPolicies godoc
+// @Summary Перечитать правила доступа
+// @Description Загружает правила из базы заново (если их меняли напрямую или на другом экземпляре)
+// @Tags policies
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Success 204
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /api/policies/reload [post]
+func (pc *PolicyController) ReloadPolicies(c *gin.Context) {
+	if err := auth.ReloadPolicy(); err != nil {
+		log.Println("Error reloading policies:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to reload policies"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetRoles godoc
+// @Summary Получить роли
+// @Description Возвращает все роли из правил доступа и наследование ролей
+// @Tags policies
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {object} models.RolesResponse
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /api/roles [get]
+func (pc *PolicyController) GetRoles(c *gin.Context) {
+	roles, err := auth.GetAllRoles()
+	if err != nil {
+		log.Println("Error fetching roles:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch roles"})
+		return
+	}
+	rules, err := auth.GetRoleInheritance()
+	if err != nil {
+		log.Println("Error fetching role inheritance:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch roles"})
+		return
+	}
+	inheritance := make([]models.RoleInheritance, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) < 2 {
+			continue
+		}
+		inheritance = append(inheritance, models.RoleInheritance{Role: rule[0], Parent: rule[1]})
+	}
+	c.JSON(http.StatusOK, models.RolesResponse{Roles: roles, Inheritance: inheritance})
+}
+
+// AddRoleInheritance godoc
+// @Summary Унаследовать права роли
+// @Description Роль role получает все права роли parent
+// @Tags policies
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.RoleInheritance true "Наследование"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.RoleInheritance
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 409 {object} gin.H "Уже существует"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /api/roles [post]
+func (pc *PolicyController) AddRoleInheritance(c *gin.Context) {
+	var inheritance models.RoleInheritance
+	if err := c.ShouldBindJSON(&inheritance); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if inheritance.Role == inheritance.Parent {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role cannot inherit from itself"})
+		return
+	}
+
+	added, err := auth.AddRoleInheritance(inheritance.Role, inheritance.Parent)
+	if err != nil {
+		log.Println("Error adding role inheritance:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to add role inheritance"})
+		return
+	}
+	if !added {
+		c.JSON(http.StatusConflict, gin.H{"error": "Role inheritance already exists"})
+		return
+	}
+	c.JSON(http.StatusCreated, inheritance)
+}
+
+// RemoveRoleInheritance godoc
+// @Summary Отменить наследование прав роли
+// @Description Роль role перестаёт получать права роли parent
+// @Tags policies
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.RoleInheritance true "Наследование"
+// @Accept json
+// @Success 204
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Не найдено"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /api/roles [delete]
+func (pc *PolicyController) RemoveRoleInheritance(c *gin.Context) {
+	var inheritance models.RoleInheritance
+	if err := c.ShouldBindJSON(&inheritance); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	removed, err := auth.RemoveRoleInheritance(inheritance.Role, inheritance.Parent)
+	if err != nil {
+		log.Println("Error removing role inheritance:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove role inheritance"})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role inheritance not found"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
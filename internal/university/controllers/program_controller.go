@@ -0,0 +1,315 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProgramController struct {
+	programService services.ProgramService
+}
+
+func NewProgramController(service services.ProgramService) *ProgramController {
+	return &ProgramController{programService: service}
+}
+
+// GetPrograms godoc
+// @Summary Получить образовательные программы
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {array} models.Program
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /programs [get]
+func (pc *ProgramController) GetPrograms(c *gin.Context) {
+	programs, err := pc.programService.GetPrograms(c.Request.Context())
+	if err != nil {
+		respondProgramError(c, err, "Unable to fetch programs")
+		return
+	}
+	c.JSON(http.StatusOK, programs)
+}
+
+// GetProgram godoc
+// @Summary Получить образовательную программу
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID программы"
+// @Produce json
+// @Success 200 {object} models.Program
+// @Failure 404 {object} gin.H "Программа не найдена"
+// @Router /programs/{id} [get]
+func (pc *ProgramController) GetProgram(c *gin.Context) {
+	program, err := pc.programService.GetProgram(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondProgramError(c, err, "Unable to fetch program")
+		return
+	}
+	c.JSON(http.StatusOK, program)
+}
+
+// CreateProgram godoc
+// @Summary Создать образовательную программу
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.Program true "Код, название, степень и факультет"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Program
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 409 {object} gin.H "Код программы уже занят"
+// @Router /programs [post]
+func (pc *ProgramController) CreateProgram(c *gin.Context) {
+	var program models.Program
+	if err := c.ShouldBindJSON(&program); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := pc.programService.CreateProgram(c.Request.Context(), &program)
+	if err != nil {
+		respondProgramError(c, err, "Unable to create program")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateProgram godoc
+// @Summary Изменить образовательную программу
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID программы"
+// @Param input body models.Program true "Код, название, степень и факультет"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Program
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Программа не найдена"
+// @Failure 409 {object} gin.H "Код программы уже занят"
+// @Router /programs/{id} [put]
+func (pc *ProgramController) UpdateProgram(c *gin.Context) {
+	var program models.Program
+	if err := c.ShouldBindJSON(&program); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	program.ID = c.Param("id")
+	updated, err := pc.programService.UpdateProgram(c.Request.Context(), program)
+	if err != nil {
+		respondProgramError(c, err, "Unable to update program")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteProgram godoc
+// @Summary Удалить образовательную программу
+// @Description Удаляет программу вместе с учебными планами, если на неё не назначены студенты
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID программы"
+// @Success 204 "Программа удалена"
+// @Failure 404 {object} gin.H "Программа не найдена"
+// @Failure 409 {object} gin.H "На программу назначены студенты"
+// @Router /programs/{id} [delete]
+func (pc *ProgramController) DeleteProgram(c *gin.Context) {
+	if err := pc.programService.DeleteProgram(c.Request.Context(), c.Param("id")); err != nil {
+		respondProgramError(c, err, "Unable to delete program")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetProgramCurricula godoc
+// @Summary Учебные планы программы
+// @Description Планы программы, начиная с самого нового набора; состав плана возвращает /curricula/{id}
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID программы"
+// @Produce json
+// @Success 200 {array} models.Curriculum
+// @Failure 404 {object} gin.H "Программа не найдена"
+// @Router /programs/{id}/curricula [get]
+func (pc *ProgramController) GetProgramCurricula(c *gin.Context) {
+	curricula, err := pc.programService.GetProgramCurricula(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondProgramError(c, err, "Unable to fetch curricula")
+		return
+	}
+	c.JSON(http.StatusOK, curricula)
+}
+
+// CreateCurriculum godoc
+// @Summary Создать учебный план программы
+// @Description Обязательные курсы, группы курсов по выбору с минимумом кредитов и общий объём кредитов для выпуска
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID программы"
+// @Param input body models.Curriculum true "Учебный план"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Curriculum
+// @Failure 400 {object} gin.H "Некорректный учебный план или неизвестный курс"
+// @Failure 404 {object} gin.H "Программа не найдена"
+// @Router /programs/{id}/curricula [post]
+func (pc *ProgramController) CreateCurriculum(c *gin.Context) {
+	var curriculum models.Curriculum
+	if err := c.ShouldBindJSON(&curriculum); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := pc.programService.CreateCurriculum(c.Request.Context(), c.Param("id"), &curriculum)
+	if err != nil {
+		respondProgramError(c, err, "Unable to create curriculum")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetCurriculum godoc
+// @Summary Учебный план
+// @Description Учебный план с обязательными курсами и группами курсов по выбору
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID учебного плана"
+// @Produce json
+// @Success 200 {object} models.Curriculum
+// @Failure 404 {object} gin.H "Учебный план не найден"
+// @Router /curricula/{id} [get]
+func (pc *ProgramController) GetCurriculum(c *gin.Context) {
+	curriculum, err := pc.programService.GetCurriculum(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondProgramError(c, err, "Unable to fetch curriculum")
+		return
+	}
+	c.JSON(http.StatusOK, curriculum)
+}
+
+// UpdateCurriculum godoc
+// @Summary Изменить учебный план
+// @Description Заменяет обязательные курсы и группы по выбору; аудит студентов сразу учитывает новый состав
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID учебного плана"
+// @Param input body models.Curriculum true "Учебный план"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Curriculum
+// @Failure 400 {object} gin.H "Некорректный учебный план или неизвестный курс"
+// @Failure 404 {object} gin.H "Учебный план не найден"
+// @Router /curricula/{id} [put]
+func (pc *ProgramController) UpdateCurriculum(c *gin.Context) {
+	var curriculum models.Curriculum
+	if err := c.ShouldBindJSON(&curriculum); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	curriculum.ID = c.Param("id")
+	updated, err := pc.programService.UpdateCurriculum(c.Request.Context(), curriculum)
+	if err != nil {
+		respondProgramError(c, err, "Unable to update curriculum")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteCurriculum godoc
+// @Summary Удалить учебный план
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID учебного плана"
+// @Success 204 "Учебный план удалён"
+// @Failure 404 {object} gin.H "Учебный план не найден"
+// @Failure 409 {object} gin.H "На учебный план назначены студенты"
+// @Router /curricula/{id} [delete]
+func (pc *ProgramController) DeleteCurriculum(c *gin.Context) {
+	if err := pc.programService.DeleteCurriculum(c.Request.Context(), c.Param("id")); err != nil {
+		respondProgramError(c, err, "Unable to delete curriculum")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AssignStudentProgram godoc
+// @Summary Назначить студенту программу
+// @Description Назначает программу и учебный план; без curriculum_id выбирается план самого нового набора
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param input body models.StudentProgram true "Программа и учебный план"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.StudentProgram
+// @Failure 400 {object} gin.H "Учебный план другой программы"
+// @Failure 404 {object} gin.H "Студент, программа или учебный план не найдены"
+// @Router /students/{id}/program [put]
+func (pc *ProgramController) AssignStudentProgram(c *gin.Context) {
+	var assignment models.StudentProgram
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	assignment.StudentID = c.Param("id")
+	assigned, err := pc.programService.AssignStudent(c.Request.Context(), assignment)
+	if err != nil {
+		respondProgramError(c, err, "Unable to assign program")
+		return
+	}
+	c.JSON(http.StatusOK, assigned)
+}
+
+// GetDegreeAudit godoc
+// @Summary Аудит выполнения учебного плана
+// @Description Сравнивает пройденные курсы и оценки студента с учебным планом его программы и показывает, что осталось: обязательные курсы, кредиты в группах по выбору и общий объём кредитов
+// @Tags programs
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {object} models.DegreeAudit
+// @Failure 404 {object} gin.H "Студент не найден"
+// @Failure 409 {object} gin.H "Студенту не назначена программа"
+// @Router /students/{id}/degree-audit [get]
+func (pc *ProgramController) GetDegreeAudit(c *gin.Context) {
+	audit, err := pc.programService.GetDegreeAudit(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondProgramError(c, err, "Unable to build degree audit")
+		return
+	}
+	c.JSON(http.StatusOK, audit)
+}
+
+func respondProgramError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrProgramNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Program not found"})
+	case errors.Is(err, models.ErrCurriculumNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+	case errors.Is(err, models.ErrInvalidCurriculum), errors.Is(err, models.ErrCurriculumCourseNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrProgramCodeTaken), errors.Is(err, models.ErrProgramInUse),
+		errors.Is(err, models.ErrCurriculumInUse), errors.Is(err, models.ErrStudentProgramNotAssigned):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomController struct {
+	roomService services.RoomService
+}
+
+func NewRoomController(service services.RoomService) *RoomController {
+	return &RoomController{roomService: service}
+}
+
+// GetRooms godoc
+// @Summary Получить аудитории
+// @Description Возвращает аудитории; можно отобрать по вместимости и оборудованию
+// @Tags rooms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param min_capacity query int false "Минимальная вместимость"
+// @Param equipment query []string false "Необходимое оборудование" collectionFormat(multi)
+// @Produce json
+// @Success 200 {array} models.Room
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /rooms [get]
+func (rc *RoomController) GetRooms(c *gin.Context) {
+	var filter models.RoomFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rooms, err := rc.roomService.GetRooms(c.Request.Context(), filter)
+	if err != nil {
+		respondRoomError(c, err, "Unable to fetch rooms")
+		return
+	}
+	c.JSON(http.StatusOK, rooms)
+}
+
+// GetRoomByID godoc
+// @Summary Получить аудиторию
+// @Tags rooms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID аудитории"
+// @Produce json
+// @Success 200 {object} models.Room
+// @Failure 404 {object} gin.H "Аудитория не найдена"
+// @Router /rooms/{id} [get]
+func (rc *RoomController) GetRoomByID(c *gin.Context) {
+	room, err := rc.roomService.GetRoomByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondRoomError(c, err, "Unable to fetch room")
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+// CreateRoom godoc
+// @Summary Создать аудиторию
+// @Tags rooms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.Room true "Данные аудитории"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Room
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 409 {object} gin.H "Аудитория с таким названием уже есть в корпусе"
+// @Router /rooms [post]
+func (rc *RoomController) CreateRoom(c *gin.Context) {
+	var room models.Room
+	if err := c.ShouldBindJSON(&room); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := rc.roomService.CreateRoom(c.Request.Context(), &room)
+	if err != nil {
+		respondRoomError(c, err, "Unable to create room")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateRoom godoc
+// @Summary Изменить аудиторию
+// @Tags rooms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID аудитории"
+// @Param input body models.Room true "Данные аудитории"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.Room
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Аудитория не найдена"
+// @Failure 409 {object} gin.H "Аудитория с таким названием уже есть в корпусе"
+// @Router /rooms/{id} [put]
+func (rc *RoomController) UpdateRoom(c *gin.Context) {
+	var room models.Room
+	if err := c.ShouldBindJSON(&room); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	room.ID = c.Param("id")
+	updated, err := rc.roomService.UpdateRoom(c.Request.Context(), room)
+	if err != nil {
+		respondRoomError(c, err, "Unable to update room")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteRoom godoc
+// @Summary Удалить аудиторию
+// @Tags rooms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID аудитории"
+// @Success 204 "Аудитория удалена"
+// @Failure 404 {object} gin.H "Аудитория не найдена"
+// @Failure 409 {object} gin.H "Аудитория занята в расписании"
+// @Router /rooms/{id} [delete]
+func (rc *RoomController) DeleteRoom(c *gin.Context) {
+	if err := rc.roomService.DeleteRoom(c.Request.Context(), c.Param("id")); err != nil {
+		respondRoomError(c, err, "Unable to delete room")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondRoomError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+	case errors.Is(err, models.ErrRoomExists), errors.Is(err, models.ErrRoomInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
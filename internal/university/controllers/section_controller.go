@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/auth"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SectionController struct {
+	sectionService services.SectionService
+}
+
+func NewSectionController(service services.SectionService) *SectionController {
+	return &SectionController{sectionService: service}
+}
+
+// GetOfferingSections godoc
+// @Summary Получить секции курса в периоде
+// @Description Возвращает секции курса с числом записанных и ожидающих студентов
+// @Tags sections
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Produce json
+// @Success 200 {array} models.CourseSection
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id}/offerings/{offering_id}/sections [get]
+func (sc *SectionController) GetOfferingSections(c *gin.Context) {
+	sections, err := sc.sectionService.GetOfferingSections(c.Request.Context(), c.Param("id"), c.Param("offering_id"))
+	if err != nil {
+		respondSectionError(c, err, "Unable to fetch sections")
+		return
+	}
+	c.JSON(http.StatusOK, sections)
+}
+
+// CreateSection godoc
+// @Summary Создать секцию
+// @Description Добавляет секцию курса с преподавателем, аудиторией и лимитом мест
+// @Tags sections
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Param input body models.CourseSection true "Данные секции"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.CourseSection
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Курс в периоде не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id}/offerings/{offering_id}/sections [post]
+func (sc *SectionController) CreateSection(c *gin.Context) {
+	var section models.CourseSection
+	if err := c.ShouldBindJSON(&section); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	section.OfferingID = c.Param("offering_id")
+	created, err := sc.sectionService.CreateSection(c.Request.Context(), c.Param("id"), &section)
+	if err != nil {
+		respondSectionError(c, err, "Unable to create section")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetSectionByID godoc
+// @Summary Получить секцию
+// @Description Возвращает секцию с числом записанных и ожидающих студентов
+// @Tags sections
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID секции"
+// @Produce json
+// @Success 200 {object} models.CourseSection
+// @Failure 404 {object} gin.H "Секция не найдена"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /sections/{id} [get]
+func (sc *SectionController) GetSectionByID(c *gin.Context) {
+	section, err := sc.sectionService.GetSectionByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondSectionError(c, err, "Unable to fetch section")
+		return
+	}
+	c.JSON(http.StatusOK, section)
+}
+
+// UpdateSection godoc
+// @Summary Обновить секцию
+// @Description Обновляет секцию. При увеличении лимита места получают студенты из листа ожидания.
+// @Tags sections
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID секции"
+// @Param input body models.CourseSection true "Данные секции"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.CourseSection
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Секция не найдена"
+// @Failure 409 {object} gin.H "Лимит меньше числа записанных"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /sections/{id} [put]
+func (sc *SectionController) UpdateSection(c *gin.Context) {
+	var section models.CourseSection
+	if err := c.ShouldBindJSON(&section); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	section.ID = c.Param("id")
+	updated, err := sc.sectionService.UpdateSection(c.Request.Context(), section, auth.CurrentUserID(c))
+	if err != nil {
+		respondSectionError(c, err, "Unable to update section")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteSection godoc
+// @Summary Удалить секцию
+// @Description Удаляет секцию и её лист ожидания; записанные студенты остаются на курсе без секции
+// @Tags sections
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID секции"
+// @Success 204
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /sections/{id} [delete]
+func (sc *SectionController) DeleteSection(c *gin.Context) {
+	if err := sc.sectionService.DeleteSection(c.Request.Context(), c.Param("id")); err != nil {
+		respondSectionError(c, err, "Unable to delete section")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetSectionWaitlist godoc
+// @Summary Лист ожидания секции
+// @Description Возвращает очередь студентов, ожидающих места в секции
+// @Tags sections
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID секции"
+// @Produce json
+// @Success 200 {array} models.WaitlistEntry
+// @Failure 404 {object} gin.H "Секция не найдена"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /sections/{id}/waitlist [get]
+func (sc *SectionController) GetSectionWaitlist(c *gin.Context) {
+	entries, err := sc.sectionService.GetSectionWaitlist(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondSectionError(c, err, "Unable to fetch waitlist")
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+func respondSectionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrSectionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case errors.Is(err, models.ErrOfferingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Course offering not found"})
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrInvalidTerm):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrCapacityTooSmall):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StandingController struct {
+	standingService services.StandingService
+}
+
+func NewStandingController(service services.StandingService) *StandingController {
+	return &StandingController{standingService: service}
+}
+
+// GetStandingRules godoc
+// @Summary Правила академического положения
+// @Tags standing
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {object} models.StandingRules
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /standing-rules [get]
+func (sc *StandingController) GetStandingRules(c *gin.Context) {
+	rules, err := sc.standingService.GetRules(c.Request.Context())
+	if err != nil {
+		respondStandingError(c, err, "Unable to fetch standing rules")
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// UpdateStandingRules godoc
+// @Summary Изменить правила академического положения
+// @Description Новые правила применяются при следующем закрытии периода или пересчёте положения за период
+// @Tags standing
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.StandingRules true "Пороги испытательного срока и списка отличников"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.StandingRules
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Router /standing-rules [put]
+func (sc *StandingController) UpdateStandingRules(c *gin.Context) {
+	var rules models.StandingRules
+	if err := c.ShouldBindJSON(&rules); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := sc.standingService.UpdateRules(c.Request.Context(), rules)
+	if err != nil {
+		respondStandingError(c, err, "Unable to update standing rules")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// ComputeTermStandings godoc
+// @Summary Пересчитать положение за период
+// @Description Положение рассчитывается автоматически при закрытии периода; пересчёт нужен после изменения оценок
+// @Description (например, по апелляции) или правил. Прежний расчёт за период заменяется.
+// @Tags standing
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.AcademicStanding
+// @Failure 400 {object} gin.H "Период не закрыт"
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/standings [post]
+func (sc *StandingController) ComputeTermStandings(c *gin.Context) {
+	standings, err := sc.standingService.ComputeTermStandings(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondStandingError(c, err, "Unable to compute academic standing")
+		return
+	}
+	c.JSON(http.StatusOK, standings)
+}
+
+// GetTermStandings godoc
+// @Summary Отчёт по академическому положению за период
+// @Description Студенты по убыванию GPA за период. Менеджер видит студентов факультета своей кафедры.
+// @Tags standing
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param status query string false "good, warning, probation или honors"
+// @Produce json
+// @Success 200 {array} models.AcademicStanding
+// @Failure 403 {object} gin.H "Менеджер без кафедры"
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/standings [get]
+func (sc *StandingController) GetTermStandings(c *gin.Context) {
+	standings, err := sc.standingService.GetTermReport(c.Request.Context(), c.Param("id"), c.Query("status"), managerScope(c))
+	if err != nil {
+		respondStandingError(c, err, "Unable to fetch academic standing report")
+		return
+	}
+	c.JSON(http.StatusOK, standings)
+}
+
+// GetStudentStandings godoc
+// @Summary Академическое положение студента
+// @Description Положение по итогам каждого закрытого периода в хронологическом порядке
+// @Tags standing
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.AcademicStanding
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /students/{id}/standings [get]
+func (sc *StandingController) GetStudentStandings(c *gin.Context) {
+	standings, err := sc.standingService.GetStudentStandings(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondStandingError(c, err, "Unable to fetch academic standing")
+		return
+	}
+	c.JSON(http.StatusOK, standings)
+}
+
+func respondStandingError(c *gin.Context, err error, message string) {
+	if respondScopeError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrTermNotClosed):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -1,9 +1,11 @@
 package controller
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"log"
 	"net/http"
+	"university_system/internal/auth"
 	"university_system/internal/domain/models"
 	"university_system/internal/university/services"
 )
@@ -40,7 +42,7 @@ func (sc *StudentController) GetStudents(c *gin.Context) {
 
 // GetStudentById godoc
 // @Summary Получить информацию о студенте
-// @Description Возвращает данные студента по его ID
+// @Description Возвращает данные студента по его ID вместе с академическим положением по последнему рассчитанному периоду
 // @Tags students
 // @Security BearerAuth
 // @Param Authorization header string true "Bearer токен"
@@ -94,7 +96,10 @@ func (sc *StudentController) CreateStudent(c *gin.Context) {
 	}
 	student.ID = userID
 	student.User.ID = userID
-	createdStudent, err := sc.studentService.CreateStudent(c.Request.Context(), &student)
+	createdStudent, err := sc.studentService.CreateStudent(c.Request.Context(), &student, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка создания профиля студента: " + err.Error()})
 		return
@@ -127,7 +132,10 @@ func (sc *StudentController) UpdateStudent(c *gin.Context) {
 
 	student.ID = id
 
-	updatedUser, err := sc.studentService.UpdateStudent(c.Request.Context(), student)
+	updatedUser, err := sc.studentService.UpdateStudent(c.Request.Context(), student, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Println("Error updating student:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update student"})
@@ -150,7 +158,11 @@ func (sc *StudentController) UpdateStudent(c *gin.Context) {
 // @Router /students/{id} [delete]
 func (sc *StudentController) DeleteStudent(c *gin.Context) {
 	id := c.Param("id")
-	if err := sc.studentService.DeleteStudent(c.Request.Context(), id); err != nil {
+	err := sc.studentService.DeleteStudent(c.Request.Context(), id, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
+	if err != nil {
 		log.Println("Error deleting student:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete student"})
 		return
@@ -160,14 +172,24 @@ func (sc *StudentController) DeleteStudent(c *gin.Context) {
 
 // EnrollStudentToCourse godoc
 // @Summary Записать студента на курс
-// @Description Записывает студента на указанный курс
+// @Description Записывает студента на указанный курс в академическом периоде.
+// @Description Без term_id используется период, в котором сейчас открыта запись.
+// @Description Если в секции нет мест, студент ставится в лист ожидания (ответ 202).
+// @Description Если программа студента требует согласования консультанта, создаётся заявка (ответ 202, статус pending_approval).
+// @Description Проверяются пре- и корреквизиты, курс обучения, факультет и лимит кредитов за период.
 // @Tags students
 // @Security BearerAuth
 // @Param Authorization header string true "Bearer токен"
 // @Param student_id path string true "ID студента"
 // @Param course_id path string true "ID курса"
-// @Success 200 {object} map[string]string
+// @Param term_id query string false "ID академического периода"
+// @Param section_id query string false "ID секции (обязателен, если у курса несколько секций)"
+// @Success 200 {object} models.EnrollmentResult
+// @Success 202 {object} models.EnrollmentResult
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 422 {object} models.EnrollmentRuleError "Нарушены пререквизиты или ограничения курса"
 // @Failure 500 {object} models.ErrorResponse
 // @Router /students/{student_id}/courses/{course_id} [post]
 func (sc *StudentController) EnrollStudentToCourse(ctx *gin.Context) {
@@ -177,12 +199,178 @@ func (sc *StudentController) EnrollStudentToCourse(ctx *gin.Context) {
 	ctx.Set("student_id", studentID)
 	ctx.Set("course_id", courseID)
 
-	if err := sc.studentService.EnrollStudentToCourse(ctx.Request.Context(), studentID, courseID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка записи на курс", "details": err.Error()})
+	result, err := sc.studentService.EnrollStudentToCourse(ctx.Request.Context(), studentID, courseID, ctx.Query("term_id"), ctx.Query("section_id"), auth.CurrentUserID(ctx))
+	if err != nil {
+		respondEnrollmentError(ctx, err, "Ошибка записи на курс")
+		return
+	}
+
+	if result.Status == models.EnrollmentStatusWaitlisted || result.Status == models.EnrollmentStatusPendingApproval {
+		ctx.JSON(http.StatusAccepted, result)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// DropStudentFromCourse godoc
+// @Summary Бросить курс
+// @Description До срока add/drop отмечает курс как брошенный; освободившееся место получает первый в листе ожидания.
+// @Description Запись не удаляется и остаётся в истории, но в транскрипт не попадает.
+// @Tags students
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param course_id path string true "ID курса"
+// @Param term_id query string false "ID академического периода"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /students/{id}/courses/{course_id} [delete]
+func (sc *StudentController) DropStudentFromCourse(ctx *gin.Context) {
+	err := sc.studentService.DropStudentFromCourse(ctx.Request.Context(), ctx.Param("id"), ctx.Param("course_id"), ctx.Query("term_id"), auth.CurrentUserID(ctx))
+	if err != nil {
+		respondEnrollmentError(ctx, err, "Ошибка отписки от курса")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// WithdrawStudentFromCourse godoc
+// @Summary Отозваться с курса
+// @Description После срока add/drop и до срока отзыва переводит запись в статус withdrawn (отметка W в транскрипте)
+// @Tags students
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param student_id path string true "ID студента"
+// @Param course_id path string true "ID курса"
+// @Param term_id query string false "ID академического периода"
+// @Success 204
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /students/{student_id}/courses/{course_id}/withdraw [post]
+func (sc *StudentController) WithdrawStudentFromCourse(ctx *gin.Context) {
+	err := sc.studentService.WithdrawStudentFromCourse(ctx.Request.Context(), ctx.Param("student_id"), ctx.Param("course_id"), ctx.Query("term_id"), auth.CurrentUserID(ctx))
+	if err != nil {
+		respondEnrollmentError(ctx, err, "Ошибка отзыва с курса")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetStudentEnrollments godoc
+// @Summary Записи студента на курсы
+// @Description Возвращает все записи студента по периодам со статусами, включая брошенные и отозванные
+// @Tags students
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.Enrollment
+// @Failure 500 {object} models.ErrorResponse
+// @Router /students/{id}/enrollments [get]
+func (sc *StudentController) GetStudentEnrollments(ctx *gin.Context) {
+	enrollments, err := sc.studentService.GetStudentEnrollments(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		log.Println("Error fetching enrollments:", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch enrollments"})
 		return
 	}
+	ctx.JSON(http.StatusOK, enrollments)
+}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Студент успешно записан на курс"})
+// GetEnrollmentHistory godoc
+// @Summary История изменений записей
+// @Description Возвращает журнал смены статусов записей студента в хронологическом порядке
+// @Tags students
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.EnrollmentEvent
+// @Failure 500 {object} models.ErrorResponse
+// @Router /students/{id}/enrollments/history [get]
+func (sc *StudentController) GetEnrollmentHistory(ctx *gin.Context) {
+	events, err := sc.studentService.GetEnrollmentHistory(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		log.Println("Error fetching enrollment history:", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch enrollment history"})
+		return
+	}
+	ctx.JSON(http.StatusOK, events)
+}
+
+// GetStudentWaitlist godoc
+// @Summary Листы ожидания студента
+// @Description Возвращает секции, в очереди которых стоит студент, и его позицию в каждой
+// @Tags students
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Produce json
+// @Success 200 {array} models.WaitlistEntry
+// @Failure 500 {object} models.ErrorResponse
+// @Router /students/{id}/waitlist [get]
+func (sc *StudentController) GetStudentWaitlist(ctx *gin.Context) {
+	entries, err := sc.studentService.GetStudentWaitlist(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		log.Println("Error fetching waitlist:", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch waitlist"})
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// LeaveWaitlist godoc
+// @Summary Выйти из листа ожидания
+// @Description Убирает студента из очереди секции
+// @Tags students
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param section_id path string true "ID секции"
+// @Success 204
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /students/{id}/waitlist/{section_id} [delete]
+func (sc *StudentController) LeaveWaitlist(ctx *gin.Context) {
+	if err := sc.studentService.LeaveWaitlist(ctx.Request.Context(), ctx.Param("id"), ctx.Param("section_id")); err != nil {
+		respondEnrollmentError(ctx, err, "Unable to leave waitlist")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func respondEnrollmentError(ctx *gin.Context, err error, message string) {
+	var ruleErr *models.EnrollmentRuleError
+	switch {
+	case errors.As(err, &ruleErr):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Не выполнены условия записи на курс", "violations": ruleErr.Violations})
+	case errors.Is(err, models.ErrTermNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Академический период не найден"})
+	case errors.Is(err, models.ErrSectionNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Секция не найдена"})
+	case errors.Is(err, models.ErrStudentNotEnrolled), errors.Is(err, models.ErrNotOnWaitlist):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrCourseNotOffered):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Курс не проводится в этом периоде"})
+	case errors.Is(err, models.ErrSectionRequired):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAlreadyEnrolled), errors.Is(err, models.ErrApprovalPending):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrEnrollmentClosed), errors.Is(err, models.ErrNoOpenEnrollment):
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Запись на курсы закрыта", "details": err.Error()})
+	case errors.Is(err, models.ErrNoActiveTerm):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrNotActiveEnrollment), errors.Is(err, models.ErrAddDropClosed),
+		errors.Is(err, models.ErrWithdrawalNotOpen), errors.Is(err, models.ErrWithdrawalClosed):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
 }
 
 // GetStudentCourses godoc
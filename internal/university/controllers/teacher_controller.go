@@ -93,7 +93,10 @@ func (tc *TeacherController) CreateTeacher(c *gin.Context) {
 	teacher.ID = userID
 	teacher.User.ID = userID
 	// 2. Создать преподавателя
-	createdTeacher, err := tc.teacherService.CreateTeacher(c.Request.Context(), &teacher)
+	createdTeacher, err := tc.teacherService.CreateTeacher(c.Request.Context(), &teacher, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка создания профиля преподавателя: " + err.Error()})
 		return
@@ -124,7 +127,10 @@ func (tc *TeacherController) UpdateTeacher(c *gin.Context) {
 		return
 	}
 	teacher.ID = id
-	updatedTeacher, err := tc.teacherService.UpdateTeacher(c.Request.Context(), teacher)
+	updatedTeacher, err := tc.teacherService.UpdateTeacher(c.Request.Context(), teacher, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Println(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update teacher"})
@@ -146,7 +152,11 @@ func (tc *TeacherController) UpdateTeacher(c *gin.Context) {
 // @Router /teachers/{id} [delete]
 func (tc *TeacherController) DeleteTeacher(c *gin.Context) {
 	id := c.Param("id")
-	if err := tc.teacherService.DeleteTeacher(c.Request.Context(), id); err != nil {
+	err := tc.teacherService.DeleteTeacher(c.Request.Context(), id, managerScope(c))
+	if respondScopeError(c, err) {
+		return
+	}
+	if err != nil {
 		log.Println(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete teacher"})
 		return
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TermController struct {
+	termService services.TermService
+}
+
+func NewTermController(service services.TermService) *TermController {
+	return &TermController{termService: service}
+}
+
+// GetTerms godoc
+// @Summary Получить академические периоды
+// @Description Возвращает все академические периоды (семестры) по дате начала
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Produce json
+// @Success 200 {array} models.AcademicTerm
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms [get]
+func (tc *TermController) GetTerms(c *gin.Context) {
+	terms, err := tc.termService.GetTerms(c.Request.Context())
+	if err != nil {
+		log.Println("Error fetching terms:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch terms"})
+		return
+	}
+	c.JSON(http.StatusOK, terms)
+}
+
+// GetTermByID godoc
+// @Summary Получить академический период
+// @Description Возвращает академический период по ID
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {object} models.AcademicTerm
+// @Failure 404 {object} gin.H "Период не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id} [get]
+func (tc *TermController) GetTermByID(c *gin.Context) {
+	term, err := tc.termService.GetTermByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondTermError(c, err, "Unable to fetch term")
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+// CreateTerm godoc
+// @Summary Создать академический период
+// @Description Создаёт семестр с окном записи и сроком выставления оценок
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param input body models.AcademicTerm true "Данные периода"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.AcademicTerm
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms [post]
+func (tc *TermController) CreateTerm(c *gin.Context) {
+	var term models.AcademicTerm
+	if err := c.ShouldBindJSON(&term); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := tc.termService.CreateTerm(c.Request.Context(), &term)
+	if err != nil {
+		respondTermError(c, err, "Unable to create term")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateTerm godoc
+// @Summary Обновить академический период
+// @Description Обновляет даты и статус периода. При переводе в closed записи на курсы завершаются
+// @Description и рассчитывается положение студентов; повторное сохранение закрытого периода их не пересчитывает.
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param input body models.AcademicTerm true "Данные периода"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.AcademicTerm
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Период не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id} [put]
+func (tc *TermController) UpdateTerm(c *gin.Context) {
+	var term models.AcademicTerm
+	if err := c.ShouldBindJSON(&term); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	term.ID = c.Param("id")
+	updated, err := tc.termService.UpdateTerm(c.Request.Context(), term)
+	if err != nil {
+		respondTermError(c, err, "Unable to update term")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteTerm godoc
+// @Summary Удалить академический период
+// @Description Удаляет период вместе с его курсами; период, в котором есть записи студентов или оценки, удалить нельзя
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Success 204
+// @Failure 409 {object} gin.H "В периоде есть записи или оценки"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id} [delete]
+func (tc *TermController) DeleteTerm(c *gin.Context) {
+	if err := tc.termService.DeleteTerm(c.Request.Context(), c.Param("id")); err != nil {
+		respondTermError(c, err, "Unable to delete term")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTermOfferings godoc
+// @Summary Курсы периода
+// @Description Возвращает курсы, открытые в академическом периоде
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.CourseOffering
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id}/offerings [get]
+func (tc *TermController) GetTermOfferings(c *gin.Context) {
+	offerings, err := tc.termService.GetTermOfferings(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		log.Println("Error fetching offerings:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch offerings"})
+		return
+	}
+	c.JSON(http.StatusOK, offerings)
+}
+
+// CreateOffering godoc
+// @Summary Открыть курс в периоде
+// @Description Делает курс доступным для записи в академическом периоде
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param input body models.CourseOffering true "Курс"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.CourseOffering
+// @Failure 400 {object} gin.H "Ошибка валидации"
+// @Failure 404 {object} gin.H "Период не найден"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id}/offerings [post]
+func (tc *TermController) CreateOffering(c *gin.Context) {
+	var offering models.CourseOffering
+	if err := c.ShouldBindJSON(&offering); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	offering.TermID = c.Param("id")
+	created, err := tc.termService.CreateOffering(c.Request.Context(), &offering)
+	if err != nil {
+		respondTermError(c, err, "Unable to create offering")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// DeleteOffering godoc
+// @Summary Убрать курс из периода
+// @Description Удаляет курс из периода; курс, на который записаны студенты или по которому есть оценки, удалить нельзя
+// @Tags terms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Param offering_id path string true "ID курса в периоде"
+// @Success 204
+// @Failure 409 {object} gin.H "На курс записаны студенты или есть оценки"
+// @Failure 500 {object} gin.H "Ошибка сервера"
+// @Router /terms/{id}/offerings/{offering_id} [delete]
+func (tc *TermController) DeleteOffering(c *gin.Context) {
+	if err := tc.termService.DeleteOffering(c.Request.Context(), c.Param("id"), c.Param("offering_id")); err != nil {
+		respondTermError(c, err, "Unable to delete offering")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondTermError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrInvalidTerm):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrTermHasHistory), errors.Is(err, models.ErrOfferingHasHistory):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"university_system/internal/domain/models"
+	"university_system/internal/university/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimetableController struct {
+	timetableService services.TimetableService
+}
+
+func NewTimetableController(service services.TimetableService) *TimetableController {
+	return &TimetableController{timetableService: service}
+}
+
+// GetSectionMeetings godoc
+// @Summary Еженедельные занятия секции
+// @Tags timetable
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID секции"
+// @Produce json
+// @Success 200 {array} models.SectionMeeting
+// @Failure 404 {object} gin.H "Секция не найдена"
+// @Router /sections/{id}/meetings [get]
+func (tc *TimetableController) GetSectionMeetings(c *gin.Context) {
+	meetings, err := tc.timetableService.GetSectionMeetings(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondTimetableError(c, err, "Unable to fetch meetings")
+		return
+	}
+	c.JSON(http.StatusOK, meetings)
+}
+
+// CreateMeeting godoc
+// @Summary Добавить занятие в расписание секции
+// @Description Проверяет занятость аудитории, преподавателей и записанных студентов. С force=true допускаются все конфликты, кроме занятой аудитории.
+// @Tags timetable
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID секции"
+// @Param force query bool false "Игнорировать конфликты преподавателей, студентов и вместимости"
+// @Param input body models.SectionMeeting true "День недели, время и аудитория"
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.SectionMeeting
+// @Failure 400 {object} gin.H "Некорректное время"
+// @Failure 404 {object} gin.H "Секция или аудитория не найдены"
+// @Failure 409 {object} gin.H "Конфликты расписания"
+// @Router /sections/{id}/meetings [post]
+func (tc *TimetableController) CreateMeeting(c *gin.Context) {
+	var meeting models.SectionMeeting
+	if err := c.ShouldBindJSON(&meeting); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	meeting.SectionID = c.Param("id")
+	created, err := tc.timetableService.CreateMeeting(c.Request.Context(), &meeting, c.Query("force") == "true")
+	if err != nil {
+		respondTimetableError(c, err, "Unable to create meeting")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateMeeting godoc
+// @Summary Перенести занятие
+// @Description Меняет день, время или аудиторию занятия с теми же проверками конфликтов
+// @Tags timetable
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID занятия"
+// @Param force query bool false "Игнорировать конфликты преподавателей, студентов и вместимости"
+// @Param input body models.SectionMeeting true "День недели, время и аудитория"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.SectionMeeting
+// @Failure 400 {object} gin.H "Некорректное время"
+// @Failure 404 {object} gin.H "Занятие или аудитория не найдены"
+// @Failure 409 {object} gin.H "Конфликты расписания"
+// @Router /meetings/{id} [put]
+func (tc *TimetableController) UpdateMeeting(c *gin.Context) {
+	var meeting models.SectionMeeting
+	if err := c.ShouldBindJSON(&meeting); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	meeting.ID = c.Param("id")
+	updated, err := tc.timetableService.UpdateMeeting(c.Request.Context(), meeting, c.Query("force") == "true")
+	if err != nil {
+		respondTimetableError(c, err, "Unable to update meeting")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteMeeting godoc
+// @Summary Удалить занятие из расписания
+// @Tags timetable
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID занятия"
+// @Success 204 "Занятие удалено"
+// @Failure 404 {object} gin.H "Занятие не найдено"
+// @Router /meetings/{id} [delete]
+func (tc *TimetableController) DeleteMeeting(c *gin.Context) {
+	if err := tc.timetableService.DeleteMeeting(c.Request.Context(), c.Param("id")); err != nil {
+		respondTimetableError(c, err, "Unable to delete meeting")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTermConflicts godoc
+// @Summary Конфликты расписания периода
+// @Description Пересечения занятий периода по аудиториям, преподавателям и записанным студентам
+// @Tags timetable
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID периода"
+// @Produce json
+// @Success 200 {array} models.ScheduleConflict
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /terms/{id}/timetable/conflicts [get]
+func (tc *TimetableController) GetTermConflicts(c *gin.Context) {
+	conflicts, err := tc.timetableService.GetTermConflicts(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondTimetableError(c, err, "Unable to fetch conflicts")
+		return
+	}
+	c.JSON(http.StatusOK, conflicts)
+}
+
+// GetStudentTimetable godoc
+// @Summary Недельное расписание студента
+// @Tags timetable
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID студента"
+// @Param term_id query string false "ID периода (по умолчанию — текущий)"
+// @Produce json
+// @Success 200 {array} models.TimetableEntry
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /students/{id}/timetable [get]
+func (tc *TimetableController) GetStudentTimetable(c *gin.Context) {
+	entries, err := tc.timetableService.GetStudentTimetable(c.Request.Context(), c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		respondTimetableError(c, err, "Unable to fetch timetable")
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetTeacherTimetable godoc
+// @Summary Недельное расписание преподавателя
+// @Description Занятия секций, которые ведёт преподаватель, и секций без преподавателя по его курсам
+// @Tags timetable
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID преподавателя"
+// @Param term_id query string false "ID периода (по умолчанию — текущий)"
+// @Produce json
+// @Success 200 {array} models.TimetableEntry
+// @Failure 404 {object} gin.H "Период не найден"
+// @Router /teachers/{id}/timetable [get]
+func (tc *TimetableController) GetTeacherTimetable(c *gin.Context) {
+	entries, err := tc.timetableService.GetTeacherTimetable(c.Request.Context(), c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		respondTimetableError(c, err, "Unable to fetch timetable")
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetRoomTimetable godoc
+// @Summary Недельное расписание аудитории
+// @Tags rooms
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID аудитории"
+// @Param term_id query string false "ID периода (по умолчанию — текущий)"
+// @Produce json
+// @Success 200 {array} models.TimetableEntry
+// @Failure 404 {object} gin.H "Аудитория или период не найдены"
+// @Router /rooms/{id}/timetable [get]
+func (tc *TimetableController) GetRoomTimetable(c *gin.Context) {
+	entries, err := tc.timetableService.GetRoomTimetable(c.Request.Context(), c.Param("id"), c.Query("term_id"))
+	if err != nil {
+		respondTimetableError(c, err, "Unable to fetch timetable")
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+func respondTimetableError(c *gin.Context, err error, message string) {
+	var conflictErr *models.ScheduleConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		c.JSON(http.StatusConflict, gin.H{"error": "Schedule conflict", "conflicts": conflictErr.Conflicts})
+	case errors.Is(err, models.ErrMeetingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting not found"})
+	case errors.Is(err, models.ErrSectionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+	case errors.Is(err, models.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+	case errors.Is(err, models.ErrTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, models.ErrNoActiveTerm):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidMeetingTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(message+":", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type AdvisingService interface {
+	GetStudentAdvisors(ctx context.Context, studentID string) ([]models.AdvisorAssignment, error)
+	AssignAdvisor(ctx context.Context, assignment models.AdvisorAssignment, assignedBy, managerID string) (*models.AdvisorAssignment, error)
+	RemoveAdvisor(ctx context.Context, studentID, programID, managerID string) error
+	GetAdvisees(ctx context.Context, teacherID string) ([]models.Advisee, error)
+	GetNotes(ctx context.Context, studentID, userID, role string) ([]models.AdvisingNote, error)
+	CreateNote(ctx context.Context, note *models.AdvisingNote, userID, role string) (*models.AdvisingNote, error)
+	UpdateNote(ctx context.Context, note models.AdvisingNote, userID string) (*models.AdvisingNote, error)
+	DeleteNote(ctx context.Context, id, userID, role string) error
+	GetApprovals(ctx context.Context, status, userID, role string) ([]models.EnrollmentApproval, error)
+	ApproveEnrollment(ctx context.Context, id, userID, role, comment string) (*models.EnrollmentApproval, error)
+	RejectEnrollment(ctx context.Context, id, userID, role, comment string) (*models.EnrollmentApproval, error)
+}
+
+type advisingService struct {
+	repo        repository.AdvisingRepository
+	students    repository.StudentRepository
+	org         repository.OrganizationRepository
+	terms       repository.TermRepository
+	enrollments StudentService
+	grades      GradeService
+	attendance  AttendanceService
+	now         func() time.Time
+}
+
+func NewAdvisingService(repo repository.AdvisingRepository, students repository.StudentRepository, org repository.OrganizationRepository,
+	terms repository.TermRepository, enrollments StudentService, grades GradeService, attendance AttendanceService) AdvisingService {
+	return &advisingService{repo: repo, students: students, org: org, terms: terms, enrollments: enrollments,
+		grades: grades, attendance: attendance, now: time.Now}
+}
+
+func (s *advisingService) GetStudentAdvisors(ctx context.Context, studentID string) ([]models.AdvisorAssignment, error) {
+	return s.repo.GetStudentAdvisors(ctx, studentID)
+}
+
+// AssignAdvisor назначает консультанта по текущей программе студента, заменяя прежнего консультанта
+// этой программы. Менеджер (managerID не пуст) назначает консультантов только студентам факультета своей кафедры.
+func (s *advisingService) AssignAdvisor(ctx context.Context, assignment models.AdvisorAssignment, assignedBy, managerID string) (*models.AdvisorAssignment, error) {
+	student, err := s.scopedStudent(ctx, assignment.StudentID, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if student.ProgramID == nil {
+		return nil, models.ErrStudentProgramNotAssigned
+	}
+	assignment.ProgramID = *student.ProgramID
+	if assignedBy != "" {
+		assignment.AssignedBy = &assignedBy
+	}
+	return s.repo.AssignAdvisor(ctx, &assignment)
+}
+
+func (s *advisingService) RemoveAdvisor(ctx context.Context, studentID, programID, managerID string) error {
+	if _, err := s.scopedStudent(ctx, studentID, managerID); err != nil {
+		return err
+	}
+	return s.repo.RemoveAdvisor(ctx, studentID, programID)
+}
+
+// scopedStudent загружает студента и проверяет, что менеджер managerID ведёт его факультет
+func (s *advisingService) scopedStudent(ctx context.Context, studentID, managerID string) (*models.Student, error) {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return nil, err
+	}
+	student, err := s.students.GetStudentById(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !inFaculty(scope, student.FacultyID) {
+		return nil, models.ErrOutsideDepartment
+	}
+	return student, nil
+}
+
+// GetAdvisees собирает панель консультанта: накопленный GPA и положение по транскрипту и признаки риска —
+// испытательный срок, несданный курс в последнем периоде с оценками, посещаемость ниже порога в идущем периоде
+func (s *advisingService) GetAdvisees(ctx context.Context, teacherID string) ([]models.Advisee, error) {
+	advisees, err := s.repo.GetAdvisees(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	currentTermID := ""
+	term, err := termOrCurrent(ctx, s.terms, "", s.now())
+	switch {
+	case err == nil:
+		currentTermID = term.ID
+	case !errors.Is(err, models.ErrNoActiveTerm):
+		return nil, err
+	}
+
+	for i := range advisees {
+		advisee := &advisees[i]
+		transcript, err := s.grades.GetTranscript(ctx, advisee.StudentID)
+		if err != nil {
+			return nil, err
+		}
+		advisee.CumulativeGPA = transcript.CumulativeGPA
+		advisee.EarnedCredits = transcript.EarnedCredits
+		advisee.Standing = transcript.Standing
+		advisee.RiskFlags = []string{}
+		if transcript.Standing == models.StandingProbation {
+			advisee.RiskFlags = append(advisee.RiskFlags, models.RiskProbation)
+		}
+		if failedLastTerm(transcript) {
+			advisee.RiskFlags = append(advisee.RiskFlags, models.RiskFailedCourse)
+		}
+		if currentTermID != "" {
+			summaries, err := s.attendance.GetStudentAttendance(ctx, advisee.StudentID)
+			if err != nil {
+				return nil, err
+			}
+			for _, summary := range summaries {
+				if summary.TermID == currentTermID && summary.BelowThreshold {
+					advisee.RiskFlags = append(advisee.RiskFlags, models.RiskLowAttendance)
+					break
+				}
+			}
+		}
+		advisee.AtRisk = len(advisee.RiskFlags) > 0
+	}
+	return advisees, nil
+}
+
+// failedLastTerm — в последнем периоде с итоговыми оценками есть несданный курс
+func failedLastTerm(transcript *models.Transcript) bool {
+	for i := len(transcript.Terms) - 1; i >= 0; i-- {
+		term := transcript.Terms[i]
+		if term.AttemptedCredits == 0 {
+			continue
+		}
+		for _, course := range term.Courses {
+			if course.Status == models.EnrollmentStatusFailed {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// GetNotes возвращает заметки о студенте, видимые пользователю (см. noteVisible). Преподаватель,
+// не консультирующий студента, и другой студент заметок не видят.
+func (s *advisingService) GetNotes(ctx context.Context, studentID, userID, role string) ([]models.AdvisingNote, error) {
+	advisor, err := s.noteReader(ctx, studentID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := s.repo.GetNotes(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	visible := []models.AdvisingNote{}
+	for _, note := range notes {
+		if noteVisible(note, userID, role, advisor) {
+			visible = append(visible, note)
+		}
+	}
+	return visible, nil
+}
+
+// CreateNote добавляет заметку о студенте; преподаватель пишет заметки только о консультируемых студентах
+func (s *advisingService) CreateNote(ctx context.Context, note *models.AdvisingNote, userID, role string) (*models.AdvisingNote, error) {
+	if role == "student" {
+		return nil, models.ErrAdvisingForbidden
+	}
+	if _, err := s.students.GetStudentById(ctx, note.StudentID); err != nil {
+		return nil, err
+	}
+	if _, err := s.noteReader(ctx, note.StudentID, userID, role); err != nil {
+		return nil, err
+	}
+	if note.Visibility == "" {
+		note.Visibility = models.NoteVisibilityAdvisors
+	}
+	note.AuthorID, note.AuthorRole = &userID, role
+	return s.repo.CreateNote(ctx, note)
+}
+
+// UpdateNote изменяет текст и видимость заметки; изменять заметку может только её автор
+func (s *advisingService) UpdateNote(ctx context.Context, note models.AdvisingNote, userID string) (*models.AdvisingNote, error) {
+	current, err := s.repo.GetNoteByID(ctx, note.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.AuthorID == nil || *current.AuthorID != userID {
+		return nil, models.ErrAdvisingForbidden
+	}
+	if note.Visibility == "" {
+		note.Visibility = current.Visibility
+	}
+	return s.repo.UpdateNote(ctx, note)
+}
+
+// DeleteNote удаляет заметку; кроме автора это может сделать администратор
+func (s *advisingService) DeleteNote(ctx context.Context, id, userID, role string) error {
+	current, err := s.repo.GetNoteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role != "admin" && (current.AuthorID == nil || *current.AuthorID != userID) {
+		return models.ErrAdvisingForbidden
+	}
+	return s.repo.DeleteNote(ctx, id)
+}
+
+// noteReader проверяет, что пользователь может читать заметки о студенте, и сообщает, консультирует ли он его.
+// Менеджер читает заметки только о студентах факультета своей кафедры.
+func (s *advisingService) noteReader(ctx context.Context, studentID, userID, role string) (bool, error) {
+	switch role {
+	case "admin":
+		return false, nil
+	case "manager":
+		_, err := s.scopedStudent(ctx, studentID, userID)
+		return false, err
+	case "teacher":
+		advisor, err := s.repo.IsAdvisor(ctx, userID, studentID)
+		if err != nil {
+			return false, err
+		}
+		if advisor {
+			return true, nil
+		}
+	case "student":
+		if userID == studentID {
+			return false, nil
+		}
+	}
+	return false, models.ErrAdvisingForbidden
+}
+
+// noteVisible применяет видимость заметки: автор видит свои заметки всегда, private — только автор,
+// advisors — консультанты студента, менеджеры и администраторы, student — они же и сам студент
+func noteVisible(note models.AdvisingNote, userID, role string, advisor bool) bool {
+	if note.AuthorID != nil && *note.AuthorID == userID {
+		return true
+	}
+	staff := advisor || role == "admin" || role == "manager"
+	switch note.Visibility {
+	case models.NoteVisibilityAdvisors:
+		return staff
+	case models.NoteVisibilityStudent:
+		return staff || role == "student" && note.StudentID == userID
+	}
+	return false
+}
+
+// GetApprovals возвращает заявки на согласование: преподавателю — консультируемых им студентов,
+// студенту — его собственные, менеджеру — студентов факультета его кафедры, администратору — все
+func (s *advisingService) GetApprovals(ctx context.Context, status, userID, role string) ([]models.EnrollmentApproval, error) {
+	teacherID, studentID, facultyID := "", "", ""
+	switch role {
+	case "teacher":
+		teacherID = userID
+	case "student":
+		studentID = userID
+	case "manager":
+		scope, err := managerDepartment(ctx, s.org, userID)
+		if err != nil {
+			return nil, err
+		}
+		if scope != nil {
+			if scope.FacultyID == nil {
+				return []models.EnrollmentApproval{}, nil
+			}
+			facultyID = *scope.FacultyID
+		}
+	}
+	return s.repo.GetApprovals(ctx, status, teacherID, studentID, facultyID)
+}
+
+// ApproveEnrollment одобряет заявку и записывает студента на курс. Если запись не удалась
+// (например, закрылась запись или студент больше не проходит правила курса), заявка остаётся ожидающей.
+func (s *advisingService) ApproveEnrollment(ctx context.Context, id, userID, role, comment string) (*models.EnrollmentApproval, error) {
+	approval, err := s.pendingApproval(ctx, id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.enrollments.EnrollApproved(ctx, *approval, userID)
+	if err != nil {
+		return nil, err
+	}
+	decided, err := s.repo.DecideApproval(ctx, id, models.ApprovalStatusApproved, userID, comment)
+	if err != nil {
+		return nil, err
+	}
+	decided.Enrollment = result
+	return decided, nil
+}
+
+func (s *advisingService) RejectEnrollment(ctx context.Context, id, userID, role, comment string) (*models.EnrollmentApproval, error) {
+	if _, err := s.pendingApproval(ctx, id, userID, role); err != nil {
+		return nil, err
+	}
+	return s.repo.DecideApproval(ctx, id, models.ApprovalStatusRejected, userID, comment)
+}
+
+// pendingApproval загружает ожидающую заявку и проверяет право решения: консультант студента,
+// менеджер кафедры факультета студента или администратор
+func (s *advisingService) pendingApproval(ctx context.Context, id, userID, role string) (*models.EnrollmentApproval, error) {
+	approval, err := s.repo.GetApprovalByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch role {
+	case "admin":
+	case "manager":
+		if _, err := s.scopedStudent(ctx, approval.StudentID, userID); err != nil {
+			return nil, err
+		}
+	case "teacher":
+		advisor, err := s.repo.IsAdvisor(ctx, userID, approval.StudentID)
+		if err != nil {
+			return nil, err
+		}
+		if !advisor {
+			return nil, models.ErrAdvisingForbidden
+		}
+	default:
+		return nil, models.ErrAdvisingForbidden
+	}
+	if approval.Status != models.ApprovalStatusPending {
+		return nil, models.ErrApprovalDecided
+	}
+	return approval, nil
+}
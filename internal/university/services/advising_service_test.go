@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockAdvisingRepo struct {
+	mock.Mock
+}
+
+func (m *mockAdvisingRepo) GetStudentAdvisors(ctx context.Context, studentID string) ([]models.AdvisorAssignment, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AdvisorAssignment), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) AssignAdvisor(ctx context.Context, assignment *models.AdvisorAssignment) (*models.AdvisorAssignment, error) {
+	args := m.Called(ctx, assignment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AdvisorAssignment), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) RemoveAdvisor(ctx context.Context, studentID, programID string) error {
+	return m.Called(ctx, studentID, programID).Error(0)
+}
+
+func (m *mockAdvisingRepo) IsAdvisor(ctx context.Context, teacherID, studentID string) (bool, error) {
+	args := m.Called(ctx, teacherID, studentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) GetAdvisees(ctx context.Context, teacherID string) ([]models.Advisee, error) {
+	args := m.Called(ctx, teacherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Advisee), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) GetNotes(ctx context.Context, studentID string) ([]models.AdvisingNote, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AdvisingNote), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) GetNoteByID(ctx context.Context, id string) (*models.AdvisingNote, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AdvisingNote), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) CreateNote(ctx context.Context, note *models.AdvisingNote) (*models.AdvisingNote, error) {
+	args := m.Called(ctx, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AdvisingNote), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) UpdateNote(ctx context.Context, note models.AdvisingNote) (*models.AdvisingNote, error) {
+	args := m.Called(ctx, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AdvisingNote), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) DeleteNote(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockAdvisingRepo) RequiresApproval(ctx context.Context, studentID string) (bool, error) {
+	args := m.Called(ctx, studentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) CreateApproval(ctx context.Context, approval *models.EnrollmentApproval) (*models.EnrollmentApproval, error) {
+	args := m.Called(ctx, approval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EnrollmentApproval), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) GetApprovalByID(ctx context.Context, id string) (*models.EnrollmentApproval, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EnrollmentApproval), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) GetApprovals(ctx context.Context, status, teacherID, studentID, facultyID string) ([]models.EnrollmentApproval, error) {
+	args := m.Called(ctx, status, teacherID, studentID, facultyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EnrollmentApproval), args.Error(1)
+}
+
+func (m *mockAdvisingRepo) DecideApproval(ctx context.Context, id, status, decidedBy, comment string) (*models.EnrollmentApproval, error) {
+	args := m.Called(ctx, id, status, decidedBy, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EnrollmentApproval), args.Error(1)
+}
+
+// mockEnrollments — StudentService, из которого сервис консультантов использует только EnrollApproved
+type mockEnrollments struct {
+	mock.Mock
+	StudentService
+}
+
+func (m *mockEnrollments) EnrollApproved(ctx context.Context, approval models.EnrollmentApproval, changedBy string) (*models.EnrollmentResult, error) {
+	args := m.Called(ctx, approval, changedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EnrollmentResult), args.Error(1)
+}
+
+func newTestAdvisingService(repo *mockAdvisingRepo, students *mockStudentRepo, enrollments *mockEnrollments) AdvisingService {
+	return newScopedTestAdvisingService(repo, students, new(mockOrganizationRepo), enrollments)
+}
+
+// newScopedTestAdvisingService — сервис с репозиторием организации для проверок кафедры менеджера
+func newScopedTestAdvisingService(repo *mockAdvisingRepo, students *mockStudentRepo, org *mockOrganizationRepo, enrollments *mockEnrollments) AdvisingService {
+	return NewAdvisingService(repo, students, org, new(mockTermRepo), enrollments, new(mockGradeService),
+		NewAttendanceService(new(mockAttendanceRepo), new(mockTermRepo), new(mockSectionRepo), new(mockGradeRepo)))
+}
+
+func TestAdvisingService_AssignAdvisor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Assigned For Current Program", func(t *testing.T) {
+		// Arrange
+		repo, students := new(mockAdvisingRepo), new(mockStudentRepo)
+		svc := newTestAdvisingService(repo, students, new(mockEnrollments))
+		students.On("GetStudentById", ctx, "1").Return(&models.Student{ProgramID: strPtr("3")}, nil).Once()
+		repo.On("AssignAdvisor", ctx, mock.MatchedBy(func(a *models.AdvisorAssignment) bool {
+			return a.StudentID == "1" && a.TeacherID == "20" && a.ProgramID == "3" && *a.AssignedBy == "99"
+		})).Return(&models.AdvisorAssignment{ID: "5"}, nil).Once()
+
+		// Act
+		assigned, err := svc.AssignAdvisor(ctx, models.AdvisorAssignment{StudentID: "1", TeacherID: "20", ProgramID: "8"}, "99", "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "5", assigned.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Student Without Program", func(t *testing.T) {
+		// Arrange
+		repo, students := new(mockAdvisingRepo), new(mockStudentRepo)
+		svc := newTestAdvisingService(repo, students, new(mockEnrollments))
+		students.On("GetStudentById", ctx, "1").Return(&models.Student{}, nil).Once()
+
+		// Act
+		_, err := svc.AssignAdvisor(ctx, models.AdvisorAssignment{StudentID: "1", TeacherID: "20"}, "99", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrStudentProgramNotAssigned)
+		repo.AssertNotCalled(t, "AssignAdvisor", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdvisingService_GetAdvisees(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, terms, grades, attendance := new(mockAdvisingRepo), new(mockTermRepo), new(mockGradeService), new(mockAttendanceRepo)
+	svc := NewAdvisingService(repo, new(mockStudentRepo), new(mockOrganizationRepo), terms, new(mockEnrollments), grades,
+		NewAttendanceService(attendance, terms, new(mockSectionRepo), new(mockGradeRepo)))
+	svc.(*advisingService).now = func() time.Time { return date(2025, time.October, 1) }
+	term := fallTerm()
+	repo.On("GetAdvisees", ctx, "20").Return([]models.Advisee{{StudentID: "1"}, {StudentID: "2"}}, nil).Once()
+	terms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+	grades.On("GetTranscript", ctx, "1").Return(&models.Transcript{CumulativeGPA: 1.7, Standing: models.StandingProbation, Terms: []models.TranscriptTerm{
+		{TermID: "0", AttemptedCredits: 10, Courses: []models.TranscriptCourse{{Status: models.EnrollmentStatusFailed}}},
+		{TermID: term.ID, Courses: []models.TranscriptCourse{{Status: models.EnrollmentStatusEnrolled}}},
+	}}, nil).Once()
+	grades.On("GetTranscript", ctx, "2").Return(&models.Transcript{CumulativeGPA: 3.4, Standing: models.StandingGood}, nil).Once()
+	attendance.On("GetStudentAttendance", ctx, "1").Return([]models.AttendanceSummary{{TermID: term.ID, Present: 1, Absent: 4}}, nil).Once()
+	attendance.On("GetStudentAttendance", ctx, "2").Return([]models.AttendanceSummary{{TermID: "0", Absent: 5}}, nil).Once()
+	attendance.On("GetSettings", ctx).Return(&models.AttendanceSettings{MinPercentage: 75, MinSessions: 3}, nil).Twice()
+
+	// Act
+	advisees, err := svc.GetAdvisees(ctx, "20")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, advisees[0].AtRisk)
+	assert.Equal(t, []string{models.RiskProbation, models.RiskFailedCourse, models.RiskLowAttendance}, advisees[0].RiskFlags)
+	assert.Equal(t, 1.7, advisees[0].CumulativeGPA)
+	assert.False(t, advisees[1].AtRisk, "low attendance in a past term is not a current risk")
+	assert.Empty(t, advisees[1].RiskFlags)
+}
+
+func TestAdvisingService_GetNotes(t *testing.T) {
+	ctx := context.Background()
+	notes := []models.AdvisingNote{
+		{ID: "1", StudentID: "1", AuthorID: strPtr("20"), Visibility: models.NoteVisibilityPrivate},
+		{ID: "2", StudentID: "1", AuthorID: strPtr("21"), Visibility: models.NoteVisibilityPrivate},
+		{ID: "3", StudentID: "1", AuthorID: strPtr("21"), Visibility: models.NoteVisibilityAdvisors},
+		{ID: "4", StudentID: "1", AuthorID: strPtr("21"), Visibility: models.NoteVisibilityStudent},
+	}
+	ids := func(notes []models.AdvisingNote) []string {
+		result := []string{}
+		for _, note := range notes {
+			result = append(result, note.ID)
+		}
+		return result
+	}
+
+	t.Run("Advisor Sees Own And Shared Notes", func(t *testing.T) {
+		// Arrange
+		repo := new(mockAdvisingRepo)
+		svc := newTestAdvisingService(repo, new(mockStudentRepo), new(mockEnrollments))
+		repo.On("IsAdvisor", ctx, "20", "1").Return(true, nil).Once()
+		repo.On("GetNotes", ctx, "1").Return(notes, nil).Once()
+
+		// Act
+		visible, err := svc.GetNotes(ctx, "1", "20", "teacher")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "3", "4"}, ids(visible))
+	})
+
+	t.Run("Student Sees Only Shared With Student", func(t *testing.T) {
+		// Arrange
+		repo := new(mockAdvisingRepo)
+		svc := newTestAdvisingService(repo, new(mockStudentRepo), new(mockEnrollments))
+		repo.On("GetNotes", ctx, "1").Return(notes, nil).Once()
+
+		// Act
+		visible, err := svc.GetNotes(ctx, "1", "1", "student")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"4"}, ids(visible))
+	})
+
+	t.Run("Teacher Who Is Not An Advisor", func(t *testing.T) {
+		// Arrange
+		repo := new(mockAdvisingRepo)
+		svc := newTestAdvisingService(repo, new(mockStudentRepo), new(mockEnrollments))
+		repo.On("IsAdvisor", ctx, "30", "1").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.GetNotes(ctx, "1", "30", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAdvisingForbidden)
+		repo.AssertNotCalled(t, "GetNotes", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Manager Sees Shared Notes Of Own Faculty", func(t *testing.T) {
+		// Arrange
+		repo, students, org := new(mockAdvisingRepo), new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := newScopedTestAdvisingService(repo, students, org, new(mockEnrollments))
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		students.On("GetStudentById", ctx, "1").Return(&models.Student{FacultyID: strPtr("7")}, nil).Once()
+		repo.On("GetNotes", ctx, "1").Return(notes, nil).Once()
+
+		// Act
+		visible, err := svc.GetNotes(ctx, "1", "40", "manager")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3", "4"}, ids(visible))
+	})
+
+	t.Run("Manager From Another Faculty", func(t *testing.T) {
+		// Arrange
+		repo, students, org := new(mockAdvisingRepo), new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := newScopedTestAdvisingService(repo, students, org, new(mockEnrollments))
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		students.On("GetStudentById", ctx, "1").Return(&models.Student{FacultyID: strPtr("8")}, nil).Once()
+
+		// Act
+		_, err := svc.GetNotes(ctx, "1", "40", "manager")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		repo.AssertNotCalled(t, "GetNotes", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdvisingService_GetApprovals(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Manager Sees Own Faculty", func(t *testing.T) {
+		// Arrange
+		repo, org := new(mockAdvisingRepo), new(mockOrganizationRepo)
+		svc := newScopedTestAdvisingService(repo, new(mockStudentRepo), org, new(mockEnrollments))
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		repo.On("GetApprovals", ctx, models.ApprovalStatusPending, "", "", "7").Return([]models.EnrollmentApproval{{ID: "9"}}, nil).Once()
+
+		// Act
+		approvals, err := svc.GetApprovals(ctx, models.ApprovalStatusPending, "40", "manager")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, approvals, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Manager Of Department Without Faculty", func(t *testing.T) {
+		// Arrange
+		repo, org := new(mockAdvisingRepo), new(mockOrganizationRepo)
+		svc := newScopedTestAdvisingService(repo, new(mockStudentRepo), org, new(mockEnrollments))
+		org.On("GetManagerDepartment", ctx, "40").Return(&models.Department{ID: "5"}, nil).Once()
+
+		// Act
+		approvals, err := svc.GetApprovals(ctx, "", "40", "manager")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, approvals)
+		repo.AssertNotCalled(t, "GetApprovals", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin Sees All", func(t *testing.T) {
+		// Arrange
+		repo := new(mockAdvisingRepo)
+		svc := newTestAdvisingService(repo, new(mockStudentRepo), new(mockEnrollments))
+		repo.On("GetApprovals", ctx, "", "", "", "").Return([]models.EnrollmentApproval{}, nil).Once()
+
+		// Act
+		_, err := svc.GetApprovals(ctx, "", "1", "admin")
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAdvisingService_UpdateNote(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(mockAdvisingRepo)
+	svc := newTestAdvisingService(repo, new(mockStudentRepo), new(mockEnrollments))
+	repo.On("GetNoteByID", ctx, "3").Return(&models.AdvisingNote{ID: "3", AuthorID: strPtr("21"), Visibility: models.NoteVisibilityAdvisors}, nil).Once()
+
+	// Act
+	_, err := svc.UpdateNote(ctx, models.AdvisingNote{ID: "3", Body: "edited"}, "20")
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrAdvisingForbidden)
+	repo.AssertNotCalled(t, "UpdateNote", mock.Anything, mock.Anything)
+}
+
+func TestAdvisingService_ApproveEnrollment(t *testing.T) {
+	ctx := context.Background()
+	pending := &models.EnrollmentApproval{ID: "9", StudentID: "1", CourseID: "101", TermID: "1", Status: models.ApprovalStatusPending}
+
+	t.Run("Advisor Approves And Student Is Enrolled", func(t *testing.T) {
+		// Arrange
+		repo, enrollments := new(mockAdvisingRepo), new(mockEnrollments)
+		svc := newTestAdvisingService(repo, new(mockStudentRepo), enrollments)
+		repo.On("GetApprovalByID", ctx, "9").Return(pending, nil).Once()
+		repo.On("IsAdvisor", ctx, "20", "1").Return(true, nil).Once()
+		enrollments.On("EnrollApproved", ctx, *pending, "20").Return(&models.EnrollmentResult{Status: models.EnrollmentStatusEnrolled}, nil).Once()
+		repo.On("DecideApproval", ctx, "9", models.ApprovalStatusApproved, "20", "ok").
+			Return(&models.EnrollmentApproval{ID: "9", Status: models.ApprovalStatusApproved}, nil).Once()
+
+		// Act
+		approval, err := svc.ApproveEnrollment(ctx, "9", "20", "teacher", "ok")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.ApprovalStatusApproved, approval.Status)
+		assert.Equal(t, models.EnrollmentStatusEnrolled, approval.Enrollment.Status)
+		repo.AssertExpectations(t)
+		enrollments.AssertExpectations(t)
+	})
+
+	t.Run("Enrollment Fails And Request Stays Pending", func(t *testing.T) {
+		// Arrange
+		repo, students, org, enrollments := new(mockAdvisingRepo), new(mockStudentRepo), new(mockOrganizationRepo), new(mockEnrollments)
+		svc := newScopedTestAdvisingService(repo, students, org, enrollments)
+		repo.On("GetApprovalByID", ctx, "9").Return(pending, nil).Once()
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		students.On("GetStudentById", ctx, "1").Return(&models.Student{FacultyID: strPtr("7")}, nil).Once()
+		enrollments.On("EnrollApproved", ctx, *pending, "40").Return(nil, models.ErrEnrollmentClosed).Once()
+
+		// Act
+		_, err := svc.ApproveEnrollment(ctx, "9", "40", "manager", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrEnrollmentClosed)
+		repo.AssertNotCalled(t, "DecideApproval", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Manager From Another Department", func(t *testing.T) {
+		// Arrange
+		repo, students, org, enrollments := new(mockAdvisingRepo), new(mockStudentRepo), new(mockOrganizationRepo), new(mockEnrollments)
+		svc := newScopedTestAdvisingService(repo, students, org, enrollments)
+		repo.On("GetApprovalByID", ctx, "9").Return(pending, nil).Once()
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		students.On("GetStudentById", ctx, "1").Return(&models.Student{FacultyID: strPtr("8")}, nil).Once()
+
+		// Act
+		_, err := svc.ApproveEnrollment(ctx, "9", "40", "manager", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		enrollments.AssertNotCalled(t, "EnrollApproved", mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "DecideApproval", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Teacher Who Is Not An Advisor", func(t *testing.T) {
+		// Arrange
+		repo, enrollments := new(mockAdvisingRepo), new(mockEnrollments)
+		svc := newTestAdvisingService(repo, new(mockStudentRepo), enrollments)
+		repo.On("GetApprovalByID", ctx, "9").Return(pending, nil).Once()
+		repo.On("IsAdvisor", ctx, "30", "1").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.ApproveEnrollment(ctx, "9", "30", "teacher", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAdvisingForbidden)
+		enrollments.AssertNotCalled(t, "EnrollApproved", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Already Decided", func(t *testing.T) {
+		// Arrange
+		repo := new(mockAdvisingRepo)
+		svc := newTestAdvisingService(repo, new(mockStudentRepo), new(mockEnrollments))
+		repo.On("GetApprovalByID", ctx, "9").Return(&models.EnrollmentApproval{ID: "9", StudentID: "1", Status: models.ApprovalStatusRejected}, nil).Once()
+
+		// Act
+		_, err := svc.RejectEnrollment(ctx, "9", "1", "admin", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrApprovalDecided)
+	})
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type AnnouncementService interface {
+	GetFeed(ctx context.Context, userID, role string, unreadOnly bool, page, pageSize int) (*models.AnnouncementFeed, error)
+	CreateAnnouncement(ctx context.Context, announcement *models.Announcement, userID, role string) (*models.Announcement, error)
+	UpdateAnnouncement(ctx context.Context, announcement models.Announcement, userID, role string) (*models.Announcement, error)
+	DeleteAnnouncement(ctx context.Context, id, userID, role string) error
+	MarkRead(ctx context.Context, id, userID, role string) error
+	MarkAllRead(ctx context.Context, userID, role string) (int, error)
+}
+
+type announcementService struct {
+	repo    repository.AnnouncementRepository
+	courses repository.CourseRepository
+	org     repository.OrganizationRepository
+	grades  repository.GradeRepository
+}
+
+func NewAnnouncementService(repo repository.AnnouncementRepository, courses repository.CourseRepository,
+	org repository.OrganizationRepository, grades repository.GradeRepository) AnnouncementService {
+	return &announcementService{repo: repo, courses: courses, org: org, grades: grades}
+}
+
+// GetFeed возвращает страницу ленты пользователя: объявления университета, кафедр и курсов,
+// адресованные его роли, от новых к старым. Страницы нумеруются с 1; размер страницы по умолчанию —
+// models.DefaultFeedPageSize, не больше models.MaxFeedPageSize.
+func (s *announcementService) GetFeed(ctx context.Context, userID, role string, unreadOnly bool, page, pageSize int) (*models.AnnouncementFeed, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = models.DefaultFeedPageSize
+	}
+	if pageSize > models.MaxFeedPageSize {
+		pageSize = models.MaxFeedPageSize
+	}
+	feed, err := s.repo.GetFeed(ctx, userID, role, unreadOnly, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	feed.Page, feed.PageSize = page, pageSize
+	return feed, nil
+}
+
+// CreateAnnouncement публикует объявление. Университетское объявление публикует менеджер, кафедральное —
+// менеджер своей кафедры, объявление курса — преподаватель курса или менеджер кафедры курса;
+// администратор публикует любые объявления.
+func (s *announcementService) CreateAnnouncement(ctx context.Context, announcement *models.Announcement, userID, role string) (*models.Announcement, error) {
+	if err := normalizeAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+	switch announcement.Scope {
+	case models.AnnouncementScopeUniversity:
+		if announcement.DepartmentID != nil || announcement.CourseID != nil {
+			return nil, fmt.Errorf("%w: university announcement has no department or course", models.ErrInvalidAnnouncement)
+		}
+		if role != "admin" && role != "manager" {
+			return nil, models.ErrAnnouncementForbidden
+		}
+	case models.AnnouncementScopeDepartment:
+		if announcement.DepartmentID == nil || announcement.CourseID != nil {
+			return nil, fmt.Errorf("%w: department announcement needs department_id only", models.ErrInvalidAnnouncement)
+		}
+		if err := s.checkDepartmentAuthor(ctx, *announcement.DepartmentID, userID, role); err != nil {
+			return nil, err
+		}
+	case models.AnnouncementScopeCourse:
+		if announcement.CourseID == nil || announcement.DepartmentID != nil {
+			return nil, fmt.Errorf("%w: course announcement needs course_id only", models.ErrInvalidAnnouncement)
+		}
+		if err := s.checkCourseAuthor(ctx, *announcement.CourseID, userID, role); err != nil {
+			return nil, err
+		}
+	}
+	announcement.AuthorID = &userID
+	return s.repo.CreateAnnouncement(ctx, announcement)
+}
+
+func (s *announcementService) checkDepartmentAuthor(ctx context.Context, departmentID, userID, role string) error {
+	if role != "admin" && role != "manager" {
+		return models.ErrAnnouncementForbidden
+	}
+	if _, err := s.org.GetDepartmentByID(ctx, departmentID); err != nil {
+		return err
+	}
+	if role != "manager" {
+		return nil
+	}
+	scope, err := managerDepartment(ctx, s.org, userID)
+	if err != nil {
+		return err
+	}
+	if !inDepartment(scope, &departmentID) {
+		return models.ErrOutsideDepartment
+	}
+	return nil
+}
+
+func (s *announcementService) checkCourseAuthor(ctx context.Context, courseID, userID, role string) error {
+	course, err := s.courses.GetCourseByID(ctx, courseID)
+	if err != nil {
+		return err
+	}
+	switch role {
+	case "admin":
+		return nil
+	case "teacher":
+		isTeacher, err := s.grades.IsTeacherOfCourse(ctx, userID, course.ID)
+		if err != nil {
+			return err
+		}
+		if !isTeacher {
+			return models.ErrNotCourseTeacher
+		}
+		return nil
+	case "manager":
+		scope, err := managerDepartment(ctx, s.org, userID)
+		if err != nil {
+			return err
+		}
+		if !inDepartment(scope, course.DepartmentID) {
+			return models.ErrOutsideDepartment
+		}
+		return nil
+	}
+	return models.ErrAnnouncementForbidden
+}
+
+// UpdateAnnouncement меняет текст и адресатов объявления; охват не меняется. Изменить объявление
+// может его автор или администратор.
+func (s *announcementService) UpdateAnnouncement(ctx context.Context, announcement models.Announcement, userID, role string) (*models.Announcement, error) {
+	if _, err := s.authoredAnnouncement(ctx, announcement.ID, userID, role); err != nil {
+		return nil, err
+	}
+	if err := normalizeAnnouncement(&announcement); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateAnnouncement(ctx, announcement)
+}
+
+func (s *announcementService) DeleteAnnouncement(ctx context.Context, id, userID, role string) error {
+	if _, err := s.authoredAnnouncement(ctx, id, userID, role); err != nil {
+		return err
+	}
+	return s.repo.DeleteAnnouncement(ctx, id)
+}
+
+func (s *announcementService) authoredAnnouncement(ctx context.Context, id, userID, role string) (*models.Announcement, error) {
+	announcement, err := s.repo.GetAnnouncementByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && (announcement.AuthorID == nil || *announcement.AuthorID != userID) {
+		return nil, models.ErrAnnouncementForbidden
+	}
+	return announcement, nil
+}
+
+// MarkRead отмечает объявление прочитанным; объявление не из ленты пользователя считается ненайденным
+func (s *announcementService) MarkRead(ctx context.Context, id, userID, role string) error {
+	visible, err := s.repo.IsVisible(ctx, id, userID, role)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return models.ErrAnnouncementNotFound
+	}
+	return s.repo.MarkRead(ctx, id, userID)
+}
+
+func (s *announcementService) MarkAllRead(ctx context.Context, userID, role string) (int, error) {
+	return s.repo.MarkAllRead(ctx, userID, role)
+}
+
+func normalizeAnnouncement(announcement *models.Announcement) error {
+	announcement.Title = strings.TrimSpace(announcement.Title)
+	announcement.Body = strings.TrimSpace(announcement.Body)
+	if announcement.Title == "" || announcement.Body == "" {
+		return fmt.Errorf("%w: title and body must not be blank", models.ErrInvalidAnnouncement)
+	}
+	if announcement.Audience == "" {
+		announcement.Audience = models.AnnouncementAudienceAll
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockAnnouncementRepo struct {
+	mock.Mock
+}
+
+func (m *mockAnnouncementRepo) CreateAnnouncement(ctx context.Context, announcement *models.Announcement) (*models.Announcement, error) {
+	args := m.Called(ctx, announcement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Announcement), args.Error(1)
+}
+
+func (m *mockAnnouncementRepo) GetAnnouncementByID(ctx context.Context, id string) (*models.Announcement, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Announcement), args.Error(1)
+}
+
+func (m *mockAnnouncementRepo) UpdateAnnouncement(ctx context.Context, announcement models.Announcement) (*models.Announcement, error) {
+	args := m.Called(ctx, announcement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Announcement), args.Error(1)
+}
+
+func (m *mockAnnouncementRepo) DeleteAnnouncement(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockAnnouncementRepo) GetFeed(ctx context.Context, userID, role string, unreadOnly bool, limit, offset int) (*models.AnnouncementFeed, error) {
+	args := m.Called(ctx, userID, role, unreadOnly, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AnnouncementFeed), args.Error(1)
+}
+
+func (m *mockAnnouncementRepo) IsVisible(ctx context.Context, id, userID, role string) (bool, error) {
+	args := m.Called(ctx, id, userID, role)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAnnouncementRepo) MarkRead(ctx context.Context, id, userID string) error {
+	return m.Called(ctx, id, userID).Error(0)
+}
+
+func (m *mockAnnouncementRepo) MarkAllRead(ctx context.Context, userID, role string) (int, error) {
+	args := m.Called(ctx, userID, role)
+	return args.Int(0), args.Error(1)
+}
+
+func TestAnnouncementService_GetFeed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Default Page", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+		repo.On("GetFeed", ctx, "101", "student", false, models.DefaultFeedPageSize, 0).
+			Return(&models.AnnouncementFeed{Items: []models.Announcement{}, Total: 3, Unread: 1}, nil).Once()
+
+		// Act
+		feed, err := svc.GetFeed(ctx, "101", "student", false, 0, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, feed.Page)
+		assert.Equal(t, models.DefaultFeedPageSize, feed.PageSize)
+		assert.Equal(t, 1, feed.Unread)
+	})
+
+	t.Run("Page Size Is Capped", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+		repo.On("GetFeed", ctx, "101", "student", true, models.MaxFeedPageSize, 2*models.MaxFeedPageSize).
+			Return(&models.AnnouncementFeed{Items: []models.Announcement{}}, nil).Once()
+
+		// Act
+		feed, err := svc.GetFeed(ctx, "101", "student", true, 3, 500)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, feed.Page)
+		assert.Equal(t, models.MaxFeedPageSize, feed.PageSize)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAnnouncementService_CreateAnnouncement(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Teacher Cannot Post University-Wide", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+
+		// Act
+		_, err := svc.CreateAnnouncement(ctx, &models.Announcement{Scope: models.AnnouncementScopeUniversity,
+			Title: "Exams", Body: "Schedule is out"}, "201", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAnnouncementForbidden)
+		repo.AssertNotCalled(t, "CreateAnnouncement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Scope Without Target", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+
+		// Act
+		_, err := svc.CreateAnnouncement(ctx, &models.Announcement{Scope: models.AnnouncementScopeCourse,
+			Title: "Quiz", Body: "On Monday"}, "201", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidAnnouncement)
+	})
+
+	t.Run("Manager Outside Department", func(t *testing.T) {
+		repo, org := new(mockAnnouncementRepo), new(mockOrganizationRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), org, new(mockGradeRepo))
+		org.On("GetDepartmentByID", ctx, "2").Return(&models.Department{ID: "2"}, nil).Once()
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+
+		// Act
+		_, err := svc.CreateAnnouncement(ctx, &models.Announcement{Scope: models.AnnouncementScopeDepartment,
+			DepartmentID: strPtr("2"), Title: "Seminar", Body: "Room 101"}, "40", "manager")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		repo.AssertNotCalled(t, "CreateAnnouncement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not Course Teacher", func(t *testing.T) {
+		repo, courses, grades := new(mockAnnouncementRepo), new(mockCourseRepo), new(mockGradeRepo)
+		svc := NewAnnouncementService(repo, courses, new(mockOrganizationRepo), grades)
+		courses.On("GetCourseByID", ctx, "11").Return(&models.Course{ID: "11"}, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "202", "11").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.CreateAnnouncement(ctx, &models.Announcement{Scope: models.AnnouncementScopeCourse,
+			CourseID: strPtr("11"), Title: "Quiz", Body: "On Monday"}, "202", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotCourseTeacher)
+		repo.AssertNotCalled(t, "CreateAnnouncement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Course Teacher Posts", func(t *testing.T) {
+		repo, courses, grades := new(mockAnnouncementRepo), new(mockCourseRepo), new(mockGradeRepo)
+		svc := NewAnnouncementService(repo, courses, new(mockOrganizationRepo), grades)
+		courses.On("GetCourseByID", ctx, "11").Return(&models.Course{ID: "11"}, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "201", "11").Return(true, nil).Once()
+		repo.On("CreateAnnouncement", ctx, mock.MatchedBy(func(a *models.Announcement) bool {
+			return a.AuthorID != nil && *a.AuthorID == "201" && a.Audience == models.AnnouncementAudienceAll && a.Title == "Quiz"
+		})).Return(&models.Announcement{ID: "70"}, nil).Once()
+
+		// Act
+		created, err := svc.CreateAnnouncement(ctx, &models.Announcement{Scope: models.AnnouncementScopeCourse,
+			CourseID: strPtr("11"), Title: " Quiz ", Body: "On Monday"}, "201", "teacher")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "70", created.ID)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAnnouncementService_UpdateAnnouncement(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	current := &models.Announcement{ID: "70", Scope: models.AnnouncementScopeCourse, CourseID: strPtr("11"), AuthorID: strPtr("201")}
+
+	t.Run("Not Author", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+		repo.On("GetAnnouncementByID", ctx, "70").Return(current, nil).Once()
+
+		// Act
+		_, err := svc.UpdateAnnouncement(ctx, models.Announcement{ID: "70", Title: "Quiz", Body: "Moved"}, "202", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAnnouncementForbidden)
+		repo.AssertNotCalled(t, "UpdateAnnouncement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin Edits", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+		repo.On("GetAnnouncementByID", ctx, "70").Return(current, nil).Once()
+		repo.On("UpdateAnnouncement", ctx, mock.MatchedBy(func(a models.Announcement) bool {
+			return a.ID == "70" && a.Audience == models.AnnouncementAudienceStudents
+		})).Return(current, nil).Once()
+
+		// Act
+		_, err := svc.UpdateAnnouncement(ctx, models.Announcement{ID: "70", Title: "Quiz", Body: "Moved",
+			Audience: models.AnnouncementAudienceStudents}, "1", "admin")
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAnnouncementService_MarkRead(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Not In Feed", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+		repo.On("IsVisible", ctx, "70", "101", "student").Return(false, nil).Once()
+
+		// Act
+		err := svc.MarkRead(ctx, "70", "101", "student")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAnnouncementNotFound)
+		repo.AssertNotCalled(t, "MarkRead", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(mockAnnouncementRepo)
+		svc := NewAnnouncementService(repo, new(mockCourseRepo), new(mockOrganizationRepo), new(mockGradeRepo))
+		repo.On("IsVisible", ctx, "70", "101", "student").Return(true, nil).Once()
+		repo.On("MarkRead", ctx, "70", "101").Return(nil).Once()
+
+		// Act
+		err := svc.MarkRead(ctx, "70", "101", "student")
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type AppealService interface {
+	FileAppeal(ctx context.Context, studentID string, appeal *models.GradeAppeal, attachment *models.AppealAttachment) (*models.GradeAppeal, error)
+	GetAppeal(ctx context.Context, id, userID, role string) (*models.GradeAppeal, error)
+	GetAppeals(ctx context.Context, status, userID, role string) ([]models.GradeAppeal, error)
+	GetStudentAppeals(ctx context.Context, studentID string) ([]models.GradeAppeal, error)
+	GetAttachment(ctx context.Context, id, userID, role string) (*models.AppealAttachment, error)
+	AddComment(ctx context.Context, id, userID, role, body string) (*models.AppealComment, error)
+	StartReview(ctx context.Context, id, userID, role string) (*models.GradeAppeal, error)
+	ResolveAppeal(ctx context.Context, id, userID, role string, resolution models.AppealResolution) (*models.GradeAppeal, error)
+	WithdrawAppeal(ctx context.Context, id, studentID string) (*models.GradeAppeal, error)
+}
+
+type appealService struct {
+	repo    repository.AppealRepository
+	grades  repository.GradeRepository
+	schemes repository.GradingSchemeRepository
+}
+
+func NewAppealService(repo repository.AppealRepository, grades repository.GradeRepository, schemes repository.GradingSchemeRepository) AppealService {
+	return &appealService{repo: repo, grades: grades, schemes: schemes}
+}
+
+var openAppealStatuses = []string{models.AppealStatusSubmitted, models.AppealStatusUnderReview}
+
+// FileAppeal подаёт апелляцию на часть оценки, которая уже выставлена студенту в курсе периода.
+// Текущее значение оценки сохраняется в апелляции.
+func (s *appealService) FileAppeal(ctx context.Context, studentID string, appeal *models.GradeAppeal, attachment *models.AppealAttachment) (*models.GradeAppeal, error) {
+	if attachment != nil && len(attachment.Data) > models.MaxAppealAttachmentSize {
+		return nil, models.ErrAttachmentTooLarge
+	}
+	marks, err := s.grades.GetOfferingMarks(ctx, appeal.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+	var mark *models.Mark
+	for i := range marks {
+		if strconv.FormatUint(uint64(marks[i].StudentID), 10) == studentID {
+			mark = &marks[i]
+			break
+		}
+	}
+	if mark == nil {
+		return nil, models.ErrNoMarkToAppeal
+	}
+
+	appeal.StudentID = studentID
+	appeal.CourseID = strconv.FormatUint(uint64(mark.CourseID), 10)
+	appeal.Status = models.AppealStatusSubmitted
+	switch appeal.MarkType {
+	case models.MarkTypeFirstAttestation:
+		appeal.OriginalValue = mark.FirstAttestation
+	case models.MarkTypeSecondAttestation:
+		appeal.OriginalValue = mark.SecondAttestation
+	case models.MarkTypeFinal:
+		appeal.OriginalValue = mark.FinalMark
+	default:
+		return nil, models.ErrInvalidMarkType
+	}
+	return s.repo.CreateAppeal(ctx, appeal, attachment)
+}
+
+// GetAppeal возвращает апелляцию с комментариями студенту-автору, преподавателю курса или менеджеру
+func (s *appealService) GetAppeal(ctx context.Context, id, userID, role string) (*models.GradeAppeal, error) {
+	appeal, err := s.accessibleAppeal(ctx, id, userID, role, false)
+	if err != nil {
+		return nil, err
+	}
+	if appeal.Comments, err = s.repo.GetComments(ctx, id); err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// GetAppeals возвращает апелляции для рассмотрения: преподавателю — по его курсам, менеджеру — все
+func (s *appealService) GetAppeals(ctx context.Context, status, userID, role string) ([]models.GradeAppeal, error) {
+	teacherID := ""
+	if role == "teacher" {
+		teacherID = userID
+	}
+	return s.repo.GetAppeals(ctx, status, teacherID)
+}
+
+func (s *appealService) GetStudentAppeals(ctx context.Context, studentID string) ([]models.GradeAppeal, error) {
+	return s.repo.GetStudentAppeals(ctx, studentID)
+}
+
+func (s *appealService) GetAttachment(ctx context.Context, id, userID, role string) (*models.AppealAttachment, error) {
+	if _, err := s.accessibleAppeal(ctx, id, userID, role, false); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAttachment(ctx, id)
+}
+
+// AddComment добавляет комментарий к апелляции, пока по ней нет решения
+func (s *appealService) AddComment(ctx context.Context, id, userID, role, body string) (*models.AppealComment, error) {
+	appeal, err := s.accessibleAppeal(ctx, id, userID, role, false)
+	if err != nil {
+		return nil, err
+	}
+	if !appeal.IsOpen() {
+		return nil, models.ErrAppealState
+	}
+	return s.repo.AddComment(ctx, &models.AppealComment{AppealID: id, AuthorID: &userID, AuthorRole: role, Body: body})
+}
+
+// StartReview берёт поданную апелляцию на рассмотрение
+func (s *appealService) StartReview(ctx context.Context, id, userID, role string) (*models.GradeAppeal, error) {
+	if _, err := s.accessibleAppeal(ctx, id, userID, role, true); err != nil {
+		return nil, err
+	}
+	err := s.repo.UpdateAppealStatus(ctx, id, []string{models.AppealStatusSubmitted}, models.AppealStatusUnderReview, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppealByID(ctx, id)
+}
+
+// ResolveAppeal закрывает апелляцию. Если оценка изменена, новое значение проверяется по схеме
+// оценивания и записывается вместе с решением, с записью в журнал изменений оценок.
+// В отправленной или утверждённой ведомости оценку по апелляции меняет только менеджер или
+// администратор; преподавателю для этого нужен запрос на изменение оценки.
+func (s *appealService) ResolveAppeal(ctx context.Context, id, userID, role string, resolution models.AppealResolution) (*models.GradeAppeal, error) {
+	appeal, err := s.accessibleAppeal(ctx, id, userID, role, true)
+	if err != nil {
+		return nil, err
+	}
+	if !appeal.IsOpen() {
+		return nil, models.ErrAppealState
+	}
+
+	var resolvedValue float64
+	switch resolution.Outcome {
+	case models.AppealStatusUpheld:
+		resolvedValue = appeal.OriginalValue
+	case models.AppealStatusChanged:
+		if resolution.NewValue == nil || *resolution.NewValue == appeal.OriginalValue {
+			return nil, models.ErrInvalidAppealResolution
+		}
+		resolvedValue = *resolution.NewValue
+		scheme, err := offeringSchemeOrDefault(ctx, s.schemes, appeal.OfferingID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkMarkRange(scheme, appeal.MarkType, resolvedValue); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, models.ErrInvalidAppealResolution
+	}
+
+	override := role == "admin" || role == "manager"
+	if err := s.repo.ResolveAppeal(ctx, appeal, resolution.Outcome, userID, resolvedValue, override); err != nil {
+		return nil, err
+	}
+	if resolution.Comment != "" {
+		if _, err := s.repo.AddComment(ctx, &models.AppealComment{AppealID: id, AuthorID: &userID, AuthorRole: role, Body: resolution.Comment}); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetAppeal(ctx, id, userID, role)
+}
+
+// WithdrawAppeal отзывает апелляцию по просьбе её автора, пока по ней нет решения
+func (s *appealService) WithdrawAppeal(ctx context.Context, id, studentID string) (*models.GradeAppeal, error) {
+	if _, err := s.accessibleAppeal(ctx, id, studentID, "student", false); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateAppealStatus(ctx, id, openAppealStatuses, models.AppealStatusWithdrawn, studentID, nil); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppealByID(ctx, id)
+}
+
+// accessibleAppeal загружает апелляцию и проверяет доступ: менеджер и администратор видят и рассматривают все,
+// преподаватель — апелляции по своим курсам, студент — только свои и без права рассмотрения
+func (s *appealService) accessibleAppeal(ctx context.Context, id, userID, role string, review bool) (*models.GradeAppeal, error) {
+	appeal, err := s.repo.GetAppealByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch role {
+	case "admin", "manager":
+		return appeal, nil
+	case "teacher":
+		isTeacher, err := s.grades.IsTeacherOfCourse(ctx, userID, appeal.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		if isTeacher {
+			return appeal, nil
+		}
+	case "student":
+		if !review && appeal.StudentID == userID {
+			return appeal, nil
+		}
+	}
+	return nil, models.ErrAppealForbidden
+}
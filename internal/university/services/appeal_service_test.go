@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockAppealRepo struct {
+	mock.Mock
+}
+
+func (m *mockAppealRepo) CreateAppeal(ctx context.Context, appeal *models.GradeAppeal, attachment *models.AppealAttachment) (*models.GradeAppeal, error) {
+	args := m.Called(ctx, appeal, attachment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeAppeal), args.Error(1)
+}
+
+func (m *mockAppealRepo) GetAppealByID(ctx context.Context, id string) (*models.GradeAppeal, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeAppeal), args.Error(1)
+}
+
+func (m *mockAppealRepo) GetAppeals(ctx context.Context, status, teacherID string) ([]models.GradeAppeal, error) {
+	args := m.Called(ctx, status, teacherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GradeAppeal), args.Error(1)
+}
+
+func (m *mockAppealRepo) GetStudentAppeals(ctx context.Context, studentID string) ([]models.GradeAppeal, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GradeAppeal), args.Error(1)
+}
+
+func (m *mockAppealRepo) GetAttachment(ctx context.Context, id string) (*models.AppealAttachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppealAttachment), args.Error(1)
+}
+
+func (m *mockAppealRepo) UpdateAppealStatus(ctx context.Context, id string, from []string, to, actorID string, resolvedValue *float64) error {
+	args := m.Called(ctx, id, from, to, actorID, resolvedValue)
+	return args.Error(0)
+}
+
+func (m *mockAppealRepo) ResolveAppeal(ctx context.Context, appeal *models.GradeAppeal, outcome, actorID string, resolvedValue float64, overrideSheet bool) error {
+	args := m.Called(ctx, appeal, outcome, actorID, resolvedValue, overrideSheet)
+	return args.Error(0)
+}
+
+func (m *mockAppealRepo) AddComment(ctx context.Context, comment *models.AppealComment) (*models.AppealComment, error) {
+	args := m.Called(ctx, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AppealComment), args.Error(1)
+}
+
+func (m *mockAppealRepo) GetComments(ctx context.Context, appealID string) ([]models.AppealComment, error) {
+	args := m.Called(ctx, appealID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AppealComment), args.Error(1)
+}
+
+func openAppeal(status string) *models.GradeAppeal {
+	return &models.GradeAppeal{ID: "3", StudentID: "101", OfferingID: "7", CourseID: "201",
+		MarkType: models.MarkTypeFinal, OriginalValue: 25, Status: status}
+}
+
+func TestAppealService_FileAppeal(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	marks := []models.Mark{{StudentID: 101, CourseID: 201, OfferingID: 7, FirstAttestation: 20, FinalMark: 25}}
+
+	t.Run("Success Records Current Mark", func(t *testing.T) {
+		repo, grades := new(mockAppealRepo), new(mockGradeRepo)
+		svc := NewAppealService(repo, grades, new(mockGradingSchemeRepo))
+		appeal := &models.GradeAppeal{OfferingID: "7", MarkType: models.MarkTypeFinal, Reason: "Question 3 was marked wrong"}
+		attachment := &models.AppealAttachment{Name: "exam.pdf", ContentType: "application/pdf", Data: []byte("%PDF")}
+		grades.On("GetOfferingMarks", ctx, "7").Return(marks, nil).Once()
+		repo.On("CreateAppeal", ctx, appeal, attachment).Return(appeal, nil).Once()
+
+		// Act
+		created, err := svc.FileAppeal(ctx, "101", appeal, attachment)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "101", created.StudentID)
+		assert.Equal(t, "201", created.CourseID)
+		assert.Equal(t, 25.0, created.OriginalValue)
+		assert.Equal(t, models.AppealStatusSubmitted, created.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("No Mark In Course", func(t *testing.T) {
+		repo, grades := new(mockAppealRepo), new(mockGradeRepo)
+		svc := NewAppealService(repo, grades, new(mockGradingSchemeRepo))
+		appeal := &models.GradeAppeal{OfferingID: "7", MarkType: models.MarkTypeFinal, Reason: "..."}
+		grades.On("GetOfferingMarks", ctx, "7").Return(marks, nil).Once()
+
+		// Act
+		_, err := svc.FileAppeal(ctx, "102", appeal, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNoMarkToAppeal)
+		repo.AssertNotCalled(t, "CreateAppeal", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Attachment Too Large", func(t *testing.T) {
+		repo, grades := new(mockAppealRepo), new(mockGradeRepo)
+		svc := NewAppealService(repo, grades, new(mockGradingSchemeRepo))
+		appeal := &models.GradeAppeal{OfferingID: "7", MarkType: models.MarkTypeFinal, Reason: "..."}
+		attachment := &models.AppealAttachment{Name: "scan.png", Data: make([]byte, models.MaxAppealAttachmentSize+1)}
+
+		// Act
+		_, err := svc.FileAppeal(ctx, "101", appeal, attachment)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAttachmentTooLarge)
+		grades.AssertNotCalled(t, "GetOfferingMarks", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Open Appeal Exists", func(t *testing.T) {
+		repo, grades := new(mockAppealRepo), new(mockGradeRepo)
+		svc := NewAppealService(repo, grades, new(mockGradingSchemeRepo))
+		appeal := &models.GradeAppeal{OfferingID: "7", MarkType: models.MarkTypeFirstAttestation, Reason: "..."}
+		grades.On("GetOfferingMarks", ctx, "7").Return(marks, nil).Once()
+		repo.On("CreateAppeal", ctx, appeal, (*models.AppealAttachment)(nil)).Return(nil, models.ErrAppealExists).Once()
+
+		// Act
+		_, err := svc.FileAppeal(ctx, "101", appeal, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAppealExists)
+	})
+}
+
+func TestAppealService_Access(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Student Sees Own Appeal", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusSubmitted), nil).Once()
+		repo.On("GetComments", ctx, "3").Return([]models.AppealComment{{ID: "1", Body: "please check"}}, nil).Once()
+
+		// Act
+		appeal, err := svc.GetAppeal(ctx, "3", "101", "student")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, appeal.Comments, 1)
+	})
+
+	t.Run("Other Student Is Forbidden", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusSubmitted), nil).Once()
+
+		// Act
+		_, err := svc.GetAppeal(ctx, "3", "102", "student")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAppealForbidden)
+	})
+
+	t.Run("Teacher Of Another Course Cannot Review", func(t *testing.T) {
+		repo, grades := new(mockAppealRepo), new(mockGradeRepo)
+		svc := NewAppealService(repo, grades, new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusSubmitted), nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "6", "201").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.StartReview(ctx, "3", "6", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAppealForbidden)
+		repo.AssertNotCalled(t, "UpdateAppealStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Student Cannot Review Own Appeal", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusSubmitted), nil).Once()
+
+		// Act
+		_, err := svc.ResolveAppeal(ctx, "3", "101", "student", models.AppealResolution{Outcome: models.AppealStatusUpheld})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAppealForbidden)
+	})
+
+	t.Run("Teacher Sees Only Own Courses", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppeals", ctx, models.AppealStatusSubmitted, "5").Return([]models.GradeAppeal{}, nil).Once()
+
+		// Act
+		_, err := svc.GetAppeals(ctx, models.AppealStatusSubmitted, "5", "teacher")
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAppealService_ResolveAppeal(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Changed Applies Mark", func(t *testing.T) {
+		repo, grades, schemes := new(mockAppealRepo), new(mockGradeRepo), new(mockGradingSchemeRepo)
+		svc := NewAppealService(repo, grades, schemes)
+		newValue := 32.0
+		resolved := openAppeal(models.AppealStatusChanged)
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusUnderReview), nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		repo.On("ResolveAppeal", ctx, mock.MatchedBy(func(a *models.GradeAppeal) bool { return a.ID == "3" }),
+			models.AppealStatusChanged, "9", 32.0, true).Return(nil).Once()
+		repo.On("AddComment", ctx, mock.MatchedBy(func(c *models.AppealComment) bool {
+			return c.AppealID == "3" && c.Body == "Question 3 re-marked" && c.AuthorRole == "manager"
+		})).Return(&models.AppealComment{ID: "1"}, nil).Once()
+		repo.On("GetAppealByID", ctx, "3").Return(resolved, nil).Once()
+		repo.On("GetComments", ctx, "3").Return([]models.AppealComment{}, nil).Once()
+
+		// Act
+		appeal, err := svc.ResolveAppeal(ctx, "3", "9", "manager",
+			models.AppealResolution{Outcome: models.AppealStatusChanged, NewValue: &newValue, Comment: "Question 3 re-marked"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.AppealStatusChanged, appeal.Status)
+		repo.AssertExpectations(t)
+		grades.AssertExpectations(t)
+	})
+
+	t.Run("Upheld Keeps Mark", func(t *testing.T) {
+		repo, grades := new(mockAppealRepo), new(mockGradeRepo)
+		svc := NewAppealService(repo, grades, new(mockGradingSchemeRepo))
+		original := 25.0
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusSubmitted), nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("ResolveAppeal", ctx, mock.Anything, models.AppealStatusUpheld, "5", original, false).Return(nil).Once()
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusUpheld), nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("GetComments", ctx, "3").Return([]models.AppealComment{}, nil).Once()
+
+		// Act
+		appeal, err := svc.ResolveAppeal(ctx, "3", "5", "teacher", models.AppealResolution{Outcome: models.AppealStatusUpheld})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.AppealStatusUpheld, appeal.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Teacher Cannot Change Mark In Submitted Sheet", func(t *testing.T) {
+		repo, grades, schemes := new(mockAppealRepo), new(mockGradeRepo), new(mockGradingSchemeRepo)
+		svc := NewAppealService(repo, grades, schemes)
+		newValue := 32.0
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusUnderReview), nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		repo.On("ResolveAppeal", ctx, mock.Anything, models.AppealStatusChanged, "5", 32.0, false).Return(models.ErrAppealSheetLocked).Once()
+
+		// Act
+		_, err := svc.ResolveAppeal(ctx, "3", "5", "teacher", models.AppealResolution{Outcome: models.AppealStatusChanged, NewValue: &newValue})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAppealSheetLocked)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "AddComment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Changed Without New Value", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusUnderReview), nil).Once()
+
+		// Act
+		_, err := svc.ResolveAppeal(ctx, "3", "9", "manager", models.AppealResolution{Outcome: models.AppealStatusChanged})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidAppealResolution)
+	})
+
+	t.Run("New Value Out Of Range", func(t *testing.T) {
+		repo, schemes := new(mockAppealRepo), new(mockGradingSchemeRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), schemes)
+		newValue := 41.0
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusUnderReview), nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+
+		// Act
+		_, err := svc.ResolveAppeal(ctx, "3", "9", "manager", models.AppealResolution{Outcome: models.AppealStatusChanged, NewValue: &newValue})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrMarkOutOfRange)
+		repo.AssertNotCalled(t, "ResolveAppeal", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Already Resolved", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusWithdrawn), nil).Once()
+
+		// Act
+		_, err := svc.ResolveAppeal(ctx, "3", "9", "manager", models.AppealResolution{Outcome: models.AppealStatusUpheld})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAppealState)
+	})
+}
+
+func TestAppealService_AddComment(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Closed Appeal", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusUpheld), nil).Once()
+
+		// Act
+		_, err := svc.AddComment(ctx, "3", "101", "student", "but why?")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAppealState)
+		repo.AssertNotCalled(t, "AddComment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Student Comments Own Appeal", func(t *testing.T) {
+		repo := new(mockAppealRepo)
+		svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusUnderReview), nil).Once()
+		repo.On("AddComment", ctx, mock.MatchedBy(func(c *models.AppealComment) bool {
+			return c.AppealID == "3" && *c.AuthorID == "101" && c.AuthorRole == "student"
+		})).Return(&models.AppealComment{ID: "2"}, nil).Once()
+
+		// Act
+		comment, err := svc.AddComment(ctx, "3", "101", "student", "Scan attached")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "2", comment.ID)
+	})
+}
+
+func TestAppealService_WithdrawAppeal(t *testing.T) {
+	// Arrange
+	repo := new(mockAppealRepo)
+	svc := NewAppealService(repo, new(mockGradeRepo), new(mockGradingSchemeRepo))
+	ctx := context.Background()
+	repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusSubmitted), nil).Once()
+	repo.On("UpdateAppealStatus", ctx, "3", openAppealStatuses, models.AppealStatusWithdrawn, "101", (*float64)(nil)).Return(nil).Once()
+	repo.On("GetAppealByID", ctx, "3").Return(openAppeal(models.AppealStatusWithdrawn), nil).Once()
+
+	// Act
+	appeal, err := svc.WithdrawAppeal(ctx, "3", "101")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AppealStatusWithdrawn, appeal.Status)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,386 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type AssignmentService interface {
+	GetOfferingAssignments(ctx context.Context, termID, offeringID string) ([]models.Assignment, error)
+	GetAssignment(ctx context.Context, id string) (*models.Assignment, error)
+	CreateAssignment(ctx context.Context, termID, offeringID, userID string, asTeacher bool, assignment *models.Assignment) (*models.Assignment, error)
+	UpdateAssignment(ctx context.Context, userID string, asTeacher bool, assignment models.Assignment) (*models.Assignment, error)
+	DeleteAssignment(ctx context.Context, id, userID string, asTeacher bool) error
+	Submit(ctx context.Context, assignmentID, studentID string, upload models.SubmissionUpload) (*models.Submission, error)
+	GetSubmissions(ctx context.Context, assignmentID, userID string, asTeacher bool) ([]models.Submission, error)
+	GetSubmission(ctx context.Context, id, userID, role string) (*models.Submission, error)
+	GetSubmissionFile(ctx context.Context, id, userID, role string) (*models.SubmissionFile, error)
+	GetStudentSubmissions(ctx context.Context, studentID string) ([]models.Submission, error)
+	GradeSubmission(ctx context.Context, id, userID string, asTeacher bool, grade models.SubmissionGrade) (*models.Submission, error)
+	ApplyMarks(ctx context.Context, termID, offeringID, userID string, asTeacher bool, markType string) ([]models.AssignmentMark, error)
+}
+
+type assignmentService struct {
+	repo    repository.AssignmentRepository
+	storage repository.BlobStorage
+	terms   repository.TermRepository
+	grades  repository.GradeRepository
+	schemes repository.GradingSchemeRepository
+	now     func() time.Time
+}
+
+func NewAssignmentService(repo repository.AssignmentRepository, storage repository.BlobStorage, terms repository.TermRepository,
+	grades repository.GradeRepository, schemes repository.GradingSchemeRepository) AssignmentService {
+	return &assignmentService{repo: repo, storage: storage, terms: terms, grades: grades, schemes: schemes, now: time.Now}
+}
+
+func (s *assignmentService) GetOfferingAssignments(ctx context.Context, termID, offeringID string) ([]models.Assignment, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOfferingAssignments(ctx, offeringID)
+}
+
+func (s *assignmentService) GetAssignment(ctx context.Context, id string) (*models.Assignment, error) {
+	return s.repo.GetAssignmentByID(ctx, id)
+}
+
+// CreateAssignment добавляет задание курсу в периоде. Если asTeacher, пользователь должен вести этот курс.
+func (s *assignmentService) CreateAssignment(ctx context.Context, termID, offeringID, userID string, asTeacher bool, assignment *models.Assignment) (*models.Assignment, error) {
+	offering, err := s.terms.GetOfferingByID(ctx, termID, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTeacher(ctx, userID, offering.CourseID, asTeacher); err != nil {
+		return nil, err
+	}
+	if err := normalizeLatePolicy(assignment); err != nil {
+		return nil, err
+	}
+	assignment.OfferingID = offering.ID
+	assignment.CreatedBy = &userID
+	return s.repo.CreateAssignment(ctx, assignment)
+}
+
+func (s *assignmentService) UpdateAssignment(ctx context.Context, userID string, asTeacher bool, assignment models.Assignment) (*models.Assignment, error) {
+	if _, err := s.teacherAssignment(ctx, assignment.ID, userID, asTeacher); err != nil {
+		return nil, err
+	}
+	if err := normalizeLatePolicy(&assignment); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateAssignment(ctx, assignment)
+}
+
+// DeleteAssignment удаляет задание вместе с работами и их файлами
+func (s *assignmentService) DeleteAssignment(ctx context.Context, id, userID string, asTeacher bool) error {
+	if _, err := s.teacherAssignment(ctx, id, userID, asTeacher); err != nil {
+		return err
+	}
+	submissions, err := s.repo.GetSubmissions(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAssignment(ctx, id); err != nil {
+		return err
+	}
+	// Записи о работах уже удалены, поэтому оставшийся файл никому не виден и не мешает работе
+	for _, submission := range submissions {
+		_ = s.storage.Delete(ctx, submission.StorageKey)
+	}
+	return nil
+}
+
+// Submit принимает работу студента, записанного на курс. После срока работа принимается
+// по правилу задания; оценённую работу заменить нельзя.
+func (s *assignmentService) Submit(ctx context.Context, assignmentID, studentID string, upload models.SubmissionUpload) (*models.Submission, error) {
+	if upload.Size > models.MaxSubmissionSize {
+		return nil, models.ErrSubmissionTooLarge
+	}
+	assignment, err := s.repo.GetAssignmentByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	enrolled, err := s.repo.IsEnrolled(ctx, assignment.OfferingID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !enrolled {
+		return nil, models.ErrStudentNotEnrolled
+	}
+	previous, err := s.repo.GetSubmission(ctx, assignmentID, studentID)
+	if err != nil && !errors.Is(err, models.ErrSubmissionNotFound) {
+		return nil, err
+	}
+	if previous != nil && previous.Score != nil {
+		return nil, models.ErrSubmissionGraded
+	}
+
+	now := s.now()
+	daysLate := lateDays(assignment.DueAt, now)
+	if daysLate > 0 && assignment.LatePolicy == models.LatePolicyReject {
+		return nil, models.ErrSubmissionClosed
+	}
+
+	key, err := newStorageKey(assignmentID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, key, upload.Data); err != nil {
+		return nil, err
+	}
+	submission, err := s.repo.SaveSubmission(ctx, &models.Submission{
+		AssignmentID: assignmentID,
+		StudentID:    studentID,
+		FileName:     path.Base(upload.FileName),
+		ContentType:  upload.ContentType,
+		Size:         upload.Size,
+		StorageKey:   key,
+		Comment:      upload.Comment,
+		SubmittedAt:  now,
+		DaysLate:     daysLate,
+	})
+	if err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return nil, err
+	}
+	if previous != nil {
+		_ = s.storage.Delete(ctx, previous.StorageKey)
+	}
+	return submission, nil
+}
+
+// GetSubmissions возвращает работы по заданию; преподаватель видит только работы по своим курсам
+func (s *assignmentService) GetSubmissions(ctx context.Context, assignmentID, userID string, asTeacher bool) ([]models.Submission, error) {
+	if _, err := s.teacherAssignment(ctx, assignmentID, userID, asTeacher); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSubmissions(ctx, assignmentID)
+}
+
+func (s *assignmentService) GetSubmission(ctx context.Context, id, userID, role string) (*models.Submission, error) {
+	return s.accessibleSubmission(ctx, id, userID, role)
+}
+
+func (s *assignmentService) GetSubmissionFile(ctx context.Context, id, userID, role string) (*models.SubmissionFile, error) {
+	submission, err := s.accessibleSubmission(ctx, id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.storage.Get(ctx, submission.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SubmissionFile{Name: submission.FileName, ContentType: submission.ContentType, Size: submission.Size, Data: data}, nil
+}
+
+func (s *assignmentService) GetStudentSubmissions(ctx context.Context, studentID string) ([]models.Submission, error) {
+	return s.repo.GetStudentSubmissions(ctx, studentID)
+}
+
+// GradeSubmission ставит балл за работу. Балл не больше максимального за задание; при правиле
+// penalty итоговый балл уменьшается на штраф за каждый день опоздания, но не ниже нуля.
+// Работу можно переоценить.
+func (s *assignmentService) GradeSubmission(ctx context.Context, id, userID string, asTeacher bool, grade models.SubmissionGrade) (*models.Submission, error) {
+	submission, err := s.repo.GetSubmissionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	assignment, err := s.teacherAssignment(ctx, submission.AssignmentID, userID, asTeacher)
+	if err != nil {
+		return nil, err
+	}
+	score := *grade.Score
+	if score < 0 || score > assignment.MaxPoints {
+		return nil, models.ErrScoreOutOfRange
+	}
+	finalScore := score
+	if assignment.LatePolicy == models.LatePolicyPenalty && submission.DaysLate > 0 {
+		factor := math.Max(0, 1-assignment.LatePenaltyPercent*float64(submission.DaysLate)/100)
+		finalScore = math.Round(score*factor*100) / 100
+	}
+	if err := s.repo.GradeSubmission(ctx, id, score, finalScore, grade.Feedback, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSubmissionByID(ctx, id)
+}
+
+// ApplyMarks переносит баллы за задания, привязанные к аттестации, в оценки студентов курса.
+// Оценка — доля набранных итоговых баллов от суммы максимальных, умноженная на максимум
+// аттестации по схеме оценивания; несданная или неоценённая работа даёт ноль. Оценки пишутся
+// через журнал оценок, поэтому в отправленную или закрытую ведомость перенести их нельзя.
+func (s *assignmentService) ApplyMarks(ctx context.Context, termID, offeringID, userID string, asTeacher bool, markType string) ([]models.AssignmentMark, error) {
+	offering, err := s.terms.GetOfferingByID(ctx, termID, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTeacher(ctx, userID, offering.CourseID, asTeacher); err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.GetOfferingAssignments(ctx, offering.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var maxPoints float64
+	points := map[string]float64{}
+	for _, assignment := range assignments {
+		if assignment.MarkType == nil || *assignment.MarkType != markType {
+			continue
+		}
+		maxPoints += assignment.MaxPoints
+		submissions, err := s.repo.GetSubmissions(ctx, assignment.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, submission := range submissions {
+			if submission.FinalScore != nil {
+				points[submission.StudentID] += *submission.FinalScore
+			}
+		}
+	}
+	if maxPoints == 0 {
+		return nil, models.ErrNoLinkedAssignments
+	}
+
+	scheme, err := offeringSchemeOrDefault(ctx, s.schemes, offering.ID)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := scheme.ComponentMax(markType)
+	if err != nil {
+		return nil, err
+	}
+	students, err := s.repo.GetOfferingStudents(ctx, offering.ID)
+	if err != nil {
+		return nil, err
+	}
+	courseID, err := strconv.ParseUint(offering.CourseID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	offeringUID, err := strconv.ParseUint(offering.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	marks := make([]models.AssignmentMark, 0, len(students))
+	written := make([]models.Mark, 0, len(students))
+	for _, studentID := range students {
+		sid, err := strconv.ParseUint(studentID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		value := math.Round(points[studentID]/maxPoints*limit*100) / 100
+		mark := models.Mark{StudentID: uint(sid), CourseID: uint(courseID), OfferingID: uint(offeringUID)}
+		switch markType {
+		case models.MarkTypeFirstAttestation:
+			mark.FirstAttestation = value
+		case models.MarkTypeSecondAttestation:
+			mark.SecondAttestation = value
+		}
+		written = append(written, mark)
+		marks = append(marks, models.AssignmentMark{StudentID: studentID, Points: points[studentID], MaxPoints: maxPoints, Value: value})
+	}
+	// Все оценки записываются одной транзакцией: при закрытой ведомости не меняется ни одна
+	if err := s.grades.AddMarks(ctx, offering.ID, written, markType, userID); err != nil {
+		return nil, err
+	}
+	return marks, nil
+}
+
+// accessibleSubmission загружает работу и проверяет доступ: менеджер и администратор видят все,
+// преподаватель — работы по своим курсам, студент — только свои
+func (s *assignmentService) accessibleSubmission(ctx context.Context, id, userID, role string) (*models.Submission, error) {
+	submission, err := s.repo.GetSubmissionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch role {
+	case "admin", "manager":
+		return submission, nil
+	case "teacher":
+		assignment, err := s.repo.GetAssignmentByID(ctx, submission.AssignmentID)
+		if err != nil {
+			return nil, err
+		}
+		isTeacher, err := s.grades.IsTeacherOfCourse(ctx, userID, assignment.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		if isTeacher {
+			return submission, nil
+		}
+	case "student":
+		if submission.StudentID == userID {
+			return submission, nil
+		}
+	}
+	return nil, models.ErrSubmissionForbidden
+}
+
+// teacherAssignment загружает задание и, если asTeacher, проверяет, что пользователь ведёт курс
+func (s *assignmentService) teacherAssignment(ctx context.Context, id, userID string, asTeacher bool) (*models.Assignment, error) {
+	assignment, err := s.repo.GetAssignmentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTeacher(ctx, userID, assignment.CourseID, asTeacher); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (s *assignmentService) checkTeacher(ctx context.Context, userID, courseID string, asTeacher bool) error {
+	if !asTeacher {
+		return nil
+	}
+	isTeacher, err := s.grades.IsTeacherOfCourse(ctx, userID, courseID)
+	if err != nil {
+		return err
+	}
+	if !isTeacher {
+		return models.ErrNotCourseTeacher
+	}
+	return nil
+}
+
+// normalizeLatePolicy подставляет правило accept по умолчанию; штраф имеет смысл только для penalty
+func normalizeLatePolicy(assignment *models.Assignment) error {
+	if assignment.LatePolicy == "" {
+		assignment.LatePolicy = models.LatePolicyAccept
+	}
+	if assignment.LatePolicy != models.LatePolicyPenalty {
+		assignment.LatePenaltyPercent = 0
+	} else if assignment.LatePenaltyPercent <= 0 {
+		return fmt.Errorf("%w: late_penalty_percent is required for the penalty policy", models.ErrInvalidAssignment)
+	}
+	return nil
+}
+
+// lateDays возвращает число начатых суток после срока: сдача через час после срока — один день опоздания
+func lateDays(dueAt, submittedAt time.Time) int {
+	if !submittedAt.After(dueAt) {
+		return 0
+	}
+	return int(math.Ceil(submittedAt.Sub(dueAt).Hours() / 24))
+}
+
+// newStorageKey возвращает новый ключ файла работы; при замене файла ключ меняется,
+// чтобы прежний файл оставался доступен, пока новая запись не сохранена
+func newStorageKey(assignmentID, studentID string) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return path.Join("assignments", assignmentID, studentID, hex.EncodeToString(raw)), nil
+}
@@ -0,0 +1,520 @@
+package services
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockAssignmentRepo struct {
+	mock.Mock
+}
+
+func (m *mockAssignmentRepo) GetOfferingAssignments(ctx context.Context, offeringID string) ([]models.Assignment, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) GetAssignmentByID(ctx context.Context, id string) (*models.Assignment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) CreateAssignment(ctx context.Context, assignment *models.Assignment) (*models.Assignment, error) {
+	args := m.Called(ctx, assignment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) UpdateAssignment(ctx context.Context, assignment models.Assignment) (*models.Assignment, error) {
+	args := m.Called(ctx, assignment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Assignment), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) DeleteAssignment(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockAssignmentRepo) GetOfferingStudents(ctx context.Context, offeringID string) ([]string, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) IsEnrolled(ctx context.Context, offeringID, studentID string) (bool, error) {
+	args := m.Called(ctx, offeringID, studentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) GetSubmissions(ctx context.Context, assignmentID string) ([]models.Submission, error) {
+	args := m.Called(ctx, assignmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Submission), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) GetSubmission(ctx context.Context, assignmentID, studentID string) (*models.Submission, error) {
+	args := m.Called(ctx, assignmentID, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Submission), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) GetSubmissionByID(ctx context.Context, id string) (*models.Submission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Submission), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) GetStudentSubmissions(ctx context.Context, studentID string) ([]models.Submission, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Submission), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) SaveSubmission(ctx context.Context, submission *models.Submission) (*models.Submission, error) {
+	args := m.Called(ctx, submission)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Submission), args.Error(1)
+}
+
+func (m *mockAssignmentRepo) GradeSubmission(ctx context.Context, id string, score, finalScore float64, feedback, gradedBy string) error {
+	args := m.Called(ctx, id, score, finalScore, feedback, gradedBy)
+	return args.Error(0)
+}
+
+type mockBlobStorage struct {
+	mock.Mock
+}
+
+func (m *mockBlobStorage) Put(ctx context.Context, key string, data io.Reader) error {
+	args := m.Called(ctx, key, data)
+	return args.Error(0)
+}
+
+func (m *mockBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockBlobStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func newTestAssignmentService(repo *mockAssignmentRepo, storage *mockBlobStorage, terms *mockTermRepo, grades *mockGradeRepo,
+	schemes *mockGradingSchemeRepo, now time.Time) AssignmentService {
+	svc := NewAssignmentService(repo, storage, terms, grades, schemes)
+	svc.(*assignmentService).now = func() time.Time { return now }
+	return svc
+}
+
+func TestAssignmentService_CreateAssignment(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	offering := &models.CourseOffering{ID: "7", CourseID: "201", TermID: "1"}
+
+	t.Run("Defaults To Accepting Late Work", func(t *testing.T) {
+		repo, terms, grades := new(mockAssignmentRepo), new(mockTermRepo), new(mockGradeRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), terms, grades, new(mockGradingSchemeRepo))
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("CreateAssignment", ctx, mock.MatchedBy(func(a *models.Assignment) bool {
+			return a.OfferingID == "7" && a.LatePolicy == models.LatePolicyAccept && a.LatePenaltyPercent == 0 && *a.CreatedBy == "5"
+		})).Return(&models.Assignment{ID: "1"}, nil).Once()
+		assignment := &models.Assignment{Title: "Essay", MaxPoints: 10, LatePenaltyPercent: 20}
+
+		// Act
+		created, err := svc.CreateAssignment(ctx, "1", "7", "5", true, assignment)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1", created.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Penalty Requires Percent", func(t *testing.T) {
+		repo, terms := new(mockAssignmentRepo), new(mockTermRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), terms, new(mockGradeRepo), new(mockGradingSchemeRepo))
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		assignment := &models.Assignment{Title: "Essay", MaxPoints: 10, LatePolicy: models.LatePolicyPenalty}
+
+		// Act
+		_, err := svc.CreateAssignment(ctx, "1", "7", "1", false, assignment)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidAssignment)
+		repo.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Teacher Of Another Course", func(t *testing.T) {
+		repo, terms, grades := new(mockAssignmentRepo), new(mockTermRepo), new(mockGradeRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), terms, grades, new(mockGradingSchemeRepo))
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "6", "201").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.CreateAssignment(ctx, "1", "7", "6", true, &models.Assignment{Title: "Essay", MaxPoints: 10})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotCourseTeacher)
+		repo.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAssignmentService_Submit(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	due := time.Date(2025, 10, 1, 23, 59, 0, 0, time.UTC)
+	upload := models.SubmissionUpload{FileName: "essay.pdf", ContentType: "application/pdf", Size: 1024, Data: strings.NewReader("pdf")}
+	assignment := func(policy string) *models.Assignment {
+		return &models.Assignment{ID: "3", OfferingID: "7", CourseID: "201", DueAt: due, MaxPoints: 10, LatePolicy: policy}
+	}
+
+	t.Run("On Time", func(t *testing.T) {
+		repo, storage := new(mockAssignmentRepo), new(mockBlobStorage)
+		svc := newTestAssignmentService(repo, storage, new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due.Add(-time.Hour))
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment(models.LatePolicyReject), nil).Once()
+		repo.On("IsEnrolled", ctx, "7", "101").Return(true, nil).Once()
+		repo.On("GetSubmission", ctx, "3", "101").Return(nil, models.ErrSubmissionNotFound).Once()
+		var key string
+		storage.On("Put", ctx, mock.Anything, upload.Data).Run(func(args mock.Arguments) {
+			key = args.String(1)
+		}).Return(nil).Once()
+		repo.On("SaveSubmission", ctx, mock.MatchedBy(func(s *models.Submission) bool {
+			return s.StorageKey == key && s.DaysLate == 0 && s.FileName == "essay.pdf" && s.Size == 1024
+		})).Return(&models.Submission{ID: "9"}, nil).Once()
+
+		// Act
+		submission, err := svc.Submit(ctx, "3", "101", upload)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "9", submission.ID)
+		assert.True(t, strings.HasPrefix(key, "assignments/3/101/"))
+		repo.AssertExpectations(t)
+		storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Late Counts Started Days", func(t *testing.T) {
+		repo, storage := new(mockAssignmentRepo), new(mockBlobStorage)
+		svc := newTestAssignmentService(repo, storage, new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due.Add(25*time.Hour))
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment(models.LatePolicyPenalty), nil).Once()
+		repo.On("IsEnrolled", ctx, "7", "101").Return(true, nil).Once()
+		repo.On("GetSubmission", ctx, "3", "101").Return(nil, models.ErrSubmissionNotFound).Once()
+		storage.On("Put", ctx, mock.Anything, upload.Data).Return(nil).Once()
+		repo.On("SaveSubmission", ctx, mock.MatchedBy(func(s *models.Submission) bool {
+			return s.DaysLate == 2
+		})).Return(&models.Submission{ID: "9", DaysLate: 2}, nil).Once()
+
+		// Act
+		_, err := svc.Submit(ctx, "3", "101", upload)
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Late Rejected", func(t *testing.T) {
+		repo, storage := new(mockAssignmentRepo), new(mockBlobStorage)
+		svc := newTestAssignmentService(repo, storage, new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due.Add(time.Minute))
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment(models.LatePolicyReject), nil).Once()
+		repo.On("IsEnrolled", ctx, "7", "101").Return(true, nil).Once()
+		repo.On("GetSubmission", ctx, "3", "101").Return(nil, models.ErrSubmissionNotFound).Once()
+
+		// Act
+		_, err := svc.Submit(ctx, "3", "101", upload)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubmissionClosed)
+		storage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Resubmission Replaces File", func(t *testing.T) {
+		repo, storage := new(mockAssignmentRepo), new(mockBlobStorage)
+		svc := newTestAssignmentService(repo, storage, new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due.Add(-time.Hour))
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment(models.LatePolicyAccept), nil).Once()
+		repo.On("IsEnrolled", ctx, "7", "101").Return(true, nil).Once()
+		repo.On("GetSubmission", ctx, "3", "101").Return(&models.Submission{ID: "9", StorageKey: "assignments/3/101/old"}, nil).Once()
+		storage.On("Put", ctx, mock.Anything, upload.Data).Return(nil).Once()
+		repo.On("SaveSubmission", ctx, mock.Anything).Return(&models.Submission{ID: "9"}, nil).Once()
+		storage.On("Delete", ctx, "assignments/3/101/old").Return(nil).Once()
+
+		// Act
+		_, err := svc.Submit(ctx, "3", "101", upload)
+
+		// Assert
+		assert.NoError(t, err)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("Graded Submission Cannot Be Replaced", func(t *testing.T) {
+		repo, storage := new(mockAssignmentRepo), new(mockBlobStorage)
+		svc := newTestAssignmentService(repo, storage, new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due.Add(-time.Hour))
+		score := 8.0
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment(models.LatePolicyAccept), nil).Once()
+		repo.On("IsEnrolled", ctx, "7", "101").Return(true, nil).Once()
+		repo.On("GetSubmission", ctx, "3", "101").Return(&models.Submission{ID: "9", Score: &score}, nil).Once()
+
+		// Act
+		_, err := svc.Submit(ctx, "3", "101", upload)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubmissionGraded)
+		storage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Save Removes Uploaded File", func(t *testing.T) {
+		repo, storage := new(mockAssignmentRepo), new(mockBlobStorage)
+		svc := newTestAssignmentService(repo, storage, new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due.Add(-time.Hour))
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment(models.LatePolicyAccept), nil).Once()
+		repo.On("IsEnrolled", ctx, "7", "101").Return(true, nil).Once()
+		repo.On("GetSubmission", ctx, "3", "101").Return(nil, models.ErrSubmissionNotFound).Once()
+		var key string
+		storage.On("Put", ctx, mock.Anything, upload.Data).Run(func(args mock.Arguments) {
+			key = args.String(1)
+		}).Return(nil).Once()
+		repo.On("SaveSubmission", ctx, mock.Anything).Return(nil, models.ErrSubmissionGraded).Once()
+		storage.On("Delete", ctx, mock.Anything).Return(nil).Once()
+
+		// Act
+		_, err := svc.Submit(ctx, "3", "101", upload)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubmissionGraded)
+		storage.AssertCalled(t, "Delete", ctx, key)
+	})
+
+	t.Run("Student Not Enrolled", func(t *testing.T) {
+		repo := new(mockAssignmentRepo)
+		svc := newTestAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due)
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment(models.LatePolicyAccept), nil).Once()
+		repo.On("IsEnrolled", ctx, "7", "102").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.Submit(ctx, "3", "102", upload)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrStudentNotEnrolled)
+	})
+
+	t.Run("File Too Large", func(t *testing.T) {
+		repo := new(mockAssignmentRepo)
+		svc := newTestAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo), due)
+		big := upload
+		big.Size = models.MaxSubmissionSize + 1
+
+		// Act
+		_, err := svc.Submit(ctx, "3", "101", big)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubmissionTooLarge)
+		repo.AssertNotCalled(t, "GetAssignmentByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAssignmentService_GradeSubmission(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	assignment := &models.Assignment{ID: "3", CourseID: "201", MaxPoints: 10, LatePolicy: models.LatePolicyPenalty, LatePenaltyPercent: 20}
+	score := func(v float64) *float64 { return &v }
+
+	t.Run("Applies Late Penalty", func(t *testing.T) {
+		repo, grades := new(mockAssignmentRepo), new(mockGradeRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), grades, new(mockGradingSchemeRepo))
+		repo.On("GetSubmissionByID", ctx, "9").Return(&models.Submission{ID: "9", AssignmentID: "3", DaysLate: 2}, nil).Twice()
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("GradeSubmission", ctx, "9", 9.0, 5.4, "Good", "5").Return(nil).Once()
+
+		// Act
+		_, err := svc.GradeSubmission(ctx, "9", "5", true, models.SubmissionGrade{Score: score(9), Feedback: "Good"})
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Penalty Does Not Go Below Zero", func(t *testing.T) {
+		repo := new(mockAssignmentRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetSubmissionByID", ctx, "9").Return(&models.Submission{ID: "9", AssignmentID: "3", DaysLate: 7}, nil).Twice()
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment, nil).Once()
+		repo.On("GradeSubmission", ctx, "9", 10.0, 0.0, "", "1").Return(nil).Once()
+
+		// Act
+		_, err := svc.GradeSubmission(ctx, "9", "1", false, models.SubmissionGrade{Score: score(10)})
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Score Above Max Points", func(t *testing.T) {
+		repo := new(mockAssignmentRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetSubmissionByID", ctx, "9").Return(&models.Submission{ID: "9", AssignmentID: "3"}, nil).Once()
+		repo.On("GetAssignmentByID", ctx, "3").Return(assignment, nil).Once()
+
+		// Act
+		_, err := svc.GradeSubmission(ctx, "9", "1", false, models.SubmissionGrade{Score: score(11)})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrScoreOutOfRange)
+		repo.AssertNotCalled(t, "GradeSubmission", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAssignmentService_GetSubmission(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	submission := &models.Submission{ID: "9", AssignmentID: "3", StudentID: "101"}
+
+	t.Run("Author", func(t *testing.T) {
+		repo := new(mockAssignmentRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetSubmissionByID", ctx, "9").Return(submission, nil).Once()
+
+		// Act
+		result, err := svc.GetSubmission(ctx, "9", "101", "student")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "9", result.ID)
+	})
+
+	t.Run("Another Student", func(t *testing.T) {
+		repo := new(mockAssignmentRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo))
+		repo.On("GetSubmissionByID", ctx, "9").Return(submission, nil).Once()
+
+		// Act
+		_, err := svc.GetSubmission(ctx, "9", "102", "student")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubmissionForbidden)
+	})
+
+	t.Run("Teacher Of Another Course", func(t *testing.T) {
+		repo, grades := new(mockAssignmentRepo), new(mockGradeRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), new(mockTermRepo), grades, new(mockGradingSchemeRepo))
+		repo.On("GetSubmissionByID", ctx, "9").Return(submission, nil).Once()
+		repo.On("GetAssignmentByID", ctx, "3").Return(&models.Assignment{ID: "3", CourseID: "201"}, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "6", "201").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.GetSubmission(ctx, "9", "6", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubmissionForbidden)
+	})
+}
+
+func TestAssignmentService_ApplyMarks(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	offering := &models.CourseOffering{ID: "7", CourseID: "201", TermID: "1"}
+	first, second := models.MarkTypeFirstAttestation, models.MarkTypeSecondAttestation
+	assignments := []models.Assignment{
+		{ID: "3", MaxPoints: 10, MarkType: &first},
+		{ID: "4", MaxPoints: 30, MarkType: &first},
+		{ID: "5", MaxPoints: 50, MarkType: &second},
+		{ID: "6", MaxPoints: 5},
+	}
+	points := func(v float64) *float64 { return &v }
+
+	t.Run("Scales Points To Scheme Max", func(t *testing.T) {
+		repo, terms, grades, schemes := new(mockAssignmentRepo), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), terms, grades, schemes)
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("GetOfferingAssignments", ctx, "7").Return(assignments, nil).Once()
+		repo.On("GetSubmissions", ctx, "3").Return([]models.Submission{
+			{StudentID: "101", FinalScore: points(10)},
+			{StudentID: "102", FinalScore: points(5)},
+		}, nil).Once()
+		repo.On("GetSubmissions", ctx, "4").Return([]models.Submission{
+			{StudentID: "101", FinalScore: points(20)},
+			{StudentID: "102"},
+		}, nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		repo.On("GetOfferingStudents", ctx, "7").Return([]string{"101", "102", "103"}, nil).Once()
+		written := map[uint]float64{}
+		grades.On("AddMarks", ctx, "7", mock.Anything, first, "5").Run(func(args mock.Arguments) {
+			for _, mark := range args.Get(2).([]models.Mark) {
+				written[mark.StudentID] = mark.FirstAttestation
+			}
+		}).Return(nil).Once()
+
+		// Act
+		marks, err := svc.ApplyMarks(ctx, "1", "7", "5", false, first)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, marks, 3)
+		assert.Equal(t, 40.0, marks[0].MaxPoints)
+		assert.Equal(t, map[uint]float64{101: 22.5, 102: 3.75, 103: 0}, written)
+		repo.AssertNotCalled(t, "GetSubmissions", ctx, "5")
+	})
+
+	t.Run("No Linked Assignments", func(t *testing.T) {
+		repo, terms, grades := new(mockAssignmentRepo), new(mockTermRepo), new(mockGradeRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), terms, grades, new(mockGradingSchemeRepo))
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("GetOfferingAssignments", ctx, "7").Return([]models.Assignment{{ID: "6", MaxPoints: 5}}, nil).Once()
+
+		// Act
+		_, err := svc.ApplyMarks(ctx, "1", "7", "5", false, second)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNoLinkedAssignments)
+		grades.AssertNotCalled(t, "AddMarks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Locked Grade Sheet", func(t *testing.T) {
+		repo, terms, grades, schemes := new(mockAssignmentRepo), new(mockTermRepo), new(mockGradeRepo), new(mockGradingSchemeRepo)
+		svc := NewAssignmentService(repo, new(mockBlobStorage), terms, grades, schemes)
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("GetOfferingAssignments", ctx, "7").Return(assignments, nil).Once()
+		repo.On("GetSubmissions", ctx, "5").Return([]models.Submission{}, nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		repo.On("GetOfferingStudents", ctx, "7").Return([]string{"101"}, nil).Once()
+		grades.On("AddMarks", ctx, "7", mock.Anything, second, "5").Return(models.ErrGradesLocked).Once()
+
+		// Act
+		_, err := svc.ApplyMarks(ctx, "1", "7", "5", false, second)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrGradesLocked)
+	})
+}
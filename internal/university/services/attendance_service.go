@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type AttendanceService interface {
+	GenerateSessions(ctx context.Context, termID, offeringID, userID string, asTeacher bool, schedule models.SessionSchedule) ([]models.ClassSession, error)
+	GetOfferingSessions(ctx context.Context, termID, offeringID string) ([]models.ClassSession, error)
+	DeleteSession(ctx context.Context, id, userID string, asTeacher bool) error
+	GetSessionAttendance(ctx context.Context, sessionID, userID string, asTeacher bool) ([]models.AttendanceRecord, error)
+	MarkAttendance(ctx context.Context, sessionID, userID string, asTeacher bool, bulk models.BulkAttendance) ([]models.AttendanceRecord, error)
+	GetStudentAttendance(ctx context.Context, studentID string) ([]models.AttendanceSummary, error)
+	GetAttendanceFlags(ctx context.Context, termID string) ([]models.AttendanceSummary, error)
+	GetSettings(ctx context.Context) (*models.AttendanceSettings, error)
+	UpdateSettings(ctx context.Context, settings models.AttendanceSettings) (*models.AttendanceSettings, error)
+}
+
+type attendanceService struct {
+	repo     repository.AttendanceRepository
+	terms    repository.TermRepository
+	sections repository.SectionRepository
+	grades   repository.GradeRepository
+}
+
+func NewAttendanceService(repo repository.AttendanceRepository, terms repository.TermRepository,
+	sections repository.SectionRepository, grades repository.GradeRepository) AttendanceService {
+	return &attendanceService{repo: repo, terms: terms, sections: sections, grades: grades}
+}
+
+// GenerateSessions создаёт занятия по еженедельному расписанию в пределах периода.
+// Если asTeacher, пользователь должен вести этот курс.
+func (s *attendanceService) GenerateSessions(ctx context.Context, termID, offeringID, userID string, asTeacher bool, schedule models.SessionSchedule) ([]models.ClassSession, error) {
+	term, err := s.terms.GetTermByID(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	offering, err := s.terms.GetOfferingByID(ctx, termID, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTeacher(ctx, userID, offering.CourseID, asTeacher); err != nil {
+		return nil, err
+	}
+	if schedule.SectionID != nil {
+		section, err := s.sections.GetSectionByID(ctx, *schedule.SectionID)
+		if err != nil {
+			return nil, err
+		}
+		if section.OfferingID != offering.ID {
+			return nil, models.ErrSectionNotInOffering
+		}
+	}
+
+	sessions, err := scheduleSessions(term, offering, schedule)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.CreateSessions(ctx, sessions)
+}
+
+// scheduleSessions раскладывает расписание по дням периода; From и To сужают период, но не выходят за него
+func scheduleSessions(term *models.AcademicTerm, offering *models.CourseOffering, schedule models.SessionSchedule) ([]models.ClassSession, error) {
+	start, err := time.Parse("15:04", schedule.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: start_time must be HH:MM", models.ErrInvalidSchedule)
+	}
+	from, to := term.StartDate, term.EndDate
+	if schedule.From != nil && schedule.From.After(from) {
+		from = *schedule.From
+	}
+	if schedule.To != nil && schedule.To.Before(to) {
+		to = *schedule.To
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: the range is outside the term", models.ErrInvalidSchedule)
+	}
+
+	weekdays := map[time.Weekday]bool{}
+	for _, day := range schedule.Weekdays {
+		weekdays[time.Weekday(day)] = true
+	}
+	duration := time.Duration(schedule.DurationMinutes) * time.Minute
+	sessions := []models.ClassSession{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !weekdays[day.Weekday()] {
+			continue
+		}
+		startsAt := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, day.Location())
+		sessions = append(sessions, models.ClassSession{
+			OfferingID: offering.ID,
+			CourseID:   offering.CourseID,
+			SectionID:  schedule.SectionID,
+			StartsAt:   startsAt,
+			EndsAt:     startsAt.Add(duration),
+		})
+	}
+	return sessions, nil
+}
+
+func (s *attendanceService) GetOfferingSessions(ctx context.Context, termID, offeringID string) ([]models.ClassSession, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOfferingSessions(ctx, offeringID)
+}
+
+func (s *attendanceService) DeleteSession(ctx context.Context, id, userID string, asTeacher bool) error {
+	if _, err := s.teacherSession(ctx, id, userID, asTeacher); err != nil {
+		return err
+	}
+	return s.repo.DeleteSession(ctx, id)
+}
+
+// GetSessionAttendance возвращает список студентов занятия с отметками
+func (s *attendanceService) GetSessionAttendance(ctx context.Context, sessionID, userID string, asTeacher bool) ([]models.AttendanceRecord, error) {
+	if _, err := s.teacherSession(ctx, sessionID, userID, asTeacher); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSessionRoster(ctx, sessionID)
+}
+
+// MarkAttendance сохраняет отметки занятия: перечисленным студентам — их отметки, остальным студентам
+// занятия — отметку по умолчанию, если она задана. Отмечать можно только студентов занятия.
+func (s *attendanceService) MarkAttendance(ctx context.Context, sessionID, userID string, asTeacher bool, bulk models.BulkAttendance) ([]models.AttendanceRecord, error) {
+	if _, err := s.teacherSession(ctx, sessionID, userID, asTeacher); err != nil {
+		return nil, err
+	}
+	roster, err := s.repo.GetSessionRoster(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	inSession := make(map[string]bool, len(roster))
+	for _, record := range roster {
+		inSession[record.StudentID] = true
+	}
+
+	markedBy := &userID
+	marked := map[string]bool{}
+	records := make([]models.AttendanceRecord, 0, len(roster))
+	for _, mark := range bulk.Records {
+		if !inSession[mark.StudentID] {
+			return nil, fmt.Errorf("%w: student %s", models.ErrNotInSession, mark.StudentID)
+		}
+		marked[mark.StudentID] = true
+		records = append(records, models.AttendanceRecord{
+			SessionID: sessionID, StudentID: mark.StudentID, Status: mark.Status, Note: mark.Note, MarkedBy: markedBy,
+		})
+	}
+	if bulk.Default != "" {
+		for _, record := range roster {
+			if !marked[record.StudentID] {
+				records = append(records, models.AttendanceRecord{
+					SessionID: sessionID, StudentID: record.StudentID, Status: bulk.Default, MarkedBy: markedBy,
+				})
+			}
+		}
+	}
+
+	if err := s.repo.MarkAttendance(ctx, records); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSessionRoster(ctx, sessionID)
+}
+
+// GetStudentAttendance возвращает процент посещаемости студента по каждому курсу
+func (s *attendanceService) GetStudentAttendance(ctx context.Context, studentID string) ([]models.AttendanceSummary, error) {
+	summaries, err := s.repo.GetStudentAttendance(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range summaries {
+		applyAttendanceRule(&summaries[i], settings)
+	}
+	return summaries, nil
+}
+
+// GetAttendanceFlags возвращает студентов периода, чья посещаемость курса ниже порога правила
+func (s *attendanceService) GetAttendanceFlags(ctx context.Context, termID string) ([]models.AttendanceSummary, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	summaries, err := s.repo.GetTermAttendance(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flagged := []models.AttendanceSummary{}
+	for _, summary := range summaries {
+		applyAttendanceRule(&summary, settings)
+		if summary.BelowThreshold {
+			flagged = append(flagged, summary)
+		}
+	}
+	return flagged, nil
+}
+
+func (s *attendanceService) GetSettings(ctx context.Context) (*models.AttendanceSettings, error) {
+	return s.repo.GetSettings(ctx)
+}
+
+func (s *attendanceService) UpdateSettings(ctx context.Context, settings models.AttendanceSettings) (*models.AttendanceSettings, error) {
+	return s.repo.UpdateSettings(ctx, settings)
+}
+
+// applyAttendanceRule считает процент посещаемости (опоздание — присутствие, уважительные пропуски
+// не учитываются) и отмечает студента, если после MinSessions учтённых занятий процент ниже порога
+func applyAttendanceRule(summary *models.AttendanceSummary, settings *models.AttendanceSettings) {
+	attended := summary.Present + summary.Late
+	counted := attended + summary.Absent
+	if counted == 0 {
+		summary.Percentage = 100
+		summary.BelowThreshold = false
+		return
+	}
+	summary.Percentage = math.Round(float64(attended)/float64(counted)*10000) / 100
+	summary.BelowThreshold = counted >= settings.MinSessions && summary.Percentage < settings.MinPercentage
+}
+
+// teacherSession загружает занятие и, если asTeacher, проверяет, что пользователь ведёт курс
+func (s *attendanceService) teacherSession(ctx context.Context, sessionID, userID string, asTeacher bool) (*models.ClassSession, error) {
+	session, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTeacher(ctx, userID, session.CourseID, asTeacher); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *attendanceService) checkTeacher(ctx context.Context, userID, courseID string, asTeacher bool) error {
+	if !asTeacher {
+		return nil
+	}
+	isTeacher, err := s.grades.IsTeacherOfCourse(ctx, userID, courseID)
+	if err != nil {
+		return err
+	}
+	if !isTeacher {
+		return models.ErrNotCourseTeacher
+	}
+	return nil
+}
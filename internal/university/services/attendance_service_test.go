@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockAttendanceRepo struct {
+	mock.Mock
+}
+
+func (m *mockAttendanceRepo) CreateSessions(ctx context.Context, sessions []models.ClassSession) ([]models.ClassSession, error) {
+	args := m.Called(ctx, sessions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClassSession), args.Error(1)
+}
+
+func (m *mockAttendanceRepo) GetOfferingSessions(ctx context.Context, offeringID string) ([]models.ClassSession, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClassSession), args.Error(1)
+}
+
+func (m *mockAttendanceRepo) GetSessionByID(ctx context.Context, id string) (*models.ClassSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClassSession), args.Error(1)
+}
+
+func (m *mockAttendanceRepo) DeleteSession(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockAttendanceRepo) GetSessionRoster(ctx context.Context, sessionID string) ([]models.AttendanceRecord, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AttendanceRecord), args.Error(1)
+}
+
+func (m *mockAttendanceRepo) MarkAttendance(ctx context.Context, records []models.AttendanceRecord) error {
+	args := m.Called(ctx, records)
+	return args.Error(0)
+}
+
+func (m *mockAttendanceRepo) GetStudentAttendance(ctx context.Context, studentID string) ([]models.AttendanceSummary, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AttendanceSummary), args.Error(1)
+}
+
+func (m *mockAttendanceRepo) GetTermAttendance(ctx context.Context, termID string) ([]models.AttendanceSummary, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AttendanceSummary), args.Error(1)
+}
+
+func (m *mockAttendanceRepo) GetSettings(ctx context.Context) (*models.AttendanceSettings, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AttendanceSettings), args.Error(1)
+}
+
+func (m *mockAttendanceRepo) UpdateSettings(ctx context.Context, settings models.AttendanceSettings) (*models.AttendanceSettings, error) {
+	args := m.Called(ctx, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AttendanceSettings), args.Error(1)
+}
+
+func TestAttendanceService_GenerateSessions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	// 2 сентября 2024 — понедельник
+	term := &models.AcademicTerm{ID: "1",
+		StartDate: time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC)}
+	offering := &models.CourseOffering{ID: "7", CourseID: "201", TermID: "1"}
+
+	t.Run("Creates Sessions On Weekdays", func(t *testing.T) {
+		repo, terms, grades := new(mockAttendanceRepo), new(mockTermRepo), new(mockGradeRepo)
+		svc := NewAttendanceService(repo, terms, new(mockSectionRepo), grades)
+		terms.On("GetTermByID", ctx, "1").Return(term, nil).Once()
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		var sessions []models.ClassSession
+		repo.On("CreateSessions", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			sessions = args.Get(1).([]models.ClassSession)
+		}).Return([]models.ClassSession{}, nil).Once()
+		schedule := models.SessionSchedule{Weekdays: []int{1, 3}, StartTime: "09:30", DurationMinutes: 90}
+
+		// Act
+		_, err := svc.GenerateSessions(ctx, "1", "7", "5", true, schedule)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 4)
+		assert.Equal(t, time.Date(2024, 9, 2, 9, 30, 0, 0, time.UTC), sessions[0].StartsAt)
+		assert.Equal(t, time.Date(2024, 9, 2, 11, 0, 0, 0, time.UTC), sessions[0].EndsAt)
+		assert.Equal(t, time.Wednesday, sessions[1].StartsAt.Weekday())
+		assert.Equal(t, "7", sessions[3].OfferingID)
+	})
+
+	t.Run("Range Is Clamped To Term", func(t *testing.T) {
+		repo, terms := new(mockAttendanceRepo), new(mockTermRepo)
+		svc := NewAttendanceService(repo, terms, new(mockSectionRepo), new(mockGradeRepo))
+		terms.On("GetTermByID", ctx, "1").Return(term, nil).Once()
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		from := time.Date(2024, 9, 10, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		repo.On("CreateSessions", ctx, mock.MatchedBy(func(sessions []models.ClassSession) bool {
+			return len(sessions) == 1 && sessions[0].StartsAt.Day() == 11
+		})).Return([]models.ClassSession{{ID: "1"}}, nil).Once()
+		schedule := models.SessionSchedule{Weekdays: []int{3}, StartTime: "14:00", DurationMinutes: 60, From: &from, To: &to}
+
+		// Act
+		_, err := svc.GenerateSessions(ctx, "1", "7", "1", false, schedule)
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Start Time", func(t *testing.T) {
+		repo, terms := new(mockAttendanceRepo), new(mockTermRepo)
+		svc := NewAttendanceService(repo, terms, new(mockSectionRepo), new(mockGradeRepo))
+		terms.On("GetTermByID", ctx, "1").Return(term, nil).Once()
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		schedule := models.SessionSchedule{Weekdays: []int{1}, StartTime: "9am", DurationMinutes: 60}
+
+		// Act
+		_, err := svc.GenerateSessions(ctx, "1", "7", "1", false, schedule)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidSchedule)
+		repo.AssertNotCalled(t, "CreateSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Section Of Another Offering", func(t *testing.T) {
+		repo, terms, sections := new(mockAttendanceRepo), new(mockTermRepo), new(mockSectionRepo)
+		svc := NewAttendanceService(repo, terms, sections, new(mockGradeRepo))
+		terms.On("GetTermByID", ctx, "1").Return(term, nil).Once()
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		sections.On("GetSectionByID", ctx, "3").Return(&models.CourseSection{ID: "3", OfferingID: "8"}, nil).Once()
+		sectionID := "3"
+		schedule := models.SessionSchedule{SectionID: &sectionID, Weekdays: []int{1}, StartTime: "09:00", DurationMinutes: 60}
+
+		// Act
+		_, err := svc.GenerateSessions(ctx, "1", "7", "1", false, schedule)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSectionNotInOffering)
+	})
+
+	t.Run("Teacher Of Another Course", func(t *testing.T) {
+		repo, terms, grades := new(mockAttendanceRepo), new(mockTermRepo), new(mockGradeRepo)
+		svc := NewAttendanceService(repo, terms, new(mockSectionRepo), grades)
+		terms.On("GetTermByID", ctx, "1").Return(term, nil).Once()
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "6", "201").Return(false, nil).Once()
+		schedule := models.SessionSchedule{Weekdays: []int{1}, StartTime: "09:00", DurationMinutes: 60}
+
+		// Act
+		_, err := svc.GenerateSessions(ctx, "1", "7", "6", true, schedule)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotCourseTeacher)
+		repo.AssertNotCalled(t, "CreateSessions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttendanceService_MarkAttendance(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	session := &models.ClassSession{ID: "11", OfferingID: "7", CourseID: "201"}
+	roster := []models.AttendanceRecord{{SessionID: "11", StudentID: "101"}, {SessionID: "11", StudentID: "102"}, {SessionID: "11", StudentID: "103"}}
+
+	t.Run("Default Fills Unlisted Students", func(t *testing.T) {
+		repo := new(mockAttendanceRepo)
+		svc := NewAttendanceService(repo, new(mockTermRepo), new(mockSectionRepo), new(mockGradeRepo))
+		repo.On("GetSessionByID", ctx, "11").Return(session, nil).Once()
+		repo.On("GetSessionRoster", ctx, "11").Return(roster, nil).Twice()
+		repo.On("MarkAttendance", ctx, mock.MatchedBy(func(records []models.AttendanceRecord) bool {
+			statuses := map[string]string{}
+			for _, record := range records {
+				statuses[record.StudentID] = record.Status
+			}
+			return len(records) == 3 && statuses["101"] == models.AttendanceAbsent &&
+				statuses["102"] == models.AttendancePresent && statuses["103"] == models.AttendancePresent &&
+				*records[0].MarkedBy == "1"
+		})).Return(nil).Once()
+		bulk := models.BulkAttendance{
+			Default: models.AttendancePresent,
+			Records: []models.AttendanceMark{{StudentID: "101", Status: models.AttendanceAbsent}},
+		}
+
+		// Act
+		_, err := svc.MarkAttendance(ctx, "11", "1", false, bulk)
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Student Not In Session", func(t *testing.T) {
+		repo := new(mockAttendanceRepo)
+		svc := NewAttendanceService(repo, new(mockTermRepo), new(mockSectionRepo), new(mockGradeRepo))
+		repo.On("GetSessionByID", ctx, "11").Return(session, nil).Once()
+		repo.On("GetSessionRoster", ctx, "11").Return(roster, nil).Once()
+		bulk := models.BulkAttendance{Records: []models.AttendanceMark{{StudentID: "999", Status: models.AttendancePresent}}}
+
+		// Act
+		_, err := svc.MarkAttendance(ctx, "11", "1", false, bulk)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotInSession)
+		repo.AssertNotCalled(t, "MarkAttendance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Teacher Of Another Course", func(t *testing.T) {
+		repo, grades := new(mockAttendanceRepo), new(mockGradeRepo)
+		svc := NewAttendanceService(repo, new(mockTermRepo), new(mockSectionRepo), grades)
+		repo.On("GetSessionByID", ctx, "11").Return(session, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "6", "201").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.MarkAttendance(ctx, "11", "6", true, models.BulkAttendance{Default: models.AttendancePresent})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotCourseTeacher)
+		repo.AssertNotCalled(t, "GetSessionRoster", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttendanceService_Summaries(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	settings := &models.AttendanceSettings{MinPercentage: 75, MinSessions: 3}
+
+	t.Run("Student Percentages", func(t *testing.T) {
+		repo := new(mockAttendanceRepo)
+		svc := NewAttendanceService(repo, new(mockTermRepo), new(mockSectionRepo), new(mockGradeRepo))
+		repo.On("GetStudentAttendance", ctx, "101").Return([]models.AttendanceSummary{
+			{OfferingID: "7", Present: 4, Late: 1, Absent: 1, Excused: 2},
+			{OfferingID: "8", Excused: 1},
+			{OfferingID: "9", Present: 1, Absent: 1},
+		}, nil).Once()
+		repo.On("GetSettings", ctx).Return(settings, nil).Once()
+
+		// Act
+		summaries, err := svc.GetStudentAttendance(ctx, "101")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 83.33, summaries[0].Percentage)
+		assert.False(t, summaries[0].BelowThreshold)
+		assert.Equal(t, 100.0, summaries[1].Percentage)
+		assert.Equal(t, 50.0, summaries[2].Percentage)
+		assert.False(t, summaries[2].BelowThreshold, "too few sessions to flag")
+	})
+
+	t.Run("Flags Only Students Below Threshold", func(t *testing.T) {
+		repo, terms := new(mockAttendanceRepo), new(mockTermRepo)
+		svc := NewAttendanceService(repo, terms, new(mockSectionRepo), new(mockGradeRepo))
+		terms.On("GetTermByID", ctx, "1").Return(&models.AcademicTerm{ID: "1"}, nil).Once()
+		repo.On("GetTermAttendance", ctx, "1").Return([]models.AttendanceSummary{
+			{StudentID: "101", Present: 2, Absent: 2},
+			{StudentID: "102", Present: 3, Absent: 1},
+			{StudentID: "103", Absent: 2},
+		}, nil).Once()
+		repo.On("GetSettings", ctx).Return(settings, nil).Once()
+
+		// Act
+		flagged, err := svc.GetAttendanceFlags(ctx, "1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, flagged, 1)
+		assert.Equal(t, "101", flagged[0].StudentID)
+		assert.True(t, flagged[0].BelowThreshold)
+	})
+}
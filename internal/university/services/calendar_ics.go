@@ -0,0 +1,107 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Время в ленте записывается без часового пояса (floating time по RFC 5545):
+// календарь показывает занятия в том же местном времени, в котором они заданы в расписании.
+const (
+	icsDateTime = "20060102T150405"
+	icsDate     = "20060102"
+	icsLineMax  = 75
+)
+
+var icsWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// calendarEvent — событие ленты: еженедельное занятие (Until не нулевой) или событие на весь день
+type calendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Until       time.Time
+	ExDates     []time.Time
+	// Reminder — за сколько до начала календарь напомнит о событии (VALARM); ноль — без напоминания
+	Reminder time.Duration
+}
+
+// renderICS собирает VCALENDAR из событий; stamp — момент формирования ленты (DTSTAMP)
+func renderICS(name string, events []calendarEvent, stamp time.Time) []byte {
+	var buf bytes.Buffer
+	writeICSLine(&buf, "BEGIN:VCALENDAR")
+	writeICSLine(&buf, "VERSION:2.0")
+	writeICSLine(&buf, "PRODID:-//university_system//Schedule//EN")
+	writeICSLine(&buf, "CALSCALE:GREGORIAN")
+	writeICSLine(&buf, "METHOD:PUBLISH")
+	writeICSLine(&buf, "X-WR-CALNAME:"+escapeICSText(name))
+	for _, event := range events {
+		writeICSLine(&buf, "BEGIN:VEVENT")
+		writeICSLine(&buf, "UID:"+event.UID)
+		writeICSLine(&buf, "DTSTAMP:"+stamp.UTC().Format(icsDateTime)+"Z")
+		if event.AllDay {
+			writeICSLine(&buf, "DTSTART;VALUE=DATE:"+event.Start.Format(icsDate))
+			writeICSLine(&buf, "DTEND;VALUE=DATE:"+event.Start.AddDate(0, 0, 1).Format(icsDate))
+		} else {
+			writeICSLine(&buf, "DTSTART:"+event.Start.Format(icsDateTime))
+			writeICSLine(&buf, "DTEND:"+event.End.Format(icsDateTime))
+		}
+		if !event.Until.IsZero() {
+			writeICSLine(&buf, "RRULE:FREQ=WEEKLY;BYDAY="+icsWeekdays[event.Start.Weekday()]+";UNTIL="+event.Until.Format(icsDateTime))
+			for _, exDate := range event.ExDates {
+				writeICSLine(&buf, "EXDATE:"+exDate.Format(icsDateTime))
+			}
+		}
+		writeICSLine(&buf, "SUMMARY:"+escapeICSText(event.Summary))
+		if event.Description != "" {
+			writeICSLine(&buf, "DESCRIPTION:"+escapeICSText(event.Description))
+		}
+		if event.Location != "" {
+			writeICSLine(&buf, "LOCATION:"+escapeICSText(event.Location))
+		}
+		if event.AllDay {
+			writeICSLine(&buf, "TRANSP:TRANSPARENT")
+		}
+		if event.Reminder > 0 {
+			writeICSLine(&buf, "BEGIN:VALARM")
+			writeICSLine(&buf, "ACTION:DISPLAY")
+			writeICSLine(&buf, "DESCRIPTION:"+escapeICSText(event.Summary))
+			writeICSLine(&buf, fmt.Sprintf("TRIGGER:-PT%dM", int(event.Reminder.Minutes())))
+			writeICSLine(&buf, "END:VALARM")
+		}
+		writeICSLine(&buf, "END:VEVENT")
+	}
+	writeICSLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeICSLine пишет строку с CRLF, перенося её по 75 октетов без разрыва символов UTF-8
+func writeICSLine(buf *bytes.Buffer, line string) {
+	limit := icsLineMax
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// продолжение начинается с пробела, который тоже входит в 75 октетов
+		limit = icsLineMax - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeICSText(text string) string {
+	return icsTextEscaper.Replace(text)
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+// bookingReminder — за сколько до консультации календарь напоминает о ней
+const bookingReminder = time.Hour
+
+type CalendarService interface {
+	GetFeed(ctx context.Context, userID string) (*models.CalendarFeed, error)
+	RotateFeed(ctx context.Context, userID string) (*models.CalendarFeed, error)
+	RenderFeed(ctx context.Context, token string) ([]byte, error)
+	GetTermHolidays(ctx context.Context, termID string) ([]models.Holiday, error)
+	CreateHoliday(ctx context.Context, termID string, holiday *models.Holiday) (*models.Holiday, error)
+	DeleteHoliday(ctx context.Context, termID, id string) error
+}
+
+type calendarService struct {
+	repo      repository.CalendarRepository
+	terms     repository.TermRepository
+	timetable repository.TimetableRepository
+	exams     repository.ExamRepository
+	bookings  repository.OfficeHourRepository
+	feedURL   string
+	now       func() time.Time
+}
+
+// NewCalendarService создаёт сервис календарных лент; feedURL — публичный адрес лент,
+// к которому дописывается токен (например, "https://uni.example/calendar/feeds/")
+func NewCalendarService(repo repository.CalendarRepository, terms repository.TermRepository,
+	timetable repository.TimetableRepository, exams repository.ExamRepository, bookings repository.OfficeHourRepository,
+	feedURL string) CalendarService {
+	return &calendarService{repo: repo, terms: terms, timetable: timetable, exams: exams, bookings: bookings, feedURL: feedURL, now: time.Now}
+}
+
+// GetFeed возвращает ссылку на ленту пользователя, создавая её при первом обращении
+func (s *calendarService) GetFeed(ctx context.Context, userID string) (*models.CalendarFeed, error) {
+	feed, err := s.repo.GetFeedByUser(ctx, userID)
+	if errors.Is(err, models.ErrCalendarFeedNotFound) {
+		return s.RotateFeed(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	feed.URL = s.feedURL + feed.Token + ".ics"
+	return feed, nil
+}
+
+// RotateFeed выпускает новый токен ленты; прежняя ссылка перестаёт работать
+func (s *calendarService) RotateFeed(ctx context.Context, userID string) (*models.CalendarFeed, error) {
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	feed, err := s.repo.SaveFeed(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	feed.URL = s.feedURL + feed.Token + ".ics"
+	return feed, nil
+}
+
+// RenderFeed собирает ленту по токену: еженедельные занятия студента (по его записям на курсы)
+// или преподавателя (по его секциям и курсам) с исключением праздников, экзамены, сроки и праздники
+// всех незакрытых периодов, а также предстоящие консультации с напоминанием
+func (s *calendarService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.repo.GetFeedByToken(ctx, strings.TrimSuffix(token, ".ics"))
+	if err != nil {
+		return nil, err
+	}
+	terms, err := s.terms.GetTerms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []calendarEvent{}
+	for _, term := range terms {
+		if term.Status == models.TermStatusClosed {
+			continue
+		}
+		holidays, err := s.repo.GetTermHolidays(ctx, term.ID)
+		if err != nil {
+			return nil, err
+		}
+		var entries []models.TimetableEntry
+		var exams []calendarEvent
+		switch feed.Role {
+		case "student":
+			entries, err = s.timetable.GetStudentTimetable(ctx, feed.UserID, term.ID)
+			if err == nil {
+				exams, err = s.studentExamEvents(ctx, feed.UserID, term.ID)
+			}
+		case "teacher":
+			entries, err = s.timetable.GetTeacherTimetable(ctx, feed.UserID, term.ID)
+			if err == nil {
+				exams, err = s.teacherExamEvents(ctx, feed.UserID, term.ID)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		classes, err := classEvents(term, entries, holidays)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, classes...)
+		events = append(events, exams...)
+		events = append(events, deadlineEvents(term, feed.Role)...)
+		events = append(events, holidayEvents(holidays)...)
+	}
+	bookings, err := s.bookingEvents(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, bookings...)
+	return renderICS("University schedule", events, s.now()), nil
+}
+
+func (s *calendarService) GetTermHolidays(ctx context.Context, termID string) ([]models.Holiday, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTermHolidays(ctx, termID)
+}
+
+// CreateHoliday добавляет нерабочий день; он должен попадать в даты периода
+func (s *calendarService) CreateHoliday(ctx context.Context, termID string, holiday *models.Holiday) (*models.Holiday, error) {
+	term, err := s.terms.GetTermByID(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	holiday.Date = time.Date(holiday.Date.Year(), holiday.Date.Month(), holiday.Date.Day(), 0, 0, 0, 0, time.UTC)
+	if holiday.Date.Before(term.StartDate) || holiday.Date.After(term.EndDate) {
+		return nil, models.ErrHolidayOutsideTerm
+	}
+	holiday.TermID = termID
+	return s.repo.CreateHoliday(ctx, holiday)
+}
+
+func (s *calendarService) DeleteHoliday(ctx context.Context, termID, id string) error {
+	return s.repo.DeleteHoliday(ctx, termID, id)
+}
+
+// classEvents превращает недельное расписание периода в повторяющиеся события: первое занятие —
+// первый подходящий день недели с начала периода, повторы — до конца периода, кроме праздников
+func classEvents(term models.AcademicTerm, entries []models.TimetableEntry, holidays []models.Holiday) ([]calendarEvent, error) {
+	events := make([]calendarEvent, 0, len(entries))
+	until := atClock(term.EndDate, 23, 59, 59)
+	for _, entry := range entries {
+		start, err := time.Parse("15:04", entry.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err := time.Parse("15:04", entry.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		first := term.StartDate
+		for first.Weekday() != time.Weekday(entry.Weekday) {
+			first = first.AddDate(0, 0, 1)
+		}
+		if first.After(term.EndDate) {
+			continue
+		}
+
+		event := calendarEvent{
+			UID:         fmt.Sprintf("meeting-%s-term-%s@university_system", entry.MeetingID, term.ID),
+			Summary:     strings.TrimSpace(entry.Code + " " + entry.Name),
+			Description: fmt.Sprintf("Section %s, %s", entry.SectionName, termTitle(term.Season, term.Year)),
+			Location:    strings.Trim(entry.Building+", "+entry.RoomName, ", "),
+			Start:       atClock(first, start.Hour(), start.Minute(), 0),
+			End:         atClock(first, end.Hour(), end.Minute(), 0),
+			Until:       until,
+		}
+		for _, holiday := range holidays {
+			if holiday.Date.Weekday() == first.Weekday() && !holiday.Date.Before(first) && !holiday.Date.After(term.EndDate) {
+				event.ExDates = append(event.ExDates, atClock(holiday.Date, start.Hour(), start.Minute(), 0))
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// studentExamEvents возвращает экзамены студента в периоде; место указывается, если рассадка составлена
+func (s *calendarService) studentExamEvents(ctx context.Context, studentID, termID string) ([]calendarEvent, error) {
+	exams, err := s.exams.GetStudentExams(ctx, studentID, termID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]calendarEvent, 0, len(exams))
+	for _, exam := range exams {
+		event := examEvent(exam.Exam)
+		if exam.Seat != nil {
+			event.Location = strings.Trim(exam.Building+", "+exam.RoomName, ", ")
+			event.Description = fmt.Sprintf("Seat %d", *exam.Seat)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (s *calendarService) teacherExamEvents(ctx context.Context, teacherID, termID string) ([]calendarEvent, error) {
+	exams, err := s.exams.GetTeacherExams(ctx, teacherID, termID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]calendarEvent, 0, len(exams))
+	for _, exam := range exams {
+		events = append(events, examEvent(exam))
+	}
+	return events, nil
+}
+
+// bookingEvents возвращает предстоящие записи на консультации: студенту — к кому он записан,
+// преподавателю — кто записан к нему. Напоминание приходит за bookingReminder до начала.
+func (s *calendarService) bookingEvents(ctx context.Context, feed *models.CalendarFeed) ([]calendarEvent, error) {
+	from := wallClock(s.now())
+	var bookings []models.OfficeHourBooking
+	var err error
+	switch feed.Role {
+	case "student":
+		bookings, err = s.bookings.GetStudentBookings(ctx, feed.UserID, &from)
+	case "teacher":
+		bookings, err = s.bookings.GetTeacherBookings(ctx, feed.UserID, &from)
+	}
+	if err != nil {
+		return nil, err
+	}
+	events := make([]calendarEvent, 0, len(bookings))
+	for _, booking := range bookings {
+		if booking.Status != models.BookingStatusBooked {
+			continue
+		}
+		with := booking.TeacherName
+		if feed.Role == "teacher" {
+			with = booking.StudentName
+		}
+		events = append(events, calendarEvent{
+			UID:         fmt.Sprintf("booking-%s@university_system", booking.ID),
+			Summary:     "Office hours: " + with,
+			Description: booking.Topic,
+			Location:    booking.Location,
+			Start:       booking.StartsAt,
+			End:         booking.EndsAt,
+			Reminder:    bookingReminder,
+		})
+	}
+	return events, nil
+}
+
+func examEvent(exam models.Exam) calendarEvent {
+	return calendarEvent{
+		UID:      fmt.Sprintf("exam-%s@university_system", exam.ID),
+		Summary:  strings.TrimSpace(fmt.Sprintf("%s %s: %s", exam.Code, exam.Name, exam.Title)),
+		Location: strings.Join(exam.Rooms, "; "),
+		Start:    exam.StartsAt,
+		End:      exam.EndsAt,
+	}
+}
+
+// deadlineEvents возвращает сроки периода, важные для роли: студенту — запись, отказ и отзыв,
+// преподавателю — выставление оценок, остальным — все
+func deadlineEvents(term models.AcademicTerm, role string) []calendarEvent {
+	type deadline struct {
+		key, summary string
+		date         time.Time
+	}
+	student := []deadline{
+		{"enrollment-start", "Enrollment opens", term.EnrollmentStart},
+		{"enrollment-end", "Enrollment closes", term.EnrollmentEnd},
+		{"add-drop", "Add/drop deadline", term.AddDropDeadline},
+		{"withdrawal", "Withdrawal deadline", term.WithdrawalDeadline},
+	}
+	teacher := []deadline{{"grading", "Grading deadline", term.GradingDeadline}}
+
+	deadlines := []deadline{{"start", "Term starts", term.StartDate}, {"end", "Term ends", term.EndDate}}
+	switch role {
+	case "student":
+		deadlines = append(deadlines, student...)
+	case "teacher":
+		deadlines = append(deadlines, teacher...)
+	default:
+		deadlines = append(append(deadlines, student...), teacher...)
+	}
+
+	title := termTitle(term.Season, term.Year)
+	events := make([]calendarEvent, 0, len(deadlines))
+	for _, d := range deadlines {
+		if d.date.IsZero() {
+			continue
+		}
+		events = append(events, calendarEvent{
+			UID:     fmt.Sprintf("term-%s-%s@university_system", term.ID, d.key),
+			Summary: fmt.Sprintf("%s (%s)", d.summary, title),
+			Start:   d.date,
+			AllDay:  true,
+		})
+	}
+	return events
+}
+
+func holidayEvents(holidays []models.Holiday) []calendarEvent {
+	events := make([]calendarEvent, 0, len(holidays))
+	for _, holiday := range holidays {
+		events = append(events, calendarEvent{
+			UID:     fmt.Sprintf("holiday-%s@university_system", holiday.ID),
+			Summary: "Holiday: " + holiday.Name,
+			Start:   holiday.Date,
+			AllDay:  true,
+		})
+	}
+	return events
+}
+
+func atClock(day time.Time, hour, minute, second int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, day.Location())
+}
+
+// newFeedToken возвращает случайный токен ленты (160 бит, hex)
+func newFeedToken() (string, error) {
+	raw := make([]byte, 20)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
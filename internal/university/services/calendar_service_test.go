@@ -0,0 +1,220 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockCalendarRepo struct {
+	mock.Mock
+}
+
+func (m *mockCalendarRepo) GetFeedByUser(ctx context.Context, userID string) (*models.CalendarFeed, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CalendarFeed), args.Error(1)
+}
+
+func (m *mockCalendarRepo) GetFeedByToken(ctx context.Context, token string) (*models.CalendarFeed, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CalendarFeed), args.Error(1)
+}
+
+func (m *mockCalendarRepo) SaveFeed(ctx context.Context, userID, token string) (*models.CalendarFeed, error) {
+	args := m.Called(ctx, userID, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CalendarFeed), args.Error(1)
+}
+
+func (m *mockCalendarRepo) GetTermHolidays(ctx context.Context, termID string) ([]models.Holiday, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Holiday), args.Error(1)
+}
+
+func (m *mockCalendarRepo) CreateHoliday(ctx context.Context, holiday *models.Holiday) (*models.Holiday, error) {
+	args := m.Called(ctx, holiday)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Holiday), args.Error(1)
+}
+
+func (m *mockCalendarRepo) DeleteHoliday(ctx context.Context, termID, id string) error {
+	args := m.Called(ctx, termID, id)
+	return args.Error(0)
+}
+
+func TestCalendarService_GetFeed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Creates Feed On First Request", func(t *testing.T) {
+		repo := new(mockCalendarRepo)
+		svc := NewCalendarService(repo, new(mockTermRepo), new(mockTimetableRepo), new(mockExamRepo), new(mockOfficeHourRepo), "https://uni.example/calendar/feeds/")
+		repo.On("GetFeedByUser", ctx, "101").Return(nil, models.ErrCalendarFeedNotFound).Once()
+		repo.On("SaveFeed", ctx, "101", mock.MatchedBy(func(token string) bool { return len(token) == 40 })).
+			Return(&models.CalendarFeed{UserID: "101", Token: "abc"}, nil).Once()
+
+		// Act
+		feed, err := svc.GetFeed(ctx, "101")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://uni.example/calendar/feeds/abc.ics", feed.URL)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Returns Existing Feed", func(t *testing.T) {
+		repo := new(mockCalendarRepo)
+		svc := NewCalendarService(repo, new(mockTermRepo), new(mockTimetableRepo), new(mockExamRepo), new(mockOfficeHourRepo), "https://uni.example/calendar/feeds/")
+		repo.On("GetFeedByUser", ctx, "101").Return(&models.CalendarFeed{UserID: "101", Token: "abc"}, nil).Once()
+
+		// Act
+		feed, err := svc.GetFeed(ctx, "101")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "https://uni.example/calendar/feeds/abc.ics", feed.URL)
+		repo.AssertNotCalled(t, "SaveFeed", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCalendarService_RenderFeed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	term := fallTerm()
+	closed := fallTerm()
+	closed.ID, closed.Status = "0", models.TermStatusClosed
+	roomID := "3"
+	// 3 ноября 2025 — понедельник
+	holidays := []models.Holiday{{ID: "4", TermID: "1", Date: date(2025, time.November, 3), Name: "Unity Day"}}
+	entries := []models.TimetableEntry{{MeetingID: "21", SectionName: "A", Code: "CS101", Name: "Algorithms",
+		Weekday: 1, StartTime: "09:30", EndTime: "11:00", RoomID: &roomID, Building: "Main", RoomName: "A-101"}}
+
+	seat := 7
+	exams := []models.StudentExam{{Exam: models.Exam{ID: "31", Code: "CS101", Name: "Algorithms", Title: "Final exam",
+		StartsAt: time.Date(2025, time.December, 20, 10, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, time.December, 20, 12, 0, 0, 0, time.UTC),
+		Rooms: []string{"Main, A-101", "Main, A-102"}}, Building: "Main", RoomName: "A-102", Seat: &seat}}
+
+	booked := []models.OfficeHourBooking{
+		{ID: "50", TeacherName: "Anna Smirnova", Topic: "Project", Location: "B-204", Status: models.BookingStatusBooked,
+			StartsAt: time.Date(2025, time.October, 6, 10, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, time.October, 6, 10, 20, 0, 0, time.UTC)},
+		{ID: "51", TeacherName: "Anna Smirnova", Status: models.BookingStatusCancelled,
+			StartsAt: time.Date(2025, time.October, 13, 10, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, time.October, 13, 10, 20, 0, 0, time.UTC)},
+	}
+
+	repo, terms, timetable, examRepo := new(mockCalendarRepo), new(mockTermRepo), new(mockTimetableRepo), new(mockExamRepo)
+	bookings := new(mockOfficeHourRepo)
+	svc := NewCalendarService(repo, terms, timetable, examRepo, bookings, "")
+	svc.(*calendarService).now = func() time.Time { return date(2025, time.October, 1) }
+	repo.On("GetFeedByToken", ctx, "abc").Return(&models.CalendarFeed{UserID: "101", Role: "student", Token: "abc"}, nil).Once()
+	terms.On("GetTerms", ctx).Return([]models.AcademicTerm{closed, term}, nil).Once()
+	repo.On("GetTermHolidays", ctx, "1").Return(holidays, nil).Once()
+	timetable.On("GetStudentTimetable", ctx, "101", "1").Return(entries, nil).Once()
+	examRepo.On("GetStudentExams", ctx, "101", "1").Return(exams, nil).Once()
+	bookings.On("GetStudentBookings", ctx, "101", mock.MatchedBy(func(from *time.Time) bool {
+		return from != nil && from.Equal(date(2025, time.October, 1))
+	})).Return(booked, nil).Once()
+
+	// Act
+	ics, err := svc.RenderFeed(ctx, "abc.ics")
+
+	// Assert
+	assert.NoError(t, err)
+	body := string(ics)
+	assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	// 1 сентября 2025 — понедельник, первое занятие в тот же день
+	assert.Contains(t, body, "DTSTART:20250901T093000\r\n")
+	assert.Contains(t, body, "DTEND:20250901T110000\r\n")
+	assert.Contains(t, body, "RRULE:FREQ=WEEKLY;BYDAY=MO;UNTIL=20251225T235959\r\n")
+	assert.Contains(t, body, "EXDATE:20251103T093000\r\n")
+	assert.Contains(t, body, "LOCATION:Main\\, A-101\r\n")
+	assert.Contains(t, body, "UID:exam-31@university_system\r\n")
+	assert.Contains(t, body, "SUMMARY:CS101 Algorithms: Final exam\r\n")
+	assert.Contains(t, body, "DTSTART:20251220T100000\r\n")
+	assert.Contains(t, body, "LOCATION:Main\\, A-102\r\n")
+	assert.Contains(t, body, "DESCRIPTION:Seat 7\r\n")
+	assert.Contains(t, body, "SUMMARY:Withdrawal deadline (Fall 2025)\r\n")
+	assert.NotContains(t, body, "Grading deadline")
+	assert.Contains(t, body, "SUMMARY:Holiday: Unity Day\r\n")
+	assert.Contains(t, body, "UID:booking-50@university_system\r\n")
+	assert.Contains(t, body, "SUMMARY:Office hours: Anna Smirnova\r\n")
+	assert.Contains(t, body, "BEGIN:VALARM\r\nACTION:DISPLAY\r\nDESCRIPTION:Office hours: Anna Smirnova\r\nTRIGGER:-PT60M\r\nEND:VALARM\r\n")
+	assert.NotContains(t, body, "booking-51")
+	assert.Equal(t, strings.Count(body, "BEGIN:VEVENT"), strings.Count(body, "END:VEVENT"))
+	repo.AssertNotCalled(t, "GetTermHolidays", mock.Anything, "0")
+}
+
+func TestCalendarService_CreateHoliday(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	term := fallTerm()
+
+	t.Run("Outside Term", func(t *testing.T) {
+		repo, terms := new(mockCalendarRepo), new(mockTermRepo)
+		svc := NewCalendarService(repo, terms, new(mockTimetableRepo), new(mockExamRepo), new(mockOfficeHourRepo), "")
+		terms.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+
+		// Act
+		_, err := svc.CreateHoliday(ctx, "1", &models.Holiday{Date: date(2026, time.January, 1), Name: "New Year"})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrHolidayOutsideTerm)
+		repo.AssertNotCalled(t, "CreateHoliday", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		repo, terms := new(mockCalendarRepo), new(mockTermRepo)
+		svc := NewCalendarService(repo, terms, new(mockTimetableRepo), new(mockExamRepo), new(mockOfficeHourRepo), "")
+		terms.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+		holiday := &models.Holiday{Date: date(2025, time.November, 4).Add(15 * time.Hour), Name: "Unity Day"}
+		repo.On("CreateHoliday", ctx, holiday).Return(holiday, nil).Once()
+
+		// Act
+		created, err := svc.CreateHoliday(ctx, "1", holiday)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1", created.TermID)
+		assert.Equal(t, date(2025, time.November, 4), created.Date)
+	})
+}
+
+func TestWriteICSLine_FoldsLongLines(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	line := "SUMMARY:" + strings.Repeat("Алгоритмы ", 12)
+
+	// Act
+	writeICSLine(&buf, line)
+
+	// Assert
+	folded := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	assert.Greater(t, len(folded), 1)
+	unfolded := folded[0]
+	for i, part := range folded {
+		assert.LessOrEqual(t, len(part), 75)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(part, " "))
+			unfolded += part[1:]
+		}
+	}
+	assert.Equal(t, line, unfolded)
+}
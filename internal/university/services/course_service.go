@@ -2,29 +2,53 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"university_system/internal/domain/models"
 	"university_system/internal/domain/repository"
 )
 
 type CourseService interface {
-	CreateCourse(ctx context.Context, course *models.Course) (*models.Course, error)
+	CreateCourse(ctx context.Context, course *models.Course, managerID string) (*models.Course, error)
 	GetAllCourses(ctx context.Context) ([]models.Course, error)
 	GetCourseByID(ctx context.Context, id string) (*models.Course, error)
-	UpdateCourse(ctx context.Context, course models.Course) (*models.Course, error)
-	DeleteCourse(ctx context.Context, id string) error
+	UpdateCourse(ctx context.Context, course models.Course, managerID string) (*models.Course, error)
+	DeleteCourse(ctx context.Context, id, managerID string) error
 	GetCourseStudents(ctx context.Context, courseID string) ([]models.Student, error)
 	GetCourseTeachers(ctx context.Context, courseID string) ([]models.Teacher, error)
 }
 
 type courseService struct {
 	repo repository.CourseRepository
+	org  repository.OrganizationRepository
 }
 
-func NewCourseService(repo repository.CourseRepository) CourseService {
-	return &courseService{repo: repo}
+func NewCourseService(repo repository.CourseRepository, org repository.OrganizationRepository) CourseService {
+	return &courseService{repo: repo, org: org}
 }
 
-func (s *courseService) CreateCourse(ctx context.Context, course *models.Course) (*models.Course, error) {
+// validateRequisites проверяет, что выражения пре- и корреквизитов разбираются
+func validateRequisites(course *models.Course) error {
+	if _, err := parseRequisites(course.Prerequisites); err != nil {
+		return fmt.Errorf("prerequisites: %w", err)
+	}
+	if _, err := parseRequisites(course.Corequisites); err != nil {
+		return fmt.Errorf("corequisites: %w", err)
+	}
+	return nil
+}
+
+// CreateCourse создаёт курс; курс менеджера (managerID не пуст) всегда относится к его кафедре
+func (s *courseService) CreateCourse(ctx context.Context, course *models.Course, managerID string) (*models.Course, error) {
+	if err := validateRequisites(course); err != nil {
+		return nil, err
+	}
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignOrganization(ctx, course, scope); err != nil {
+		return nil, err
+	}
 	return s.repo.CreateCourse(ctx, course)
 }
 
@@ -36,14 +60,74 @@ func (s *courseService) GetCourseByID(ctx context.Context, id string) (*models.C
 	return s.repo.GetCourseByID(ctx, id)
 }
 
-func (s *courseService) UpdateCourse(ctx context.Context, course models.Course) (*models.Course, error) {
+// UpdateCourse изменяет курс; менеджер изменяет только курсы своей кафедры, курсы без кафедры —
+// только администратор
+func (s *courseService) UpdateCourse(ctx context.Context, course models.Course, managerID string) (*models.Course, error) {
+	if err := validateRequisites(&course); err != nil {
+		return nil, err
+	}
+	scope, err := s.scopedCourse(ctx, course.ID, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignOrganization(ctx, &course, scope); err != nil {
+		return nil, err
+	}
 	return s.repo.UpdateCourse(ctx, course)
 }
 
-func (s *courseService) DeleteCourse(ctx context.Context, id string) error {
+func (s *courseService) DeleteCourse(ctx context.Context, id, managerID string) error {
+	if _, err := s.scopedCourse(ctx, id, managerID); err != nil {
+		return err
+	}
 	return s.repo.DeleteCourse(ctx, id)
 }
 
+// scopedCourse проверяет, что курс courseID относится к кафедре менеджера managerID, и возвращает эту кафедру
+func (s *courseService) scopedCourse(ctx context.Context, courseID, managerID string) (*models.Department, error) {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil || scope == nil {
+		return scope, err
+	}
+	current, err := s.repo.GetCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !inDepartment(scope, current.DepartmentID) {
+		return nil, models.ErrOutsideDepartment
+	}
+	return scope, nil
+}
+
+// assignOrganization проверяет кафедру курса и заменяет допущенные факультеты их каноническими
+// названиями, чтобы правило записи сравнивало их с факультетом студента. Курсу менеджера без
+// указанной кафедры подставляется кафедра менеджера.
+func (s *courseService) assignOrganization(ctx context.Context, course *models.Course, scope *models.Department) error {
+	department, err := resolveDepartment(ctx, s.org, course.DepartmentID, "")
+	if err != nil {
+		return err
+	}
+	if department == nil {
+		department = scope
+	}
+	course.DepartmentID = nil
+	if department != nil {
+		course.DepartmentID = &department.ID
+	}
+	if !inDepartment(scope, course.DepartmentID) {
+		return models.ErrOutsideDepartment
+	}
+
+	for i, name := range course.AllowedFaculties {
+		faculty, err := s.org.FindFaculty(ctx, name)
+		if err != nil {
+			return err
+		}
+		course.AllowedFaculties[i] = faculty.Name
+	}
+	return nil
+}
+
 func (s *courseService) GetCourseStudents(ctx context.Context, courseID string) ([]models.Student, error) {
 	return s.repo.GetCourseStudents(ctx, courseID)
 }
@@ -70,7 +70,7 @@ func (m *mockCourseRepo) GetCourseTeachers(ctx context.Context, courseID string)
 func TestCourseService_CreateCourse(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockCourseRepo)
-	svc := NewCourseService(mockRepo)
+	svc := NewCourseService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -86,7 +86,7 @@ func TestCourseService_CreateCourse(t *testing.T) {
 		mockRepo.On("CreateCourse", ctx, newCourse).Return(expectedCourse, nil).Once()
 
 		// Act
-		course, err := svc.CreateCourse(ctx, newCourse)
+		course, err := svc.CreateCourse(ctx, newCourse, "")
 
 		// Assert
 		assert.NoError(t, err)
@@ -103,7 +103,7 @@ func TestCourseService_CreateCourse(t *testing.T) {
 		mockRepo.On("CreateCourse", ctx, newCourse).Return(nil, expectedError).Once()
 
 		// Act
-		course, err := svc.CreateCourse(ctx, newCourse)
+		course, err := svc.CreateCourse(ctx, newCourse, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -111,12 +111,96 @@ func TestCourseService_CreateCourse(t *testing.T) {
 		assert.Nil(t, course)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Invalid Prerequisites", func(t *testing.T) {
+		newCourse := &models.Course{
+			Name:          "Algorithms",
+			Prerequisites: "(CS101 and",
+		}
+
+		// Act
+		course, err := svc.CreateCourse(ctx, newCourse, "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidRequisite)
+		assert.Nil(t, course)
+		mockRepo.AssertNotCalled(t, "CreateCourse", ctx, newCourse)
+	})
+}
+
+func TestCourseService_CreateCourse_Organization(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Manager Defaults To Own Department", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockCourseRepo), new(mockOrganizationRepo)
+		svc := NewCourseService(mockRepo, org)
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		mockRepo.On("CreateCourse", ctx, mock.MatchedBy(func(c *models.Course) bool {
+			return c.DepartmentID != nil && *c.DepartmentID == "5"
+		})).Return(&models.Course{ID: "1"}, nil).Once()
+
+		// Act
+		_, err := svc.CreateCourse(ctx, &models.Course{Name: "Compilers"}, "40")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Manager Outside Department", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockCourseRepo), new(mockOrganizationRepo)
+		svc := NewCourseService(mockRepo, org)
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		org.On("GetDepartmentByID", ctx, "2").Return(&models.Department{ID: "2"}, nil).Once()
+
+		// Act
+		_, err := svc.CreateCourse(ctx, &models.Course{Name: "Compilers", DepartmentID: strPtr("2")}, "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "CreateCourse", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Allowed Faculties Are Canonicalized", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockCourseRepo), new(mockOrganizationRepo)
+		svc := NewCourseService(mockRepo, org)
+		org.On("FindFaculty", ctx, "fit").Return(&models.Faculty{ID: "7", Name: "Information Technology"}, nil).Once()
+		mockRepo.On("CreateCourse", ctx, mock.MatchedBy(func(c *models.Course) bool {
+			return assert.ObjectsAreEqual([]string{"Information Technology"}, []string(c.AllowedFaculties))
+		})).Return(&models.Course{ID: "1"}, nil).Once()
+
+		// Act
+		_, err := svc.CreateCourse(ctx, &models.Course{Name: "Compilers", AllowedFaculties: []string{"fit"}}, "")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCourseService_DeleteCourse_OtherDepartment(t *testing.T) {
+	// Arrange
+	mockRepo, org := new(mockCourseRepo), new(mockOrganizationRepo)
+	svc := NewCourseService(mockRepo, org)
+	ctx := context.Background()
+	org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+	mockRepo.On("GetCourseByID", ctx, "1").Return(&models.Course{ID: "1", DepartmentID: strPtr("2")}, nil).Once()
+
+	// Act
+	err := svc.DeleteCourse(ctx, "1", "40")
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+	mockRepo.AssertNotCalled(t, "DeleteCourse", mock.Anything, mock.Anything)
 }
 
 func TestCourseService_GetAllCourses(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockCourseRepo)
-	svc := NewCourseService(mockRepo)
+	svc := NewCourseService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -166,7 +250,7 @@ func TestCourseService_GetAllCourses(t *testing.T) {
 func TestCourseService_GetCourseByID(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockCourseRepo)
-	svc := NewCourseService(mockRepo)
+	svc := NewCourseService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -219,7 +303,7 @@ func TestCourseService_GetCourseByID(t *testing.T) {
 func TestCourseService_UpdateCourse(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockCourseRepo)
-	svc := NewCourseService(mockRepo)
+	svc := NewCourseService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -231,7 +315,7 @@ func TestCourseService_UpdateCourse(t *testing.T) {
 		mockRepo.On("UpdateCourse", ctx, updatedCourse).Return(&updatedCourse, nil).Once()
 
 		// Act
-		course, err := svc.UpdateCourse(ctx, updatedCourse)
+		course, err := svc.UpdateCourse(ctx, updatedCourse, "")
 
 		// Assert
 		assert.NoError(t, err)
@@ -249,7 +333,7 @@ func TestCourseService_UpdateCourse(t *testing.T) {
 		mockRepo.On("UpdateCourse", ctx, updatedCourse).Return(nil, expectedError).Once()
 
 		// Act
-		course, err := svc.UpdateCourse(ctx, updatedCourse)
+		course, err := svc.UpdateCourse(ctx, updatedCourse, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -262,7 +346,7 @@ func TestCourseService_UpdateCourse(t *testing.T) {
 func TestCourseService_DeleteCourse(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockCourseRepo)
-	svc := NewCourseService(mockRepo)
+	svc := NewCourseService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -270,7 +354,7 @@ func TestCourseService_DeleteCourse(t *testing.T) {
 		mockRepo.On("DeleteCourse", ctx, courseID).Return(nil).Once()
 
 		// Act
-		err := svc.DeleteCourse(ctx, courseID)
+		err := svc.DeleteCourse(ctx, courseID, "")
 
 		// Assert
 		assert.NoError(t, err)
@@ -283,7 +367,7 @@ func TestCourseService_DeleteCourse(t *testing.T) {
 		mockRepo.On("DeleteCourse", ctx, courseID).Return(expectedError).Once()
 
 		// Act
-		err := svc.DeleteCourse(ctx, courseID)
+		err := svc.DeleteCourse(ctx, courseID, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -295,7 +379,7 @@ func TestCourseService_DeleteCourse(t *testing.T) {
 func TestCourseService_GetCourseStudents(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockCourseRepo)
-	svc := NewCourseService(mockRepo)
+	svc := NewCourseService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -348,7 +432,7 @@ func TestCourseService_GetCourseStudents(t *testing.T) {
 func TestCourseService_GetCourseTeachers(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockCourseRepo)
-	svc := NewCourseService(mockRepo)
+	svc := NewCourseService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+	"university_system/internal/domain/models"
+
+	"github.com/jung-kurt/gofpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const universityName = "University System"
+
+// Документы печатаются встроенным шрифтом DejaVu Sans Condensed в UTF-8,
+// поэтому кириллица и другие алфавиты в именах и названиях курсов выводятся как есть
+const pdfFont = "DejaVu"
+
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	dejaVuRegular []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	dejaVuBold []byte
+	//go:embed fonts/DejaVuSansCondensed-Oblique.ttf
+	dejaVuItalic []byte
+)
+
+// newUTF8PDF создаёт документ A4 со шрифтом pdfFont (начертания "", "B" и "I"),
+// полями 20 мм и датой создания createdAt, чтобы одинаковые данные давали одинаковый PDF
+func newUTF8PDF(title string, createdAt time.Time, bottomMargin float64) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(pdfFont, "", dejaVuRegular)
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", dejaVuBold)
+	pdf.AddUTF8FontFromBytes(pdfFont, "I", dejaVuItalic)
+	pdf.SetCreationDate(createdAt)
+	pdf.SetModificationDate(createdAt)
+	pdf.SetCatalogSort(true)
+	pdf.SetTitle(title, true)
+	pdf.SetAuthor(universityName, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, bottomMargin)
+	return pdf
+}
+
+// pdfFooter печатает внизу каждой страницы строку, которую возвращает text
+func pdfFooter(pdf *gofpdf.Fpdf, text func() string) {
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(pdfFont, "I", 8)
+		pdf.CellFormat(0, 5, text(), "", 0, "C", false, 0, "")
+	})
+}
+
+// pdfHeading печатает шапку страницы: название университета, заголовок документа,
+// необязательную строку note и разделительную черту
+func pdfHeading(pdf *gofpdf.Fpdf, title, note string) {
+	pdf.SetFont(pdfFont, "B", 16)
+	pdf.CellFormat(0, 8, universityName, "", 1, "C", false, 0, "")
+	pdf.SetFont(pdfFont, "", 12)
+	pdf.CellFormat(0, 7, title, "", 1, "C", false, 0, "")
+	if note != "" {
+		pdf.SetFont(pdfFont, "", 9)
+		pdf.CellFormat(0, 5, note, "", 1, "C", false, 0, "")
+	}
+	pdf.Line(20, pdf.GetY()+2, 190, pdf.GetY()+2)
+	pdf.Ln(6)
+}
+
+// pdfSignature печатает строку для подписи с должностью подписывающего
+func pdfSignature(pdf *gofpdf.Fpdf, role string) {
+	pdf.SetFont(pdfFont, "", 10)
+	pdf.CellFormat(80, 6, "______________________________", "", 1, "L", false, 0, "")
+	pdf.CellFormat(80, 5, role, "", 1, "L", false, 0, "")
+}
+
+// documentPDF — общий макет официального документа: шапка, подпись и блок проверки
+type documentPDF struct {
+	pdf       *gofpdf.Fpdf
+	document  *models.IssuedDocument
+	verifyURL string
+}
+
+func newDocumentPDF(title string, document *models.IssuedDocument, verifyURL string) *documentPDF {
+	pdf := newUTF8PDF(title, document.IssuedAt, 25)
+	d := &documentPDF{pdf: pdf, document: document, verifyURL: verifyURL}
+	pdfFooter(pdf, func() string {
+		return fmt.Sprintf("Verification code %s - page %d", document.Code, pdf.PageNo())
+	})
+	pdf.AddPage()
+	pdfHeading(pdf, title, "Issued "+document.IssuedAt.Format("2 January 2006"))
+	return d
+}
+
+func (d *documentPDF) field(label, value string) {
+	d.pdf.SetFont(pdfFont, "B", 10)
+	d.pdf.CellFormat(40, 6, label, "", 0, "L", false, 0, "")
+	d.pdf.SetFont(pdfFont, "", 10)
+	d.pdf.CellFormat(0, 6, value, "", 1, "L", false, 0, "")
+}
+
+func (d *documentPDF) paragraph(text string) {
+	d.pdf.SetFont(pdfFont, "", 11)
+	d.pdf.MultiCell(0, 6, text, "", "J", false)
+	d.pdf.Ln(3)
+}
+
+// table печатает таблицу; ширины колонок в миллиметрах, первая колонка выравнивается влево
+func (d *documentPDF) table(widths []float64, header []string, rows [][]string) {
+	d.pdf.SetFont(pdfFont, "B", 9)
+	d.pdf.SetFillColor(230, 230, 230)
+	for i, title := range header {
+		d.pdf.CellFormat(widths[i], 6, title, "1", 0, "C", true, 0, "")
+	}
+	d.pdf.Ln(-1)
+	d.pdf.SetFont(pdfFont, "", 9)
+	for _, row := range rows {
+		for i, value := range row {
+			align := "C"
+			if i <= 1 {
+				align = "L"
+			}
+			d.pdf.CellFormat(widths[i], 6, value, "1", 0, align, false, 0, "")
+		}
+		d.pdf.Ln(-1)
+	}
+}
+
+// finish добавляет подпись регистратора, QR-код со ссылкой проверки и возвращает PDF
+func (d *documentPDF) finish() ([]byte, error) {
+	pdf := d.pdf
+	if pdf.GetY() > 230 {
+		pdf.AddPage()
+	}
+	pdf.Ln(12)
+	top := pdf.GetY()
+	pdfSignature(pdf, "Registrar")
+	pdf.CellFormat(80, 5, universityName, "", 1, "L", false, 0, "")
+
+	link := d.verifyURL + d.document.Code
+	png, err := qrcode.Encode(link, qrcode.Medium, 256)
+	if err != nil {
+		return nil, err
+	}
+	options := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("verification-qr", options, bytes.NewReader(png))
+	pdf.ImageOptions("verification-qr", 155, top-4, 35, 35, false, options, 0, link)
+
+	pdf.SetY(top + 34)
+	pdf.SetFont(pdfFont, "", 8)
+	pdf.MultiCell(0, 4, fmt.Sprintf("Verify this document at %s using code %s.", link, d.document.Code), "", "L", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func studentFullName(student *models.Student) string {
+	return strings.TrimSpace(student.Firstname + " " + student.Lastname)
+}
+
+func termTitle(season string, year int) string {
+	if season == "" {
+		return fmt.Sprint(year)
+	}
+	return fmt.Sprintf("%s%s %d", strings.ToUpper(season[:1]), season[1:], year)
+}
+
+func formatPoints(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *value)
+}
+
+func renderTranscriptPDF(student *models.Student, transcript *models.Transcript, document *models.IssuedDocument, verifyURL string) ([]byte, error) {
+	d := newDocumentPDF("Official Academic Transcript", document, verifyURL)
+	d.field("Student", studentFullName(student))
+	d.field("Student ID", student.ID)
+	d.field("Faculty", student.Faculty)
+	d.field("Year of study", fmt.Sprint(student.StudentYear))
+	d.pdf.Ln(4)
+
+	widths := []float64{25, 70, 17, 20, 18, 20}
+	for _, term := range transcript.Terms {
+		d.pdf.SetFont(pdfFont, "B", 11)
+		d.pdf.CellFormat(0, 7, termTitle(term.Season, term.Year), "", 1, "L", false, 0, "")
+		rows := make([][]string, 0, len(term.Courses))
+		for _, course := range term.Courses {
+			letter := course.Letter
+			if letter == "" {
+				letter = "IP"
+			}
+			rows = append(rows, []string{course.Code, course.Name, fmt.Sprint(course.Credits),
+				formatPoints(course.Total), letter, formatPoints(course.GPAPoints)})
+		}
+		d.table(widths, []string{"Code", "Course", "Credits", "Total", "Grade", "Points"}, rows)
+		d.pdf.SetFont(pdfFont, "", 9)
+		d.pdf.CellFormat(0, 6, fmt.Sprintf("Credits attempted %d, earned %d. Term GPA %.2f, cumulative GPA %.2f.",
+			term.AttemptedCredits, term.EarnedCredits, term.TermGPA, term.CumulativeGPA), "", 1, "L", false, 0, "")
+		d.pdf.Ln(3)
+	}
+	if len(transcript.Terms) == 0 {
+		d.paragraph("No courses have been recorded for this student.")
+	}
+
+	d.pdf.Ln(2)
+	d.field("Credits earned", fmt.Sprint(transcript.EarnedCredits))
+	d.field("Cumulative GPA", fmt.Sprintf("%.2f", transcript.CumulativeGPA))
+	d.field("Standing", transcript.Standing)
+	d.pdf.SetFont(pdfFont, "I", 8)
+	d.pdf.MultiCell(0, 4, "W - withdrawn, IP - in progress. GPA is weighted by course credits.", "", "L", false)
+	return d.finish()
+}
+
+func renderEnrollmentCertificatePDF(student *models.Student, term *models.AcademicTerm, courses []models.Course,
+	document *models.IssuedDocument, verifyURL string) ([]byte, error) {
+	d := newDocumentPDF("Certificate of Enrollment", document, verifyURL)
+	credits := 0
+	rows := make([][]string, 0, len(courses))
+	for _, course := range courses {
+		credits += course.Credits
+		rows = append(rows, []string{course.Code, course.Name, fmt.Sprint(course.Credits)})
+	}
+
+	d.paragraph(fmt.Sprintf("This is to certify that %s (student ID %s) is enrolled as a year %d student of the faculty of %s "+
+		"for the %s term, which runs from %s to %s, with a course load of %d credits.",
+		studentFullName(student), student.ID, student.StudentYear, student.Faculty, termTitle(term.Season, term.Year),
+		term.StartDate.Format("2 January 2006"), term.EndDate.Format("2 January 2006"), credits))
+	d.table([]float64{30, 110, 30}, []string{"Code", "Course", "Credits"}, rows)
+	d.pdf.Ln(4)
+	d.paragraph("This certificate is issued upon request and is valid as of " + document.IssuedAt.Format(time.DateOnly) + ".")
+	return d.finish()
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type DocumentService interface {
+	IssueTranscript(ctx context.Context, studentID, issuedBy string) (*models.IssuedDocument, []byte, error)
+	IssueEnrollmentCertificate(ctx context.Context, studentID, issuedBy string) (*models.IssuedDocument, []byte, error)
+	GetStudentDocuments(ctx context.Context, studentID string) ([]models.IssuedDocument, error)
+	VerifyDocument(ctx context.Context, code, contentHash string) (*models.DocumentVerification, error)
+}
+
+type documentService struct {
+	repo      repository.DocumentRepository
+	students  repository.StudentRepository
+	terms     repository.TermRepository
+	grades    GradeService
+	verifyURL string
+	now       func() time.Time
+}
+
+// NewDocumentService создаёт сервис документов; verifyURL — публичный адрес проверки,
+// к которому дописывается код документа (например, "https://uni.example/verify/")
+func NewDocumentService(repo repository.DocumentRepository, students repository.StudentRepository,
+	terms repository.TermRepository, grades GradeService, verifyURL string) DocumentService {
+	return &documentService{repo: repo, students: students, terms: terms, grades: grades, verifyURL: verifyURL, now: time.Now}
+}
+
+func (s *documentService) IssueTranscript(ctx context.Context, studentID, issuedBy string) (*models.IssuedDocument, []byte, error) {
+	student, err := s.students.GetStudentById(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	transcript, err := s.grades.GetTranscript(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, err := s.newDocument(models.DocumentTypeTranscript, student, issuedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	pdf, err := renderTranscriptPDF(student, transcript, document, s.verifyURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.save(ctx, document, pdf)
+}
+
+// IssueEnrollmentCertificate подтверждает запись студента на курсы идущего периода
+func (s *documentService) IssueEnrollmentCertificate(ctx context.Context, studentID, issuedBy string) (*models.IssuedDocument, []byte, error) {
+	student, err := s.students.GetStudentById(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	terms, err := s.terms.GetTerms(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var term *models.AcademicTerm
+	now := s.now()
+	for i := range terms {
+		if terms[i].InSession(now) {
+			term = &terms[i]
+			break
+		}
+	}
+	if term == nil {
+		return nil, nil, models.ErrNotEnrolledInTerm
+	}
+	courses, err := s.students.GetTermCourses(ctx, studentID, term.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(courses) == 0 {
+		return nil, nil, models.ErrNotEnrolledInTerm
+	}
+
+	document, err := s.newDocument(models.DocumentTypeEnrollmentCertificate, student, issuedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	document.TermID = &term.ID
+	pdf, err := renderEnrollmentCertificatePDF(student, term, courses, document, s.verifyURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.save(ctx, document, pdf)
+}
+
+func (s *documentService) GetStudentDocuments(ctx context.Context, studentID string) ([]models.IssuedDocument, error) {
+	return s.repo.GetStudentDocuments(ctx, studentID)
+}
+
+// VerifyDocument находит документ по коду; если передан хеш файла, сравнивает его с хешем выданного документа
+func (s *documentService) VerifyDocument(ctx context.Context, code, contentHash string) (*models.DocumentVerification, error) {
+	document, err := s.repo.GetDocumentByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	verification := &models.DocumentVerification{
+		Valid:       true,
+		Code:        document.Code,
+		Type:        document.Type,
+		StudentName: document.StudentName,
+		TermID:      document.TermID,
+		IssuedAt:    document.IssuedAt,
+		ContentHash: document.ContentHash,
+	}
+	if contentHash != "" {
+		matches := strings.EqualFold(strings.TrimSpace(contentHash), document.ContentHash)
+		verification.HashMatches = &matches
+	}
+	return verification, nil
+}
+
+func (s *documentService) newDocument(documentType string, student *models.Student, issuedBy string) (*models.IssuedDocument, error) {
+	code, err := newVerificationCode()
+	if err != nil {
+		return nil, err
+	}
+	document := &models.IssuedDocument{
+		Code:        code,
+		Type:        documentType,
+		StudentID:   student.ID,
+		StudentName: studentFullName(student),
+		IssuedAt:    s.now().UTC().Truncate(time.Second),
+	}
+	if issuedBy != "" {
+		document.IssuedBy = &issuedBy
+	}
+	return document, nil
+}
+
+func (s *documentService) save(ctx context.Context, document *models.IssuedDocument, pdf []byte) (*models.IssuedDocument, []byte, error) {
+	sum := sha256.Sum256(pdf)
+	document.ContentHash = hex.EncodeToString(sum[:])
+	saved, err := s.repo.CreateDocument(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	return saved, pdf, nil
+}
+
+// newVerificationCode возвращает случайный код вида XXXX-XXXX-XXXX-XXXX (80 бит, base32)
+func newVerificationCode() (string, error) {
+	raw := make([]byte, 10)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	encoded := base32.StdEncoding.EncodeToString(raw)
+	parts := make([]string, 0, 4)
+	for i := 0; i < len(encoded); i += 4 {
+		parts = append(parts, encoded[i:i+4])
+	}
+	return strings.Join(parts, "-"), nil
+}
@@ -0,0 +1,340 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockDocumentRepo struct {
+	mock.Mock
+}
+
+func (m *mockDocumentRepo) CreateDocument(ctx context.Context, document *models.IssuedDocument) (*models.IssuedDocument, error) {
+	args := m.Called(ctx, document)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IssuedDocument), args.Error(1)
+}
+
+func (m *mockDocumentRepo) GetDocumentByCode(ctx context.Context, code string) (*models.IssuedDocument, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IssuedDocument), args.Error(1)
+}
+
+func (m *mockDocumentRepo) GetStudentDocuments(ctx context.Context, studentID string) ([]models.IssuedDocument, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.IssuedDocument), args.Error(1)
+}
+
+type mockGradeService struct {
+	mock.Mock
+}
+
+func (m *mockGradeService) GetStudentMarks(ctx context.Context, studentID string) ([]models.GradedMark, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GradedMark), args.Error(1)
+}
+
+func (m *mockGradeService) GetCourseMarks(ctx context.Context, courseID string) ([]models.GradedMark, error) {
+	args := m.Called(ctx, courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GradedMark), args.Error(1)
+}
+
+func (m *mockGradeService) AddMark(ctx context.Context, teacherID, changedBy string, mark *models.Mark, markType string) error {
+	args := m.Called(ctx, teacherID, changedBy, mark, markType)
+	return args.Error(0)
+}
+
+func (m *mockGradeService) GetTranscript(ctx context.Context, studentID string) (*models.Transcript, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *mockGradeService) GetGradeSheet(ctx context.Context, termID, offeringID string) (*models.GradeSheet, error) {
+	args := m.Called(ctx, termID, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeSheet), args.Error(1)
+}
+
+func (m *mockGradeService) SubmitGrades(ctx context.Context, termID, offeringID, userID string, asTeacher bool) error {
+	args := m.Called(ctx, termID, offeringID, userID, asTeacher)
+	return args.Error(0)
+}
+
+func (m *mockGradeService) ApproveGrades(ctx context.Context, termID, offeringID, managerID, comment string) error {
+	args := m.Called(ctx, termID, offeringID, managerID, comment)
+	return args.Error(0)
+}
+
+func (m *mockGradeService) ReturnGrades(ctx context.Context, termID, offeringID, managerID, comment string) error {
+	args := m.Called(ctx, termID, offeringID, managerID, comment)
+	return args.Error(0)
+}
+
+func (m *mockGradeService) GetMarkHistory(ctx context.Context, termID, offeringID string) ([]models.MarkChange, error) {
+	args := m.Called(ctx, termID, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MarkChange), args.Error(1)
+}
+
+func (m *mockGradeService) RequestGradeChange(ctx context.Context, termID, userID string, asTeacher bool, request *models.GradeChangeRequest) (*models.GradeChangeRequest, error) {
+	args := m.Called(ctx, termID, userID, asTeacher, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeChangeRequest), args.Error(1)
+}
+
+func (m *mockGradeService) GetGradeChangeRequests(ctx context.Context, status string) ([]models.GradeChangeRequest, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GradeChangeRequest), args.Error(1)
+}
+
+func (m *mockGradeService) ReviewGradeChange(ctx context.Context, id, managerID string, approve bool, comment string) (*models.GradeChangeRequest, error) {
+	args := m.Called(ctx, id, managerID, approve, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeChangeRequest), args.Error(1)
+}
+
+var verificationCodePattern = regexp.MustCompile(`^[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}$`)
+
+func TestDocumentService_IssueTranscript(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockDocumentRepo)
+	mockStudents := new(mockStudentRepo)
+	mockGrades := new(mockGradeService)
+	svc := NewDocumentService(mockRepo, mockStudents, new(mockTermRepo), mockGrades, "https://uni.example/verify/")
+	ctx := context.Background()
+	student := &models.Student{User: models.User{ID: "101", Firstname: "Aruzhan", Lastname: "Sadykova"}, StudentYear: 2, Faculty: "FIT"}
+	total := 96.0
+	points := 4.0
+	transcript := &models.Transcript{
+		StudentID: "101",
+		Terms: []models.TranscriptTerm{{
+			TermID: "1", Year: 2025, Season: models.SeasonFall,
+			Courses:          []models.TranscriptCourse{{Code: "CS101", Name: "Programming", Credits: 5, Total: &total, Letter: "A", GPAPoints: &points, CreditsEarned: 5}},
+			AttemptedCredits: 5, EarnedCredits: 5, TermGPA: 4, CumulativeGPA: 4, Standing: models.StandingGood,
+		}},
+		EarnedCredits: 5, CumulativeGPA: 4, Standing: models.StandingGood,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockStudents.On("GetStudentById", ctx, "101").Return(student, nil).Once()
+		mockGrades.On("GetTranscript", ctx, "101").Return(transcript, nil).Once()
+		var saved *models.IssuedDocument
+		mockRepo.On("CreateDocument", ctx, mock.AnythingOfType("*models.IssuedDocument")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*models.IssuedDocument) }).
+			Return(&models.IssuedDocument{ID: "1"}, nil).Once()
+
+		// Act
+		document, pdf, err := svc.IssueTranscript(ctx, "101", "30")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1", document.ID)
+		document = saved
+		assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+		sum := sha256.Sum256(pdf)
+		assert.Equal(t, hex.EncodeToString(sum[:]), document.ContentHash)
+		assert.Regexp(t, verificationCodePattern, document.Code)
+		assert.Equal(t, models.DocumentTypeTranscript, document.Type)
+		assert.Equal(t, "Aruzhan Sadykova", document.StudentName)
+		assert.Equal(t, "30", *document.IssuedBy)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// pdfText распаковывает потоки содержимого PDF, в которых лежит напечатанный текст
+func pdfText(pdf []byte) []byte {
+	var text []byte
+	for rest := pdf; ; {
+		start := bytes.Index(rest, []byte("stream\n"))
+		if start < 0 {
+			return text
+		}
+		rest = rest[start+len("stream\n"):]
+		end := bytes.Index(rest, []byte("endstream"))
+		if end < 0 {
+			return text
+		}
+		if reader, err := zlib.NewReader(bytes.NewReader(rest[:end])); err == nil {
+			content, _ := io.ReadAll(reader)
+			text = append(text, content...)
+		}
+		rest = rest[end+len("endstream"):]
+	}
+}
+
+// utf16BE кодирует строку так, как UTF-8 шрифт gofpdf печатает её в потоке содержимого
+func utf16BE(s string) []byte {
+	var encoded []byte
+	for _, c := range utf16.Encode([]rune(s)) {
+		encoded = append(encoded, byte(c>>8), byte(c))
+	}
+	return encoded
+}
+
+func TestRenderTranscriptPDF_Cyrillic(t *testing.T) {
+	// Arrange
+	student := &models.Student{User: models.User{ID: "101", Firstname: "Айгерим", Lastname: "Иванова"}, StudentYear: 2, Faculty: "ФИТ"}
+	document := &models.IssuedDocument{Code: "ABCD2345EFGH", IssuedAt: time.Date(2025, time.September, 5, 10, 0, 0, 0, time.UTC)}
+
+	// Act
+	pdf, err := renderTranscriptPDF(student, &models.Transcript{StudentID: "101"}, document, "https://uni.example/verify/")
+
+	// Assert
+	assert.NoError(t, err)
+	text := pdfText(pdf)
+	assert.True(t, bytes.Contains(text, utf16BE("Айгерим Иванова")), "student name is printed in Cyrillic")
+	assert.False(t, bytes.Contains(text, []byte("??????? ???????")))
+	assert.True(t, bytes.Contains(pdf, []byte("/BaseFont /utf8dejavu")))
+}
+
+func TestDocumentService_IssueEnrollmentCertificate(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockDocumentRepo)
+	mockStudents := new(mockStudentRepo)
+	mockTerms := new(mockTermRepo)
+	svc := NewDocumentService(mockRepo, mockStudents, mockTerms, new(mockGradeService), "https://uni.example/verify/")
+	ctx := context.Background()
+	student := &models.Student{User: models.User{ID: "101", Firstname: "Aruzhan", Lastname: "Sadykova"}, StudentYear: 2, Faculty: "FIT"}
+	term := fallTerm()
+
+	t.Run("Success", func(t *testing.T) {
+		svc.(*documentService).now = func() time.Time { return date(2025, time.October, 1) }
+		mockStudents.On("GetStudentById", ctx, "101").Return(student, nil).Once()
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockStudents.On("GetTermCourses", ctx, "101", term.ID).Return([]models.Course{{ID: "7", Code: "CS101", Name: "Programming", Credits: 5}}, nil).Once()
+		var saved *models.IssuedDocument
+		mockRepo.On("CreateDocument", ctx, mock.AnythingOfType("*models.IssuedDocument")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*models.IssuedDocument) }).
+			Return(&models.IssuedDocument{ID: "1"}, nil).Once()
+
+		// Act
+		_, pdf, err := svc.IssueEnrollmentCertificate(ctx, "101", "")
+
+		// Assert
+		assert.NoError(t, err)
+		document := saved
+		assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+		assert.Equal(t, models.DocumentTypeEnrollmentCertificate, document.Type)
+		assert.Equal(t, term.ID, *document.TermID)
+		assert.Nil(t, document.IssuedBy)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("No Courses This Term", func(t *testing.T) {
+		svc.(*documentService).now = func() time.Time { return date(2025, time.October, 1) }
+		mockStudents.On("GetStudentById", ctx, "102").Return(student, nil).Once()
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockStudents.On("GetTermCourses", ctx, "102", term.ID).Return([]models.Course{}, nil).Once()
+
+		// Act
+		_, _, err := svc.IssueEnrollmentCertificate(ctx, "102", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotEnrolledInTerm)
+	})
+
+	t.Run("Term Over", func(t *testing.T) {
+		svc.(*documentService).now = func() time.Time { return date(2026, time.February, 1) }
+		mockStudents.On("GetStudentById", ctx, "101").Return(student, nil).Once()
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+
+		// Act
+		_, _, err := svc.IssueEnrollmentCertificate(ctx, "101", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotEnrolledInTerm)
+		mockStudents.AssertExpectations(t)
+	})
+}
+
+func TestDocumentService_VerifyDocument(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockDocumentRepo)
+	svc := NewDocumentService(mockRepo, new(mockStudentRepo), new(mockTermRepo), new(mockGradeService), "")
+	ctx := context.Background()
+	document := &models.IssuedDocument{Code: "ABCD-EFGH-IJKL-MNOP", Type: models.DocumentTypeTranscript, StudentName: "Aruzhan Sadykova", ContentHash: "ab12"}
+
+	t.Run("Hash Matches", func(t *testing.T) {
+		mockRepo.On("GetDocumentByCode", ctx, "ABCD-EFGH-IJKL-MNOP").Return(document, nil).Once()
+
+		// Act
+		verification, err := svc.VerifyDocument(ctx, " abcd-efgh-ijkl-mnop ", "AB12")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, verification.Valid)
+		assert.True(t, *verification.HashMatches)
+	})
+
+	t.Run("Hash Differs", func(t *testing.T) {
+		mockRepo.On("GetDocumentByCode", ctx, "ABCD-EFGH-IJKL-MNOP").Return(document, nil).Once()
+
+		// Act
+		verification, err := svc.VerifyDocument(ctx, "ABCD-EFGH-IJKL-MNOP", "ff00")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, *verification.HashMatches)
+	})
+
+	t.Run("Without Hash", func(t *testing.T) {
+		mockRepo.On("GetDocumentByCode", ctx, "ABCD-EFGH-IJKL-MNOP").Return(document, nil).Once()
+
+		// Act
+		verification, err := svc.VerifyDocument(ctx, "ABCD-EFGH-IJKL-MNOP", "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, verification.HashMatches)
+	})
+
+	t.Run("Unknown Code", func(t *testing.T) {
+		mockRepo.On("GetDocumentByCode", ctx, "ZZZZ").Return(nil, models.ErrDocumentNotFound).Once()
+
+		// Act
+		_, err := svc.VerifyDocument(ctx, "zzzz", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrDocumentNotFound)
+	})
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"university_system/internal/domain/models"
+)
+
+// Выражения пре- и корреквизитов задаются над кодами курсов, например
+// "(CS101 >= 70 or CS102) and MATH101". Код без порога означает, что курс сдан
+// (итоговый балл не ниже проходного по схеме оценивания, действовавшей в том периоде).
+// Вместо and/or можно писать && и ||.
+
+// requisiteContext — сведения о студенте, по которым вычисляются выражения
+type requisiteContext struct {
+	completed map[string]float64 // код курса → лучший итоговый балл за прошлые периоды
+	passed    map[string]bool    // курсы, сданные в прошлых периодах по своей схеме оценивания
+	current   map[string]bool    // курсы, на которые студент записан в текущем периоде
+}
+
+type requisiteExpr interface {
+	// satisfied проверяет выражение; при allowCurrent курс, изучаемый в том же периоде,
+	// засчитывается (так работают корреквизиты)
+	satisfied(rc requisiteContext, allowCurrent bool) bool
+	// unmet перечисляет невыполненные требования для объяснения отказа
+	unmet(rc requisiteContext, allowCurrent bool) []string
+}
+
+// courseRequirement — требование к курсу: балл не ниже minTotal, а без порога (hasMin == false) —
+// курс сдан по своей схеме оценивания
+type courseRequirement struct {
+	code     string
+	minTotal float64
+	hasMin   bool
+}
+
+func (r courseRequirement) satisfied(rc requisiteContext, allowCurrent bool) bool {
+	if allowCurrent && rc.current[r.code] {
+		return true
+	}
+	if !r.hasMin {
+		return rc.passed[r.code]
+	}
+	total, ok := rc.completed[r.code]
+	return ok && total >= r.minTotal
+}
+
+func (r courseRequirement) unmet(rc requisiteContext, allowCurrent bool) []string {
+	if r.satisfied(rc, allowCurrent) {
+		return nil
+	}
+	want := r.code
+	if r.hasMin {
+		want = fmt.Sprintf("%s >= %g", r.code, r.minTotal)
+	}
+	if total, ok := rc.completed[r.code]; ok {
+		if !r.hasMin {
+			return []string{fmt.Sprintf("%s (not passed, best result %g)", want, total)}
+		}
+		return []string{fmt.Sprintf("%s (best result %g)", want, total)}
+	}
+	if allowCurrent {
+		return []string{want + " (not completed and not enrolled this term)"}
+	}
+	return []string{want + " (not completed)"}
+}
+
+type allOf []requisiteExpr
+
+func (a allOf) satisfied(rc requisiteContext, allowCurrent bool) bool {
+	for _, e := range a {
+		if !e.satisfied(rc, allowCurrent) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allOf) unmet(rc requisiteContext, allowCurrent bool) []string {
+	var result []string
+	for _, e := range a {
+		result = append(result, e.unmet(rc, allowCurrent)...)
+	}
+	return result
+}
+
+type anyOf []requisiteExpr
+
+func (a anyOf) satisfied(rc requisiteContext, allowCurrent bool) bool {
+	for _, e := range a {
+		if e.satisfied(rc, allowCurrent) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a anyOf) unmet(rc requisiteContext, allowCurrent bool) []string {
+	if a.satisfied(rc, allowCurrent) {
+		return nil
+	}
+	options := make([]string, 0, len(a))
+	for _, e := range a {
+		options = append(options, strings.Join(e.unmet(rc, allowCurrent), " and "))
+	}
+	return []string{"one of: " + strings.Join(options, "; ")}
+}
+
+// parseRequisites разбирает выражение; пустая строка означает отсутствие требований
+func parseRequisites(expr string) (requisiteExpr, error) {
+	tokens, err := tokenizeRequisites(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &requisiteParser{tokens: tokens}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", models.ErrInvalidRequisite, p.tokens[p.pos])
+	}
+	return result, nil
+}
+
+func tokenizeRequisites(expr string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		ch := rune(expr[i])
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '(' || ch == ')':
+			tokens = append(tokens, string(ch))
+			i++
+		case strings.HasPrefix(expr[i:], ">="), strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case isCodeChar(ch):
+			start := i
+			for i < len(expr) && isCodeChar(rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, expr[start:i])
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q", models.ErrInvalidRequisite, ch)
+		}
+	}
+	return tokens, nil
+}
+
+func isCodeChar(ch rune) bool {
+	return ch < unicode.MaxASCII && (unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_' || ch == '-' || ch == '.')
+}
+
+type requisiteParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *requisiteParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *requisiteParser) parseOr() (requisiteExpr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	items := anyOf{first}
+	for tok := p.peek(); tok == "||" || strings.EqualFold(tok, "or"); tok = p.peek() {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, next)
+	}
+	if len(items) == 1 {
+		return first, nil
+	}
+	return items, nil
+}
+
+func (p *requisiteParser) parseAnd() (requisiteExpr, error) {
+	first, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	items := allOf{first}
+	for tok := p.peek(); tok == "&&" || strings.EqualFold(tok, "and"); tok = p.peek() {
+		p.pos++
+		next, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, next)
+	}
+	if len(items) == 1 {
+		return first, nil
+	}
+	return items, nil
+}
+
+func (p *requisiteParser) parseFactor() (requisiteExpr, error) {
+	tok := p.peek()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("%w: unexpected end of expression", models.ErrInvalidRequisite)
+	case tok == "(":
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", models.ErrInvalidRequisite)
+		}
+		p.pos++
+		return inner, nil
+	case tok == ")" || tok == ">=" || tok == "&&" || tok == "||" || strings.EqualFold(tok, "and") || strings.EqualFold(tok, "or"):
+		return nil, fmt.Errorf("%w: unexpected %q", models.ErrInvalidRequisite, tok)
+	}
+
+	p.pos++
+	req := courseRequirement{code: strings.ToUpper(tok)}
+	if p.peek() == ">=" {
+		p.pos++
+		value, err := strconv.ParseFloat(p.peek(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: minimum grade for %s must be a number", models.ErrInvalidRequisite, req.code)
+		}
+		p.pos++
+		req.minTotal, req.hasMin = value, true
+	}
+	return req, nil
+}
+
+// checkEnrollmentRules проверяет все правила записи студента на курс в периоде term.
+// completed — результаты за прошлые периоды, current — курсы, на которые студент уже записан в term,
+// waitlisted — курсы term, в листе ожидания которых он стоит. Курсы из листа ожидания учитываются
+// в нагрузке: из очереди студент попадает в секцию без повторной проверки правил.
+// Возвращает nil, если все правила выполнены, иначе *models.EnrollmentRuleError со списком нарушений.
+func checkEnrollmentRules(student *models.Student, course *models.Course, term *models.AcademicTerm,
+	completed []models.CourseResult, current, waitlisted []models.Course) error {
+	rc := requisiteContext{completed: map[string]float64{}, passed: map[string]bool{}, current: map[string]bool{}}
+	for _, result := range completed {
+		code := strings.ToUpper(result.Code)
+		if total, ok := rc.completed[code]; !ok || result.Total > total {
+			rc.completed[code] = result.Total
+		}
+		if result.Passed() {
+			rc.passed[code] = true
+		}
+	}
+	load := 0
+	for _, c := range current {
+		rc.current[strings.ToUpper(c.Code)] = true
+		load += c.Credits
+	}
+	for _, c := range waitlisted {
+		if c.ID != course.ID && !rc.current[strings.ToUpper(c.Code)] {
+			load += c.Credits
+		}
+	}
+
+	var violations []models.RuleViolation
+	requisites := []struct {
+		rule         string
+		expression   string
+		allowCurrent bool
+	}{
+		{models.RulePrerequisite, course.Prerequisites, false},
+		{models.RuleCorequisite, course.Corequisites, true},
+	}
+	for _, r := range requisites {
+		expr, err := parseRequisites(r.expression)
+		if err != nil {
+			return fmt.Errorf("course %s %s: %w", course.Code, r.rule, err)
+		}
+		if expr != nil && !expr.satisfied(rc, r.allowCurrent) {
+			violations = append(violations, models.RuleViolation{
+				Rule:       r.rule,
+				Message:    r.rule + " requirements are not met",
+				Expression: r.expression,
+				Unmet:      expr.unmet(rc, r.allowCurrent),
+			})
+		}
+	}
+
+	if course.MinStudentYear > 0 && student.StudentYear < course.MinStudentYear {
+		violations = append(violations, models.RuleViolation{
+			Rule:    models.RuleStudentYear,
+			Message: fmt.Sprintf("course is open from year %d, student is in year %d", course.MinStudentYear, student.StudentYear),
+		})
+	}
+
+	if len(course.AllowedFaculties) > 0 {
+		allowed := false
+		for _, faculty := range course.AllowedFaculties {
+			if strings.EqualFold(strings.TrimSpace(faculty), strings.TrimSpace(student.Faculty)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, models.RuleViolation{
+				Rule:    models.RuleFaculty,
+				Message: fmt.Sprintf("course is restricted to faculties %s, student is in %s", strings.Join(course.AllowedFaculties, ", "), student.Faculty),
+			})
+		}
+	}
+
+	if term.MaxCredits > 0 && load+course.Credits > term.MaxCredits {
+		violations = append(violations, models.RuleViolation{
+			Rule:    models.RuleCreditLimit,
+			Message: fmt.Sprintf("enrolling would bring the term load to %d credits, the limit is %d", load+course.Credits, term.MaxCredits),
+		})
+	}
+
+	if len(violations) > 0 {
+		return &models.EnrollmentRuleError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"university_system/internal/domain/models"
+)
+
+func result(code string, total float64) models.CourseResult {
+	return models.CourseResult{Code: code, Total: total, PassingTotal: models.PassingTotal}
+}
+
+func TestParseRequisites(t *testing.T) {
+	valid := []string{
+		"",
+		"CS101",
+		"CS101 >= 70",
+		"(CS101 >= 70 or CS102) and MATH101",
+		"cs101 && (phys-1 || PHYS.2 >= 55.5)",
+	}
+	for _, expr := range valid {
+		_, err := parseRequisites(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	invalid := []string{
+		"CS101 and",
+		"(CS101 or CS102",
+		"CS101 >= high",
+		"CS101 CS102",
+		"CS101 > 50",
+		"or CS101",
+	}
+	for _, expr := range invalid {
+		_, err := parseRequisites(expr)
+		assert.ErrorIs(t, err, models.ErrInvalidRequisite, expr)
+	}
+}
+
+func TestCheckEnrollmentRules(t *testing.T) {
+	term := fallTerm()
+	term.MaxCredits = 12
+	student := &models.Student{StudentYear: 2, Faculty: "IT"}
+
+	t.Run("All Rules Met", func(t *testing.T) {
+		course := &models.Course{
+			Code:             "CS301",
+			Credits:          5,
+			Prerequisites:    "(CS101 >= 70 or CS102) and MATH101",
+			Corequisites:     "CS302",
+			MinStudentYear:   2,
+			AllowedFaculties: []string{"it", "Math"},
+		}
+		completed := []models.CourseResult{result("CS101", 60), result("cs101", 75), result("MATH101", 50)}
+		current := []models.Course{{Code: "CS302", Credits: 5}}
+
+		// Act
+		err := checkEnrollmentRules(student, course, &term, completed, current, nil)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Explains Every Failed Rule", func(t *testing.T) {
+		course := &models.Course{
+			Code:             "CS401",
+			Credits:          5,
+			Prerequisites:    "CS101 >= 70 and (CS201 or CS202)",
+			Corequisites:     "CS402",
+			MinStudentYear:   3,
+			AllowedFaculties: []string{"Math"},
+		}
+		completed := []models.CourseResult{result("CS101", 65)}
+		current := []models.Course{{Code: "PHYS101", Credits: 8}}
+
+		// Act
+		err := checkEnrollmentRules(student, course, &term, completed, current, nil)
+
+		// Assert
+		var ruleErr *models.EnrollmentRuleError
+		assert.ErrorAs(t, err, &ruleErr)
+		rules := []string{}
+		for _, v := range ruleErr.Violations {
+			rules = append(rules, v.Rule)
+		}
+		assert.Equal(t, []string{
+			models.RulePrerequisite, models.RuleCorequisite, models.RuleStudentYear, models.RuleFaculty, models.RuleCreditLimit,
+		}, rules)
+		assert.Equal(t, []string{
+			"CS101 >= 70 (best result 65)",
+			"one of: CS201 (not completed); CS202 (not completed)",
+		}, ruleErr.Violations[0].Unmet)
+		assert.Equal(t, []string{"CS402 (not completed and not enrolled this term)"}, ruleErr.Violations[1].Unmet)
+	})
+
+	t.Run("Failed Course Does Not Count As Completed", func(t *testing.T) {
+		course := &models.Course{Code: "CS201", Prerequisites: "CS101"}
+
+		// Act
+		err := checkEnrollmentRules(student, course, &term, []models.CourseResult{result("CS101", 40)}, nil, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrEnrollmentRulesFailed)
+	})
+
+	t.Run("Passing Total Comes From Course Scheme", func(t *testing.T) {
+		course := &models.Course{Code: "CS201", Prerequisites: "CS101 and MATH101"}
+		// CS101 оценивался по схеме с проходным баллом 60, MATH101 — по схеме с проходным баллом 40
+		completed := []models.CourseResult{
+			{Code: "CS101", Total: 55, PassingTotal: 60},
+			{Code: "MATH101", Total: 45, PassingTotal: 40},
+		}
+
+		// Act
+		err := checkEnrollmentRules(student, course, &term, completed, nil, nil)
+
+		// Assert
+		var ruleErr *models.EnrollmentRuleError
+		assert.ErrorAs(t, err, &ruleErr)
+		assert.Equal(t, []string{"CS101 (not passed, best result 55)"}, ruleErr.Violations[0].Unmet)
+	})
+	t.Run("Waitlisted Courses Count Toward Credit Limit", func(t *testing.T) {
+		course := &models.Course{ID: "3", Code: "CS303", Credits: 4}
+		current := []models.Course{{ID: "1", Code: "CS301", Credits: 4}}
+		waitlisted := []models.Course{{ID: "2", Code: "CS302", Credits: 5}}
+
+		// Act
+		err := checkEnrollmentRules(student, course, &term, nil, current, waitlisted)
+
+		// Assert
+		var ruleErr *models.EnrollmentRuleError
+		assert.ErrorAs(t, err, &ruleErr)
+		assert.Equal(t, models.RuleCreditLimit, ruleErr.Violations[0].Rule)
+	})
+
+	t.Run("Waiting For The Same Course Is Not Counted Twice", func(t *testing.T) {
+		course := &models.Course{ID: "2", Code: "CS302", Credits: 5}
+		current := []models.Course{{ID: "1", Code: "CS301", Credits: 4}}
+		waitlisted := []models.Course{{ID: "2", Code: "CS302", Credits: 5}}
+
+		// Act
+		err := checkEnrollmentRules(student, course, &term, nil, current, waitlisted)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
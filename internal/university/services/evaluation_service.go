@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type EvaluationService interface {
+	GetTemplates(ctx context.Context) ([]models.EvaluationTemplate, error)
+	GetTemplateByID(ctx context.Context, id string) (*models.EvaluationTemplate, error)
+	CreateTemplate(ctx context.Context, template *models.EvaluationTemplate, createdBy string) (*models.EvaluationTemplate, error)
+	DeleteTemplate(ctx context.Context, id string) error
+	OpenEvaluations(ctx context.Context, termID string, request models.OpenEvaluationsRequest) ([]models.CourseEvaluation, error)
+	GetTermEvaluations(ctx context.Context, termID string) ([]models.CourseEvaluation, error)
+	GetStudentEvaluations(ctx context.Context, studentID string) ([]models.CourseEvaluation, error)
+	GetTeacherEvaluations(ctx context.Context, teacherID string) ([]models.CourseEvaluation, error)
+	SubmitResponse(ctx context.Context, evaluationID, studentID string, response models.EvaluationResponse) error
+	GetResults(ctx context.Context, evaluationID, userID, role string) (*models.EvaluationResults, error)
+}
+
+type evaluationService struct {
+	repo   repository.EvaluationRepository
+	terms  repository.TermRepository
+	grades repository.GradeRepository
+	now    func() time.Time
+}
+
+func NewEvaluationService(repo repository.EvaluationRepository, terms repository.TermRepository, grades repository.GradeRepository) EvaluationService {
+	return &evaluationService{repo: repo, terms: terms, grades: grades, now: time.Now}
+}
+
+func (s *evaluationService) GetTemplates(ctx context.Context) ([]models.EvaluationTemplate, error) {
+	return s.repo.GetTemplates(ctx)
+}
+
+func (s *evaluationService) GetTemplateByID(ctx context.Context, id string) (*models.EvaluationTemplate, error) {
+	return s.repo.GetTemplateByID(ctx, id)
+}
+
+// CreateTemplate сохраняет шаблон анкеты; вопросы нумеруются в порядке перечисления,
+// порог ответов по умолчанию — models.DefaultEvaluationMinResponses
+func (s *evaluationService) CreateTemplate(ctx context.Context, template *models.EvaluationTemplate, createdBy string) (*models.EvaluationTemplate, error) {
+	if template.MinResponses == 0 {
+		template.MinResponses = models.DefaultEvaluationMinResponses
+	}
+	for i := range template.Questions {
+		template.Questions[i].Position = i + 1
+	}
+	if createdBy != "" {
+		template.CreatedBy = &createdBy
+	}
+	return s.repo.CreateTemplate(ctx, template)
+}
+
+func (s *evaluationService) DeleteTemplate(ctx context.Context, id string) error {
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+// OpenEvaluations открывает анкету по шаблону для каждого курса периода; курсы, для которых
+// анкета уже открыта, не меняются. Возвращает все анкеты периода.
+func (s *evaluationService) OpenEvaluations(ctx context.Context, termID string, request models.OpenEvaluationsRequest) ([]models.CourseEvaluation, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetTemplateByID(ctx, request.TemplateID); err != nil {
+		return nil, err
+	}
+	if !request.ClosesAt.After(s.now()) {
+		return nil, fmt.Errorf("%w: closes_at must be in the future", models.ErrInvalidEvaluation)
+	}
+	if err := s.repo.OpenEvaluations(ctx, termID, request.TemplateID, request.ClosesAt); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTermEvaluations(ctx, termID)
+}
+
+func (s *evaluationService) GetTermEvaluations(ctx context.Context, termID string) ([]models.CourseEvaluation, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTermEvaluations(ctx, termID)
+}
+
+func (s *evaluationService) GetStudentEvaluations(ctx context.Context, studentID string) ([]models.CourseEvaluation, error) {
+	return s.repo.GetStudentEvaluations(ctx, studentID)
+}
+
+func (s *evaluationService) GetTeacherEvaluations(ctx context.Context, teacherID string) ([]models.CourseEvaluation, error) {
+	return s.repo.GetTeacherEvaluations(ctx, teacherID)
+}
+
+// SubmitResponse принимает анкету от студента, записанного на курс; заполнить анкету можно один раз
+// до её закрытия. Ответы проверяются по вопросам шаблона.
+func (s *evaluationService) SubmitResponse(ctx context.Context, evaluationID, studentID string, response models.EvaluationResponse) error {
+	evaluation, err := s.repo.GetEvaluationByID(ctx, evaluationID)
+	if err != nil {
+		return err
+	}
+	if !s.now().Before(evaluation.ClosesAt) {
+		return models.ErrEvaluationClosed
+	}
+	participant, err := s.repo.IsParticipant(ctx, evaluationID, studentID)
+	if err != nil {
+		return err
+	}
+	if !participant {
+		return models.ErrStudentNotEnrolled
+	}
+	template, err := s.repo.GetTemplateByID(ctx, evaluation.TemplateID)
+	if err != nil {
+		return err
+	}
+	answers, err := validateAnswers(template.Questions, response.Answers)
+	if err != nil {
+		return err
+	}
+	return s.repo.SubmitResponse(ctx, evaluationID, studentID, answers)
+}
+
+// validateAnswers сверяет ответы с вопросами: у likert — оценка по шкале, у text — непустой комментарий,
+// на каждый вопрос не больше одного ответа, обязательные вопросы отвечены
+func validateAnswers(questions []models.EvaluationQuestion, answers []models.EvaluationAnswer) ([]models.EvaluationAnswer, error) {
+	byID := make(map[string]models.EvaluationQuestion, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	answered := map[string]bool{}
+	normalized := make([]models.EvaluationAnswer, 0, len(answers))
+	for _, answer := range answers {
+		question, ok := byID[answer.QuestionID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown question %s", models.ErrInvalidEvaluationAnswer, answer.QuestionID)
+		}
+		if answered[question.ID] {
+			return nil, fmt.Errorf("%w: question %s is answered twice", models.ErrInvalidEvaluationAnswer, question.ID)
+		}
+		switch question.Kind {
+		case models.EvaluationQuestionLikert:
+			if answer.Rating == nil || *answer.Rating < models.LikertMin || *answer.Rating > models.LikertMax {
+				return nil, fmt.Errorf("%w: question %s needs a rating from %d to %d",
+					models.ErrInvalidEvaluationAnswer, question.ID, models.LikertMin, models.LikertMax)
+			}
+			answer.Comment = ""
+		case models.EvaluationQuestionText:
+			answer.Rating = nil
+			answer.Comment = strings.TrimSpace(answer.Comment)
+			if answer.Comment == "" {
+				continue
+			}
+		}
+		answered[question.ID] = true
+		normalized = append(normalized, answer)
+	}
+	for _, question := range questions {
+		if question.Required && !answered[question.ID] {
+			return nil, fmt.Errorf("%w: question %s is required", models.ErrInvalidEvaluationAnswer, question.ID)
+		}
+	}
+	return normalized, nil
+}
+
+// GetResults возвращает обезличенные результаты анкеты, если ответов не меньше порога шаблона.
+// Преподаватель видит результаты только своих курсов и только после утверждения ведомости.
+func (s *evaluationService) GetResults(ctx context.Context, evaluationID, userID, role string) (*models.EvaluationResults, error) {
+	evaluation, err := s.repo.GetEvaluationByID(ctx, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "teacher" {
+		isTeacher, err := s.grades.IsTeacherOfCourse(ctx, userID, evaluation.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		if !isTeacher {
+			return nil, models.ErrNotCourseTeacher
+		}
+		sheet, err := s.grades.GetGradeSheet(ctx, evaluation.OfferingID)
+		if err != nil {
+			return nil, err
+		}
+		if sheet.Status != models.GradeSheetLocked {
+			return nil, models.ErrEvaluationGradesNotLocked
+		}
+	}
+	template, err := s.repo.GetTemplateByID(ctx, evaluation.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.ResponseCount < template.MinResponses {
+		return nil, models.ErrNotEnoughResponses
+	}
+	answers, err := s.repo.GetAnswers(ctx, evaluationID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.EvaluationResults{
+		EvaluationID:  evaluation.ID,
+		OfferingID:    evaluation.OfferingID,
+		CourseCode:    evaluation.CourseCode,
+		CourseName:    evaluation.CourseName,
+		TermID:        evaluation.TermID,
+		ResponseCount: evaluation.ResponseCount,
+		EnrolledCount: evaluation.EnrolledCount,
+		Questions:     aggregateAnswers(template.Questions, answers),
+	}, nil
+}
+
+// aggregateAnswers сводит ответы по вопросам: для likert — среднее и распределение оценок,
+// для text — комментарии по алфавиту
+func aggregateAnswers(questions []models.EvaluationQuestion, answers []models.EvaluationAnswer) []models.QuestionResult {
+	results := make([]models.QuestionResult, len(questions))
+	index := make(map[string]int, len(questions))
+	for i, question := range questions {
+		results[i] = models.QuestionResult{QuestionID: question.ID, Position: question.Position, Kind: question.Kind, Text: question.Text}
+		if question.Kind == models.EvaluationQuestionLikert {
+			results[i].Distribution = make([]int, models.LikertMax-models.LikertMin+1)
+		} else {
+			results[i].Comments = []string{}
+		}
+		index[question.ID] = i
+	}
+
+	sums := make([]int, len(questions))
+	for _, answer := range answers {
+		i, ok := index[answer.QuestionID]
+		if !ok {
+			continue
+		}
+		result := &results[i]
+		result.Answers++
+		if answer.Rating != nil && result.Distribution != nil {
+			result.Distribution[*answer.Rating-models.LikertMin]++
+			sums[i] += *answer.Rating
+		} else if answer.Comment != "" {
+			result.Comments = append(result.Comments, answer.Comment)
+		}
+	}
+	for i := range results {
+		result := &results[i]
+		if result.Distribution != nil && result.Answers > 0 {
+			average := math.Round(float64(sums[i])/float64(result.Answers)*100) / 100
+			result.Average = &average
+		}
+		sort.Strings(result.Comments)
+	}
+	return results
+}
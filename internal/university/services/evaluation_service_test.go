@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockEvaluationRepo struct {
+	mock.Mock
+}
+
+func (m *mockEvaluationRepo) GetTemplates(ctx context.Context) ([]models.EvaluationTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EvaluationTemplate), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) GetTemplateByID(ctx context.Context, id string) (*models.EvaluationTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EvaluationTemplate), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) CreateTemplate(ctx context.Context, template *models.EvaluationTemplate) (*models.EvaluationTemplate, error) {
+	args := m.Called(ctx, template)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EvaluationTemplate), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) DeleteTemplate(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockEvaluationRepo) OpenEvaluations(ctx context.Context, termID, templateID string, closesAt time.Time) error {
+	return m.Called(ctx, termID, templateID, closesAt).Error(0)
+}
+
+func (m *mockEvaluationRepo) GetTermEvaluations(ctx context.Context, termID string) ([]models.CourseEvaluation, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseEvaluation), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) GetEvaluationByID(ctx context.Context, id string) (*models.CourseEvaluation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseEvaluation), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) GetStudentEvaluations(ctx context.Context, studentID string) ([]models.CourseEvaluation, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseEvaluation), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) GetTeacherEvaluations(ctx context.Context, teacherID string) ([]models.CourseEvaluation, error) {
+	args := m.Called(ctx, teacherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseEvaluation), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) IsParticipant(ctx context.Context, evaluationID, studentID string) (bool, error) {
+	args := m.Called(ctx, evaluationID, studentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockEvaluationRepo) SubmitResponse(ctx context.Context, evaluationID, studentID string, answers []models.EvaluationAnswer) error {
+	return m.Called(ctx, evaluationID, studentID, answers).Error(0)
+}
+
+func (m *mockEvaluationRepo) GetAnswers(ctx context.Context, evaluationID string) ([]models.EvaluationAnswer, error) {
+	args := m.Called(ctx, evaluationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EvaluationAnswer), args.Error(1)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// teachingTemplate — шаблон с обязательным вопросом likert и необязательным text
+func teachingTemplate() *models.EvaluationTemplate {
+	return &models.EvaluationTemplate{ID: "2", Name: "Teaching", MinResponses: 3, Questions: []models.EvaluationQuestion{
+		{ID: "10", Position: 1, Kind: models.EvaluationQuestionLikert, Text: "Clarity", Required: true},
+		{ID: "11", Position: 2, Kind: models.EvaluationQuestionText, Text: "Comments"},
+	}}
+}
+
+func newTestEvaluationService(repo *mockEvaluationRepo, terms *mockTermRepo, grades *mockGradeRepo) EvaluationService {
+	svc := NewEvaluationService(repo, terms, grades)
+	svc.(*evaluationService).now = func() time.Time { return date(2025, time.December, 1) }
+	return svc
+}
+
+func TestEvaluationService_CreateTemplate(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(mockEvaluationRepo)
+	svc := newTestEvaluationService(repo, new(mockTermRepo), new(mockGradeRepo))
+	template := &models.EvaluationTemplate{Name: "Teaching", Questions: []models.EvaluationQuestion{
+		{Kind: models.EvaluationQuestionLikert, Text: "Clarity"}, {Kind: models.EvaluationQuestionText, Text: "Comments"},
+	}}
+	repo.On("CreateTemplate", ctx, mock.MatchedBy(func(t *models.EvaluationTemplate) bool {
+		return t.MinResponses == models.DefaultEvaluationMinResponses && t.Questions[0].Position == 1 &&
+			t.Questions[1].Position == 2 && *t.CreatedBy == "40"
+	})).Return(template, nil).Once()
+
+	// Act
+	_, err := svc.CreateTemplate(ctx, template, "40")
+
+	// Assert
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestEvaluationService_OpenEvaluations(t *testing.T) {
+	ctx := context.Background()
+	term := fallTerm()
+
+	t.Run("Opens For Every Offering", func(t *testing.T) {
+		// Arrange
+		repo, terms := new(mockEvaluationRepo), new(mockTermRepo)
+		svc := newTestEvaluationService(repo, terms, new(mockGradeRepo))
+		closesAt := date(2025, time.December, 20)
+		terms.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		repo.On("GetTemplateByID", ctx, "2").Return(teachingTemplate(), nil).Once()
+		repo.On("OpenEvaluations", ctx, term.ID, "2", closesAt).Return(nil).Once()
+		repo.On("GetTermEvaluations", ctx, term.ID).Return([]models.CourseEvaluation{{ID: "1"}, {ID: "2"}}, nil).Once()
+
+		// Act
+		evaluations, err := svc.OpenEvaluations(ctx, term.ID, models.OpenEvaluationsRequest{TemplateID: "2", ClosesAt: closesAt})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, evaluations, 2)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Closing Date In The Past", func(t *testing.T) {
+		// Arrange
+		repo, terms := new(mockEvaluationRepo), new(mockTermRepo)
+		svc := newTestEvaluationService(repo, terms, new(mockGradeRepo))
+		terms.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		repo.On("GetTemplateByID", ctx, "2").Return(teachingTemplate(), nil).Once()
+
+		// Act
+		_, err := svc.OpenEvaluations(ctx, term.ID, models.OpenEvaluationsRequest{TemplateID: "2", ClosesAt: date(2025, time.November, 1)})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidEvaluation)
+		repo.AssertNotCalled(t, "OpenEvaluations", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEvaluationService_SubmitResponse(t *testing.T) {
+	ctx := context.Background()
+	evaluation := &models.CourseEvaluation{ID: "5", TemplateID: "2", OfferingID: "7", CourseID: "101", ClosesAt: date(2025, time.December, 20)}
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		repo := new(mockEvaluationRepo)
+		svc := newTestEvaluationService(repo, new(mockTermRepo), new(mockGradeRepo))
+		repo.On("GetEvaluationByID", ctx, "5").Return(evaluation, nil).Once()
+		repo.On("IsParticipant", ctx, "5", "1").Return(true, nil).Once()
+		repo.On("GetTemplateByID", ctx, "2").Return(teachingTemplate(), nil).Once()
+		repo.On("SubmitResponse", ctx, "5", "1", []models.EvaluationAnswer{
+			{QuestionID: "10", Rating: intPtr(4)}, {QuestionID: "11", Comment: "Great labs"},
+		}).Return(nil).Once()
+
+		// Act
+		err := svc.SubmitResponse(ctx, "5", "1", models.EvaluationResponse{Answers: []models.EvaluationAnswer{
+			{QuestionID: "10", Rating: intPtr(4), Comment: "ignored"}, {QuestionID: "11", Comment: "  Great labs "},
+		}})
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		// Arrange
+		repo := new(mockEvaluationRepo)
+		svc := newTestEvaluationService(repo, new(mockTermRepo), new(mockGradeRepo))
+		closed := *evaluation
+		closed.ClosesAt = date(2025, time.November, 30)
+		repo.On("GetEvaluationByID", ctx, "5").Return(&closed, nil).Once()
+
+		// Act
+		err := svc.SubmitResponse(ctx, "5", "1", models.EvaluationResponse{})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrEvaluationClosed)
+	})
+
+	t.Run("Student Not Enrolled", func(t *testing.T) {
+		// Arrange
+		repo := new(mockEvaluationRepo)
+		svc := newTestEvaluationService(repo, new(mockTermRepo), new(mockGradeRepo))
+		repo.On("GetEvaluationByID", ctx, "5").Return(evaluation, nil).Once()
+		repo.On("IsParticipant", ctx, "5", "9").Return(false, nil).Once()
+
+		// Act
+		err := svc.SubmitResponse(ctx, "5", "9", models.EvaluationResponse{})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrStudentNotEnrolled)
+		repo.AssertNotCalled(t, "SubmitResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	invalid := map[string][]models.EvaluationAnswer{
+		"Rating Out Of Scale":      {{QuestionID: "10", Rating: intPtr(6)}},
+		"Required Question Missed": {{QuestionID: "11", Comment: "ok"}},
+		"Unknown Question":         {{QuestionID: "10", Rating: intPtr(3)}, {QuestionID: "99", Comment: "?"}},
+		"Question Answered Twice":  {{QuestionID: "10", Rating: intPtr(3)}, {QuestionID: "10", Rating: intPtr(5)}},
+	}
+	for name, answers := range invalid {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			repo := new(mockEvaluationRepo)
+			svc := newTestEvaluationService(repo, new(mockTermRepo), new(mockGradeRepo))
+			repo.On("GetEvaluationByID", ctx, "5").Return(evaluation, nil).Once()
+			repo.On("IsParticipant", ctx, "5", "1").Return(true, nil).Once()
+			repo.On("GetTemplateByID", ctx, "2").Return(teachingTemplate(), nil).Once()
+
+			// Act
+			err := svc.SubmitResponse(ctx, "5", "1", models.EvaluationResponse{Answers: answers})
+
+			// Assert
+			assert.ErrorIs(t, err, models.ErrInvalidEvaluationAnswer)
+			repo.AssertNotCalled(t, "SubmitResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestEvaluationService_GetResults(t *testing.T) {
+	ctx := context.Background()
+	evaluation := &models.CourseEvaluation{ID: "5", TemplateID: "2", OfferingID: "7", CourseID: "101", CourseCode: "CS201", ResponseCount: 3}
+
+	t.Run("Teacher Sees Aggregated Results After Lock", func(t *testing.T) {
+		// Arrange
+		repo, grades := new(mockEvaluationRepo), new(mockGradeRepo)
+		svc := newTestEvaluationService(repo, new(mockTermRepo), grades)
+		repo.On("GetEvaluationByID", ctx, "5").Return(evaluation, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "20", "101").Return(true, nil).Once()
+		grades.On("GetGradeSheet", ctx, "7").Return(&models.GradeSheet{Status: models.GradeSheetLocked}, nil).Once()
+		repo.On("GetTemplateByID", ctx, "2").Return(teachingTemplate(), nil).Once()
+		repo.On("GetAnswers", ctx, "5").Return([]models.EvaluationAnswer{
+			{QuestionID: "10", Rating: intPtr(5)}, {QuestionID: "11", Comment: "Too fast"},
+			{QuestionID: "10", Rating: intPtr(4)},
+			{QuestionID: "10", Rating: intPtr(4)}, {QuestionID: "11", Comment: "Great labs"},
+		}, nil).Once()
+
+		// Act
+		results, err := svc.GetResults(ctx, "5", "20", "teacher")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, results.ResponseCount)
+		likert, text := results.Questions[0], results.Questions[1]
+		assert.Equal(t, 4.33, *likert.Average)
+		assert.Equal(t, []int{0, 0, 0, 2, 1}, likert.Distribution)
+		assert.Equal(t, []string{"Great labs", "Too fast"}, text.Comments)
+		assert.Equal(t, 2, text.Answers)
+	})
+
+	t.Run("Teacher Before Grades Are Locked", func(t *testing.T) {
+		// Arrange
+		repo, grades := new(mockEvaluationRepo), new(mockGradeRepo)
+		svc := newTestEvaluationService(repo, new(mockTermRepo), grades)
+		repo.On("GetEvaluationByID", ctx, "5").Return(evaluation, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "20", "101").Return(true, nil).Once()
+		grades.On("GetGradeSheet", ctx, "7").Return(&models.GradeSheet{Status: models.GradeSheetSubmitted}, nil).Once()
+
+		// Act
+		_, err := svc.GetResults(ctx, "5", "20", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrEvaluationGradesNotLocked)
+		repo.AssertNotCalled(t, "GetAnswers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Teacher Of Another Course", func(t *testing.T) {
+		// Arrange
+		repo, grades := new(mockEvaluationRepo), new(mockGradeRepo)
+		svc := newTestEvaluationService(repo, new(mockTermRepo), grades)
+		repo.On("GetEvaluationByID", ctx, "5").Return(evaluation, nil).Once()
+		grades.On("IsTeacherOfCourse", ctx, "30", "101").Return(false, nil).Once()
+
+		// Act
+		_, err := svc.GetResults(ctx, "5", "30", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotCourseTeacher)
+	})
+
+	t.Run("Below Minimum Responses Even For Manager", func(t *testing.T) {
+		// Arrange
+		repo, grades := new(mockEvaluationRepo), new(mockGradeRepo)
+		svc := newTestEvaluationService(repo, new(mockTermRepo), grades)
+		few := *evaluation
+		few.ResponseCount = 2
+		repo.On("GetEvaluationByID", ctx, "5").Return(&few, nil).Once()
+		repo.On("GetTemplateByID", ctx, "2").Return(teachingTemplate(), nil).Once()
+
+		// Act
+		_, err := svc.GetResults(ctx, "5", "40", "manager")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotEnoughResponses)
+		grades.AssertNotCalled(t, "GetGradeSheet", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "GetAnswers", mock.Anything, mock.Anything)
+	})
+}
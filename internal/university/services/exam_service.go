@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+const defaultExamTitle = "Final exam"
+
+type ExamService interface {
+	GetOfferingExams(ctx context.Context, termID, offeringID string) ([]models.Exam, error)
+	GetTermExams(ctx context.Context, termID string) ([]models.Exam, error)
+	GetExam(ctx context.Context, id string) (*models.Exam, error)
+	CreateExam(ctx context.Context, termID, offeringID string, exam *models.Exam, force bool) (*models.Exam, error)
+	UpdateExam(ctx context.Context, exam models.Exam, force bool) (*models.Exam, error)
+	DeleteExam(ctx context.Context, id string) error
+	GetTermConflicts(ctx context.Context, termID string) ([]models.ExamConflict, error)
+	AllocateSeating(ctx context.Context, examID string) ([]models.ExamSeat, error)
+	GetSeating(ctx context.Context, examID string) ([]models.ExamSeat, error)
+	RenderRoomSeating(ctx context.Context, examID, roomID string) ([]byte, error)
+	GetStudentExams(ctx context.Context, studentID, termID string) ([]models.StudentExam, error)
+	GetTeacherExams(ctx context.Context, teacherID, termID string) ([]models.Exam, error)
+}
+
+type examService struct {
+	repo  repository.ExamRepository
+	rooms repository.RoomRepository
+	terms repository.TermRepository
+	now   func() time.Time
+}
+
+func NewExamService(repo repository.ExamRepository, rooms repository.RoomRepository, terms repository.TermRepository) ExamService {
+	return &examService{repo: repo, rooms: rooms, terms: terms, now: time.Now}
+}
+
+func (s *examService) GetOfferingExams(ctx context.Context, termID, offeringID string) ([]models.Exam, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOfferingExams(ctx, offeringID)
+}
+
+func (s *examService) GetTermExams(ctx context.Context, termID string) ([]models.Exam, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTermExams(ctx, termID)
+}
+
+func (s *examService) GetExam(ctx context.Context, id string) (*models.Exam, error) {
+	return s.repo.GetExamByID(ctx, id)
+}
+
+// CreateExam назначает экзамен курса в периоде, если он ни с чем не конфликтует.
+// С force допускаются конфликты наблюдателей, студентов и нехватка мест, но не занятая аудитория.
+func (s *examService) CreateExam(ctx context.Context, termID, offeringID string, exam *models.Exam, force bool) (*models.Exam, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return nil, err
+	}
+	exam.ID = ""
+	exam.OfferingID = offeringID
+	exam.TermID = termID
+	if err := s.checkExam(ctx, exam, force); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateExam(ctx, exam)
+}
+
+// UpdateExam переносит экзамен с теми же проверками, что и при создании; курс экзамена не меняется.
+// Составленная рассадка при этом сбрасывается.
+func (s *examService) UpdateExam(ctx context.Context, exam models.Exam, force bool) (*models.Exam, error) {
+	current, err := s.repo.GetExamByID(ctx, exam.ID)
+	if err != nil {
+		return nil, err
+	}
+	exam.OfferingID = current.OfferingID
+	exam.TermID = current.TermID
+	if err := s.checkExam(ctx, &exam, force); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateExam(ctx, exam)
+}
+
+func (s *examService) DeleteExam(ctx context.Context, id string) error {
+	if _, err := s.repo.GetExamByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteExam(ctx, id)
+}
+
+// GetTermConflicts возвращает конфликты уже назначенных экзаменов периода, в том числе
+// появившиеся после записи студентов
+func (s *examService) GetTermConflicts(ctx context.Context, termID string) ([]models.ExamConflict, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTermConflicts(ctx, termID)
+}
+
+// AllocateSeating заново рассаживает записанных студентов по аудиториям экзамена. Студенты
+// распределяются пропорционально вместимости аудиторий в алфавитном порядке, места нумеруются с 1.
+func (s *examService) AllocateSeating(ctx context.Context, examID string) ([]models.ExamSeat, error) {
+	if _, err := s.repo.GetExamByID(ctx, examID); err != nil {
+		return nil, err
+	}
+	rooms, err := s.repo.GetExamRooms(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+	students, err := s.repo.GetExamStudents(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := distributeSeats(rooms, len(students))
+	if err != nil {
+		return nil, err
+	}
+
+	seats := make([]models.ExamSeat, 0, len(students))
+	next := 0
+	for i, room := range rooms {
+		for seat := 1; seat <= counts[i]; seat++ {
+			student := students[next]
+			student.ExamID = examID
+			student.RoomID, student.Building, student.RoomName = room.RoomID, room.Building, room.Name
+			student.Seat = seat
+			seats = append(seats, student)
+			next++
+		}
+	}
+	if err := s.repo.SaveSeating(ctx, examID, seats); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSeating(ctx, examID)
+}
+
+func (s *examService) GetSeating(ctx context.Context, examID string) ([]models.ExamSeat, error) {
+	if _, err := s.repo.GetExamByID(ctx, examID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSeating(ctx, examID)
+}
+
+// RenderRoomSeating печатает список рассадки одной аудитории экзамена для наблюдателя
+func (s *examService) RenderRoomSeating(ctx context.Context, examID, roomID string) ([]byte, error) {
+	exam, err := s.repo.GetExamByID(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := s.repo.GetExamRooms(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+	var room *models.ExamRoom
+	for i := range rooms {
+		if rooms[i].RoomID == roomID {
+			room = &rooms[i]
+		}
+	}
+	if room == nil {
+		return nil, models.ErrExamRoomNotFound
+	}
+
+	seating, err := s.repo.GetSeating(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+	if len(seating) == 0 {
+		return nil, models.ErrSeatingNotAllocated
+	}
+	seats := []models.ExamSeat{}
+	for _, seat := range seating {
+		if seat.RoomID == roomID {
+			seats = append(seats, seat)
+		}
+	}
+	return renderSeatingPDF(exam, *room, seats, s.now())
+}
+
+// GetStudentExams возвращает экзамены студента с его местами в периоде termID, а без него — в идущем
+func (s *examService) GetStudentExams(ctx context.Context, studentID, termID string) ([]models.StudentExam, error) {
+	term, err := termOrCurrent(ctx, s.terms, termID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetStudentExams(ctx, studentID, term.ID)
+}
+
+// GetTeacherExams возвращает экзамены курсов преподавателя и экзамены, где он наблюдает,
+// в периоде termID, а без него — в идущем
+func (s *examService) GetTeacherExams(ctx context.Context, teacherID, termID string) ([]models.Exam, error) {
+	term, err := termOrCurrent(ctx, s.terms, termID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetTeacherExams(ctx, teacherID, term.ID)
+}
+
+// checkExam заполняет название и время окончания экзамена, проверяет, что он проходит в рамках периода
+// (до крайнего срока выставления оценок), и ищет конфликты: занятые аудитории, наблюдателей и студентов
+// на пересекающихся экзаменах, а также нехватку мест для записанных на курс студентов
+func (s *examService) checkExam(ctx context.Context, exam *models.Exam, force bool) error {
+	if exam.Title == "" {
+		exam.Title = defaultExamTitle
+	}
+	exam.EndsAt = exam.StartsAt.Add(time.Duration(exam.DurationMinutes) * time.Minute)
+	exam.RoomIDs = uniqueIDs(exam.RoomIDs)
+	exam.ProctorIDs = uniqueIDs(exam.ProctorIDs)
+
+	term, err := s.terms.GetTermByID(ctx, exam.TermID)
+	if err != nil {
+		return err
+	}
+	last := term.GradingDeadline
+	if last.IsZero() {
+		last = term.EndDate
+	}
+	if exam.StartsAt.Before(term.StartDate) || exam.EndsAt.After(last.AddDate(0, 0, 1)) {
+		return fmt.Errorf("%w: exams must take place between %s and %s", models.ErrExamOutsideTerm,
+			term.StartDate.Format(time.DateOnly), last.Format(time.DateOnly))
+	}
+
+	capacity := 0
+	for _, roomID := range exam.RoomIDs {
+		room, err := s.rooms.GetRoomByID(ctx, roomID)
+		if err != nil {
+			return err
+		}
+		capacity += room.Capacity
+	}
+	students, err := s.repo.CountOfferingStudents(ctx, exam.OfferingID)
+	if err != nil {
+		return err
+	}
+
+	conflicts := []models.ExamConflict{}
+	if capacity < students {
+		conflicts = append(conflicts, models.ExamConflict{
+			Kind: models.ConflictCapacity, SubjectID: fmt.Sprint(students), ExamID: exam.ID,
+			StartsAt: exam.StartsAt, EndsAt: exam.EndsAt,
+		})
+	}
+	found, err := s.repo.FindConflicts(ctx, *exam)
+	if err != nil {
+		return err
+	}
+	conflicts = append(conflicts, found...)
+
+	blocking := []models.ExamConflict{}
+	for _, conflict := range conflicts {
+		if !force || conflict.Kind == models.ConflictRoom {
+			blocking = append(blocking, conflict)
+		}
+	}
+	if len(blocking) > 0 {
+		return &models.ExamConflictError{Conflicts: blocking}
+	}
+	return nil
+}
+
+// distributeSeats делит n студентов между аудиториями пропорционально вместимости;
+// остаток от округления достаётся аудиториям со свободными местами по очереди
+func distributeSeats(rooms []models.ExamRoom, n int) ([]int, error) {
+	total := 0
+	for _, room := range rooms {
+		total += room.Capacity
+	}
+	if total < n {
+		return nil, fmt.Errorf("%w: %d students, %d seats", models.ErrNotEnoughExamSeats, n, total)
+	}
+	counts := make([]int, len(rooms))
+	assigned := 0
+	for i, room := range rooms {
+		counts[i] = room.Capacity * n / total
+		assigned += counts[i]
+	}
+	for i := 0; assigned < n; i = (i + 1) % len(rooms) {
+		if counts[i] < rooms[i].Capacity {
+			counts[i]++
+			assigned++
+		}
+	}
+	return counts, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
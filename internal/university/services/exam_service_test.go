@@ -0,0 +1,365 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockExamRepo struct {
+	mock.Mock
+}
+
+func (m *mockExamRepo) GetOfferingExams(ctx context.Context, offeringID string) ([]models.Exam, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Exam), args.Error(1)
+}
+
+func (m *mockExamRepo) GetTermExams(ctx context.Context, termID string) ([]models.Exam, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Exam), args.Error(1)
+}
+
+func (m *mockExamRepo) GetExamByID(ctx context.Context, id string) (*models.Exam, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Exam), args.Error(1)
+}
+
+func (m *mockExamRepo) CreateExam(ctx context.Context, exam *models.Exam) (*models.Exam, error) {
+	args := m.Called(ctx, exam)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Exam), args.Error(1)
+}
+
+func (m *mockExamRepo) UpdateExam(ctx context.Context, exam models.Exam) (*models.Exam, error) {
+	args := m.Called(ctx, exam)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Exam), args.Error(1)
+}
+
+func (m *mockExamRepo) DeleteExam(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockExamRepo) CountOfferingStudents(ctx context.Context, offeringID string) (int, error) {
+	args := m.Called(ctx, offeringID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockExamRepo) FindConflicts(ctx context.Context, exam models.Exam) ([]models.ExamConflict, error) {
+	args := m.Called(ctx, exam)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ExamConflict), args.Error(1)
+}
+
+func (m *mockExamRepo) GetTermConflicts(ctx context.Context, termID string) ([]models.ExamConflict, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ExamConflict), args.Error(1)
+}
+
+func (m *mockExamRepo) GetExamRooms(ctx context.Context, examID string) ([]models.ExamRoom, error) {
+	args := m.Called(ctx, examID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ExamRoom), args.Error(1)
+}
+
+func (m *mockExamRepo) GetExamStudents(ctx context.Context, examID string) ([]models.ExamSeat, error) {
+	args := m.Called(ctx, examID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ExamSeat), args.Error(1)
+}
+
+func (m *mockExamRepo) SaveSeating(ctx context.Context, examID string, seats []models.ExamSeat) error {
+	args := m.Called(ctx, examID, seats)
+	return args.Error(0)
+}
+
+func (m *mockExamRepo) GetSeating(ctx context.Context, examID string) ([]models.ExamSeat, error) {
+	args := m.Called(ctx, examID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ExamSeat), args.Error(1)
+}
+
+func (m *mockExamRepo) GetStudentExams(ctx context.Context, studentID, termID string) ([]models.StudentExam, error) {
+	args := m.Called(ctx, studentID, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StudentExam), args.Error(1)
+}
+
+func (m *mockExamRepo) GetTeacherExams(ctx context.Context, teacherID, termID string) ([]models.Exam, error) {
+	args := m.Called(ctx, teacherID, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Exam), args.Error(1)
+}
+
+func TestExamService_CreateExam(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	term := fallTerm()
+	offering := &models.CourseOffering{ID: "7", CourseID: "3", TermID: "1"}
+	newExam := func() *models.Exam {
+		return &models.Exam{StartsAt: time.Date(2025, time.December, 20, 10, 0, 0, 0, time.UTC), DurationMinutes: 120,
+			RoomIDs: []string{"3", "4", "3"}, ProctorIDs: []string{"12"}}
+	}
+	setup := func(students int, conflicts []models.ExamConflict) (*mockExamRepo, *mockRoomRepo, *mockTermRepo) {
+		repo, rooms, terms := new(mockExamRepo), new(mockRoomRepo), new(mockTermRepo)
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		terms.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+		rooms.On("GetRoomByID", ctx, "3").Return(&models.Room{ID: "3", Capacity: 30}, nil).Once()
+		rooms.On("GetRoomByID", ctx, "4").Return(&models.Room{ID: "4", Capacity: 20}, nil).Once()
+		repo.On("CountOfferingStudents", ctx, "7").Return(students, nil).Once()
+		repo.On("FindConflicts", ctx, mock.Anything).Return(conflicts, nil).Once()
+		return repo, rooms, terms
+	}
+	studentConflict := models.ExamConflict{Kind: models.ConflictStudent, SubjectID: "101", ConflictExamID: "9"}
+	roomConflict := models.ExamConflict{Kind: models.ConflictRoom, SubjectID: "3", ConflictExamID: "9"}
+
+	t.Run("Success Fills Defaults", func(t *testing.T) {
+		repo, rooms, terms := setup(45, []models.ExamConflict{})
+		svc := NewExamService(repo, rooms, terms)
+		exam := newExam()
+		repo.On("CreateExam", ctx, exam).Return(exam, nil).Once()
+
+		// Act
+		created, err := svc.CreateExam(ctx, "1", "7", exam, false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "7", created.OfferingID)
+		assert.Equal(t, "Final exam", created.Title)
+		assert.Equal(t, time.Date(2025, time.December, 20, 12, 0, 0, 0, time.UTC), created.EndsAt)
+		assert.Equal(t, []string{"3", "4"}, []string(created.RoomIDs))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Outside Term", func(t *testing.T) {
+		repo, terms := new(mockExamRepo), new(mockTermRepo)
+		svc := NewExamService(repo, new(mockRoomRepo), terms)
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		terms.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+		exam := newExam()
+		exam.StartsAt = time.Date(2026, time.January, 10, 23, 0, 0, 0, time.UTC)
+
+		// Act
+		_, err := svc.CreateExam(ctx, "1", "7", exam, false)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrExamOutsideTerm)
+		repo.AssertNotCalled(t, "CreateExam", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not Enough Seats Blocks", func(t *testing.T) {
+		repo, rooms, terms := setup(60, []models.ExamConflict{})
+		svc := NewExamService(repo, rooms, terms)
+
+		// Act
+		_, err := svc.CreateExam(ctx, "1", "7", newExam(), false)
+
+		// Assert
+		var conflictErr *models.ExamConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.ErrorIs(t, err, models.ErrExamConflict)
+		assert.Equal(t, models.ConflictCapacity, conflictErr.Conflicts[0].Kind)
+		assert.Equal(t, "60", conflictErr.Conflicts[0].SubjectID)
+		repo.AssertNotCalled(t, "CreateExam", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Force Allows Student Conflict", func(t *testing.T) {
+		repo, rooms, terms := setup(45, []models.ExamConflict{studentConflict})
+		svc := NewExamService(repo, rooms, terms)
+		exam := newExam()
+		repo.On("CreateExam", ctx, exam).Return(exam, nil).Once()
+
+		// Act
+		_, err := svc.CreateExam(ctx, "1", "7", exam, true)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Force Does Not Allow Room Conflict", func(t *testing.T) {
+		repo, rooms, terms := setup(45, []models.ExamConflict{studentConflict, roomConflict})
+		svc := NewExamService(repo, rooms, terms)
+
+		// Act
+		_, err := svc.CreateExam(ctx, "1", "7", newExam(), true)
+
+		// Assert
+		var conflictErr *models.ExamConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, []models.ExamConflict{roomConflict}, conflictErr.Conflicts)
+	})
+}
+
+func TestExamService_UpdateExam(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	term := fallTerm()
+	repo, rooms, terms := new(mockExamRepo), new(mockRoomRepo), new(mockTermRepo)
+	svc := NewExamService(repo, rooms, terms)
+	repo.On("GetExamByID", ctx, "31").Return(&models.Exam{ID: "31", OfferingID: "7", TermID: "1"}, nil).Once()
+	terms.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+	rooms.On("GetRoomByID", ctx, "3").Return(&models.Room{ID: "3", Capacity: 30}, nil).Once()
+	repo.On("CountOfferingStudents", ctx, "7").Return(25, nil).Once()
+	repo.On("FindConflicts", ctx, mock.MatchedBy(func(e models.Exam) bool {
+		return e.ID == "31" && e.OfferingID == "7"
+	})).Return([]models.ExamConflict{}, nil).Once()
+	repo.On("UpdateExam", ctx, mock.Anything).Return(&models.Exam{ID: "31", OfferingID: "7"}, nil).Once()
+
+	// Act
+	updated, err := svc.UpdateExam(ctx, models.Exam{ID: "31", OfferingID: "99", Title: "Midterm",
+		StartsAt: time.Date(2025, time.October, 20, 9, 0, 0, 0, time.UTC), DurationMinutes: 90, RoomIDs: []string{"3"}}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "7", updated.OfferingID)
+	repo.AssertExpectations(t)
+}
+
+func TestExamService_AllocateSeating(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	exam := &models.Exam{ID: "31", OfferingID: "7"}
+	rooms := []models.ExamRoom{{RoomID: "3", Name: "A-101", Capacity: 30}, {RoomID: "4", Name: "A-102", Capacity: 20}}
+	students := func(n int) []models.ExamSeat {
+		result := make([]models.ExamSeat, n)
+		for i := range result {
+			result[i] = models.ExamSeat{StudentID: string(rune('a' + i%26)), ExamID: "31"}
+		}
+		return result
+	}
+
+	t.Run("Proportional To Capacity", func(t *testing.T) {
+		repo := new(mockExamRepo)
+		svc := NewExamService(repo, new(mockRoomRepo), new(mockTermRepo))
+		var saved []models.ExamSeat
+		repo.On("GetExamByID", ctx, "31").Return(exam, nil).Once()
+		repo.On("GetExamRooms", ctx, "31").Return(rooms, nil).Once()
+		repo.On("GetExamStudents", ctx, "31").Return(students(11), nil).Once()
+		repo.On("SaveSeating", ctx, "31", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(2).([]models.ExamSeat)
+		}).Return(nil).Once()
+		repo.On("GetSeating", ctx, "31").Return([]models.ExamSeat{}, nil).Once()
+
+		// Act
+		_, err := svc.AllocateSeating(ctx, "31")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, saved, 11)
+		// 30 и 20 мест: 11*30/50 = 6 и 11*20/50 = 4, остаток — первой аудитории
+		perRoom := map[string]int{}
+		for _, seat := range saved {
+			perRoom[seat.RoomID]++
+		}
+		assert.Equal(t, map[string]int{"3": 7, "4": 4}, perRoom)
+		assert.Equal(t, "3", saved[6].RoomID)
+		assert.Equal(t, 7, saved[6].Seat)
+		assert.Equal(t, "4", saved[7].RoomID)
+		assert.Equal(t, 1, saved[7].Seat)
+		assert.Equal(t, "A-102", saved[7].RoomName)
+	})
+
+	t.Run("Not Enough Seats", func(t *testing.T) {
+		repo := new(mockExamRepo)
+		svc := NewExamService(repo, new(mockRoomRepo), new(mockTermRepo))
+		repo.On("GetExamByID", ctx, "31").Return(exam, nil).Once()
+		repo.On("GetExamRooms", ctx, "31").Return(rooms, nil).Once()
+		repo.On("GetExamStudents", ctx, "31").Return(students(51), nil).Once()
+
+		// Act
+		_, err := svc.AllocateSeating(ctx, "31")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotEnoughExamSeats)
+		repo.AssertNotCalled(t, "SaveSeating", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestExamService_RenderRoomSeating(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	exam := &models.Exam{ID: "31", Code: "CS101", Name: "Algorithms", Title: "Final exam",
+		StartsAt: time.Date(2025, time.December, 20, 10, 0, 0, 0, time.UTC), EndsAt: time.Date(2025, time.December, 20, 12, 0, 0, 0, time.UTC)}
+	rooms := []models.ExamRoom{{RoomID: "3", Building: "Main", Name: "A-101", Capacity: 30}}
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(mockExamRepo)
+		svc := NewExamService(repo, new(mockRoomRepo), new(mockTermRepo))
+		repo.On("GetExamByID", ctx, "31").Return(exam, nil).Once()
+		repo.On("GetExamRooms", ctx, "31").Return(rooms, nil).Once()
+		repo.On("GetSeating", ctx, "31").Return([]models.ExamSeat{
+			{StudentID: "101", Firstname: "Ann", Lastname: "Lee", RoomID: "3", Seat: 1},
+			{StudentID: "102", Firstname: "Данияр", Lastname: "Ахметов", RoomID: "3", Seat: 2},
+		}, nil).Once()
+
+		// Act
+		pdf, err := svc.RenderRoomSeating(ctx, "31", "3")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "%PDF", string(pdf[:4]))
+		assert.True(t, bytes.Contains(pdfText(pdf), utf16BE("Ахметов Данияр")), "student name is printed in Cyrillic")
+	})
+
+	t.Run("Room Not In Exam", func(t *testing.T) {
+		repo := new(mockExamRepo)
+		svc := NewExamService(repo, new(mockRoomRepo), new(mockTermRepo))
+		repo.On("GetExamByID", ctx, "31").Return(exam, nil).Once()
+		repo.On("GetExamRooms", ctx, "31").Return(rooms, nil).Once()
+
+		// Act
+		_, err := svc.RenderRoomSeating(ctx, "31", "4")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrExamRoomNotFound)
+	})
+
+	t.Run("Seating Not Allocated", func(t *testing.T) {
+		repo := new(mockExamRepo)
+		svc := NewExamService(repo, new(mockRoomRepo), new(mockTermRepo))
+		repo.On("GetExamByID", ctx, "31").Return(exam, nil).Once()
+		repo.On("GetExamRooms", ctx, "31").Return(rooms, nil).Once()
+		repo.On("GetSeating", ctx, "31").Return([]models.ExamSeat{}, nil).Once()
+
+		// Act
+		_, err := svc.RenderRoomSeating(ctx, "31", "3")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSeatingNotAllocated)
+	})
+}
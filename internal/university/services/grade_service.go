@@ -2,27 +2,441 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"university_system/internal/domain/models"
 	"university_system/internal/domain/repository"
 )
 
 type GradeService interface {
-	GetStudentMarks(ctx context.Context, studentID string) ([]models.Mark, error)
-	GetCourseMarks(ctx context.Context, courseID string) ([]models.Mark, error)
+	GetStudentMarks(ctx context.Context, studentID string) ([]models.GradedMark, error)
+	GetCourseMarks(ctx context.Context, courseID string) ([]models.GradedMark, error)
+	AddMark(ctx context.Context, teacherID, changedBy string, mark *models.Mark, markType string) error
+	GetTranscript(ctx context.Context, studentID string) (*models.Transcript, error)
+	GetGradeSheet(ctx context.Context, termID, offeringID string) (*models.GradeSheet, error)
+	SubmitGrades(ctx context.Context, termID, offeringID, userID string, asTeacher bool) error
+	ApproveGrades(ctx context.Context, termID, offeringID, managerID, comment string) error
+	ReturnGrades(ctx context.Context, termID, offeringID, managerID, comment string) error
+	GetMarkHistory(ctx context.Context, termID, offeringID string) ([]models.MarkChange, error)
+	RequestGradeChange(ctx context.Context, termID, userID string, asTeacher bool, request *models.GradeChangeRequest) (*models.GradeChangeRequest, error)
+	GetGradeChangeRequests(ctx context.Context, status string) ([]models.GradeChangeRequest, error)
+	ReviewGradeChange(ctx context.Context, id, managerID string, approve bool, comment string) (*models.GradeChangeRequest, error)
 }
 
 type gradeService struct {
-	repo repository.GradeRepository
+	repo      repository.GradeRepository
+	schemes   repository.GradingSchemeRepository
+	terms     repository.TermRepository
+	standings repository.StandingRepository
 }
 
-func NewGradeService(repo repository.GradeRepository) GradeService {
-	return &gradeService{repo: repo}
+func NewGradeService(repo repository.GradeRepository, schemes repository.GradingSchemeRepository, terms repository.TermRepository,
+	standings repository.StandingRepository) GradeService {
+	return &gradeService{repo: repo, schemes: schemes, terms: terms, standings: standings}
 }
 
-func (s *gradeService) GetStudentMarks(ctx context.Context, studentID string) ([]models.Mark, error) {
-	return s.repo.GetStudentMarks(ctx, studentID)
+func (s *gradeService) GetStudentMarks(ctx context.Context, studentID string) ([]models.GradedMark, error) {
+	marks, err := s.repo.GetStudentMarks(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.gradeMarks(ctx, marks)
 }
 
-func (s *gradeService) GetCourseMarks(ctx context.Context, courseID string) ([]models.Mark, error) {
-	return s.repo.GetCourseMarks(ctx, courseID)
+func (s *gradeService) GetCourseMarks(ctx context.Context, courseID string) ([]models.GradedMark, error) {
+	marks, err := s.repo.GetCourseMarks(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	return s.gradeMarks(ctx, marks)
+}
+
+// AddMark выставляет оценку от имени преподавателя курса. Значение должно укладываться
+// в максимум баллов этой части оценки по схеме оценивания курса в периоде. changedBy — пользователь,
+// который записывается в журнал изменений оценок.
+func (s *gradeService) AddMark(ctx context.Context, teacherID, changedBy string, mark *models.Mark, markType string) error {
+	courseID := strconv.FormatUint(uint64(mark.CourseID), 10)
+	isTeacher, err := s.repo.IsTeacherOfCourse(ctx, teacherID, courseID)
+	if err != nil {
+		return err
+	}
+	if !isTeacher {
+		return models.ErrNotCourseTeacher
+	}
+
+	// Оценка относится к записи студента на курс в конкретном периоде
+	offeringID, err := s.repo.GetEnrollmentOfferingID(ctx, strconv.FormatUint(uint64(mark.StudentID), 10), courseID)
+	if err != nil {
+		return err
+	}
+	mark.OfferingID = offeringID
+
+	scheme, err := s.offeringScheme(ctx, offeringID)
+	if err != nil {
+		return err
+	}
+	var value float64
+	switch markType {
+	case models.MarkTypeFirstAttestation:
+		value = mark.FirstAttestation
+	case models.MarkTypeSecondAttestation:
+		value = mark.SecondAttestation
+	case models.MarkTypeFinal:
+		value = mark.FinalMark
+	}
+	if err := checkMarkRange(scheme, markType, value); err != nil {
+		return err
+	}
+	return s.repo.AddMark(ctx, mark, markType, changedBy)
+}
+
+func checkMarkRange(scheme *models.GradingScheme, markType string, value float64) error {
+	limit, err := scheme.ComponentMax(markType)
+	if err != nil {
+		return err
+	}
+	if value < 0 || value > limit {
+		return fmt.Errorf("%w: %s must be between 0 and %g", models.ErrMarkOutOfRange, markType, limit)
+	}
+	return nil
+}
+
+// GetGradeSheet возвращает ведомость курса в периоде с оценками студентов
+func (s *gradeService) GetGradeSheet(ctx context.Context, termID, offeringID string) (*models.GradeSheet, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return nil, err
+	}
+	sheet, err := s.repo.GetGradeSheet(ctx, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	marks, err := s.repo.GetOfferingMarks(ctx, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if sheet.Marks, err = s.gradeMarks(ctx, marks); err != nil {
+		return nil, err
+	}
+	return sheet, nil
+}
+
+// SubmitGrades отправляет открытую ведомость на утверждение; после этого оценки нельзя менять
+// напрямую. Если asTeacher, пользователь должен вести этот курс (администратор отправляет без проверки).
+func (s *gradeService) SubmitGrades(ctx context.Context, termID, offeringID, userID string, asTeacher bool) error {
+	offering, err := s.terms.GetOfferingByID(ctx, termID, offeringID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCourseTeacher(ctx, userID, offering.CourseID, asTeacher); err != nil {
+		return err
+	}
+	return s.repo.UpdateGradeSheetStatus(ctx, offeringID, models.GradeSheetOpen, models.GradeSheetSubmitted, userID, "")
+}
+
+// ApproveGrades утверждает отправленную ведомость и блокирует её оценки
+func (s *gradeService) ApproveGrades(ctx context.Context, termID, offeringID, managerID, comment string) error {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return err
+	}
+	return s.repo.UpdateGradeSheetStatus(ctx, offeringID, models.GradeSheetSubmitted, models.GradeSheetLocked, managerID, comment)
+}
+
+// ReturnGrades возвращает отправленную ведомость преподавателю на доработку
+func (s *gradeService) ReturnGrades(ctx context.Context, termID, offeringID, managerID, comment string) error {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return err
+	}
+	return s.repo.UpdateGradeSheetStatus(ctx, offeringID, models.GradeSheetSubmitted, models.GradeSheetOpen, managerID, comment)
+}
+
+func (s *gradeService) GetMarkHistory(ctx context.Context, termID, offeringID string) ([]models.MarkChange, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetMarkChanges(ctx, offeringID)
+}
+
+// RequestGradeChange создаёт запрос на изменение оценки в утверждённой ведомости.
+// Текущее значение оценки сохраняется в запросе, новое проверяется по схеме оценивания.
+func (s *gradeService) RequestGradeChange(ctx context.Context, termID, userID string, asTeacher bool, request *models.GradeChangeRequest) (*models.GradeChangeRequest, error) {
+	offering, err := s.terms.GetOfferingByID(ctx, termID, request.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCourseTeacher(ctx, userID, offering.CourseID, asTeacher); err != nil {
+		return nil, err
+	}
+	sheet, err := s.repo.GetGradeSheet(ctx, offering.ID)
+	if err != nil {
+		return nil, err
+	}
+	if sheet.Status != models.GradeSheetLocked {
+		return nil, models.ErrGradesNotLocked
+	}
+
+	offeringID, err := strconv.ParseUint(offering.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	scheme, err := s.offeringScheme(ctx, uint(offeringID))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMarkRange(scheme, request.MarkType, request.NewValue); err != nil {
+		return nil, err
+	}
+
+	oldValue, err := s.currentMark(ctx, offering, request.StudentID, request.MarkType)
+	if err != nil {
+		return nil, err
+	}
+	request.OldValue = oldValue
+	request.Status = models.GradeChangePending
+	request.RequestedBy = &userID
+	return s.repo.CreateGradeChangeRequest(ctx, request)
+}
+
+func (s *gradeService) GetGradeChangeRequests(ctx context.Context, status string) ([]models.GradeChangeRequest, error) {
+	return s.repo.GetGradeChangeRequests(ctx, status)
+}
+
+// ReviewGradeChange одобряет (оценка меняется и попадает в журнал) или отклоняет запрос
+func (s *gradeService) ReviewGradeChange(ctx context.Context, id, managerID string, approve bool, comment string) (*models.GradeChangeRequest, error) {
+	var err error
+	if approve {
+		err = s.repo.ApproveGradeChange(ctx, id, managerID, comment)
+	} else {
+		err = s.repo.RejectGradeChange(ctx, id, managerID, comment)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetGradeChangeRequest(ctx, id)
+}
+
+func (s *gradeService) checkCourseTeacher(ctx context.Context, userID, courseID string, asTeacher bool) error {
+	if !asTeacher {
+		return nil
+	}
+	isTeacher, err := s.repo.IsTeacherOfCourse(ctx, userID, courseID)
+	if err != nil {
+		return err
+	}
+	if !isTeacher {
+		return models.ErrNotCourseTeacher
+	}
+	return nil
+}
+
+// currentMark возвращает текущее значение оценки студента в ведомости. Если оценки ещё нет,
+// студент должен быть записан на курс в этом периоде, а значение считается нулевым.
+func (s *gradeService) currentMark(ctx context.Context, offering *models.CourseOffering, studentID, markType string) (float64, error) {
+	marks, err := s.repo.GetOfferingMarks(ctx, offering.ID)
+	if err != nil {
+		return 0, err
+	}
+	for _, mark := range marks {
+		if strconv.FormatUint(uint64(mark.StudentID), 10) != studentID {
+			continue
+		}
+		switch markType {
+		case models.MarkTypeFirstAttestation:
+			return mark.FirstAttestation, nil
+		case models.MarkTypeSecondAttestation:
+			return mark.SecondAttestation, nil
+		default:
+			return mark.FinalMark, nil
+		}
+	}
+
+	enrolledOffering, err := s.repo.GetEnrollmentOfferingID(ctx, studentID, offering.CourseID)
+	if err != nil {
+		return 0, err
+	}
+	if strconv.FormatUint(uint64(enrolledOffering), 10) != offering.ID {
+		return 0, models.ErrStudentNotEnrolled
+	}
+	return 0, nil
+}
+
+// GetTranscript собирает транскрипт по периодам. GPA за период и накопленный GPA взвешиваются
+// по кредитам курса и учитывают только завершённые курсы (completed и failed), включая повторные попытки.
+// Положение берётся из сохранённого расчёта, см. applyStandings.
+func (s *gradeService) GetTranscript(ctx context.Context, studentID string) (*models.Transcript, error) {
+	records, err := s.repo.GetStudentRecords(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript := &models.Transcript{StudentID: studentID, Terms: []models.TranscriptTerm{}, Standing: models.StandingGood}
+	schemes := map[uint]*models.GradingScheme{}
+	for _, record := range records {
+		if len(transcript.Terms) == 0 || transcript.Terms[len(transcript.Terms)-1].TermID != record.TermID {
+			transcript.Terms = append(transcript.Terms, models.TranscriptTerm{
+				TermID: record.TermID,
+				Year:   record.TermYear,
+				Season: record.TermSeason,
+			})
+		}
+		term := &transcript.Terms[len(transcript.Terms)-1]
+
+		course := models.TranscriptCourse{
+			CourseID:          record.CourseID,
+			Code:              record.Code,
+			Name:              record.Name,
+			Credits:           record.Credits,
+			Status:            record.Status,
+			FirstAttestation:  record.FirstAttestation,
+			SecondAttestation: record.SecondAttestation,
+			FinalMark:         record.FinalMark,
+		}
+		switch record.Status {
+		case models.EnrollmentStatusWithdrawn:
+			course.Letter = "W"
+		case models.EnrollmentStatusCompleted, models.EnrollmentStatusFailed:
+			scheme, ok := schemes[record.OfferingID]
+			if !ok {
+				if scheme, err = s.offeringScheme(ctx, record.OfferingID); err != nil {
+					return nil, err
+				}
+				schemes[record.OfferingID] = scheme
+			}
+			graded := gradeMark(scheme, record.Mark)
+			course.Total = &graded.Total
+			course.Letter = graded.Letter
+			course.GPAPoints = &graded.GPAPoints
+			if record.Status == models.EnrollmentStatusCompleted {
+				course.CreditsEarned = record.Credits
+			}
+			term.AttemptedCredits += record.Credits
+			term.EarnedCredits += course.CreditsEarned
+		}
+		term.Courses = append(term.Courses, course)
+	}
+
+	// GPA периода считается по его курсам, накопленный — по всем периодам до него включительно
+	var cumulative gpaAccumulator
+	for i := range transcript.Terms {
+		term := &transcript.Terms[i]
+		var current gpaAccumulator
+		for _, course := range term.Courses {
+			if course.GPAPoints != nil {
+				current.add(*course.GPAPoints, course.Credits)
+				cumulative.add(*course.GPAPoints, course.Credits)
+			}
+		}
+		term.TermGPA = current.gpa()
+		term.CumulativeGPA = cumulative.gpa()
+		transcript.EarnedCredits += term.EarnedCredits
+	}
+	transcript.CumulativeGPA = cumulative.gpa()
+	if err := s.applyStandings(ctx, transcript); err != nil {
+		return nil, err
+	}
+	return transcript, nil
+}
+
+// applyStandings проставляет положение по периодам: сохранённое по итогам периода, а если расчёта
+// за период ещё нет — по текущим правилам положения (без списка отличников). Период без оценок
+// сохраняет положение предыдущего, положение студента — положение последнего периода.
+func (s *gradeService) applyStandings(ctx context.Context, transcript *models.Transcript) error {
+	stored, err := s.standings.GetStudentStandings(ctx, transcript.StudentID)
+	if err != nil {
+		return err
+	}
+	statuses := make(map[string]string, len(stored))
+	for _, standing := range stored {
+		statuses[standing.TermID] = standing.Status
+	}
+
+	var rules *models.StandingRules
+	current := models.StandingGood
+	for i := range transcript.Terms {
+		term := &transcript.Terms[i]
+		if status, ok := statuses[term.TermID]; ok {
+			current = status
+		} else if term.AttemptedCredits > 0 {
+			if rules == nil {
+				if rules, err = s.standings.GetRules(ctx); err != nil {
+					return err
+				}
+			}
+			standing, _ := termStanding(transcript, term.TermID, rules)
+			current = standing.Status
+		}
+		term.Standing = current
+	}
+	transcript.Standing = current
+	return nil
+}
+
+// gpaAccumulator копит баллы GPA, взвешенные по кредитам
+type gpaAccumulator struct {
+	points  float64
+	credits int
+}
+
+func (a *gpaAccumulator) add(gpaPoints float64, credits int) {
+	a.points += gpaPoints * float64(credits)
+	a.credits += credits
+}
+
+func (a gpaAccumulator) gpa() float64 {
+	if a.credits == 0 {
+		return 0
+	}
+	return math.Round(a.points/float64(a.credits)*100) / 100
+}
+
+// gradeMarks считает итоговый балл и буквенную оценку; схема запрашивается один раз на курс в периоде
+func (s *gradeService) gradeMarks(ctx context.Context, marks []models.Mark) ([]models.GradedMark, error) {
+	schemes := map[uint]*models.GradingScheme{}
+	graded := make([]models.GradedMark, 0, len(marks))
+	for _, mark := range marks {
+		scheme, ok := schemes[mark.OfferingID]
+		if !ok {
+			var err error
+			if scheme, err = s.offeringScheme(ctx, mark.OfferingID); err != nil {
+				return nil, err
+			}
+			schemes[mark.OfferingID] = scheme
+		}
+		graded = append(graded, gradeMark(scheme, mark))
+	}
+	return graded, nil
+}
+
+func (s *gradeService) offeringScheme(ctx context.Context, offeringID uint) (*models.GradingScheme, error) {
+	return offeringSchemeOrDefault(ctx, s.schemes, strconv.FormatUint(uint64(offeringID), 10))
+}
+
+// offeringSchemeOrDefault возвращает схему курса в периоде или схему по умолчанию
+func offeringSchemeOrDefault(ctx context.Context, schemes repository.GradingSchemeRepository, offeringID string) (*models.GradingScheme, error) {
+	scheme, err := schemes.GetOfferingScheme(ctx, offeringID)
+	if errors.Is(err, models.ErrGradingSchemeNotFound) {
+		return models.DefaultGradingScheme(), nil
+	}
+	return scheme, err
+}
+
+// gradeMark приводит каждую часть оценки к её весу: итог считается из 100 баллов.
+// Буквы схемы отсортированы по убыванию нижней границы.
+func gradeMark(scheme *models.GradingScheme, mark models.Mark) models.GradedMark {
+	total := mark.FirstAttestation/scheme.FirstAttestationMax*scheme.FirstAttestationWeight +
+		mark.SecondAttestation/scheme.SecondAttestationMax*scheme.SecondAttestationWeight +
+		mark.FinalMark/scheme.FinalMax*scheme.FinalWeight
+	// Убираем хвосты вроде 79.99999999 от деления
+	total = math.Round(total*100) / 100
+
+	graded := models.GradedMark{Mark: mark, Total: total, Passed: total >= scheme.PassingTotal()}
+	for _, letter := range scheme.Letters {
+		if total >= letter.MinTotal {
+			graded.Letter = letter.Letter
+			graded.GPAPoints = letter.GPAPoints
+			break
+		}
+	}
+	return graded
 }
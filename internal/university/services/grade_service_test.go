@@ -15,8 +15,13 @@ type mockGradeRepo struct {
 	mock.Mock
 }
 
-func (m *mockGradeRepo) AddMark(ctx context.Context, mark *models.Mark, markType string) error {
-	args := m.Called(ctx, mark, markType)
+func (m *mockGradeRepo) AddMark(ctx context.Context, mark *models.Mark, markType string, changedBy string) error {
+	args := m.Called(ctx, mark, markType, changedBy)
+	return args.Error(0)
+}
+
+func (m *mockGradeRepo) AddMarks(ctx context.Context, offeringID string, marks []models.Mark, markType string, changedBy string) error {
+	args := m.Called(ctx, offeringID, marks, markType, changedBy)
 	return args.Error(0)
 }
 
@@ -41,10 +46,87 @@ func (m *mockGradeRepo) IsTeacherOfCourse(ctx context.Context, teacherID string,
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockGradeRepo) GetEnrollmentOfferingID(ctx context.Context, studentID string, courseID string) (uint, error) {
+	args := m.Called(ctx, studentID, courseID)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *mockGradeRepo) GetStudentRecords(ctx context.Context, studentID string) ([]models.CourseRecord, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseRecord), args.Error(1)
+}
+
+func (m *mockGradeRepo) GetOfferingMarks(ctx context.Context, offeringID string) ([]models.Mark, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Mark), args.Error(1)
+}
+
+func (m *mockGradeRepo) GetGradeSheet(ctx context.Context, offeringID string) (*models.GradeSheet, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeSheet), args.Error(1)
+}
+
+func (m *mockGradeRepo) UpdateGradeSheetStatus(ctx context.Context, offeringID, from, to, actorID, comment string) error {
+	args := m.Called(ctx, offeringID, from, to, actorID, comment)
+	return args.Error(0)
+}
+
+func (m *mockGradeRepo) CreateGradeChangeRequest(ctx context.Context, request *models.GradeChangeRequest) (*models.GradeChangeRequest, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeChangeRequest), args.Error(1)
+}
+
+func (m *mockGradeRepo) GetGradeChangeRequests(ctx context.Context, status string) ([]models.GradeChangeRequest, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GradeChangeRequest), args.Error(1)
+}
+
+func (m *mockGradeRepo) GetGradeChangeRequest(ctx context.Context, id string) (*models.GradeChangeRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradeChangeRequest), args.Error(1)
+}
+
+func (m *mockGradeRepo) ApproveGradeChange(ctx context.Context, id, reviewerID, comment string) error {
+	args := m.Called(ctx, id, reviewerID, comment)
+	return args.Error(0)
+}
+
+func (m *mockGradeRepo) RejectGradeChange(ctx context.Context, id, reviewerID, comment string) error {
+	args := m.Called(ctx, id, reviewerID, comment)
+	return args.Error(0)
+}
+
+func (m *mockGradeRepo) GetMarkChanges(ctx context.Context, offeringID string) ([]models.MarkChange, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MarkChange), args.Error(1)
+}
+
 func TestGradeService_GetStudentMarks(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockGradeRepo)
-	svc := NewGradeService(mockRepo)
+	mockSchemes := new(mockGradingSchemeRepo)
+	svc := NewGradeService(mockRepo, mockSchemes, new(mockTermRepo), new(mockStandingRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -68,14 +150,22 @@ func TestGradeService_GetStudentMarks(t *testing.T) {
 			},
 		}
 		mockRepo.On("GetStudentMarks", ctx, fmt.Sprint(studentID)).Return(expectedMarks, nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "0").Return(nil, models.ErrGradingSchemeNotFound).Once()
 
 		// Act
 		marks, err := svc.GetStudentMarks(ctx, fmt.Sprint(studentID))
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, expectedMarks, marks)
+		assert.Len(t, marks, 2)
+		assert.Equal(t, expectedMarks[0], marks[0].Mark)
+		assert.Equal(t, 90.5, marks[0].Total)
+		assert.Equal(t, "A-", marks[0].Letter)
+		assert.Equal(t, 85.0, marks[1].Total)
+		assert.Equal(t, "B+", marks[1].Letter)
+		assert.Equal(t, 3.33, marks[1].GPAPoints)
 		mockRepo.AssertExpectations(t)
+		mockSchemes.AssertExpectations(t)
 	})
 
 	t.Run("Empty Marks", func(t *testing.T) {
@@ -111,7 +201,8 @@ func TestGradeService_GetStudentMarks(t *testing.T) {
 func TestGradeService_GetCourseMarks(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockGradeRepo)
-	svc := NewGradeService(mockRepo)
+	mockSchemes := new(mockGradingSchemeRepo)
+	svc := NewGradeService(mockRepo, mockSchemes, new(mockTermRepo), new(mockStandingRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -135,14 +226,19 @@ func TestGradeService_GetCourseMarks(t *testing.T) {
 			},
 		}
 		mockRepo.On("GetCourseMarks", ctx, fmt.Sprint(courseID)).Return(expectedMarks, nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "0").Return(nil, models.ErrGradingSchemeNotFound).Once()
 
 		// Act
 		marks, err := svc.GetCourseMarks(ctx, fmt.Sprint(courseID))
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, expectedMarks, marks)
+		assert.Equal(t, expectedMarks[1], marks[1].Mark)
+		assert.Equal(t, "A-", marks[0].Letter)
+		assert.Equal(t, "A", marks[1].Letter)
+		assert.Equal(t, 4.0, marks[1].GPAPoints)
 		mockRepo.AssertExpectations(t)
+		mockSchemes.AssertExpectations(t)
 	})
 
 	t.Run("Empty Marks", func(t *testing.T) {
@@ -174,3 +270,505 @@ func TestGradeService_GetCourseMarks(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestGradeService_GradeWithCourseScheme(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradeRepo)
+	mockSchemes := new(mockGradingSchemeRepo)
+	svc := NewGradeService(mockRepo, mockSchemes, new(mockTermRepo), new(mockStandingRepo))
+	ctx := context.Background()
+	scheme := &models.GradingScheme{
+		ID:                      "2",
+		FirstAttestationMax:     100,
+		SecondAttestationMax:    100,
+		FinalMax:                100,
+		FirstAttestationWeight:  25,
+		SecondAttestationWeight: 25,
+		FinalWeight:             50,
+		Letters: []models.LetterGrade{
+			{Letter: "Pass", MinTotal: 60, GPAPoints: 4},
+			{Letter: "Fail", MinTotal: 0, GPAPoints: 0},
+		},
+	}
+	marks := []models.Mark{
+		{ID: 1, StudentID: 101, OfferingID: 7, FirstAttestation: 80, SecondAttestation: 60, FinalMark: 50},
+		{ID: 2, StudentID: 102, OfferingID: 7, FirstAttestation: 100, SecondAttestation: 90, FinalMark: 20},
+	}
+	mockRepo.On("GetCourseMarks", ctx, "201").Return(marks, nil).Once()
+	mockSchemes.On("GetOfferingScheme", ctx, "7").Return(scheme, nil).Once()
+
+	// Act
+	graded, err := svc.GetCourseMarks(ctx, "201")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, graded[0].Total)
+	assert.Equal(t, "Pass", graded[0].Letter)
+	assert.True(t, graded[0].Passed)
+	assert.Equal(t, 57.5, graded[1].Total)
+	assert.Equal(t, "Fail", graded[1].Letter)
+	// Проходной балл схемы — 60, а не models.PassingTotal
+	assert.False(t, graded[1].Passed)
+	mockSchemes.AssertExpectations(t)
+}
+
+func TestGradeService_AddMark(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradeRepo)
+	mockSchemes := new(mockGradingSchemeRepo)
+	svc := NewGradeService(mockRepo, mockSchemes, new(mockTermRepo), new(mockStandingRepo))
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mark := &models.Mark{StudentID: 101, CourseID: 201, FirstAttestation: 30}
+		mockRepo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		mockRepo.On("GetEnrollmentOfferingID", ctx, "101", "201").Return(uint(7), nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		mockRepo.On("AddMark", ctx, mark, models.MarkTypeFirstAttestation, "5").Return(nil).Once()
+
+		// Act
+		err := svc.AddMark(ctx, "5", "5", mark, models.MarkTypeFirstAttestation)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), mark.OfferingID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Above Component Maximum", func(t *testing.T) {
+		mark := &models.Mark{StudentID: 101, CourseID: 201, FinalMark: 41}
+		mockRepo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		mockRepo.On("GetEnrollmentOfferingID", ctx, "101", "201").Return(uint(7), nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+
+		// Act
+		err := svc.AddMark(ctx, "5", "5", mark, models.MarkTypeFinal)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrMarkOutOfRange)
+		mockRepo.AssertNotCalled(t, "AddMark", ctx, mark, models.MarkTypeFinal, "5")
+	})
+
+	t.Run("Negative Mark", func(t *testing.T) {
+		mark := &models.Mark{StudentID: 101, CourseID: 201, SecondAttestation: -1}
+		mockRepo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		mockRepo.On("GetEnrollmentOfferingID", ctx, "101", "201").Return(uint(7), nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+
+		// Act
+		err := svc.AddMark(ctx, "5", "5", mark, models.MarkTypeSecondAttestation)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrMarkOutOfRange)
+	})
+
+	t.Run("Grades Locked", func(t *testing.T) {
+		mark := &models.Mark{StudentID: 101, CourseID: 201, FinalMark: 10}
+		mockRepo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		mockRepo.On("GetEnrollmentOfferingID", ctx, "101", "201").Return(uint(7), nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		mockRepo.On("AddMark", ctx, mark, models.MarkTypeFinal, "5").Return(models.ErrGradesLocked).Once()
+
+		// Act
+		err := svc.AddMark(ctx, "5", "5", mark, models.MarkTypeFinal)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrGradesLocked)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Course Teacher", func(t *testing.T) {
+		mark := &models.Mark{StudentID: 101, CourseID: 201, FinalMark: 10}
+		mockRepo.On("IsTeacherOfCourse", ctx, "6", "201").Return(false, nil).Once()
+
+		// Act
+		err := svc.AddMark(ctx, "6", "6", mark, models.MarkTypeFinal)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotCourseTeacher)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Student Not Enrolled", func(t *testing.T) {
+		mark := &models.Mark{StudentID: 102, CourseID: 201, FinalMark: 10}
+		mockRepo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		mockRepo.On("GetEnrollmentOfferingID", ctx, "102", "201").Return(uint(0), models.ErrStudentNotEnrolled).Once()
+
+		// Act
+		err := svc.AddMark(ctx, "5", "5", mark, models.MarkTypeFinal)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrStudentNotEnrolled)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGradeService_GetTranscript(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradeRepo)
+	mockSchemes := new(mockGradingSchemeRepo)
+	mockStandings := new(mockStandingRepo)
+	svc := NewGradeService(mockRepo, mockSchemes, new(mockTermRepo), mockStandings)
+	ctx := context.Background()
+	rules := &models.StandingRules{ProbationGPA: 2.0, ProbationTerms: 2, HonorsPercent: 10, HonorsMinCredits: 15, HonorsMinGPA: 3.5}
+	record := func(termID string, offeringID uint, code string, credits int, status string, first, second, final float64) models.CourseRecord {
+		return models.CourseRecord{
+			Mark:       models.Mark{OfferingID: offeringID, FirstAttestation: first, SecondAttestation: second, FinalMark: final},
+			TermID:     termID,
+			TermYear:   2025,
+			TermSeason: models.SeasonFall,
+			Code:       code,
+			Credits:    credits,
+			Status:     status,
+		}
+	}
+
+	t.Run("Grouped By Term", func(t *testing.T) {
+		records := []models.CourseRecord{
+			record("1", 1, "CS101", 5, models.EnrollmentStatusCompleted, 30, 30, 36), // 96 → A
+			record("1", 2, "MATH101", 3, models.EnrollmentStatusFailed, 10, 10, 10),  // 30 → F
+			record("1", 3, "HIST101", 2, models.EnrollmentStatusWithdrawn, 10, 0, 0),
+			record("2", 4, "CS201", 4, models.EnrollmentStatusCompleted, 25, 25, 32), // 82 → B
+			record("2", 5, "CS202", 6, models.EnrollmentStatusEnrolled, 20, 0, 0),
+		}
+		mockRepo.On("GetStudentRecords", ctx, "101").Return(records, nil).Once()
+		mockStandings.On("GetStudentStandings", ctx, "101").Return([]models.AcademicStanding{}, nil).Once()
+		mockStandings.On("GetRules", ctx).Return(rules, nil).Once()
+		for _, offeringID := range []string{"1", "2", "4"} {
+			mockSchemes.On("GetOfferingScheme", ctx, offeringID).Return(nil, models.ErrGradingSchemeNotFound).Once()
+		}
+
+		// Act
+		transcript, err := svc.GetTranscript(ctx, "101")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, transcript.Terms, 2)
+
+		fall := transcript.Terms[0]
+		assert.Len(t, fall.Courses, 3)
+		assert.Equal(t, "A", fall.Courses[0].Letter)
+		assert.Equal(t, 5, fall.Courses[0].CreditsEarned)
+		assert.Equal(t, "F", fall.Courses[1].Letter)
+		assert.Equal(t, 0, fall.Courses[1].CreditsEarned)
+		assert.Equal(t, "W", fall.Courses[2].Letter)
+		assert.Nil(t, fall.Courses[2].GPAPoints)
+		assert.Equal(t, 8, fall.AttemptedCredits)
+		assert.Equal(t, 5, fall.EarnedCredits)
+		assert.Equal(t, 2.5, fall.TermGPA) // (4*5 + 0*3) / 8
+		assert.Equal(t, 2.5, fall.CumulativeGPA)
+
+		spring := transcript.Terms[1]
+		assert.Equal(t, 3.0, spring.TermGPA)
+		assert.Equal(t, 2.67, spring.CumulativeGPA) // (20 + 0 + 12) / 12
+		assert.Empty(t, spring.Courses[1].Letter)
+		assert.Nil(t, spring.Courses[1].Total)
+
+		assert.Equal(t, 9, transcript.EarnedCredits)
+		assert.Equal(t, 2.67, transcript.CumulativeGPA)
+		assert.Equal(t, models.StandingGood, transcript.Standing)
+		mockSchemes.AssertExpectations(t)
+	})
+
+	t.Run("Configured Rules Without Stored Standing", func(t *testing.T) {
+		records := []models.CourseRecord{
+			record("1", 6, "CS101", 5, models.EnrollmentStatusCompleted, 20, 20, 15), // 55 → D+
+		}
+		mockRepo.On("GetStudentRecords", ctx, "102").Return(records, nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "6").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		mockStandings.On("GetStudentStandings", ctx, "102").Return([]models.AcademicStanding{}, nil).Once()
+		mockStandings.On("GetRules", ctx).Return(rules, nil).Once()
+
+		// Act
+		transcript, err := svc.GetTranscript(ctx, "102")
+
+		// Assert: один период ниже порога — предупреждение, испытательный срок только после двух подряд
+		assert.NoError(t, err)
+		assert.Equal(t, 1.33, transcript.CumulativeGPA)
+		assert.Equal(t, models.StandingWarning, transcript.Terms[0].Standing)
+		assert.Equal(t, models.StandingWarning, transcript.Standing)
+	})
+
+	t.Run("Stored Standing Takes Precedence", func(t *testing.T) {
+		repo, standings := new(mockGradeRepo), new(mockStandingRepo)
+		svc := NewGradeService(repo, mockSchemes, new(mockTermRepo), standings)
+		records := []models.CourseRecord{
+			record("1", 7, "CS101", 5, models.EnrollmentStatusCompleted, 30, 30, 36), // 96 → A
+			record("2", 8, "CS201", 4, models.EnrollmentStatusEnrolled, 20, 0, 0),
+		}
+		repo.On("GetStudentRecords", ctx, "104").Return(records, nil).Once()
+		mockSchemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		standings.On("GetStudentStandings", ctx, "104").Return([]models.AcademicStanding{
+			{StudentID: "104", TermID: "1", Status: models.StandingHonors},
+		}, nil).Once()
+
+		// Act
+		transcript, err := svc.GetTranscript(ctx, "104")
+
+		// Assert: период без оценок сохраняет положение предыдущего, правила не запрашиваются
+		assert.NoError(t, err)
+		assert.Equal(t, models.StandingHonors, transcript.Terms[0].Standing)
+		assert.Equal(t, models.StandingHonors, transcript.Terms[1].Standing)
+		assert.Equal(t, models.StandingHonors, transcript.Standing)
+		standings.AssertNotCalled(t, "GetRules", ctx)
+	})
+
+	t.Run("No Courses", func(t *testing.T) {
+		mockRepo.On("GetStudentRecords", ctx, "103").Return([]models.CourseRecord{}, nil).Once()
+		mockStandings.On("GetStudentStandings", ctx, "103").Return([]models.AcademicStanding{}, nil).Once()
+
+		// Act
+		transcript, err := svc.GetTranscript(ctx, "103")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, transcript.Terms)
+		assert.Equal(t, 0.0, transcript.CumulativeGPA)
+		assert.Equal(t, models.StandingGood, transcript.Standing)
+	})
+}
+
+func TestGradeService_SubmitGrades(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradeRepo)
+	mockTerms := new(mockTermRepo)
+	svc := NewGradeService(mockRepo, new(mockGradingSchemeRepo), mockTerms, new(mockStandingRepo))
+	ctx := context.Background()
+	offering := &models.CourseOffering{ID: "7", CourseID: "201", TermID: "1"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		mockRepo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		mockRepo.On("UpdateGradeSheetStatus", ctx, "7", models.GradeSheetOpen, models.GradeSheetSubmitted, "5", "").Return(nil).Once()
+
+		// Act
+		err := svc.SubmitGrades(ctx, "1", "7", "5", true)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Course Teacher", func(t *testing.T) {
+		repo := new(mockGradeRepo)
+		svc := NewGradeService(repo, new(mockGradingSchemeRepo), mockTerms, new(mockStandingRepo))
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("IsTeacherOfCourse", ctx, "6", "201").Return(false, nil).Once()
+
+		// Act
+		err := svc.SubmitGrades(ctx, "1", "7", "6", true)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotCourseTeacher)
+		repo.AssertNotCalled(t, "UpdateGradeSheetStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin Skips Teacher Check", func(t *testing.T) {
+		repo := new(mockGradeRepo)
+		svc := NewGradeService(repo, new(mockGradingSchemeRepo), mockTerms, new(mockStandingRepo))
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("UpdateGradeSheetStatus", ctx, "7", models.GradeSheetOpen, models.GradeSheetSubmitted, "1", "").Return(nil).Once()
+
+		// Act
+		err := svc.SubmitGrades(ctx, "1", "7", "1", false)
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "IsTeacherOfCourse", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Already Submitted", func(t *testing.T) {
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		mockRepo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		mockRepo.On("UpdateGradeSheetStatus", ctx, "7", models.GradeSheetOpen, models.GradeSheetSubmitted, "5", "").Return(models.ErrGradeSheetState).Once()
+
+		// Act
+		err := svc.SubmitGrades(ctx, "1", "7", "5", true)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrGradeSheetState)
+	})
+
+	t.Run("Offering In Another Term", func(t *testing.T) {
+		mockTerms.On("GetOfferingByID", ctx, "2", "7").Return(nil, models.ErrOfferingNotFound).Once()
+
+		// Act
+		err := svc.SubmitGrades(ctx, "2", "7", "5", true)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOfferingNotFound)
+	})
+}
+
+func TestGradeService_ReviewGrades(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradeRepo)
+	mockTerms := new(mockTermRepo)
+	svc := NewGradeService(mockRepo, new(mockGradingSchemeRepo), mockTerms, new(mockStandingRepo))
+	ctx := context.Background()
+	offering := &models.CourseOffering{ID: "7", CourseID: "201", TermID: "1"}
+
+	t.Run("Approve Locks Submitted Sheet", func(t *testing.T) {
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		mockRepo.On("UpdateGradeSheetStatus", ctx, "7", models.GradeSheetSubmitted, models.GradeSheetLocked, "9", "ok").Return(nil).Once()
+
+		// Act
+		err := svc.ApproveGrades(ctx, "1", "7", "9", "ok")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Return Reopens Submitted Sheet", func(t *testing.T) {
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		mockRepo.On("UpdateGradeSheetStatus", ctx, "7", models.GradeSheetSubmitted, models.GradeSheetOpen, "9", "final marks missing").Return(nil).Once()
+
+		// Act
+		err := svc.ReturnGrades(ctx, "1", "7", "9", "final marks missing")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGradeService_GetGradeSheet(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradeRepo)
+	mockSchemes := new(mockGradingSchemeRepo)
+	mockTerms := new(mockTermRepo)
+	svc := NewGradeService(mockRepo, mockSchemes, mockTerms, new(mockStandingRepo))
+	ctx := context.Background()
+
+	mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(&models.CourseOffering{ID: "7", CourseID: "201", TermID: "1"}, nil).Once()
+	mockRepo.On("GetGradeSheet", ctx, "7").Return(&models.GradeSheet{OfferingID: "7", Status: models.GradeSheetLocked}, nil).Once()
+	mockRepo.On("GetOfferingMarks", ctx, "7").Return([]models.Mark{
+		{StudentID: 101, OfferingID: 7, FirstAttestation: 30, SecondAttestation: 30, FinalMark: 40},
+	}, nil).Once()
+	mockSchemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+
+	// Act
+	sheet, err := svc.GetGradeSheet(ctx, "1", "7")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.GradeSheetLocked, sheet.Status)
+	assert.Len(t, sheet.Marks, 1)
+	assert.Equal(t, 100.0, sheet.Marks[0].Total)
+	assert.Equal(t, "A", sheet.Marks[0].Letter)
+}
+
+func TestGradeService_RequestGradeChange(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	offering := &models.CourseOffering{ID: "7", CourseID: "201", TermID: "1"}
+	locked := &models.GradeSheet{OfferingID: "7", Status: models.GradeSheetLocked}
+	marks := []models.Mark{{StudentID: 101, OfferingID: 7, FirstAttestation: 20, FinalMark: 25}}
+
+	setup := func() (*mockGradeRepo, *mockGradingSchemeRepo, *mockTermRepo, GradeService) {
+		repo := new(mockGradeRepo)
+		schemes := new(mockGradingSchemeRepo)
+		terms := new(mockTermRepo)
+		return repo, schemes, terms, NewGradeService(repo, schemes, terms, new(mockStandingRepo))
+	}
+
+	t.Run("Success Records Old Value", func(t *testing.T) {
+		repo, schemes, terms, svc := setup()
+		request := &models.GradeChangeRequest{OfferingID: "7", StudentID: "101", MarkType: models.MarkTypeFinal, NewValue: 35, Reason: "Exam re-checked"}
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("GetGradeSheet", ctx, "7").Return(locked, nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		repo.On("GetOfferingMarks", ctx, "7").Return(marks, nil).Once()
+		repo.On("CreateGradeChangeRequest", ctx, request).Return(request, nil).Once()
+
+		// Act
+		created, err := svc.RequestGradeChange(ctx, "1", "5", true, request)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 25.0, created.OldValue)
+		assert.Equal(t, models.GradeChangePending, created.Status)
+		assert.Equal(t, "5", *created.RequestedBy)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Sheet Not Locked", func(t *testing.T) {
+		repo, _, terms, svc := setup()
+		request := &models.GradeChangeRequest{OfferingID: "7", StudentID: "101", MarkType: models.MarkTypeFinal, NewValue: 35, Reason: "typo"}
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("GetGradeSheet", ctx, "7").Return(&models.GradeSheet{OfferingID: "7", Status: models.GradeSheetOpen}, nil).Once()
+
+		// Act
+		_, err := svc.RequestGradeChange(ctx, "1", "5", true, request)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrGradesNotLocked)
+		repo.AssertNotCalled(t, "CreateGradeChangeRequest", mock.Anything, mock.Anything)
+	})
+
+	t.Run("New Value Out Of Range", func(t *testing.T) {
+		repo, schemes, terms, svc := setup()
+		request := &models.GradeChangeRequest{OfferingID: "7", StudentID: "101", MarkType: models.MarkTypeFinal, NewValue: 45, Reason: "typo"}
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("GetGradeSheet", ctx, "7").Return(locked, nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+
+		// Act
+		_, err := svc.RequestGradeChange(ctx, "1", "5", true, request)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrMarkOutOfRange)
+		repo.AssertNotCalled(t, "CreateGradeChangeRequest", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Student Without Mark Must Be Enrolled", func(t *testing.T) {
+		repo, schemes, terms, svc := setup()
+		request := &models.GradeChangeRequest{OfferingID: "7", StudentID: "102", MarkType: models.MarkTypeFinal, NewValue: 30, Reason: "missed entry"}
+		terms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		repo.On("IsTeacherOfCourse", ctx, "5", "201").Return(true, nil).Once()
+		repo.On("GetGradeSheet", ctx, "7").Return(locked, nil).Once()
+		schemes.On("GetOfferingScheme", ctx, "7").Return(nil, models.ErrGradingSchemeNotFound).Once()
+		repo.On("GetOfferingMarks", ctx, "7").Return(marks, nil).Once()
+		repo.On("GetEnrollmentOfferingID", ctx, "102", "201").Return(uint(8), nil).Once()
+
+		// Act
+		_, err := svc.RequestGradeChange(ctx, "1", "5", true, request)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrStudentNotEnrolled)
+	})
+}
+
+func TestGradeService_ReviewGradeChange(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradeRepo)
+	svc := NewGradeService(mockRepo, new(mockGradingSchemeRepo), new(mockTermRepo), new(mockStandingRepo))
+	ctx := context.Background()
+
+	t.Run("Approve", func(t *testing.T) {
+		mockRepo.On("ApproveGradeChange", ctx, "3", "9", "").Return(nil).Once()
+		mockRepo.On("GetGradeChangeRequest", ctx, "3").Return(&models.GradeChangeRequest{ID: "3", Status: models.GradeChangeApproved}, nil).Once()
+
+		// Act
+		request, err := svc.ReviewGradeChange(ctx, "3", "9", true, "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.GradeChangeApproved, request.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reject Already Reviewed", func(t *testing.T) {
+		mockRepo.On("RejectGradeChange", ctx, "4", "9", "no evidence").Return(models.ErrGradeChangeNotPending).Once()
+
+		// Act
+		_, err := svc.ReviewGradeChange(ctx, "4", "9", false, "no evidence")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrGradeChangeNotPending)
+	})
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type GradingSchemeService interface {
+	GetGradingSchemes(ctx context.Context) ([]models.GradingScheme, error)
+	GetGradingSchemeByID(ctx context.Context, id string) (*models.GradingScheme, error)
+	CreateGradingScheme(ctx context.Context, scheme *models.GradingScheme) (*models.GradingScheme, error)
+	UpdateGradingScheme(ctx context.Context, scheme models.GradingScheme) (*models.GradingScheme, error)
+	DeleteGradingScheme(ctx context.Context, id string) error
+}
+
+type gradingSchemeService struct {
+	repo repository.GradingSchemeRepository
+}
+
+func NewGradingSchemeService(repo repository.GradingSchemeRepository) GradingSchemeService {
+	return &gradingSchemeService{repo: repo}
+}
+
+// validateGradingScheme проверяет, что веса дают итог из 100 баллов, а буквенная шкала
+// покрывает весь диапазон от 0; буквы сортируются по убыванию нижней границы
+func validateGradingScheme(scheme *models.GradingScheme) error {
+	if scheme.FirstAttestationMax <= 0 || scheme.SecondAttestationMax <= 0 || scheme.FinalMax <= 0 {
+		return fmt.Errorf("%w: maximum points must be positive", models.ErrInvalidGradingScheme)
+	}
+	if scheme.FirstAttestationWeight < 0 || scheme.SecondAttestationWeight < 0 || scheme.FinalWeight < 0 {
+		return fmt.Errorf("%w: weights must not be negative", models.ErrInvalidGradingScheme)
+	}
+	if sum := scheme.FirstAttestationWeight + scheme.SecondAttestationWeight + scheme.FinalWeight; math.Abs(sum-100) > 1e-6 {
+		return fmt.Errorf("%w: weights must add up to 100, got %g", models.ErrInvalidGradingScheme, sum)
+	}
+	if len(scheme.Letters) == 0 {
+		return fmt.Errorf("%w: at least one letter grade is required", models.ErrInvalidGradingScheme)
+	}
+
+	letters := map[string]bool{}
+	for i := range scheme.Letters {
+		letter := &scheme.Letters[i]
+		letter.Letter = strings.TrimSpace(letter.Letter)
+		if letter.Letter == "" {
+			return fmt.Errorf("%w: letter must not be empty", models.ErrInvalidGradingScheme)
+		}
+		if letters[letter.Letter] {
+			return fmt.Errorf("%w: letter %s is listed twice", models.ErrInvalidGradingScheme, letter.Letter)
+		}
+		letters[letter.Letter] = true
+		if letter.MinTotal < 0 || letter.MinTotal > 100 {
+			return fmt.Errorf("%w: min_total of %s must be between 0 and 100", models.ErrInvalidGradingScheme, letter.Letter)
+		}
+		if letter.GPAPoints < 0 {
+			return fmt.Errorf("%w: gpa_points of %s must not be negative", models.ErrInvalidGradingScheme, letter.Letter)
+		}
+	}
+
+	sort.SliceStable(scheme.Letters, func(i, j int) bool {
+		return scheme.Letters[i].MinTotal > scheme.Letters[j].MinTotal
+	})
+	for i := 1; i < len(scheme.Letters); i++ {
+		if scheme.Letters[i].MinTotal == scheme.Letters[i-1].MinTotal {
+			return fmt.Errorf("%w: letters %s and %s have the same min_total", models.ErrInvalidGradingScheme,
+				scheme.Letters[i-1].Letter, scheme.Letters[i].Letter)
+		}
+	}
+	if lowest := scheme.Letters[len(scheme.Letters)-1]; lowest.MinTotal != 0 {
+		return fmt.Errorf("%w: the lowest letter must start at 0", models.ErrInvalidGradingScheme)
+	}
+	return nil
+}
+
+func (s *gradingSchemeService) GetGradingSchemes(ctx context.Context) ([]models.GradingScheme, error) {
+	return s.repo.GetGradingSchemes(ctx)
+}
+
+func (s *gradingSchemeService) GetGradingSchemeByID(ctx context.Context, id string) (*models.GradingScheme, error) {
+	return s.repo.GetGradingSchemeByID(ctx, id)
+}
+
+func (s *gradingSchemeService) CreateGradingScheme(ctx context.Context, scheme *models.GradingScheme) (*models.GradingScheme, error) {
+	if err := validateGradingScheme(scheme); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateGradingScheme(ctx, scheme)
+}
+
+func (s *gradingSchemeService) UpdateGradingScheme(ctx context.Context, scheme models.GradingScheme) (*models.GradingScheme, error) {
+	if err := validateGradingScheme(&scheme); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateGradingScheme(ctx, scheme)
+}
+
+func (s *gradingSchemeService) DeleteGradingScheme(ctx context.Context, id string) error {
+	return s.repo.DeleteGradingScheme(ctx, id)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockGradingSchemeRepo struct {
+	mock.Mock
+}
+
+func (m *mockGradingSchemeRepo) GetGradingSchemes(ctx context.Context) ([]models.GradingScheme, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GradingScheme), args.Error(1)
+}
+
+func (m *mockGradingSchemeRepo) GetGradingSchemeByID(ctx context.Context, id string) (*models.GradingScheme, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradingScheme), args.Error(1)
+}
+
+func (m *mockGradingSchemeRepo) CreateGradingScheme(ctx context.Context, scheme *models.GradingScheme) (*models.GradingScheme, error) {
+	args := m.Called(ctx, scheme)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradingScheme), args.Error(1)
+}
+
+func (m *mockGradingSchemeRepo) UpdateGradingScheme(ctx context.Context, scheme models.GradingScheme) (*models.GradingScheme, error) {
+	args := m.Called(ctx, scheme)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradingScheme), args.Error(1)
+}
+
+func (m *mockGradingSchemeRepo) DeleteGradingScheme(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockGradingSchemeRepo) GetOfferingScheme(ctx context.Context, offeringID string) (*models.GradingScheme, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GradingScheme), args.Error(1)
+}
+
+func TestGradingSchemeService_CreateGradingScheme(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradingSchemeRepo)
+	svc := NewGradingSchemeService(mockRepo)
+	ctx := context.Background()
+
+	t.Run("Letters Sorted By Boundary", func(t *testing.T) {
+		scheme := models.DefaultGradingScheme()
+		scheme.Letters = []models.LetterGrade{
+			{Letter: "F", MinTotal: 0},
+			{Letter: " A ", MinTotal: 90, GPAPoints: 4},
+			{Letter: "C", MinTotal: 50, GPAPoints: 2},
+		}
+		mockRepo.On("CreateGradingScheme", ctx, scheme).Return(scheme, nil).Once()
+
+		// Act
+		created, err := svc.CreateGradingScheme(ctx, scheme)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A", "C", "F"}, []string{created.Letters[0].Letter, created.Letters[1].Letter, created.Letters[2].Letter})
+		mockRepo.AssertExpectations(t)
+	})
+
+	invalid := map[string]func(s *models.GradingScheme){
+		"Weights Not 100": func(s *models.GradingScheme) { s.FinalWeight = 30 },
+		"Zero Maximum":    func(s *models.GradingScheme) { s.FinalMax = 0 },
+		"Duplicate Letter": func(s *models.GradingScheme) {
+			s.Letters = append(s.Letters, models.LetterGrade{Letter: "A", MinTotal: 99})
+		},
+		"Same Boundary": func(s *models.GradingScheme) {
+			s.Letters = append(s.Letters, models.LetterGrade{Letter: "A+", MinTotal: 95})
+		},
+		"No Zero Boundary": func(s *models.GradingScheme) { s.Letters = s.Letters[:len(s.Letters)-1] },
+		"Boundary Above 100": func(s *models.GradingScheme) {
+			s.Letters = append(s.Letters, models.LetterGrade{Letter: "A+", MinTotal: 101})
+		},
+	}
+	for name, breakScheme := range invalid {
+		t.Run(name, func(t *testing.T) {
+			scheme := models.DefaultGradingScheme()
+			breakScheme(scheme)
+
+			// Act
+			_, err := svc.CreateGradingScheme(ctx, scheme)
+
+			// Assert
+			assert.ErrorIs(t, err, models.ErrInvalidGradingScheme)
+		})
+	}
+}
+
+func TestGradingSchemeService_UpdateGradingScheme(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockGradingSchemeRepo)
+	svc := NewGradingSchemeService(mockRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		scheme := *models.DefaultGradingScheme()
+		scheme.ID = "3"
+		mockRepo.On("UpdateGradingScheme", ctx, scheme).Return(&scheme, nil).Once()
+
+		// Act
+		updated, err := svc.UpdateGradingScheme(ctx, scheme)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "3", updated.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		scheme := *models.DefaultGradingScheme()
+		scheme.ID = "9"
+		mockRepo.On("UpdateGradingScheme", ctx, scheme).Return(nil, models.ErrGradingSchemeNotFound).Once()
+
+		// Act
+		_, err := svc.UpdateGradingScheme(ctx, scheme)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrGradingSchemeNotFound)
+	})
+}
@@ -9,19 +9,23 @@ import (
 type ManagerService interface {
 	GetManagers(ctx context.Context) ([]models.Manager, error)
 	GetManagerById(ctx context.Context, ID string) (*models.Manager, error)
-	UpdateManager(ctx context.Context, manager models.Manager) (*models.Manager, error)
+	UpdateManager(ctx context.Context, manager models.Manager, managerID string) (*models.Manager, error)
 	DeleteManager(ctx context.Context, ID string) error
 	CreateManager(ctx context.Context, manager *models.Manager) (*models.Manager, error)
 	CreateUserWithRole(ctx context.Context, user models.User, role string) (string, error)
-	AssignTeacherToCourse(ctx context.Context, teacherID, courseID string) error
+	AssignTeacherToCourse(ctx context.Context, teacherID, courseID, managerID string) error
 }
 
 type managerService struct {
-	repo repository.ManagerRepository
+	repo     repository.ManagerRepository
+	org      repository.OrganizationRepository
+	teachers repository.TeacherRepository
+	courses  repository.CourseRepository
 }
 
-func NewManagerService(repo repository.ManagerRepository) ManagerService {
-	return &managerService{repo: repo}
+func NewManagerService(repo repository.ManagerRepository, org repository.OrganizationRepository,
+	teachers repository.TeacherRepository, courses repository.CourseRepository) ManagerService {
+	return &managerService{repo: repo, org: org, teachers: teachers, courses: courses}
 }
 
 func (s *managerService) GetManagers(ctx context.Context) ([]models.Manager, error) {
@@ -32,7 +36,20 @@ func (s *managerService) GetManagerById(ctx context.Context, ID string) (*models
 	return s.repo.GetManagerById(ctx, ID)
 }
 
-func (s *managerService) UpdateManager(ctx context.Context, manager models.Manager) (*models.Manager, error) {
+// UpdateManager изменяет профиль менеджера. Менеджер, изменяющий свой профиль (managerID не пуст),
+// не может сменить кафедру: её назначает администратор.
+func (s *managerService) UpdateManager(ctx context.Context, manager models.Manager, managerID string) (*models.Manager, error) {
+	if managerID != "" {
+		current, err := s.repo.GetManagerById(ctx, manager.ID)
+		if err != nil {
+			return nil, err
+		}
+		manager.DepartmentID, manager.Department = current.DepartmentID, current.Department
+		return s.repo.UpdateManager(ctx, manager)
+	}
+	if err := s.assignDepartment(ctx, &manager); err != nil {
+		return nil, err
+	}
 	return s.repo.UpdateManager(ctx, manager)
 }
 
@@ -41,6 +58,9 @@ func (s *managerService) DeleteManager(ctx context.Context, ID string) error {
 }
 
 func (s *managerService) CreateManager(ctx context.Context, manager *models.Manager) (*models.Manager, error) {
+	if err := s.assignDepartment(ctx, manager); err != nil {
+		return nil, err
+	}
 	return s.repo.CreateManager(ctx, manager)
 }
 
@@ -48,6 +68,38 @@ func (s *managerService) CreateUserWithRole(ctx context.Context, user models.Use
 	return s.repo.CreateUserWithRole(ctx, user, role)
 }
 
-func (s *managerService) AssignTeacherToCourse(ctx context.Context, teacherID, courseID string) error {
+// AssignTeacherToCourse назначает преподавателя на курс; менеджер — только преподавателя
+// и курс своей кафедры
+func (s *managerService) AssignTeacherToCourse(ctx context.Context, teacherID, courseID, managerID string) error {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return err
+	}
+	if scope != nil {
+		teacher, err := s.teachers.GetTeacherById(ctx, teacherID)
+		if err != nil {
+			return err
+		}
+		course, err := s.courses.GetCourseByID(ctx, courseID)
+		if err != nil {
+			return err
+		}
+		if !inDepartment(scope, teacher.DepartmentID) || !inDepartment(scope, course.DepartmentID) {
+			return models.ErrOutsideDepartment
+		}
+	}
 	return s.repo.AssignTeacherToCourse(ctx, teacherID, courseID)
 }
+
+// assignDepartment сопоставляет кафедру менеджера со справочником и подставляет её каноническое название
+func (s *managerService) assignDepartment(ctx context.Context, manager *models.Manager) error {
+	department, err := resolveDepartment(ctx, s.org, manager.DepartmentID, manager.Department)
+	if err != nil {
+		return err
+	}
+	manager.DepartmentID, manager.Department = nil, ""
+	if department != nil {
+		manager.DepartmentID, manager.Department = &department.ID, department.Name
+	}
+	return nil
+}
@@ -65,7 +65,7 @@ func (m *mockManagerRepo) CreateUserWithRole(ctx context.Context, user models.Us
 func TestManagerService_GetManagers(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockManagerRepo)
-	svc := NewManagerService(mockRepo)
+	svc := NewManagerService(mockRepo, new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -102,7 +102,7 @@ func TestManagerService_GetManagers(t *testing.T) {
 func TestManagerService_GetManagerById(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockManagerRepo)
-	svc := NewManagerService(mockRepo)
+	svc := NewManagerService(mockRepo, new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -151,7 +151,7 @@ func TestManagerService_GetManagerById(t *testing.T) {
 func TestManagerService_CreateManager(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockManagerRepo)
-	svc := NewManagerService(mockRepo)
+	svc := NewManagerService(mockRepo, new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -193,7 +193,7 @@ func TestManagerService_CreateManager(t *testing.T) {
 func TestManagerService_UpdateManager(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockManagerRepo)
-	svc := NewManagerService(mockRepo)
+	svc := NewManagerService(mockRepo, new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -203,7 +203,7 @@ func TestManagerService_UpdateManager(t *testing.T) {
 		mockRepo.On("UpdateManager", ctx, updatedManager).Return(&updatedManager, nil).Once()
 
 		// Act
-		manager, err := svc.UpdateManager(ctx, updatedManager)
+		manager, err := svc.UpdateManager(ctx, updatedManager, "")
 
 		// Assert
 		assert.NoError(t, err)
@@ -219,7 +219,7 @@ func TestManagerService_UpdateManager(t *testing.T) {
 		mockRepo.On("UpdateManager", ctx, updatedManager).Return(nil, expectedError).Once()
 
 		// Act
-		manager, err := svc.UpdateManager(ctx, updatedManager)
+		manager, err := svc.UpdateManager(ctx, updatedManager, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -232,7 +232,7 @@ func TestManagerService_UpdateManager(t *testing.T) {
 func TestManagerService_DeleteManager(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockManagerRepo)
-	svc := NewManagerService(mockRepo)
+	svc := NewManagerService(mockRepo, new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -261,3 +261,55 @@ func TestManagerService_DeleteManager(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestManagerService_UpdateManager_OwnProfile(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockManagerRepo)
+	svc := NewManagerService(mockRepo, new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo))
+	ctx := context.Background()
+	mockRepo.On("GetManagerById", ctx, "40").Return(&models.Manager{Department: "Software Engineering", DepartmentID: strPtr("5")}, nil).Once()
+	mockRepo.On("UpdateManager", ctx, mock.MatchedBy(func(m models.Manager) bool {
+		return *m.DepartmentID == "5" && m.Department == "Software Engineering"
+	})).Return(&models.Manager{}, nil).Once()
+
+	// Act
+	_, err := svc.UpdateManager(ctx, models.Manager{User: models.User{ID: "40"}, DepartmentID: strPtr("2")}, "40")
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestManagerService_AssignTeacherToCourse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Admin Is Not Scoped", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mockManagerRepo)
+		svc := NewManagerService(mockRepo, new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo))
+		mockRepo.On("AssignTeacherToCourse", ctx, "1", "10").Return(nil).Once()
+
+		// Act
+		err := svc.AssignTeacherToCourse(ctx, "1", "10", "")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Course Of Another Department", func(t *testing.T) {
+		// Arrange
+		mockRepo, org, teachers, courses := new(mockManagerRepo), new(mockOrganizationRepo), new(mockTeacherRepo), new(mockCourseRepo)
+		svc := NewManagerService(mockRepo, org, teachers, courses)
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		teachers.On("GetTeacherById", ctx, "1").Return(&models.Teacher{DepartmentID: strPtr("5")}, nil).Once()
+		courses.On("GetCourseByID", ctx, "10").Return(&models.Course{DepartmentID: strPtr("2")}, nil).Once()
+
+		// Act
+		err := svc.AssignTeacherToCourse(ctx, "1", "10", "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "AssignTeacherToCourse", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
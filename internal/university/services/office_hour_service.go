@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+// Слоты по умолчанию показываются на две недели вперёд, за один запрос — не больше чем на восемь недель
+const (
+	defaultSlotRange = 14 * 24 * time.Hour
+	maxSlotRange     = 56 * 24 * time.Hour
+)
+
+type OfficeHourService interface {
+	GetTeacherOfficeHours(ctx context.Context, teacherID string) ([]models.OfficeHour, error)
+	CreateOfficeHour(ctx context.Context, teacherID string, hour *models.OfficeHour) (*models.OfficeHour, error)
+	UpdateOfficeHour(ctx context.Context, hour models.OfficeHour, userID, role string) (*models.OfficeHour, error)
+	DeleteOfficeHour(ctx context.Context, id, userID, role string) error
+	GetSlots(ctx context.Context, officeHourID string, from, to time.Time) ([]models.OfficeHourSlot, error)
+	BookSlot(ctx context.Context, officeHourID, studentID string, request models.BookSlotRequest) (*models.OfficeHourBooking, error)
+	GetStudentBookings(ctx context.Context, studentID string, includePast bool) ([]models.OfficeHourBooking, error)
+	GetTeacherBookings(ctx context.Context, teacherID string, includePast bool) ([]models.OfficeHourBooking, error)
+	CancelBooking(ctx context.Context, id, userID, role string) error
+}
+
+type officeHourService struct {
+	repo     repository.OfficeHourRepository
+	teachers repository.TeacherRepository
+	courses  repository.CourseRepository
+	now      func() time.Time
+}
+
+func NewOfficeHourService(repo repository.OfficeHourRepository, teachers repository.TeacherRepository,
+	courses repository.CourseRepository) OfficeHourService {
+	return &officeHourService{repo: repo, teachers: teachers, courses: courses, now: time.Now}
+}
+
+func (s *officeHourService) GetTeacherOfficeHours(ctx context.Context, teacherID string) ([]models.OfficeHour, error) {
+	return s.repo.GetTeacherOfficeHours(ctx, teacherID)
+}
+
+// CreateOfficeHour добавляет часы консультаций; часы не должны пересекаться с другими часами преподавателя.
+// Пересечение проверяет репозиторий в одной транзакции с записью.
+func (s *officeHourService) CreateOfficeHour(ctx context.Context, teacherID string, hour *models.OfficeHour) (*models.OfficeHour, error) {
+	if err := normalizeOfficeHour(hour); err != nil {
+		return nil, err
+	}
+	hour.TeacherID = teacherID
+	return s.repo.CreateOfficeHour(ctx, hour)
+}
+
+// UpdateOfficeHour меняет часы консультаций; пока на них есть предстоящие записи, часы не меняются,
+// чтобы записи не оказались вне расписания. Новые часы не должны пересекаться с другими часами преподавателя.
+// Обе проверки выполняет репозиторий под блокировкой часов.
+func (s *officeHourService) UpdateOfficeHour(ctx context.Context, hour models.OfficeHour, userID, role string) (*models.OfficeHour, error) {
+	current, err := s.managedOfficeHour(ctx, hour.ID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeOfficeHour(&hour); err != nil {
+		return nil, err
+	}
+	hour.TeacherID = current.TeacherID
+	return s.repo.UpdateOfficeHour(ctx, &hour, wallClock(s.now()))
+}
+
+// DeleteOfficeHour удаляет часы вместе с прошедшими записями; предстоящие записи нужно сначала отменить
+func (s *officeHourService) DeleteOfficeHour(ctx context.Context, id, userID, role string) error {
+	if _, err := s.managedOfficeHour(ctx, id, userID, role); err != nil {
+		return err
+	}
+	return s.repo.DeleteOfficeHour(ctx, id, wallClock(s.now()))
+}
+
+// managedOfficeHour возвращает часы, которыми может управлять пользователь: свои — преподаватель, любые — администратор
+func (s *officeHourService) managedOfficeHour(ctx context.Context, id, userID, role string) (*models.OfficeHour, error) {
+	hour, err := s.repo.GetOfficeHourByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && hour.TeacherID != userID {
+		return nil, models.ErrOfficeHourForbidden
+	}
+	return hour, nil
+}
+
+// GetSlots возвращает слоты часов в интервале [from, to) с отметкой, свободен ли слот. Прошедшие слоты
+// не показываются; без from — с текущего момента, без to — на две недели вперёд.
+func (s *officeHourService) GetSlots(ctx context.Context, officeHourID string, from, to time.Time) ([]models.OfficeHourSlot, error) {
+	hour, err := s.repo.GetOfficeHourByID(ctx, officeHourID)
+	if err != nil {
+		return nil, err
+	}
+	now := wallClock(s.now())
+	from, to = wallClock(from), wallClock(to)
+	if from.IsZero() || from.Before(now) {
+		from = now
+	}
+	if to.IsZero() {
+		to = from.Add(defaultSlotRange)
+	}
+	if !to.After(from) || to.Sub(from) > maxSlotRange {
+		return nil, fmt.Errorf("%w: range must be positive and at most %d days", models.ErrInvalidSlot, int(maxSlotRange.Hours()/24))
+	}
+	slots, err := officeHourSlots(*hour, from, to)
+	if err != nil {
+		return nil, err
+	}
+	booked, err := s.repo.GetBookedSlots(ctx, officeHourID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[time.Time]bool, len(booked))
+	for _, start := range booked {
+		taken[wallClock(start)] = true
+	}
+	for i := range slots {
+		slots[i].Available = !taken[slots[i].StartsAt]
+	}
+	return slots, nil
+}
+
+// BookSlot записывает студента на слот. Записаться может студент, записанный на один из курсов
+// преподавателя; слот должен совпадать с сеткой часов и ещё не начаться. Одновременные записи
+// на один слот разводит уникальный индекс в репозитории.
+func (s *officeHourService) BookSlot(ctx context.Context, officeHourID, studentID string, request models.BookSlotRequest) (*models.OfficeHourBooking, error) {
+	hour, err := s.repo.GetOfficeHourByID(ctx, officeHourID)
+	if err != nil {
+		return nil, err
+	}
+	eligible, err := s.isTeacherStudent(ctx, hour.TeacherID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !eligible {
+		return nil, models.ErrNotTeacherStudent
+	}
+
+	startsAt := wallClock(request.StartsAt)
+	if !startsAt.After(wallClock(s.now())) {
+		return nil, fmt.Errorf("%w: slot has already started", models.ErrInvalidSlot)
+	}
+	day := atClock(startsAt, 0, 0, 0)
+	slots, err := officeHourSlots(*hour, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	for _, slot := range slots {
+		if slot.StartsAt.Equal(startsAt) {
+			return s.repo.CreateBooking(ctx, &models.OfficeHourBooking{
+				OfficeHourID: hour.ID,
+				StudentID:    studentID,
+				StartsAt:     slot.StartsAt,
+				EndsAt:       slot.EndsAt,
+				Topic:        strings.TrimSpace(request.Topic),
+			})
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is not a slot of this office hour", models.ErrInvalidSlot, startsAt.Format("2006-01-02 15:04"))
+}
+
+// isTeacherStudent — студент записан (и не выбыл) хотя бы на один курс преподавателя
+func (s *officeHourService) isTeacherStudent(ctx context.Context, teacherID, studentID string) (bool, error) {
+	courses, err := s.teachers.GetTeacherCourses(ctx, teacherID)
+	if err != nil {
+		return false, err
+	}
+	for _, course := range courses {
+		students, err := s.courses.GetCourseStudents(ctx, course.ID)
+		if err != nil {
+			return false, err
+		}
+		for _, student := range students {
+			if student.ID == studentID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (s *officeHourService) GetStudentBookings(ctx context.Context, studentID string, includePast bool) ([]models.OfficeHourBooking, error) {
+	return s.repo.GetStudentBookings(ctx, studentID, s.bookingsFrom(includePast))
+}
+
+func (s *officeHourService) GetTeacherBookings(ctx context.Context, teacherID string, includePast bool) ([]models.OfficeHourBooking, error) {
+	return s.repo.GetTeacherBookings(ctx, teacherID, s.bookingsFrom(includePast))
+}
+
+func (s *officeHourService) bookingsFrom(includePast bool) *time.Time {
+	if includePast {
+		return nil
+	}
+	now := wallClock(s.now())
+	return &now
+}
+
+// CancelBooking отменяет запись до начала консультации: свою — студент, к себе — преподаватель,
+// любую — администратор. Слот снова становится свободным.
+func (s *officeHourService) CancelBooking(ctx context.Context, id, userID, role string) error {
+	booking, err := s.repo.GetBookingByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case role == "admin":
+	case role == "student" && booking.StudentID == userID:
+	case role == "teacher" && booking.TeacherID == userID:
+	default:
+		return models.ErrBookingForbidden
+	}
+	now := wallClock(s.now())
+	if booking.Status != models.BookingStatusBooked || !now.Before(booking.StartsAt) {
+		return models.ErrBookingNotCancellable
+	}
+	return s.repo.CancelBooking(ctx, id, userID, now)
+}
+
+// normalizeOfficeHour приводит время часов к виду "15:04", а даты действия — к началу дня и проверяет,
+// что в часы помещается хотя бы один слот
+func normalizeOfficeHour(hour *models.OfficeHour) error {
+	start, err := time.Parse("15:04", hour.StartTime)
+	if err != nil {
+		return fmt.Errorf("%w: start_time must be HH:MM", models.ErrInvalidOfficeHour)
+	}
+	end, err := time.Parse("15:04", hour.EndTime)
+	if err != nil {
+		return fmt.Errorf("%w: end_time must be HH:MM", models.ErrInvalidOfficeHour)
+	}
+	if end.Sub(start) < time.Duration(hour.SlotMinutes)*time.Minute {
+		return fmt.Errorf("%w: end_time must leave room for at least one slot", models.ErrInvalidOfficeHour)
+	}
+	hour.StartTime, hour.EndTime = start.Format("15:04"), end.Format("15:04")
+	for _, date := range []*time.Time{hour.ValidFrom, hour.ValidUntil} {
+		if date != nil {
+			*date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		}
+	}
+	if hour.ValidFrom != nil && hour.ValidUntil != nil && hour.ValidUntil.Before(*hour.ValidFrom) {
+		return fmt.Errorf("%w: valid_until must not be before valid_from", models.ErrInvalidOfficeHour)
+	}
+	return nil
+}
+
+// officeHourSlots раскладывает часы на слоты, начинающиеся в интервале [from, to): по дням недели часов
+// в пределах дат действия, от начала часов с шагом SlotMinutes, пока слот помещается до конца часов
+func officeHourSlots(hour models.OfficeHour, from, to time.Time) ([]models.OfficeHourSlot, error) {
+	start, err := time.Parse("15:04", hour.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse("15:04", hour.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	length := time.Duration(hour.SlotMinutes) * time.Minute
+	if length <= 0 {
+		return nil, fmt.Errorf("%w: slot_minutes must be positive", models.ErrInvalidOfficeHour)
+	}
+
+	slots := []models.OfficeHourSlot{}
+	for day := atClock(from, 0, 0, 0); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Weekday(hour.Weekday) || !officeHourValidOn(hour, day) {
+			continue
+		}
+		closes := atClock(day, end.Hour(), end.Minute(), 0)
+		for slot := atClock(day, start.Hour(), start.Minute(), 0); !slot.Add(length).After(closes); slot = slot.Add(length) {
+			if slot.Before(from) || !slot.Before(to) {
+				continue
+			}
+			slots = append(slots, models.OfficeHourSlot{
+				OfficeHourID: hour.ID,
+				StartsAt:     slot,
+				EndsAt:       slot.Add(length),
+				Location:     hour.Location,
+			})
+		}
+	}
+	return slots, nil
+}
+
+func officeHourValidOn(hour models.OfficeHour, day time.Time) bool {
+	date := day.Format("2006-01-02")
+	if hour.ValidFrom != nil && date < hour.ValidFrom.Format("2006-01-02") {
+		return false
+	}
+	if hour.ValidUntil != nil && date > hour.ValidUntil.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// wallClock переносит местное время в UTC без сдвига: записи хранятся без часового пояса,
+// как и расписание занятий, поэтому сравниваются показания часов, а не моменты времени
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
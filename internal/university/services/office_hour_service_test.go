@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockOfficeHourRepo struct {
+	mock.Mock
+}
+
+func (m *mockOfficeHourRepo) GetTeacherOfficeHours(ctx context.Context, teacherID string) ([]models.OfficeHour, error) {
+	args := m.Called(ctx, teacherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OfficeHour), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) GetOfficeHourByID(ctx context.Context, id string) (*models.OfficeHour, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OfficeHour), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) CreateOfficeHour(ctx context.Context, hour *models.OfficeHour) (*models.OfficeHour, error) {
+	args := m.Called(ctx, hour)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OfficeHour), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) UpdateOfficeHour(ctx context.Context, hour *models.OfficeHour, now time.Time) (*models.OfficeHour, error) {
+	args := m.Called(ctx, hour, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OfficeHour), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) DeleteOfficeHour(ctx context.Context, id string, now time.Time) error {
+	return m.Called(ctx, id, now).Error(0)
+}
+
+func (m *mockOfficeHourRepo) GetBookedSlots(ctx context.Context, officeHourID string, from, to time.Time) ([]time.Time, error) {
+	args := m.Called(ctx, officeHourID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) CreateBooking(ctx context.Context, booking *models.OfficeHourBooking) (*models.OfficeHourBooking, error) {
+	args := m.Called(ctx, booking)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OfficeHourBooking), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) GetBookingByID(ctx context.Context, id string) (*models.OfficeHourBooking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.OfficeHourBooking), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) GetStudentBookings(ctx context.Context, studentID string, from *time.Time) ([]models.OfficeHourBooking, error) {
+	args := m.Called(ctx, studentID, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OfficeHourBooking), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) GetTeacherBookings(ctx context.Context, teacherID string, from *time.Time) ([]models.OfficeHourBooking, error) {
+	args := m.Called(ctx, teacherID, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OfficeHourBooking), args.Error(1)
+}
+
+func (m *mockOfficeHourRepo) CancelBooking(ctx context.Context, id, cancelledBy string, at time.Time) error {
+	return m.Called(ctx, id, cancelledBy, at).Error(0)
+}
+
+// mondayOfficeHour — консультации преподавателя 201 по понедельникам 10:00–11:00, слоты по 20 минут
+func mondayOfficeHour() models.OfficeHour {
+	return models.OfficeHour{ID: "8", TeacherID: "201", Weekday: 1, StartTime: "10:00", EndTime: "11:00", SlotMinutes: 20, Location: "B-204"}
+}
+
+// 1 октября 2025 — среда, ближайшие понедельники — 6 и 13 октября
+func newTestOfficeHourService(repo *mockOfficeHourRepo, teachers *mockTeacherRepo, courses *mockCourseRepo) *officeHourService {
+	svc := NewOfficeHourService(repo, teachers, courses).(*officeHourService)
+	svc.now = func() time.Time { return time.Date(2025, time.October, 1, 12, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+func TestOfficeHourService_CreateOfficeHour(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Slot Does Not Fit", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		hour := &models.OfficeHour{Weekday: 1, StartTime: "10:00", EndTime: "10:15", SlotMinutes: 20}
+
+		// Act
+		_, err := svc.CreateOfficeHour(ctx, "201", hour)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidOfficeHour)
+		repo.AssertNotCalled(t, "CreateOfficeHour", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		validFrom := time.Date(2025, time.September, 1, 15, 30, 0, 0, time.UTC)
+		hour := &models.OfficeHour{Weekday: 1, StartTime: "9:00", EndTime: "10:00", SlotMinutes: 15, ValidFrom: &validFrom}
+		repo.On("CreateOfficeHour", ctx, mock.MatchedBy(func(h *models.OfficeHour) bool {
+			return h.TeacherID == "201" && h.StartTime == "09:00" && h.ValidFrom.Equal(date(2025, time.September, 1))
+		})).Return(hour, nil).Once()
+
+		// Act
+		_, err := svc.CreateOfficeHour(ctx, "201", hour)
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Overlaps Another Office Hour", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		hour := &models.OfficeHour{Weekday: 1, StartTime: "10:30", EndTime: "11:30", SlotMinutes: 30}
+		repo.On("CreateOfficeHour", ctx, hour).Return(nil, models.ErrOfficeHourOverlap).Once()
+
+		// Act
+		_, err := svc.CreateOfficeHour(ctx, "201", hour)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOfficeHourOverlap)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestOfficeHourService_UpdateOfficeHour(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	hour := mondayOfficeHour()
+
+	t.Run("Other Teacher", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+
+		// Act
+		_, err := svc.UpdateOfficeHour(ctx, mondayOfficeHour(), "202", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOfficeHourForbidden)
+		repo.AssertNotCalled(t, "UpdateOfficeHour", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Upcoming Bookings", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+		repo.On("UpdateOfficeHour", ctx, mock.Anything, svc.now()).Return(nil, models.ErrOfficeHourHasBookings).Once()
+
+		// Act
+		_, err := svc.UpdateOfficeHour(ctx, mondayOfficeHour(), "201", "teacher")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOfficeHourHasBookings)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+		longer := mondayOfficeHour()
+		longer.EndTime = "11:20"
+		// Преподаватель берётся из сохранённых часов, проверки выполняются на момент svc.now()
+		repo.On("UpdateOfficeHour", ctx, mock.MatchedBy(func(h *models.OfficeHour) bool {
+			return h.ID == "8" && h.TeacherID == "201" && h.EndTime == "11:20"
+		}), svc.now()).Return(&longer, nil).Once()
+
+		// Act
+		_, err := svc.UpdateOfficeHour(ctx, longer, "201", "teacher")
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestOfficeHourService_GetSlots(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	hour := mondayOfficeHour()
+
+	t.Run("Marks Booked Slots", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		now := svc.now()
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+		repo.On("GetBookedSlots", ctx, "8", now, now.Add(14*24*time.Hour)).
+			Return([]time.Time{time.Date(2025, time.October, 6, 10, 20, 0, 0, time.UTC)}, nil).Once()
+
+		// Act
+		slots, err := svc.GetSlots(ctx, "8", time.Time{}, time.Time{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, slots, 6)
+		assert.Equal(t, time.Date(2025, time.October, 6, 10, 0, 0, 0, time.UTC), slots[0].StartsAt)
+		assert.Equal(t, time.Date(2025, time.October, 6, 10, 20, 0, 0, time.UTC), slots[0].EndsAt)
+		assert.True(t, slots[0].Available)
+		assert.False(t, slots[1].Available)
+		assert.Equal(t, time.Date(2025, time.October, 13, 10, 40, 0, 0, time.UTC), slots[5].StartsAt)
+		assert.Equal(t, "B-204", slots[5].Location)
+	})
+
+	t.Run("Range Too Long", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+
+		// Act
+		_, err := svc.GetSlots(ctx, "8", date(2025, time.October, 1), date(2026, time.January, 1))
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidSlot)
+		repo.AssertNotCalled(t, "GetBookedSlots", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOfficeHourSlots_RespectsValidity(t *testing.T) {
+	// Arrange
+	hour := mondayOfficeHour()
+	validUntil := date(2025, time.October, 6)
+	hour.ValidUntil = &validUntil
+
+	// Act
+	slots, err := officeHourSlots(hour, date(2025, time.October, 1), date(2025, time.October, 20))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, slots, 3)
+	for _, slot := range slots {
+		assert.Equal(t, 6, slot.StartsAt.Day())
+	}
+}
+
+func TestOfficeHourService_BookSlot(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	hour := mondayOfficeHour()
+	courses := []models.Course{{ID: "11"}, {ID: "12"}}
+	enrolled := []models.Student{{User: models.User{ID: "101"}}}
+
+	eligibleStudent := func() (*mockTeacherRepo, *mockCourseRepo) {
+		teachers, courseRepo := new(mockTeacherRepo), new(mockCourseRepo)
+		teachers.On("GetTeacherCourses", ctx, "201").Return(courses, nil).Once()
+		courseRepo.On("GetCourseStudents", ctx, "11").Return([]models.Student{}, nil).Once()
+		courseRepo.On("GetCourseStudents", ctx, "12").Return(enrolled, nil).Once()
+		return teachers, courseRepo
+	}
+
+	t.Run("Not Teacher's Student", func(t *testing.T) {
+		repo, teachers, courseRepo := new(mockOfficeHourRepo), new(mockTeacherRepo), new(mockCourseRepo)
+		svc := newTestOfficeHourService(repo, teachers, courseRepo)
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+		teachers.On("GetTeacherCourses", ctx, "201").Return(courses, nil).Once()
+		courseRepo.On("GetCourseStudents", ctx, mock.Anything).Return([]models.Student{}, nil).Twice()
+
+		// Act
+		_, err := svc.BookSlot(ctx, "8", "101", models.BookSlotRequest{StartsAt: time.Date(2025, time.October, 6, 10, 0, 0, 0, time.UTC)})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotTeacherStudent)
+		repo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Off The Slot Grid", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		teachers, courseRepo := eligibleStudent()
+		svc := newTestOfficeHourService(repo, teachers, courseRepo)
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+
+		// Act
+		_, err := svc.BookSlot(ctx, "8", "101", models.BookSlotRequest{StartsAt: time.Date(2025, time.October, 6, 10, 10, 0, 0, time.UTC)})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidSlot)
+		repo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Past Slot", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		teachers, courseRepo := eligibleStudent()
+		svc := newTestOfficeHourService(repo, teachers, courseRepo)
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+
+		// Act
+		_, err := svc.BookSlot(ctx, "8", "101", models.BookSlotRequest{StartsAt: time.Date(2025, time.September, 29, 10, 0, 0, 0, time.UTC)})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidSlot)
+		repo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		teachers, courseRepo := eligibleStudent()
+		svc := newTestOfficeHourService(repo, teachers, courseRepo)
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+		// Время с часовым поясом сохраняется по показаниям часов, как и расписание
+		startsAt := time.Date(2025, time.October, 6, 10, 20, 0, 0, time.FixedZone("MSK", 3*60*60))
+		repo.On("CreateBooking", ctx, mock.MatchedBy(func(b *models.OfficeHourBooking) bool {
+			return b.OfficeHourID == "8" && b.StudentID == "101" && b.Topic == "Project" &&
+				b.StartsAt.Equal(time.Date(2025, time.October, 6, 10, 20, 0, 0, time.UTC)) &&
+				b.EndsAt.Equal(time.Date(2025, time.October, 6, 10, 40, 0, 0, time.UTC))
+		})).Return(&models.OfficeHourBooking{ID: "50", Status: models.BookingStatusBooked}, nil).Once()
+
+		// Act
+		booking, err := svc.BookSlot(ctx, "8", "101", models.BookSlotRequest{StartsAt: startsAt, Topic: "  Project "})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "50", booking.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Slot Taken Concurrently", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		teachers, courseRepo := eligibleStudent()
+		svc := newTestOfficeHourService(repo, teachers, courseRepo)
+		repo.On("GetOfficeHourByID", ctx, "8").Return(&hour, nil).Once()
+		repo.On("CreateBooking", ctx, mock.Anything).Return(nil, models.ErrSlotTaken).Once()
+
+		// Act
+		_, err := svc.BookSlot(ctx, "8", "101", models.BookSlotRequest{StartsAt: time.Date(2025, time.October, 6, 10, 0, 0, 0, time.UTC)})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSlotTaken)
+	})
+}
+
+func TestOfficeHourService_CancelBooking(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	booking := models.OfficeHourBooking{ID: "50", OfficeHourID: "8", TeacherID: "201", StudentID: "101",
+		StartsAt: time.Date(2025, time.October, 6, 10, 0, 0, 0, time.UTC), Status: models.BookingStatusBooked}
+
+	t.Run("Other Student", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		repo.On("GetBookingByID", ctx, "50").Return(&booking, nil).Once()
+
+		// Act
+		err := svc.CancelBooking(ctx, "50", "102", "student")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrBookingForbidden)
+		repo.AssertNotCalled(t, "CancelBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Already Started", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		started := booking
+		started.StartsAt = time.Date(2025, time.October, 1, 11, 40, 0, 0, time.UTC)
+		repo.On("GetBookingByID", ctx, "50").Return(&started, nil).Once()
+
+		// Act
+		err := svc.CancelBooking(ctx, "50", "101", "student")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrBookingNotCancellable)
+		repo.AssertNotCalled(t, "CancelBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Teacher Cancels", func(t *testing.T) {
+		repo := new(mockOfficeHourRepo)
+		svc := newTestOfficeHourService(repo, new(mockTeacherRepo), new(mockCourseRepo))
+		repo.On("GetBookingByID", ctx, "50").Return(&booking, nil).Once()
+		repo.On("CancelBooking", ctx, "50", "201", svc.now()).Return(nil).Once()
+
+		// Act
+		err := svc.CancelBooking(ctx, "50", "201", "teacher")
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
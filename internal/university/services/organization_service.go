@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type OrganizationService interface {
+	GetFaculties(ctx context.Context) ([]models.Faculty, error)
+	GetFaculty(ctx context.Context, id string) (*models.Faculty, error)
+	CreateFaculty(ctx context.Context, faculty *models.Faculty) (*models.Faculty, error)
+	UpdateFaculty(ctx context.Context, faculty models.Faculty) (*models.Faculty, error)
+	DeleteFaculty(ctx context.Context, id string) error
+	MergeFaculties(ctx context.Context, targetID, sourceID string) (*models.Faculty, error)
+	GetFacultyDepartments(ctx context.Context, facultyID string) ([]models.Department, error)
+	GetDepartments(ctx context.Context) ([]models.Department, error)
+	GetDepartment(ctx context.Context, id string) (*models.Department, error)
+	CreateDepartment(ctx context.Context, department *models.Department) (*models.Department, error)
+	UpdateDepartment(ctx context.Context, department models.Department) (*models.Department, error)
+	DeleteDepartment(ctx context.Context, id string) error
+	MergeDepartments(ctx context.Context, targetID, sourceID string) (*models.Department, error)
+}
+
+type organizationService struct {
+	repo repository.OrganizationRepository
+}
+
+func NewOrganizationService(repo repository.OrganizationRepository) OrganizationService {
+	return &organizationService{repo: repo}
+}
+
+func (s *organizationService) GetFaculties(ctx context.Context) ([]models.Faculty, error) {
+	return s.repo.GetFaculties(ctx)
+}
+
+func (s *organizationService) GetFaculty(ctx context.Context, id string) (*models.Faculty, error) {
+	return s.repo.GetFacultyByID(ctx, id)
+}
+
+func (s *organizationService) CreateFaculty(ctx context.Context, faculty *models.Faculty) (*models.Faculty, error) {
+	faculty.Code, faculty.Name = strings.TrimSpace(faculty.Code), strings.TrimSpace(faculty.Name)
+	faculty.Aliases = normalizeAliases(faculty.Code, faculty.Name, faculty.Aliases)
+	return s.repo.CreateFaculty(ctx, faculty)
+}
+
+func (s *organizationService) UpdateFaculty(ctx context.Context, faculty models.Faculty) (*models.Faculty, error) {
+	faculty.Code, faculty.Name = strings.TrimSpace(faculty.Code), strings.TrimSpace(faculty.Name)
+	faculty.Aliases = normalizeAliases(faculty.Code, faculty.Name, faculty.Aliases)
+	return s.repo.UpdateFaculty(ctx, faculty)
+}
+
+func (s *organizationService) DeleteFaculty(ctx context.Context, id string) error {
+	return s.repo.DeleteFaculty(ctx, id)
+}
+
+// MergeFaculties объединяет дубликат sourceID с факультетом targetID: студенты и кафедры переходят
+// к targetID, а код, название и псевдонимы дубликата становятся псевдонимами targetID, поэтому
+// прежнее написание по-прежнему находит факультет
+func (s *organizationService) MergeFaculties(ctx context.Context, targetID, sourceID string) (*models.Faculty, error) {
+	if targetID == sourceID {
+		return nil, models.ErrMergeIntoItself
+	}
+	target, err := s.repo.GetFacultyByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.repo.GetFacultyByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	aliases := append(append([]string{}, target.Aliases...), source.Code, source.Name)
+	aliases = normalizeAliases(target.Code, target.Name, append(aliases, source.Aliases...))
+	if err := s.repo.MergeFaculties(ctx, targetID, sourceID, aliases); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFacultyByID(ctx, targetID)
+}
+
+func (s *organizationService) GetFacultyDepartments(ctx context.Context, facultyID string) ([]models.Department, error) {
+	if _, err := s.repo.GetFacultyByID(ctx, facultyID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDepartments(ctx, facultyID)
+}
+
+func (s *organizationService) GetDepartments(ctx context.Context) ([]models.Department, error) {
+	return s.repo.GetDepartments(ctx, "")
+}
+
+func (s *organizationService) GetDepartment(ctx context.Context, id string) (*models.Department, error) {
+	return s.repo.GetDepartmentByID(ctx, id)
+}
+
+func (s *organizationService) CreateDepartment(ctx context.Context, department *models.Department) (*models.Department, error) {
+	if err := s.prepareDepartment(ctx, department); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateDepartment(ctx, department)
+}
+
+func (s *organizationService) UpdateDepartment(ctx context.Context, department models.Department) (*models.Department, error) {
+	if err := s.prepareDepartment(ctx, &department); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateDepartment(ctx, department)
+}
+
+func (s *organizationService) DeleteDepartment(ctx context.Context, id string) error {
+	return s.repo.DeleteDepartment(ctx, id)
+}
+
+// MergeDepartments объединяет дубликат sourceID с кафедрой targetID: преподаватели, менеджеры
+// и курсы переходят к targetID, названия дубликата становятся псевдонимами targetID
+func (s *organizationService) MergeDepartments(ctx context.Context, targetID, sourceID string) (*models.Department, error) {
+	if targetID == sourceID {
+		return nil, models.ErrMergeIntoItself
+	}
+	target, err := s.repo.GetDepartmentByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.repo.GetDepartmentByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	aliases := append(append([]string{}, target.Aliases...), source.Code, source.Name)
+	aliases = normalizeAliases(target.Code, target.Name, append(aliases, source.Aliases...))
+	if err := s.repo.MergeDepartments(ctx, targetID, sourceID, aliases); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDepartmentByID(ctx, targetID)
+}
+
+// prepareDepartment проверяет факультет кафедры; пустой faculty_id означает кафедру вне факультета
+func (s *organizationService) prepareDepartment(ctx context.Context, department *models.Department) error {
+	department.Code, department.Name = strings.TrimSpace(department.Code), strings.TrimSpace(department.Name)
+	department.Aliases = normalizeAliases(department.Code, department.Name, department.Aliases)
+	if department.FacultyID != nil && *department.FacultyID == "" {
+		department.FacultyID = nil
+	}
+	if department.FacultyID != nil {
+		if _, err := s.repo.GetFacultyByID(ctx, *department.FacultyID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeAliases убирает пустые и повторяющиеся (без учёта регистра) псевдонимы, а также
+// совпадающие с кодом или названием записи
+func normalizeAliases(code, name string, aliases []string) []string {
+	seen := map[string]bool{strings.ToLower(code): true, strings.ToLower(name): true}
+	normalized := []string{}
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		normalized = append(normalized, alias)
+	}
+	return normalized
+}
+
+// resolveFaculty находит факультет по ID, а без него — по коду, названию или псевдониму.
+// Если не указано ни то, ни другое, возвращает nil: факультет не задан.
+func resolveFaculty(ctx context.Context, org repository.OrganizationRepository, id *string, name string) (*models.Faculty, error) {
+	switch {
+	case id != nil && *id != "":
+		return org.GetFacultyByID(ctx, *id)
+	case strings.TrimSpace(name) != "":
+		return org.FindFaculty(ctx, name)
+	}
+	return nil, nil
+}
+
+// resolveDepartment находит кафедру по ID, а без него — по коду, названию или псевдониму
+func resolveDepartment(ctx context.Context, org repository.OrganizationRepository, id *string, name string) (*models.Department, error) {
+	switch {
+	case id != nil && *id != "":
+		return org.GetDepartmentByID(ctx, *id)
+	case strings.TrimSpace(name) != "":
+		return org.FindDepartment(ctx, name)
+	}
+	return nil, nil
+}
+
+// managerDepartment возвращает кафедру менеджера managerID. Пустой managerID означает, что
+// действие выполняет не менеджер: ограничений по кафедре нет, возвращается nil.
+func managerDepartment(ctx context.Context, org repository.OrganizationRepository, managerID string) (*models.Department, error) {
+	if managerID == "" {
+		return nil, nil
+	}
+	return org.GetManagerDepartment(ctx, managerID)
+}
+
+// inDepartment — запись с кафедрой departmentID доступна менеджеру кафедры scope (nil — без ограничений)
+func inDepartment(scope *models.Department, departmentID *string) bool {
+	return scope == nil || departmentID != nil && *departmentID == scope.ID
+}
+
+// inFaculty — студент факультета facultyID доступен менеджеру кафедры scope:
+// менеджер ведёт студентов факультета своей кафедры
+func inFaculty(scope *models.Department, facultyID *string) bool {
+	return scope == nil || scope.FacultyID != nil && facultyID != nil && *facultyID == *scope.FacultyID
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockOrganizationRepo struct {
+	mock.Mock
+}
+
+func (m *mockOrganizationRepo) GetFaculties(ctx context.Context) ([]models.Faculty, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Faculty), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) GetFacultyByID(ctx context.Context, id string) (*models.Faculty, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Faculty), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) FindFaculty(ctx context.Context, name string) (*models.Faculty, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Faculty), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) CreateFaculty(ctx context.Context, faculty *models.Faculty) (*models.Faculty, error) {
+	args := m.Called(ctx, faculty)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Faculty), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) UpdateFaculty(ctx context.Context, faculty models.Faculty) (*models.Faculty, error) {
+	args := m.Called(ctx, faculty)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Faculty), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) DeleteFaculty(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockOrganizationRepo) MergeFaculties(ctx context.Context, targetID, sourceID string, aliases []string) error {
+	return m.Called(ctx, targetID, sourceID, aliases).Error(0)
+}
+
+func (m *mockOrganizationRepo) GetDepartments(ctx context.Context, facultyID string) ([]models.Department, error) {
+	args := m.Called(ctx, facultyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Department), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) GetDepartmentByID(ctx context.Context, id string) (*models.Department, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) FindDepartment(ctx context.Context, name string) (*models.Department, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) CreateDepartment(ctx context.Context, department *models.Department) (*models.Department, error) {
+	args := m.Called(ctx, department)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) UpdateDepartment(ctx context.Context, department models.Department) (*models.Department, error) {
+	args := m.Called(ctx, department)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func (m *mockOrganizationRepo) DeleteDepartment(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockOrganizationRepo) MergeDepartments(ctx context.Context, targetID, sourceID string, aliases []string) error {
+	return m.Called(ctx, targetID, sourceID, aliases).Error(0)
+}
+
+func (m *mockOrganizationRepo) GetManagerDepartment(ctx context.Context, managerID string) (*models.Department, error) {
+	args := m.Called(ctx, managerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// itDepartment — кафедра "Software Engineering" факультета "7"
+func itDepartment() *models.Department {
+	return &models.Department{ID: "5", FacultyID: strPtr("7"), Code: "SE", Name: "Software Engineering"}
+}
+
+func TestOrganizationService_CreateFaculty(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Trims Names And Drops Duplicate Aliases", func(t *testing.T) {
+		// Arrange
+		repo := new(mockOrganizationRepo)
+		svc := NewOrganizationService(repo)
+		faculty := &models.Faculty{Code: " FIT ", Name: "Information Technology", Aliases: []string{"IT", "fit", " ", "it"}}
+		repo.On("CreateFaculty", ctx, mock.MatchedBy(func(f *models.Faculty) bool {
+			return f.Code == "FIT" && assert.ObjectsAreEqual([]string{"IT"}, []string(f.Aliases))
+		})).Return(&models.Faculty{ID: "7"}, nil).Once()
+
+		// Act
+		created, err := svc.CreateFaculty(ctx, faculty)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "7", created.ID)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestOrganizationService_MergeFaculties(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Source Names Become Aliases", func(t *testing.T) {
+		// Arrange
+		repo := new(mockOrganizationRepo)
+		svc := NewOrganizationService(repo)
+		target := &models.Faculty{ID: "7", Code: "FIT", Name: "Information Technology", Aliases: []string{"IT"}}
+		source := &models.Faculty{ID: "9", Code: "INFTECH", Name: "information technology", Aliases: []string{"IT", "Faculty of IT"}}
+		repo.On("GetFacultyByID", ctx, "7").Return(target, nil).Once()
+		repo.On("GetFacultyByID", ctx, "9").Return(source, nil).Once()
+		repo.On("MergeFaculties", ctx, "7", "9", []string{"IT", "INFTECH", "Faculty of IT"}).Return(nil).Once()
+		repo.On("GetFacultyByID", ctx, "7").Return(target, nil).Once()
+
+		// Act
+		merged, err := svc.MergeFaculties(ctx, "7", "9")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "7", merged.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Into Itself", func(t *testing.T) {
+		// Arrange
+		repo := new(mockOrganizationRepo)
+		svc := NewOrganizationService(repo)
+
+		// Act
+		_, err := svc.MergeFaculties(ctx, "7", "7")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrMergeIntoItself)
+		repo.AssertNotCalled(t, "MergeFaculties", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown Source", func(t *testing.T) {
+		// Arrange
+		repo := new(mockOrganizationRepo)
+		svc := NewOrganizationService(repo)
+		repo.On("GetFacultyByID", ctx, "7").Return(&models.Faculty{ID: "7"}, nil).Once()
+		repo.On("GetFacultyByID", ctx, "9").Return(nil, models.ErrFacultyNotFound).Once()
+
+		// Act
+		_, err := svc.MergeFaculties(ctx, "7", "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrFacultyNotFound)
+		repo.AssertNotCalled(t, "MergeFaculties", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrganizationService_CreateDepartment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unknown Faculty", func(t *testing.T) {
+		// Arrange
+		repo := new(mockOrganizationRepo)
+		svc := NewOrganizationService(repo)
+		repo.On("GetFacultyByID", ctx, "42").Return(nil, models.ErrFacultyNotFound).Once()
+
+		// Act
+		_, err := svc.CreateDepartment(ctx, &models.Department{FacultyID: strPtr("42"), Code: "SE", Name: "Software Engineering"})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrFacultyNotFound)
+		repo.AssertNotCalled(t, "CreateDepartment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Empty Faculty Means None", func(t *testing.T) {
+		// Arrange
+		repo := new(mockOrganizationRepo)
+		svc := NewOrganizationService(repo)
+		repo.On("CreateDepartment", ctx, mock.MatchedBy(func(d *models.Department) bool {
+			return d.FacultyID == nil
+		})).Return(&models.Department{ID: "5"}, nil).Once()
+
+		// Act
+		_, err := svc.CreateDepartment(ctx, &models.Department{FacultyID: strPtr(""), Code: "SE", Name: "Software Engineering"})
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestOrganizationService_MergeDepartments(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Source Names Become Aliases", func(t *testing.T) {
+		// Arrange
+		repo := new(mockOrganizationRepo)
+		svc := NewOrganizationService(repo)
+		target := itDepartment()
+		source := &models.Department{ID: "6", Code: "SWE", Name: "Software Eng."}
+		repo.On("GetDepartmentByID", ctx, "5").Return(target, nil).Twice()
+		repo.On("GetDepartmentByID", ctx, "6").Return(source, nil).Once()
+		repo.On("MergeDepartments", ctx, "5", "6", []string{"SWE", "Software Eng."}).Return(nil).Once()
+
+		// Act
+		merged, err := svc.MergeDepartments(ctx, "5", "6")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "5", merged.ID)
+		repo.AssertExpectations(t)
+	})
+}
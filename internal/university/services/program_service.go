@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type ProgramService interface {
+	GetPrograms(ctx context.Context) ([]models.Program, error)
+	GetProgram(ctx context.Context, id string) (*models.Program, error)
+	CreateProgram(ctx context.Context, program *models.Program) (*models.Program, error)
+	UpdateProgram(ctx context.Context, program models.Program) (*models.Program, error)
+	DeleteProgram(ctx context.Context, id string) error
+	GetProgramCurricula(ctx context.Context, programID string) ([]models.Curriculum, error)
+	GetCurriculum(ctx context.Context, id string) (*models.Curriculum, error)
+	CreateCurriculum(ctx context.Context, programID string, curriculum *models.Curriculum) (*models.Curriculum, error)
+	UpdateCurriculum(ctx context.Context, curriculum models.Curriculum) (*models.Curriculum, error)
+	DeleteCurriculum(ctx context.Context, id string) error
+	AssignStudent(ctx context.Context, assignment models.StudentProgram) (*models.StudentProgram, error)
+	GetDegreeAudit(ctx context.Context, studentID string) (*models.DegreeAudit, error)
+}
+
+type programService struct {
+	repo     repository.ProgramRepository
+	students repository.StudentRepository
+	grades   GradeService
+}
+
+func NewProgramService(repo repository.ProgramRepository, students repository.StudentRepository, grades GradeService) ProgramService {
+	return &programService{repo: repo, students: students, grades: grades}
+}
+
+func (s *programService) GetPrograms(ctx context.Context) ([]models.Program, error) {
+	return s.repo.GetPrograms(ctx)
+}
+
+func (s *programService) GetProgram(ctx context.Context, id string) (*models.Program, error) {
+	return s.repo.GetProgramByID(ctx, id)
+}
+
+func (s *programService) CreateProgram(ctx context.Context, program *models.Program) (*models.Program, error) {
+	return s.repo.CreateProgram(ctx, program)
+}
+
+func (s *programService) UpdateProgram(ctx context.Context, program models.Program) (*models.Program, error) {
+	return s.repo.UpdateProgram(ctx, program)
+}
+
+func (s *programService) DeleteProgram(ctx context.Context, id string) error {
+	return s.repo.DeleteProgram(ctx, id)
+}
+
+func (s *programService) GetProgramCurricula(ctx context.Context, programID string) ([]models.Curriculum, error) {
+	if _, err := s.repo.GetProgramByID(ctx, programID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetProgramCurricula(ctx, programID)
+}
+
+func (s *programService) GetCurriculum(ctx context.Context, id string) (*models.Curriculum, error) {
+	return s.repo.GetCurriculumByID(ctx, id)
+}
+
+func (s *programService) CreateCurriculum(ctx context.Context, programID string, curriculum *models.Curriculum) (*models.Curriculum, error) {
+	if _, err := s.repo.GetProgramByID(ctx, programID); err != nil {
+		return nil, err
+	}
+	curriculum.ProgramID = programID
+	if err := validateCurriculum(curriculum); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateCurriculum(ctx, curriculum)
+}
+
+// UpdateCurriculum заменяет состав учебного плана; программа плана не меняется
+func (s *programService) UpdateCurriculum(ctx context.Context, curriculum models.Curriculum) (*models.Curriculum, error) {
+	current, err := s.repo.GetCurriculumByID(ctx, curriculum.ID)
+	if err != nil {
+		return nil, err
+	}
+	curriculum.ProgramID = current.ProgramID
+	if err := validateCurriculum(&curriculum); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateCurriculum(ctx, curriculum)
+}
+
+func (s *programService) DeleteCurriculum(ctx context.Context, id string) error {
+	return s.repo.DeleteCurriculum(ctx, id)
+}
+
+// AssignStudent назначает студенту программу и учебный план. Без curriculum_id выбирается
+// план самого нового набора программы.
+func (s *programService) AssignStudent(ctx context.Context, assignment models.StudentProgram) (*models.StudentProgram, error) {
+	if _, err := s.students.GetStudentById(ctx, assignment.StudentID); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetProgramByID(ctx, assignment.ProgramID); err != nil {
+		return nil, err
+	}
+	if assignment.CurriculumID == "" {
+		curricula, err := s.repo.GetProgramCurricula(ctx, assignment.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		if len(curricula) == 0 {
+			return nil, fmt.Errorf("%w: program has no curricula", models.ErrCurriculumNotFound)
+		}
+		assignment.CurriculumID = curricula[0].ID
+	} else {
+		curriculum, err := s.repo.GetCurriculumByID(ctx, assignment.CurriculumID)
+		if err != nil {
+			return nil, err
+		}
+		if curriculum.ProgramID != assignment.ProgramID {
+			return nil, fmt.Errorf("%w: curriculum belongs to another program", models.ErrInvalidCurriculum)
+		}
+	}
+	if err := s.repo.AssignStudent(ctx, assignment); err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// GetDegreeAudit сравнивает транскрипт студента с учебным планом его программы. Каждый сданный курс
+// засчитывается в одно требование: сначала в обязательные курсы, затем в первую подходящую группу
+// по выбору. В общий объём кредитов идут все сданные курсы, включая курсы вне плана; повторно
+// сданный курс учитывается один раз.
+func (s *programService) GetDegreeAudit(ctx context.Context, studentID string) (*models.DegreeAudit, error) {
+	student, err := s.students.GetStudentById(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student.ProgramID == nil || student.CurriculumID == nil {
+		return nil, models.ErrStudentProgramNotAssigned
+	}
+	program, err := s.repo.GetProgramByID(ctx, *student.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	curriculum, err := s.repo.GetCurriculumByID(ctx, *student.CurriculumID)
+	if err != nil {
+		return nil, err
+	}
+	transcript, err := s.grades.GetTranscript(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := bestCourseResults(transcript)
+	audit := &models.DegreeAudit{
+		StudentID:       studentID,
+		ProgramID:       program.ID,
+		ProgramName:     program.Name,
+		CurriculumID:    curriculum.ID,
+		CurriculumName:  curriculum.Name,
+		TotalCredits:    curriculum.TotalCredits,
+		CumulativeGPA:   transcript.CumulativeGPA,
+		RequiredCourses: []models.AuditCourse{},
+		MissingRequired: []models.AuditCourse{},
+		ElectiveGroups:  []models.AuditElectiveGroup{},
+		Complete:        true,
+	}
+	for _, result := range results {
+		switch result.Status {
+		case models.AuditCourseCompleted:
+			audit.EarnedCredits += result.Credits
+		case models.AuditCourseInProgress:
+			audit.InProgressCredits += result.Credits
+		}
+	}
+
+	counted := map[string]bool{}
+	for _, course := range curriculum.RequiredCourses {
+		entry := auditCourse(course, results)
+		if entry.Status == models.AuditCourseCompleted {
+			entry.Counted = true
+			counted[course.CourseID] = true
+		} else {
+			audit.MissingRequired = append(audit.MissingRequired, entry)
+			audit.Complete = false
+		}
+		audit.RequiredCourses = append(audit.RequiredCourses, entry)
+	}
+
+	for _, group := range curriculum.ElectiveGroups {
+		audited := models.AuditElectiveGroup{ID: group.ID, Name: group.Name, MinCredits: group.MinCredits, Courses: []models.AuditCourse{}}
+		for _, course := range group.Courses {
+			entry := auditCourse(course, results)
+			if !counted[course.CourseID] {
+				switch entry.Status {
+				case models.AuditCourseCompleted:
+					entry.Counted = true
+					counted[course.CourseID] = true
+					audited.EarnedCredits += course.Credits
+				case models.AuditCourseInProgress:
+					audited.InProgressCredits += course.Credits
+				}
+			}
+			audited.Courses = append(audited.Courses, entry)
+		}
+		audited.RemainingCredits = max(0, group.MinCredits-audited.EarnedCredits)
+		audited.Satisfied = audited.RemainingCredits == 0
+		if !audited.Satisfied {
+			audit.Complete = false
+		}
+		audit.ElectiveGroups = append(audit.ElectiveGroups, audited)
+	}
+
+	audit.RemainingCredits = max(0, curriculum.TotalCredits-audit.EarnedCredits)
+	if audit.RemainingCredits > 0 {
+		audit.Complete = false
+	}
+	return audit, nil
+}
+
+// validateCurriculum убирает повторы курсов и проверяет группы по выбору: обязательный курс
+// не может входить в группу, а минимум кредитов группы не превышает общий объём плана
+func validateCurriculum(curriculum *models.Curriculum) error {
+	curriculum.RequiredCourseIDs = uniqueIDs(curriculum.RequiredCourseIDs)
+	required := map[string]bool{}
+	for _, id := range curriculum.RequiredCourseIDs {
+		required[id] = true
+	}
+	for i := range curriculum.ElectiveGroups {
+		group := &curriculum.ElectiveGroups[i]
+		group.CourseIDs = uniqueIDs(group.CourseIDs)
+		if group.Name == "" || group.MinCredits <= 0 || len(group.CourseIDs) == 0 {
+			return fmt.Errorf("%w: elective group needs a name, min_credits and at least one course", models.ErrInvalidCurriculum)
+		}
+		if group.MinCredits > curriculum.TotalCredits {
+			return fmt.Errorf("%w: elective group %q requires more credits than the curriculum", models.ErrInvalidCurriculum, group.Name)
+		}
+		for _, id := range group.CourseIDs {
+			if required[id] {
+				return fmt.Errorf("%w: course %s is both required and elective", models.ErrInvalidCurriculum, id)
+			}
+		}
+	}
+	return nil
+}
+
+// bestCourseResults возвращает лучший результат студента по каждому курсу транскрипта:
+// сдан > изучается > не сдан; отозванные курсы не учитываются. Из нескольких сдач берётся последняя.
+func bestCourseResults(transcript *models.Transcript) map[string]models.AuditCourse {
+	rank := map[string]int{models.AuditCourseFailed: 1, models.AuditCourseInProgress: 2, models.AuditCourseCompleted: 3}
+	results := map[string]models.AuditCourse{}
+	for _, term := range transcript.Terms {
+		for _, course := range term.Courses {
+			var status string
+			switch course.Status {
+			case models.EnrollmentStatusCompleted:
+				status = models.AuditCourseCompleted
+			case models.EnrollmentStatusEnrolled:
+				status = models.AuditCourseInProgress
+			case models.EnrollmentStatusFailed:
+				status = models.AuditCourseFailed
+			default:
+				continue
+			}
+			id := strconv.FormatUint(uint64(course.CourseID), 10)
+			if current, ok := results[id]; ok && rank[current.Status] > rank[status] {
+				continue
+			}
+			results[id] = models.AuditCourse{
+				CurriculumCourse: models.CurriculumCourse{CourseID: id, Code: course.Code, Name: course.Name, Credits: course.Credits},
+				Status:           status,
+				TermID:           term.TermID,
+				Total:            course.Total,
+				Letter:           course.Letter,
+			}
+		}
+	}
+	return results
+}
+
+func auditCourse(course models.CurriculumCourse, results map[string]models.AuditCourse) models.AuditCourse {
+	result, ok := results[course.CourseID]
+	if !ok {
+		return models.AuditCourse{CurriculumCourse: course, Status: models.AuditCourseNotTaken}
+	}
+	result.CurriculumCourse = course
+	return result
+}
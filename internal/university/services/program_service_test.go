@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockProgramRepo struct {
+	mock.Mock
+}
+
+func (m *mockProgramRepo) GetPrograms(ctx context.Context) ([]models.Program, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Program), args.Error(1)
+}
+
+func (m *mockProgramRepo) GetProgramByID(ctx context.Context, id string) (*models.Program, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Program), args.Error(1)
+}
+
+func (m *mockProgramRepo) CreateProgram(ctx context.Context, program *models.Program) (*models.Program, error) {
+	args := m.Called(ctx, program)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Program), args.Error(1)
+}
+
+func (m *mockProgramRepo) UpdateProgram(ctx context.Context, program models.Program) (*models.Program, error) {
+	args := m.Called(ctx, program)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Program), args.Error(1)
+}
+
+func (m *mockProgramRepo) DeleteProgram(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockProgramRepo) GetProgramCurricula(ctx context.Context, programID string) ([]models.Curriculum, error) {
+	args := m.Called(ctx, programID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Curriculum), args.Error(1)
+}
+
+func (m *mockProgramRepo) GetCurriculumByID(ctx context.Context, id string) (*models.Curriculum, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Curriculum), args.Error(1)
+}
+
+func (m *mockProgramRepo) CreateCurriculum(ctx context.Context, curriculum *models.Curriculum) (*models.Curriculum, error) {
+	args := m.Called(ctx, curriculum)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Curriculum), args.Error(1)
+}
+
+func (m *mockProgramRepo) UpdateCurriculum(ctx context.Context, curriculum models.Curriculum) (*models.Curriculum, error) {
+	args := m.Called(ctx, curriculum)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Curriculum), args.Error(1)
+}
+
+func (m *mockProgramRepo) DeleteCurriculum(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockProgramRepo) AssignStudent(ctx context.Context, assignment models.StudentProgram) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func TestProgramService_CreateCurriculum(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	program := &models.Program{ID: "2", Code: "CS-BSC"}
+
+	t.Run("Success Removes Duplicates", func(t *testing.T) {
+		repo := new(mockProgramRepo)
+		svc := NewProgramService(repo, new(mockStudentRepo), new(mockGradeService))
+		curriculum := &models.Curriculum{Name: "2025", CatalogYear: 2025, TotalCredits: 240,
+			RequiredCourseIDs: []string{"1", "2", "1"},
+			ElectiveGroups:    []models.ElectiveGroup{{Name: "Humanities", MinCredits: 6, CourseIDs: []string{"5", "6"}}}}
+		repo.On("GetProgramByID", ctx, "2").Return(program, nil).Once()
+		repo.On("CreateCurriculum", ctx, curriculum).Return(curriculum, nil).Once()
+
+		// Act
+		created, err := svc.CreateCurriculum(ctx, "2", curriculum)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "2", created.ProgramID)
+		assert.Equal(t, []string{"1", "2"}, []string(created.RequiredCourseIDs))
+	})
+
+	t.Run("Course Both Required And Elective", func(t *testing.T) {
+		repo := new(mockProgramRepo)
+		svc := NewProgramService(repo, new(mockStudentRepo), new(mockGradeService))
+		repo.On("GetProgramByID", ctx, "2").Return(program, nil).Once()
+
+		// Act
+		_, err := svc.CreateCurriculum(ctx, "2", &models.Curriculum{Name: "2025", TotalCredits: 240,
+			RequiredCourseIDs: []string{"1"},
+			ElectiveGroups:    []models.ElectiveGroup{{Name: "Any", MinCredits: 6, CourseIDs: []string{"1", "6"}}}})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidCurriculum)
+		repo.AssertNotCalled(t, "CreateCurriculum", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProgramService_AssignStudent(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Defaults To Latest Curriculum", func(t *testing.T) {
+		repo, students := new(mockProgramRepo), new(mockStudentRepo)
+		svc := NewProgramService(repo, students, new(mockGradeService))
+		students.On("GetStudentById", ctx, "101").Return(&models.Student{}, nil).Once()
+		repo.On("GetProgramByID", ctx, "2").Return(&models.Program{ID: "2"}, nil).Once()
+		repo.On("GetProgramCurricula", ctx, "2").Return([]models.Curriculum{{ID: "9", CatalogYear: 2025}, {ID: "8", CatalogYear: 2024}}, nil).Once()
+		repo.On("AssignStudent", ctx, models.StudentProgram{StudentID: "101", ProgramID: "2", CurriculumID: "9"}).Return(nil).Once()
+
+		// Act
+		assigned, err := svc.AssignStudent(ctx, models.StudentProgram{StudentID: "101", ProgramID: "2"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "9", assigned.CurriculumID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Curriculum Of Another Program", func(t *testing.T) {
+		repo, students := new(mockProgramRepo), new(mockStudentRepo)
+		svc := NewProgramService(repo, students, new(mockGradeService))
+		students.On("GetStudentById", ctx, "101").Return(&models.Student{}, nil).Once()
+		repo.On("GetProgramByID", ctx, "2").Return(&models.Program{ID: "2"}, nil).Once()
+		repo.On("GetCurriculumByID", ctx, "7").Return(&models.Curriculum{ID: "7", ProgramID: "3"}, nil).Once()
+
+		// Act
+		_, err := svc.AssignStudent(ctx, models.StudentProgram{StudentID: "101", ProgramID: "2", CurriculumID: "7"})
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidCurriculum)
+		repo.AssertNotCalled(t, "AssignStudent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProgramService_GetDegreeAudit(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	programID, curriculumID := "2", "9"
+	course := func(id, code string, credits int) models.CurriculumCourse {
+		return models.CurriculumCourse{CourseID: id, Code: code, Credits: credits}
+	}
+	curriculum := &models.Curriculum{ID: "9", ProgramID: "2", Name: "2025", TotalCredits: 20,
+		RequiredCourses: []models.CurriculumCourse{course("1", "CS101", 5), course("2", "CS102", 5)},
+		ElectiveGroups: []models.ElectiveGroup{
+			{ID: "11", Name: "Math", MinCredits: 6, Courses: []models.CurriculumCourse{course("1", "CS101", 5), course("3", "MATH201", 3), course("4", "MATH202", 3)}},
+			{ID: "12", Name: "Humanities", MinCredits: 3, Courses: []models.CurriculumCourse{course("3", "MATH201", 3), course("5", "HIS101", 3)}},
+		}}
+	total := 85.0
+	transcript := &models.Transcript{CumulativeGPA: 3.2, Terms: []models.TranscriptTerm{
+		{TermID: "1", Courses: []models.TranscriptCourse{
+			{CourseID: 1, Code: "CS101", Credits: 5, Status: models.EnrollmentStatusCompleted, Total: &total, Letter: "B+"},
+			{CourseID: 2, Code: "CS102", Credits: 5, Status: models.EnrollmentStatusFailed, Letter: "F"},
+			{CourseID: 3, Code: "MATH201", Credits: 3, Status: models.EnrollmentStatusCompleted, Letter: "A"},
+			{CourseID: 7, Code: "ART100", Credits: 2, Status: models.EnrollmentStatusCompleted, Letter: "A"},
+		}},
+		{TermID: "2", Courses: []models.TranscriptCourse{
+			{CourseID: 2, Code: "CS102", Credits: 5, Status: models.EnrollmentStatusEnrolled},
+			{CourseID: 4, Code: "MATH202", Credits: 3, Status: models.EnrollmentStatusWithdrawn, Letter: "W"},
+		}},
+	}}
+
+	t.Run("Reports Remaining Requirements", func(t *testing.T) {
+		repo, students, grades := new(mockProgramRepo), new(mockStudentRepo), new(mockGradeService)
+		svc := NewProgramService(repo, students, grades)
+		students.On("GetStudentById", ctx, "101").Return(&models.Student{ProgramID: &programID, CurriculumID: &curriculumID}, nil).Once()
+		repo.On("GetProgramByID", ctx, "2").Return(&models.Program{ID: "2", Name: "Computer Science"}, nil).Once()
+		repo.On("GetCurriculumByID", ctx, "9").Return(curriculum, nil).Once()
+		grades.On("GetTranscript", ctx, "101").Return(transcript, nil).Once()
+
+		// Act
+		audit, err := svc.GetDegreeAudit(ctx, "101")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, audit.Complete)
+		// CS101 5 + MATH201 3 + ART100 2 (вне плана), CS102 ещё изучается
+		assert.Equal(t, 10, audit.EarnedCredits)
+		assert.Equal(t, 5, audit.InProgressCredits)
+		assert.Equal(t, 10, audit.RemainingCredits)
+		assert.Equal(t, 3.2, audit.CumulativeGPA)
+
+		assert.Equal(t, models.AuditCourseCompleted, audit.RequiredCourses[0].Status)
+		assert.Equal(t, "B+", audit.RequiredCourses[0].Letter)
+		assert.Len(t, audit.MissingRequired, 1)
+		assert.Equal(t, "CS102", audit.MissingRequired[0].Code)
+		assert.Equal(t, models.AuditCourseInProgress, audit.MissingRequired[0].Status)
+
+		// CS101 уже засчитан как обязательный, MATH202 отозван
+		math := audit.ElectiveGroups[0]
+		assert.Equal(t, 3, math.EarnedCredits)
+		assert.Equal(t, 3, math.RemainingCredits)
+		assert.False(t, math.Satisfied)
+		assert.False(t, math.Courses[0].Counted)
+		assert.True(t, math.Courses[1].Counted)
+		assert.Equal(t, models.AuditCourseNotTaken, math.Courses[2].Status)
+
+		// MATH201 засчитан в первую группу и во второй не учитывается
+		humanities := audit.ElectiveGroups[1]
+		assert.Equal(t, 0, humanities.EarnedCredits)
+		assert.Equal(t, 3, humanities.RemainingCredits)
+		assert.Equal(t, models.AuditCourseCompleted, humanities.Courses[0].Status)
+		assert.False(t, humanities.Courses[0].Counted)
+	})
+
+	t.Run("No Program", func(t *testing.T) {
+		repo, students, grades := new(mockProgramRepo), new(mockStudentRepo), new(mockGradeService)
+		svc := NewProgramService(repo, students, grades)
+		students.On("GetStudentById", ctx, "101").Return(&models.Student{}, nil).Once()
+
+		// Act
+		_, err := svc.GetDegreeAudit(ctx, "101")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrStudentProgramNotAssigned)
+		grades.AssertNotCalled(t, "GetTranscript", mock.Anything, mock.Anything)
+	})
+}
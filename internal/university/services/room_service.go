@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type RoomService interface {
+	GetRooms(ctx context.Context, filter models.RoomFilter) ([]models.Room, error)
+	GetRoomByID(ctx context.Context, id string) (*models.Room, error)
+	CreateRoom(ctx context.Context, room *models.Room) (*models.Room, error)
+	UpdateRoom(ctx context.Context, room models.Room) (*models.Room, error)
+	DeleteRoom(ctx context.Context, id string) error
+}
+
+type roomService struct {
+	repo repository.RoomRepository
+}
+
+func NewRoomService(repo repository.RoomRepository) RoomService {
+	return &roomService{repo: repo}
+}
+
+func (s *roomService) GetRooms(ctx context.Context, filter models.RoomFilter) ([]models.Room, error) {
+	filter.Equipment = normalizeEquipment(filter.Equipment)
+	return s.repo.GetRooms(ctx, filter)
+}
+
+func (s *roomService) GetRoomByID(ctx context.Context, id string) (*models.Room, error) {
+	return s.repo.GetRoomByID(ctx, id)
+}
+
+func (s *roomService) CreateRoom(ctx context.Context, room *models.Room) (*models.Room, error) {
+	room.Equipment = normalizeEquipment(room.Equipment)
+	return s.repo.CreateRoom(ctx, room)
+}
+
+func (s *roomService) UpdateRoom(ctx context.Context, room models.Room) (*models.Room, error) {
+	room.Equipment = normalizeEquipment(room.Equipment)
+	return s.repo.UpdateRoom(ctx, room)
+}
+
+// DeleteRoom удаляет аудиторию; аудиторию, занятую в расписании, удалить нельзя
+func (s *roomService) DeleteRoom(ctx context.Context, id string) error {
+	if _, err := s.repo.GetRoomByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteRoom(ctx, id)
+}
+
+// normalizeEquipment приводит названия оборудования к нижнему регистру, убирает пустые и повторы,
+// чтобы поиск аудиторий по оборудованию не зависел от написания
+func normalizeEquipment(equipment []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, item := range equipment {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		normalized = append(normalized, item)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
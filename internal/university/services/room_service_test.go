@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockRoomRepo struct {
+	mock.Mock
+}
+
+func (m *mockRoomRepo) GetRooms(ctx context.Context, filter models.RoomFilter) ([]models.Room, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Room), args.Error(1)
+}
+
+func (m *mockRoomRepo) GetRoomByID(ctx context.Context, id string) (*models.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Room), args.Error(1)
+}
+
+func (m *mockRoomRepo) CreateRoom(ctx context.Context, room *models.Room) (*models.Room, error) {
+	args := m.Called(ctx, room)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Room), args.Error(1)
+}
+
+func (m *mockRoomRepo) UpdateRoom(ctx context.Context, room models.Room) (*models.Room, error) {
+	args := m.Called(ctx, room)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Room), args.Error(1)
+}
+
+func (m *mockRoomRepo) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestRoomService_CreateRoom(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(mockRoomRepo)
+	svc := NewRoomService(repo)
+	room := &models.Room{Building: "Main", Name: "A-101", Capacity: 40, Equipment: []string{" Projector", "whiteboard", "projector", ""}}
+	repo.On("CreateRoom", ctx, mock.MatchedBy(func(r *models.Room) bool {
+		return assert.ObjectsAreEqual([]string{"projector", "whiteboard"}, []string(r.Equipment))
+	})).Return(room, nil).Once()
+
+	// Act
+	_, err := svc.CreateRoom(ctx, room)
+
+	// Assert
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestRoomService_DeleteRoom(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Room In Use", func(t *testing.T) {
+		repo := new(mockRoomRepo)
+		svc := NewRoomService(repo)
+		repo.On("GetRoomByID", ctx, "3").Return(&models.Room{ID: "3"}, nil).Once()
+		repo.On("DeleteRoom", ctx, "3").Return(models.ErrRoomInUse).Once()
+
+		// Act
+		err := svc.DeleteRoom(ctx, "3")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrRoomInUse)
+	})
+
+	t.Run("Room Not Found", func(t *testing.T) {
+		repo := new(mockRoomRepo)
+		svc := NewRoomService(repo)
+		repo.On("GetRoomByID", ctx, "9").Return(nil, models.ErrRoomNotFound).Once()
+
+		// Act
+		err := svc.DeleteRoom(ctx, "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrRoomNotFound)
+		repo.AssertNotCalled(t, "DeleteRoom", mock.Anything, mock.Anything)
+	})
+}
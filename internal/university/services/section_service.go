@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type SectionService interface {
+	GetOfferingSections(ctx context.Context, termID, offeringID string) ([]models.CourseSection, error)
+	GetSectionByID(ctx context.Context, id string) (*models.CourseSection, error)
+	CreateSection(ctx context.Context, termID string, section *models.CourseSection) (*models.CourseSection, error)
+	UpdateSection(ctx context.Context, section models.CourseSection, changedBy string) (*models.CourseSection, error)
+	DeleteSection(ctx context.Context, id string) error
+	GetSectionWaitlist(ctx context.Context, sectionID string) ([]models.WaitlistEntry, error)
+}
+
+type sectionService struct {
+	repo  repository.SectionRepository
+	terms repository.TermRepository
+}
+
+func NewSectionService(repo repository.SectionRepository, terms repository.TermRepository) SectionService {
+	return &sectionService{repo: repo, terms: terms}
+}
+
+func (s *sectionService) GetOfferingSections(ctx context.Context, termID, offeringID string) ([]models.CourseSection, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, offeringID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOfferingSections(ctx, offeringID)
+}
+
+func (s *sectionService) GetSectionByID(ctx context.Context, id string) (*models.CourseSection, error) {
+	return s.repo.GetSectionByID(ctx, id)
+}
+
+// CreateSection добавляет секцию к курсу периода termID; в закрытом периоде секции не создаются
+func (s *sectionService) CreateSection(ctx context.Context, termID string, section *models.CourseSection) (*models.CourseSection, error) {
+	if _, err := s.terms.GetOfferingByID(ctx, termID, section.OfferingID); err != nil {
+		return nil, err
+	}
+	term, err := s.terms.GetTermByID(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if term.Status == models.TermStatusClosed {
+		return nil, fmt.Errorf("%w: term is closed", models.ErrInvalidTerm)
+	}
+	return s.repo.CreateSection(ctx, section)
+}
+
+// UpdateSection обновляет секцию; лимит мест нельзя сделать меньше числа записанных.
+// changedBy — пользователь, который меняет секцию (ему приписываются переводы из листа ожидания).
+func (s *sectionService) UpdateSection(ctx context.Context, section models.CourseSection, changedBy string) (*models.CourseSection, error) {
+	current, err := s.repo.GetSectionByID(ctx, section.ID)
+	if err != nil {
+		return nil, err
+	}
+	if section.Capacity < current.Enrolled {
+		return nil, models.ErrCapacityTooSmall
+	}
+	return s.repo.UpdateSection(ctx, section, changedBy)
+}
+
+func (s *sectionService) DeleteSection(ctx context.Context, id string) error {
+	return s.repo.DeleteSection(ctx, id)
+}
+
+func (s *sectionService) GetSectionWaitlist(ctx context.Context, sectionID string) ([]models.WaitlistEntry, error) {
+	if _, err := s.repo.GetSectionByID(ctx, sectionID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSectionWaitlist(ctx, sectionID)
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockSectionRepo struct {
+	mock.Mock
+}
+
+func (m *mockSectionRepo) GetOfferingSections(ctx context.Context, offeringID string) ([]models.CourseSection, error) {
+	args := m.Called(ctx, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseSection), args.Error(1)
+}
+
+func (m *mockSectionRepo) GetSectionByID(ctx context.Context, id string) (*models.CourseSection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseSection), args.Error(1)
+}
+
+func (m *mockSectionRepo) CreateSection(ctx context.Context, section *models.CourseSection) (*models.CourseSection, error) {
+	args := m.Called(ctx, section)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseSection), args.Error(1)
+}
+
+func (m *mockSectionRepo) UpdateSection(ctx context.Context, section models.CourseSection, changedBy string) (*models.CourseSection, error) {
+	args := m.Called(ctx, section, changedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseSection), args.Error(1)
+}
+
+func (m *mockSectionRepo) DeleteSection(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockSectionRepo) EnrollInSection(ctx context.Context, studentID, sectionID, changedBy string) (*models.EnrollmentResult, error) {
+	args := m.Called(ctx, studentID, sectionID, changedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EnrollmentResult), args.Error(1)
+}
+
+func (m *mockSectionRepo) DropEnrollment(ctx context.Context, studentID, offeringID, changedBy string) error {
+	args := m.Called(ctx, studentID, offeringID, changedBy)
+	return args.Error(0)
+}
+
+func (m *mockSectionRepo) GetSectionWaitlist(ctx context.Context, sectionID string) ([]models.WaitlistEntry, error) {
+	args := m.Called(ctx, sectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WaitlistEntry), args.Error(1)
+}
+
+func (m *mockSectionRepo) GetStudentWaitlist(ctx context.Context, studentID string) ([]models.WaitlistEntry, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WaitlistEntry), args.Error(1)
+}
+
+func (m *mockSectionRepo) LeaveWaitlist(ctx context.Context, studentID, sectionID string) error {
+	args := m.Called(ctx, studentID, sectionID)
+	return args.Error(0)
+}
+
+func TestSectionService_CreateSection(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockSectionRepo)
+	mockTerms := new(mockTermRepo)
+	svc := NewSectionService(mockRepo, mockTerms)
+	ctx := context.Background()
+	offering := &models.CourseOffering{ID: "7", CourseID: "101", TermID: "1"}
+
+	t.Run("Success", func(t *testing.T) {
+		term := fallTerm()
+		section := &models.CourseSection{OfferingID: "7", Name: "A", Capacity: 30}
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		mockTerms.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+		mockRepo.On("CreateSection", ctx, section).Return(&models.CourseSection{ID: "3", OfferingID: "7", Name: "A", Capacity: 30}, nil).Once()
+
+		// Act
+		created, err := svc.CreateSection(ctx, "1", section)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "3", created.ID)
+		mockRepo.AssertExpectations(t)
+		mockTerms.AssertExpectations(t)
+	})
+
+	t.Run("Offering From Another Term", func(t *testing.T) {
+		section := &models.CourseSection{OfferingID: "7", Name: "A", Capacity: 30}
+		mockTerms.On("GetOfferingByID", ctx, "2", "7").Return(nil, models.ErrOfferingNotFound).Once()
+
+		// Act
+		_, err := svc.CreateSection(ctx, "2", section)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOfferingNotFound)
+		mockTerms.AssertExpectations(t)
+	})
+
+	t.Run("Closed Term", func(t *testing.T) {
+		term := fallTerm()
+		term.Status = models.TermStatusClosed
+		section := &models.CourseSection{OfferingID: "7", Name: "B", Capacity: 30}
+		mockTerms.On("GetOfferingByID", ctx, "1", "7").Return(offering, nil).Once()
+		mockTerms.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+
+		// Act
+		_, err := svc.CreateSection(ctx, "1", section)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidTerm)
+		mockRepo.AssertNotCalled(t, "CreateSection", ctx, section)
+	})
+}
+
+func TestSectionService_UpdateSection(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockSectionRepo)
+	svc := NewSectionService(mockRepo, new(mockTermRepo))
+	ctx := context.Background()
+	current := &models.CourseSection{ID: "3", OfferingID: "7", Name: "A", Capacity: 2, Enrolled: 2, Waitlisted: 1}
+
+	t.Run("Capacity Increased", func(t *testing.T) {
+		section := models.CourseSection{ID: "3", Name: "A", Capacity: 3}
+		updated := &models.CourseSection{ID: "3", OfferingID: "7", Name: "A", Capacity: 3, Enrolled: 3}
+		mockRepo.On("GetSectionByID", ctx, "3").Return(current, nil).Once()
+		mockRepo.On("UpdateSection", ctx, section, "9").Return(updated, nil).Once()
+
+		// Act
+		result, err := svc.UpdateSection(ctx, section, "9")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Enrolled)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Capacity Below Enrolled", func(t *testing.T) {
+		section := models.CourseSection{ID: "3", Name: "A", Capacity: 1}
+		mockRepo.On("GetSectionByID", ctx, "3").Return(current, nil).Once()
+
+		// Act
+		_, err := svc.UpdateSection(ctx, section, "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrCapacityTooSmall)
+		mockRepo.AssertNotCalled(t, "UpdateSection", ctx, section, "9")
+	})
+}
+
+func TestSectionService_GetSectionWaitlist(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockSectionRepo)
+	svc := NewSectionService(mockRepo, new(mockTermRepo))
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		entries := []models.WaitlistEntry{
+			{SectionID: "3", StudentID: "11", Position: 1},
+			{SectionID: "3", StudentID: "12", Position: 2},
+		}
+		mockRepo.On("GetSectionByID", ctx, "3").Return(&models.CourseSection{ID: "3"}, nil).Once()
+		mockRepo.On("GetSectionWaitlist", ctx, "3").Return(entries, nil).Once()
+
+		// Act
+		result, err := svc.GetSectionWaitlist(ctx, "3")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entries, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Section Not Found", func(t *testing.T) {
+		mockRepo.On("GetSectionByID", ctx, "9").Return(nil, models.ErrSectionNotFound).Once()
+
+		// Act
+		_, err := svc.GetSectionWaitlist(ctx, "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSectionNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
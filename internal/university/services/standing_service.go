@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type StandingService interface {
+	GetRules(ctx context.Context) (*models.StandingRules, error)
+	UpdateRules(ctx context.Context, rules models.StandingRules) (*models.StandingRules, error)
+	ComputeTermStandings(ctx context.Context, termID string) ([]models.AcademicStanding, error)
+	GetStudentStandings(ctx context.Context, studentID string) ([]models.AcademicStanding, error)
+	GetTermReport(ctx context.Context, termID, status, managerID string) ([]models.AcademicStanding, error)
+}
+
+type standingService struct {
+	repo   repository.StandingRepository
+	terms  repository.TermRepository
+	org    repository.OrganizationRepository
+	grades GradeService
+}
+
+func NewStandingService(repo repository.StandingRepository, terms repository.TermRepository, org repository.OrganizationRepository,
+	grades GradeService) StandingService {
+	return &standingService{repo: repo, terms: terms, org: org, grades: grades}
+}
+
+func (s *standingService) GetRules(ctx context.Context) (*models.StandingRules, error) {
+	return s.repo.GetRules(ctx)
+}
+
+func (s *standingService) UpdateRules(ctx context.Context, rules models.StandingRules) (*models.StandingRules, error) {
+	return s.repo.UpdateRules(ctx, rules)
+}
+
+// ComputeTermStandings применяет правила положения к закрытому периоду и сохраняет результат,
+// заменяя прежний расчёт. GPA и кредиты берутся из транскрипта, поэтому учитываются схемы оценивания курсов.
+func (s *standingService) ComputeTermStandings(ctx context.Context, termID string) ([]models.AcademicStanding, error) {
+	term, err := s.terms.GetTermByID(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if term.Status != models.TermStatusClosed {
+		return nil, models.ErrTermNotClosed
+	}
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.repo.GetTermCandidates(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]models.AcademicStanding, 0, len(candidates))
+	for _, candidate := range candidates {
+		transcript, err := s.grades.GetTranscript(ctx, candidate.StudentID)
+		if err != nil {
+			return nil, err
+		}
+		standing, ok := termStanding(transcript, termID, rules)
+		if !ok {
+			continue
+		}
+		standing.StudentID, standing.FacultyID = candidate.StudentID, candidate.FacultyID
+		standing.Year, standing.Season = term.Year, term.Season
+		standings = append(standings, standing)
+	}
+	applyHonors(standings, rules)
+
+	if err := s.repo.SaveTermStandings(ctx, termID, standings); err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
+
+// termStanding считает положение за период termID без учёта списка отличников:
+// probation — GPA ниже порога rules.ProbationTerms периодов с оценками подряд, warning — меньше периодов подряд
+func termStanding(transcript *models.Transcript, termID string, rules *models.StandingRules) (models.AcademicStanding, bool) {
+	index := -1
+	for i, term := range transcript.Terms {
+		if term.TermID == termID {
+			index = i
+			break
+		}
+	}
+	if index < 0 || transcript.Terms[index].AttemptedCredits == 0 {
+		return models.AcademicStanding{}, false
+	}
+	term := transcript.Terms[index]
+	standing := models.AcademicStanding{
+		TermID:           termID,
+		Status:           models.StandingGood,
+		TermGPA:          term.TermGPA,
+		CumulativeGPA:    term.CumulativeGPA,
+		AttemptedCredits: term.AttemptedCredits,
+		EarnedCredits:    term.EarnedCredits,
+	}
+
+	// Периоды без оценок (например, все курсы отозваны) серию не прерывают
+	streak := 0
+	for i := index; i >= 0 && streak < rules.ProbationTerms; i-- {
+		previous := transcript.Terms[i]
+		if previous.AttemptedCredits == 0 {
+			continue
+		}
+		if previous.TermGPA >= rules.ProbationGPA {
+			break
+		}
+		streak++
+	}
+	switch {
+	case streak >= rules.ProbationTerms:
+		standing.Status = models.StandingProbation
+	case streak > 0:
+		standing.Status = models.StandingWarning
+	}
+	return standing, true
+}
+
+// applyHonors отбирает отличников внутри каждого факультета: из студентов, набравших за период
+// rules.HonorsMinCredits кредитов, верхние rules.HonorsPercent процентов по GPA за период (с округлением вверх).
+// Студенты с тем же GPA, что у последнего прошедшего, тоже попадают в список.
+func applyHonors(standings []models.AcademicStanding, rules *models.StandingRules) {
+	cohorts := map[string][]*models.AcademicStanding{}
+	for i := range standings {
+		standing := &standings[i]
+		if standing.AttemptedCredits < rules.HonorsMinCredits {
+			continue
+		}
+		faculty := ""
+		if standing.FacultyID != nil {
+			faculty = *standing.FacultyID
+		}
+		cohorts[faculty] = append(cohorts[faculty], standing)
+	}
+
+	for _, cohort := range cohorts {
+		slots := int(math.Ceil(float64(len(cohort)) * rules.HonorsPercent / 100))
+		if slots == 0 {
+			continue
+		}
+		sort.SliceStable(cohort, func(i, j int) bool { return cohort[i].TermGPA > cohort[j].TermGPA })
+		for rank, standing := range cohort {
+			if rank >= slots && standing.TermGPA < cohort[slots-1].TermGPA {
+				break
+			}
+			if standing.Status == models.StandingGood && standing.TermGPA >= rules.HonorsMinGPA {
+				standing.Status = models.StandingHonors
+			}
+		}
+	}
+}
+
+func (s *standingService) GetStudentStandings(ctx context.Context, studentID string) ([]models.AcademicStanding, error) {
+	return s.repo.GetStudentStandings(ctx, studentID)
+}
+
+// GetTermReport — отчёт по положению за период; менеджер (managerID не пуст) видит студентов факультета своей кафедры
+func (s *standingService) GetTermReport(ctx context.Context, termID, status, managerID string) ([]models.AcademicStanding, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return nil, err
+	}
+	facultyID := ""
+	if scope != nil {
+		if scope.FacultyID == nil {
+			return []models.AcademicStanding{}, nil
+		}
+		facultyID = *scope.FacultyID
+	}
+	return s.repo.GetTermStandings(ctx, termID, status, facultyID)
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockStandingRepo struct {
+	mock.Mock
+}
+
+func (m *mockStandingRepo) GetRules(ctx context.Context) (*models.StandingRules, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StandingRules), args.Error(1)
+}
+
+func (m *mockStandingRepo) UpdateRules(ctx context.Context, rules models.StandingRules) (*models.StandingRules, error) {
+	args := m.Called(ctx, rules)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StandingRules), args.Error(1)
+}
+
+func (m *mockStandingRepo) GetTermCandidates(ctx context.Context, termID string) ([]models.StandingCandidate, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StandingCandidate), args.Error(1)
+}
+
+func (m *mockStandingRepo) SaveTermStandings(ctx context.Context, termID string, standings []models.AcademicStanding) error {
+	return m.Called(ctx, termID, standings).Error(0)
+}
+
+func (m *mockStandingRepo) GetStudentStandings(ctx context.Context, studentID string) ([]models.AcademicStanding, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AcademicStanding), args.Error(1)
+}
+
+func (m *mockStandingRepo) GetTermStandings(ctx context.Context, termID, status, facultyID string) ([]models.AcademicStanding, error) {
+	args := m.Called(ctx, termID, status, facultyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AcademicStanding), args.Error(1)
+}
+
+// mockStandingService — StandingService, из которого сервис периодов использует только ComputeTermStandings
+type mockStandingService struct {
+	mock.Mock
+	StandingService
+}
+
+func (m *mockStandingService) ComputeTermStandings(ctx context.Context, termID string) ([]models.AcademicStanding, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AcademicStanding), args.Error(1)
+}
+
+func standingRules() *models.StandingRules {
+	return &models.StandingRules{ProbationGPA: 2.0, ProbationTerms: 2, HonorsPercent: 25, HonorsMinCredits: 15, HonorsMinGPA: 3.5}
+}
+
+// gradedTerm — период транскрипта с GPA и кредитами
+func gradedTerm(termID string, gpa float64, credits int) models.TranscriptTerm {
+	return models.TranscriptTerm{TermID: termID, TermGPA: gpa, CumulativeGPA: gpa, AttemptedCredits: credits, EarnedCredits: credits}
+}
+
+func TestStandingService_ComputeTermStandings(t *testing.T) {
+	ctx := context.Background()
+	closed := fallTerm()
+	closed.Status = models.TermStatusClosed
+
+	t.Run("Probation Warning And Honors", func(t *testing.T) {
+		// Arrange
+		repo, terms, grades := new(mockStandingRepo), new(mockTermRepo), new(mockGradeService)
+		svc := NewStandingService(repo, terms, new(mockOrganizationRepo), grades)
+		terms.On("GetTermByID", ctx, closed.ID).Return(&closed, nil).Once()
+		repo.On("GetRules", ctx).Return(standingRules(), nil).Once()
+		repo.On("GetTermCandidates", ctx, closed.ID).Return([]models.StandingCandidate{
+			{StudentID: "1", FacultyID: strPtr("7")}, {StudentID: "2", FacultyID: strPtr("7")},
+			{StudentID: "3", FacultyID: strPtr("7")}, {StudentID: "4", FacultyID: strPtr("7")},
+			{StudentID: "5", FacultyID: strPtr("7")}, {StudentID: "6", FacultyID: strPtr("8")},
+		}, nil).Once()
+		// 1 — второй период подряд ниже порога; период без оценок между ними серию не прерывает
+		grades.On("GetTranscript", ctx, "1").Return(&models.Transcript{Terms: []models.TranscriptTerm{
+			gradedTerm("0", 1.5, 15), {TermID: "9"}, gradedTerm(closed.ID, 1.8, 15),
+		}}, nil).Once()
+		// 2 — первый период ниже порога
+		grades.On("GetTranscript", ctx, "2").Return(&models.Transcript{Terms: []models.TranscriptTerm{
+			gradedTerm("0", 3.0, 15), gradedTerm(closed.ID, 1.9, 15),
+		}}, nil).Once()
+		// 3 и 4 делят первое место: оба попадают в 25% (одно место из четырёх) факультета 7
+		grades.On("GetTranscript", ctx, "3").Return(&models.Transcript{Terms: []models.TranscriptTerm{gradedTerm(closed.ID, 3.9, 15)}}, nil).Once()
+		grades.On("GetTranscript", ctx, "4").Return(&models.Transcript{Terms: []models.TranscriptTerm{gradedTerm(closed.ID, 3.9, 15)}}, nil).Once()
+		// 5 — лучший GPA, но меньше минимума кредитов, в когорту не входит
+		grades.On("GetTranscript", ctx, "5").Return(&models.Transcript{Terms: []models.TranscriptTerm{gradedTerm(closed.ID, 4.0, 10)}}, nil).Once()
+		// 6 — единственный на факультете 8, но ниже HonorsMinGPA
+		grades.On("GetTranscript", ctx, "6").Return(&models.Transcript{Terms: []models.TranscriptTerm{gradedTerm(closed.ID, 3.2, 15)}}, nil).Once()
+		repo.On("SaveTermStandings", ctx, closed.ID, mock.Anything).Return(nil).Once()
+
+		// Act
+		standings, err := svc.ComputeTermStandings(ctx, closed.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		statuses := map[string]string{}
+		for _, standing := range standings {
+			statuses[standing.StudentID] = standing.Status
+		}
+		assert.Equal(t, map[string]string{
+			"1": models.StandingProbation,
+			"2": models.StandingWarning,
+			"3": models.StandingHonors,
+			"4": models.StandingHonors,
+			"5": models.StandingGood,
+			"6": models.StandingGood,
+		}, statuses)
+		assert.Equal(t, 2025, standings[0].Year)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Term Not Closed", func(t *testing.T) {
+		// Arrange
+		repo, terms := new(mockStandingRepo), new(mockTermRepo)
+		svc := NewStandingService(repo, terms, new(mockOrganizationRepo), new(mockGradeService))
+		active := fallTerm()
+		terms.On("GetTermByID", ctx, active.ID).Return(&active, nil).Once()
+
+		// Act
+		_, err := svc.ComputeTermStandings(ctx, active.ID)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrTermNotClosed)
+		repo.AssertNotCalled(t, "SaveTermStandings", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestStandingService_GetTermReport(t *testing.T) {
+	ctx := context.Background()
+	term := fallTerm()
+
+	t.Run("Manager Sees Own Faculty", func(t *testing.T) {
+		// Arrange
+		repo, terms, org := new(mockStandingRepo), new(mockTermRepo), new(mockOrganizationRepo)
+		svc := NewStandingService(repo, terms, org, new(mockGradeService))
+		terms.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		repo.On("GetTermStandings", ctx, term.ID, models.StandingProbation, "7").
+			Return([]models.AcademicStanding{{StudentID: "1"}}, nil).Once()
+
+		// Act
+		report, err := svc.GetTermReport(ctx, term.ID, models.StandingProbation, "40")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, report, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Admin Sees All Faculties", func(t *testing.T) {
+		// Arrange
+		repo, terms, org := new(mockStandingRepo), new(mockTermRepo), new(mockOrganizationRepo)
+		svc := NewStandingService(repo, terms, org, new(mockGradeService))
+		terms.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		repo.On("GetTermStandings", ctx, term.ID, "", "").Return([]models.AcademicStanding{}, nil).Once()
+
+		// Act
+		_, err := svc.GetTermReport(ctx, term.ID, "", "")
+
+		// Assert
+		assert.NoError(t, err)
+		org.AssertNotCalled(t, "GetManagerDepartment", mock.Anything, mock.Anything)
+	})
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"errors"
+	"time"
 	"university_system/internal/domain/models"
 	"university_system/internal/domain/repository"
 )
@@ -9,20 +11,34 @@ import (
 type StudentService interface {
 	GetStudents(ctx context.Context) ([]models.Student, error)
 	GetStudentById(ctx context.Context, id string) (*models.Student, error)
-	CreateStudent(ctx context.Context, student *models.Student) (*models.Student, error)
-	UpdateStudent(ctx context.Context, student models.Student) (*models.Student, error)
-	DeleteStudent(ctx context.Context, id string) error
-	EnrollStudentToCourse(ctx context.Context, studentID, courseID string) error
+	CreateStudent(ctx context.Context, student *models.Student, managerID string) (*models.Student, error)
+	UpdateStudent(ctx context.Context, student models.Student, managerID string) (*models.Student, error)
+	DeleteStudent(ctx context.Context, id, managerID string) error
+	EnrollStudentToCourse(ctx context.Context, studentID, courseID, termID, sectionID, changedBy string) (*models.EnrollmentResult, error)
+	EnrollApproved(ctx context.Context, approval models.EnrollmentApproval, changedBy string) (*models.EnrollmentResult, error)
+	DropStudentFromCourse(ctx context.Context, studentID, courseID, termID, changedBy string) error
+	WithdrawStudentFromCourse(ctx context.Context, studentID, courseID, termID, changedBy string) error
+	GetStudentEnrollments(ctx context.Context, studentID string) ([]models.Enrollment, error)
+	GetEnrollmentHistory(ctx context.Context, studentID string) ([]models.EnrollmentEvent, error)
+	GetStudentWaitlist(ctx context.Context, studentID string) ([]models.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, studentID, sectionID string) error
 	GetStudentCourses(ctx context.Context, studentID string) ([]models.Course, error)
 	CreateUserWithRole(ctx context.Context, user models.User, role string) (string, error)
 }
 
 type studentService struct {
-	repo repository.StudentRepository
+	repo     repository.StudentRepository
+	terms    repository.TermRepository
+	sections repository.SectionRepository
+	courses  repository.CourseRepository
+	org      repository.OrganizationRepository
+	advising repository.AdvisingRepository
+	now      func() time.Time
 }
 
-func NewStudentService(repo repository.StudentRepository) StudentService {
-	return &studentService{repo: repo}
+func NewStudentService(repo repository.StudentRepository, terms repository.TermRepository, sections repository.SectionRepository,
+	courses repository.CourseRepository, org repository.OrganizationRepository, advising repository.AdvisingRepository) StudentService {
+	return &studentService{repo: repo, terms: terms, sections: sections, courses: courses, org: org, advising: advising, now: time.Now}
 }
 
 func (s *studentService) GetStudents(ctx context.Context) ([]models.Student, error) {
@@ -33,20 +49,316 @@ func (s *studentService) GetStudentById(ctx context.Context, id string) (*models
 	return s.repo.GetStudentById(ctx, id)
 }
 
-func (s *studentService) CreateStudent(ctx context.Context, student *models.Student) (*models.Student, error) {
+// CreateStudent создаёт профиль студента. Факультет сопоставляется со справочником по ID, коду,
+// названию или псевдониму; менеджер (managerID не пуст) создаёт студентов только факультета своей кафедры.
+func (s *studentService) CreateStudent(ctx context.Context, student *models.Student, managerID string) (*models.Student, error) {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignFaculty(ctx, student, scope); err != nil {
+		return nil, err
+	}
 	return s.repo.CreateStudent(ctx, student)
 }
 
-func (s *studentService) UpdateStudent(ctx context.Context, student models.Student) (*models.Student, error) {
+func (s *studentService) UpdateStudent(ctx context.Context, student models.Student, managerID string) (*models.Student, error) {
+	scope, err := s.scopedStudent(ctx, student.ID, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignFaculty(ctx, &student, scope); err != nil {
+		return nil, err
+	}
 	return s.repo.UpdateStudent(ctx, student)
 }
 
-func (s *studentService) DeleteStudent(ctx context.Context, id string) error {
+func (s *studentService) DeleteStudent(ctx context.Context, id, managerID string) error {
+	if _, err := s.scopedStudent(ctx, id, managerID); err != nil {
+		return err
+	}
 	return s.repo.DeleteStudent(ctx, id)
 }
 
-func (s *studentService) EnrollStudentToCourse(ctx context.Context, studentID, courseID string) error {
-	return s.repo.EnrollStudentToCourse(ctx, studentID, courseID)
+// scopedStudent проверяет, что менеджер managerID ведёт студента studentID, и возвращает кафедру менеджера
+func (s *studentService) scopedStudent(ctx context.Context, studentID, managerID string) (*models.Department, error) {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil || scope == nil {
+		return scope, err
+	}
+	current, err := s.repo.GetStudentById(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !inFaculty(scope, current.FacultyID) {
+		return nil, models.ErrOutsideDepartment
+	}
+	return scope, nil
+}
+
+// assignFaculty сопоставляет факультет студента со справочником и подставляет каноническое название.
+// Менеджеру без указанного факультета подставляется факультет его кафедры.
+func (s *studentService) assignFaculty(ctx context.Context, student *models.Student, scope *models.Department) error {
+	facultyID := student.FacultyID
+	if scope != nil && (facultyID == nil || *facultyID == "") && student.Faculty == "" {
+		facultyID = scope.FacultyID
+	}
+	faculty, err := resolveFaculty(ctx, s.org, facultyID, student.Faculty)
+	if err != nil {
+		return err
+	}
+	student.FacultyID, student.Faculty = nil, ""
+	if faculty != nil {
+		student.FacultyID, student.Faculty = &faculty.ID, faculty.Name
+	}
+	if !inFaculty(scope, student.FacultyID) {
+		return models.ErrOutsideDepartment
+	}
+	return nil
+}
+
+// EnrollStudentToCourse записывает студента на курс в периоде termID.
+// Если период не указан, берётся период, в котором сейчас открыта запись.
+// Если у курса есть секции, студент попадает в секцию sectionID (её можно не указывать,
+// когда секция одна), а при нехватке мест — в лист ожидания.
+// Перед записью проверяются правила курса; при нарушении возвращается *models.EnrollmentRuleError.
+// Если программа студента требует согласования консультанта, вместо записи создаётся заявка
+// (статус pending_approval); запись завершает EnrollApproved после одобрения.
+// changedBy — пользователь, который записывает студента (попадает в журнал записей).
+func (s *studentService) EnrollStudentToCourse(ctx context.Context, studentID, courseID, termID, sectionID, changedBy string) (*models.EnrollmentResult, error) {
+	offering, sectionID, err := s.prepareEnrollment(ctx, studentID, courseID, termID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	required, err := s.advising.RequiresApproval(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		approval := &models.EnrollmentApproval{StudentID: studentID, CourseID: courseID, OfferingID: offering.ID}
+		if sectionID != "" {
+			approval.SectionID = &sectionID
+		}
+		created, err := s.advising.CreateApproval(ctx, approval)
+		if err != nil {
+			return nil, err
+		}
+		return &models.EnrollmentResult{Status: models.EnrollmentStatusPendingApproval, SectionID: sectionID, ApprovalID: created.ID}, nil
+	}
+	return s.enroll(ctx, studentID, courseID, offering.ID, sectionID, changedBy)
+}
+
+// EnrollApproved записывает студента по одобренной заявке. Период, правила курса и секция
+// проверяются заново: с подачи заявки они могли измениться. changedBy — одобривший заявку.
+func (s *studentService) EnrollApproved(ctx context.Context, approval models.EnrollmentApproval, changedBy string) (*models.EnrollmentResult, error) {
+	sectionID := ""
+	if approval.SectionID != nil {
+		sectionID = *approval.SectionID
+	}
+	offering, sectionID, err := s.prepareEnrollment(ctx, approval.StudentID, approval.CourseID, approval.TermID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.enroll(ctx, approval.StudentID, approval.CourseID, offering.ID, sectionID, changedBy)
+}
+
+// prepareEnrollment находит курс в периоде записи, проверяет правила курса и выбирает секцию;
+// пустая секция означает, что у курса в периоде секций нет
+func (s *studentService) prepareEnrollment(ctx context.Context, studentID, courseID, termID, sectionID string) (*models.CourseOffering, string, error) {
+	term, offering, err := s.enrollmentOffering(ctx, courseID, termID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.checkEnrollmentRules(ctx, studentID, courseID, term); err != nil {
+		return nil, "", err
+	}
+
+	sections, err := s.sections.GetOfferingSections(ctx, offering.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(sections) == 0 {
+		if sectionID != "" {
+			return nil, "", models.ErrSectionNotFound
+		}
+		return offering, "", nil
+	}
+
+	if sectionID == "" {
+		if len(sections) > 1 {
+			return nil, "", models.ErrSectionRequired
+		}
+		sectionID = sections[0].ID
+	}
+	for _, section := range sections {
+		if section.ID == sectionID {
+			return offering, sectionID, nil
+		}
+	}
+	return nil, "", models.ErrSectionNotFound
+}
+
+// enroll записывает студента на курс либо в секцию sectionID, где при нехватке мест он попадает в лист ожидания
+func (s *studentService) enroll(ctx context.Context, studentID, courseID, offeringID, sectionID, changedBy string) (*models.EnrollmentResult, error) {
+	if sectionID == "" {
+		if err := s.repo.EnrollStudentToCourse(ctx, studentID, courseID, offeringID, changedBy); err != nil {
+			return nil, err
+		}
+		return &models.EnrollmentResult{Status: models.EnrollmentStatusEnrolled}, nil
+	}
+	return s.sections.EnrollInSection(ctx, studentID, sectionID, changedBy)
+}
+
+// DropStudentFromCourse отмечает курс как брошенный (до срока add/drop); его место в секции
+// переходит к первому в листе ожидания. В транскрипт брошенный курс не попадает.
+func (s *studentService) DropStudentFromCourse(ctx context.Context, studentID, courseID, termID, changedBy string) error {
+	term, offering, err := s.currentOffering(ctx, courseID, termID)
+	if err != nil {
+		return err
+	}
+	if !term.CanDrop(s.now()) {
+		return models.ErrAddDropClosed
+	}
+	return s.sections.DropEnrollment(ctx, studentID, offering.ID, changedBy)
+}
+
+// WithdrawStudentFromCourse отзывает студента с курса после срока add/drop, но не позже
+// срока отзыва. Запись остаётся в истории со статусом withdrawn.
+func (s *studentService) WithdrawStudentFromCourse(ctx context.Context, studentID, courseID, termID, changedBy string) error {
+	term, offering, err := s.currentOffering(ctx, courseID, termID)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	if term.CanDrop(now) {
+		return models.ErrWithdrawalNotOpen
+	}
+	if !term.CanWithdraw(now) {
+		return models.ErrWithdrawalClosed
+	}
+	return s.repo.WithdrawEnrollment(ctx, studentID, offering.ID, changedBy)
+}
+
+func (s *studentService) GetStudentEnrollments(ctx context.Context, studentID string) ([]models.Enrollment, error) {
+	return s.repo.GetStudentEnrollments(ctx, studentID)
+}
+
+func (s *studentService) GetEnrollmentHistory(ctx context.Context, studentID string) ([]models.EnrollmentEvent, error) {
+	return s.repo.GetEnrollmentEvents(ctx, studentID)
+}
+
+func (s *studentService) GetStudentWaitlist(ctx context.Context, studentID string) ([]models.WaitlistEntry, error) {
+	return s.sections.GetStudentWaitlist(ctx, studentID)
+}
+
+func (s *studentService) LeaveWaitlist(ctx context.Context, studentID, sectionID string) error {
+	return s.sections.LeaveWaitlist(ctx, studentID, sectionID)
+}
+
+func (s *studentService) enrollmentOffering(ctx context.Context, courseID, termID string) (*models.AcademicTerm, *models.CourseOffering, error) {
+	term, err := s.enrollmentTerm(ctx, termID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offering, err := s.terms.GetOffering(ctx, term.ID, courseID)
+	if errors.Is(err, models.ErrOfferingNotFound) {
+		return nil, nil, models.ErrCourseNotOffered
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return term, offering, nil
+}
+
+// currentOffering находит курс в периоде termID, а без него — в идущем периоде,
+// с которого ещё можно отписаться или отозваться
+func (s *studentService) currentOffering(ctx context.Context, courseID, termID string) (*models.AcademicTerm, *models.CourseOffering, error) {
+	term, err := termOrCurrent(ctx, s.terms, termID, s.now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offering, err := s.terms.GetOffering(ctx, term.ID, courseID)
+	if errors.Is(err, models.ErrOfferingNotFound) {
+		return nil, nil, models.ErrCourseNotOffered
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return term, offering, nil
+}
+
+// termOrCurrent возвращает период termID, а без него — идущий период (см. AcademicTerm.IsCurrent)
+func termOrCurrent(ctx context.Context, terms repository.TermRepository, termID string, now time.Time) (*models.AcademicTerm, error) {
+	if termID != "" {
+		return terms.GetTermByID(ctx, termID)
+	}
+	all, err := terms.GetTerms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		if all[i].IsCurrent(now) {
+			return &all[i], nil
+		}
+	}
+	return nil, models.ErrNoActiveTerm
+}
+
+// checkEnrollmentRules собирает историю студента и проверяет правила записи на курс
+func (s *studentService) checkEnrollmentRules(ctx context.Context, studentID, courseID string, term *models.AcademicTerm) error {
+	student, err := s.repo.GetStudentById(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	course, err := s.courses.GetCourseByID(ctx, courseID)
+	if err != nil {
+		return err
+	}
+	current, err := s.repo.GetTermCourses(ctx, studentID, term.ID)
+	if err != nil {
+		return err
+	}
+	for _, c := range current {
+		if c.ID == course.ID {
+			return models.ErrAlreadyEnrolled
+		}
+	}
+	waitlisted, err := s.repo.GetTermWaitlistCourses(ctx, studentID, term.ID)
+	if err != nil {
+		return err
+	}
+	completed, err := s.repo.GetCompletedCourses(ctx, studentID, term.ID)
+	if err != nil {
+		return err
+	}
+	return checkEnrollmentRules(student, course, term, completed, current, waitlisted)
+}
+
+func (s *studentService) enrollmentTerm(ctx context.Context, termID string) (*models.AcademicTerm, error) {
+	now := s.now()
+	if termID != "" {
+		term, err := s.terms.GetTermByID(ctx, termID)
+		if err != nil {
+			return nil, err
+		}
+		if !term.IsEnrollmentOpen(now) {
+			return nil, models.ErrEnrollmentClosed
+		}
+		return term, nil
+	}
+
+	terms, err := s.terms.GetTerms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range terms {
+		if terms[i].IsEnrollmentOpen(now) {
+			return &terms[i], nil
+		}
+	}
+	return nil, models.ErrNoOpenEnrollment
 }
 
 func (s *studentService) GetStudentCourses(ctx context.Context, studentID string) ([]models.Course, error) {
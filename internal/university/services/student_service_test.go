@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -51,8 +52,8 @@ func (m *mockStudentRepo) DeleteStudent(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
-func (m *mockStudentRepo) EnrollStudentToCourse(ctx context.Context, studentID, courseID string) error {
-	args := m.Called(ctx, studentID, courseID)
+func (m *mockStudentRepo) EnrollStudentToCourse(ctx context.Context, studentID, courseID, offeringID, changedBy string) error {
+	args := m.Called(ctx, studentID, courseID, offeringID, changedBy)
 	return args.Error(0)
 }
 
@@ -64,6 +65,51 @@ func (m *mockStudentRepo) GetStudentCourses(ctx context.Context, studentID strin
 	return args.Get(0).([]models.Course), args.Error(1)
 }
 
+func (m *mockStudentRepo) GetTermCourses(ctx context.Context, studentID, termID string) ([]models.Course, error) {
+	args := m.Called(ctx, studentID, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Course), args.Error(1)
+}
+
+func (m *mockStudentRepo) GetTermWaitlistCourses(ctx context.Context, studentID, termID string) ([]models.Course, error) {
+	args := m.Called(ctx, studentID, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Course), args.Error(1)
+}
+
+func (m *mockStudentRepo) GetCompletedCourses(ctx context.Context, studentID, beforeTermID string) ([]models.CourseResult, error) {
+	args := m.Called(ctx, studentID, beforeTermID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseResult), args.Error(1)
+}
+
+func (m *mockStudentRepo) GetStudentEnrollments(ctx context.Context, studentID string) ([]models.Enrollment, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Enrollment), args.Error(1)
+}
+
+func (m *mockStudentRepo) GetEnrollmentEvents(ctx context.Context, studentID string) ([]models.EnrollmentEvent, error) {
+	args := m.Called(ctx, studentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EnrollmentEvent), args.Error(1)
+}
+
+func (m *mockStudentRepo) WithdrawEnrollment(ctx context.Context, studentID, offeringID, changedBy string) error {
+	args := m.Called(ctx, studentID, offeringID, changedBy)
+	return args.Error(0)
+}
+
 func (m *mockStudentRepo) CreateUserWithRole(ctx context.Context, user models.User, role string) (string, error) {
 	args := m.Called(ctx, user, role)
 	return args.String(0), args.Error(1)
@@ -72,7 +118,7 @@ func (m *mockStudentRepo) CreateUserWithRole(ctx context.Context, user models.Us
 func TestStudentService_GetStudents(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockStudentRepo)
-	svc := NewStudentService(mockRepo)
+	svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), new(mockOrganizationRepo), new(mockAdvisingRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -109,7 +155,7 @@ func TestStudentService_GetStudents(t *testing.T) {
 func TestStudentService_GetStudentById(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockStudentRepo)
-	svc := NewStudentService(mockRepo)
+	svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), new(mockOrganizationRepo), new(mockAdvisingRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -156,38 +202,88 @@ func TestStudentService_GetStudentById(t *testing.T) {
 }
 
 func TestStudentService_CreateStudent(t *testing.T) {
-	// Arrange
-	mockRepo := new(mockStudentRepo)
-	svc := NewStudentService(mockRepo)
 	ctx := context.Background()
+	cs := &models.Faculty{ID: "3", Code: "CS", Name: "Computer Science"}
 
 	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
 		newStudent := &models.Student{
-			StudentYear: 1, Faculty: "CS", CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
-		}
-		expectedStudent := &models.Student{
-			StudentYear: 1, Faculty: "CS", CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
+			StudentYear: 1, Faculty: "cs", CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
 		}
-		mockRepo.On("CreateStudent", ctx, newStudent).Return(expectedStudent, nil).Once()
+		org.On("FindFaculty", ctx, "cs").Return(cs, nil).Once()
+		mockRepo.On("CreateStudent", ctx, newStudent).Return(newStudent, nil).Once()
 
 		// Act
-		student, err := svc.CreateStudent(ctx, newStudent)
+		student, err := svc.CreateStudent(ctx, newStudent, "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Computer Science", student.Faculty)
+		assert.Equal(t, "3", *student.FacultyID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Faculty", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
+		org.On("FindFaculty", ctx, "Astrology").Return(nil, models.ErrFacultyNotFound).Once()
+
+		// Act
+		_, err := svc.CreateStudent(ctx, &models.Student{Faculty: "Astrology", User: models.User{ID: "1"}}, "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrFacultyNotFound)
+		mockRepo.AssertNotCalled(t, "CreateStudent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Manager Defaults To Own Faculty", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		org.On("GetFacultyByID", ctx, "7").Return(&models.Faculty{ID: "7", Name: "Information Technology"}, nil).Once()
+		mockRepo.On("CreateStudent", ctx, mock.MatchedBy(func(s *models.Student) bool {
+			return *s.FacultyID == "7" && s.Faculty == "Information Technology"
+		})).Return(&models.Student{}, nil).Once()
+
+		// Act
+		_, err := svc.CreateStudent(ctx, &models.Student{User: models.User{ID: "1"}}, "40")
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, expectedStudent, student)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Manager Outside Faculty", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		org.On("FindFaculty", ctx, "CS").Return(cs, nil).Once()
+
+		// Act
+		_, err := svc.CreateStudent(ctx, &models.Student{Faculty: "CS", User: models.User{ID: "1"}}, "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "CreateStudent", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Error", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
 		newStudent := &models.Student{
-			StudentYear: 1, Faculty: "CS", CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
+			StudentYear: 1, CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
 		}
 		expectedError := errors.New("database error")
 		mockRepo.On("CreateStudent", ctx, newStudent).Return(nil, expectedError).Once()
 
 		// Act
-		student, err := svc.CreateStudent(ctx, newStudent)
+		student, err := svc.CreateStudent(ctx, newStudent, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -198,35 +294,57 @@ func TestStudentService_CreateStudent(t *testing.T) {
 }
 
 func TestStudentService_UpdateStudent(t *testing.T) {
-	// Arrange
-	mockRepo := new(mockStudentRepo)
-	svc := NewStudentService(mockRepo)
 	ctx := context.Background()
+	cs := &models.Faculty{ID: "3", Code: "CS", Name: "CS"}
 
 	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
 		updatedStudent := models.Student{
 			StudentYear: 1, Faculty: "CS", CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
 		}
-		mockRepo.On("UpdateStudent", ctx, updatedStudent).Return(&updatedStudent, nil).Once()
+		expected := updatedStudent
+		expected.FacultyID = strPtr("3")
+		org.On("FindFaculty", ctx, "CS").Return(cs, nil).Once()
+		mockRepo.On("UpdateStudent", ctx, expected).Return(&expected, nil).Once()
 
 		// Act
-		student, err := svc.UpdateStudent(ctx, updatedStudent)
+		student, err := svc.UpdateStudent(ctx, updatedStudent, "")
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, &updatedStudent, student)
+		assert.Equal(t, &expected, student)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Manager Cannot Edit Student Of Another Faculty", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		mockRepo.On("GetStudentById", ctx, "1").Return(&models.Student{FacultyID: strPtr("3")}, nil).Once()
+
+		// Act
+		_, err := svc.UpdateStudent(ctx, models.Student{Faculty: "Information Technology", User: models.User{ID: "1"}}, "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "UpdateStudent", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Error", func(t *testing.T) {
+		// Arrange
+		mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+		svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
 		updatedStudent := models.Student{
-			StudentYear: 1, Faculty: "CS", CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
+			StudentYear: 1, CreatedAt: "2022-01-01", UpdatedAt: "2022-01-01", DeletedAt: nil, User: models.User{ID: "1"},
 		}
 		expectedError := errors.New("database error")
 		mockRepo.On("UpdateStudent", ctx, updatedStudent).Return(nil, expectedError).Once()
 
 		// Act
-		student, err := svc.UpdateStudent(ctx, updatedStudent)
+		student, err := svc.UpdateStudent(ctx, updatedStudent, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -238,8 +356,8 @@ func TestStudentService_UpdateStudent(t *testing.T) {
 
 func TestStudentService_DeleteStudent(t *testing.T) {
 	// Arrange
-	mockRepo := new(mockStudentRepo)
-	svc := NewStudentService(mockRepo)
+	mockRepo, org := new(mockStudentRepo), new(mockOrganizationRepo)
+	svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), org, new(mockAdvisingRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -247,20 +365,43 @@ func TestStudentService_DeleteStudent(t *testing.T) {
 		mockRepo.On("DeleteStudent", ctx, studentId).Return(nil).Once()
 
 		// Act
-		err := svc.DeleteStudent(ctx, studentId)
+		err := svc.DeleteStudent(ctx, studentId, "")
 
 		// Assert
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Manager Of The Student's Faculty", func(t *testing.T) {
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		mockRepo.On("GetStudentById", ctx, "1").Return(&models.Student{FacultyID: strPtr("7")}, nil).Once()
+		mockRepo.On("DeleteStudent", ctx, "1").Return(nil).Once()
+
+		// Act
+		err := svc.DeleteStudent(ctx, "1", "40")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Manager Without Department", func(t *testing.T) {
+		org.On("GetManagerDepartment", ctx, "41").Return(nil, models.ErrManagerWithoutDepartment).Once()
+
+		// Act
+		err := svc.DeleteStudent(ctx, "1", "41")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrManagerWithoutDepartment)
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		studentId := "1"
 		expectedError := errors.New("database error")
 		mockRepo.On("DeleteStudent", ctx, studentId).Return(expectedError).Once()
 
 		// Act
-		err := svc.DeleteStudent(ctx, studentId)
+		err := svc.DeleteStudent(ctx, studentId, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -272,42 +413,354 @@ func TestStudentService_DeleteStudent(t *testing.T) {
 func TestStudentService_EnrollStudentToCourse(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockStudentRepo)
-	svc := NewStudentService(mockRepo)
+	mockTerms := new(mockTermRepo)
+	mockSections := new(mockSectionRepo)
+	mockCourses := new(mockCourseRepo)
+	mockAdvising := new(mockAdvisingRepo)
+	svc := NewStudentService(mockRepo, mockTerms, mockSections, mockCourses, new(mockOrganizationRepo), mockAdvising)
+	svc.(*studentService).now = func() time.Time { return date(2025, time.September, 5) }
 	ctx := context.Background()
+	term := fallTerm()
+	offering := &models.CourseOffering{ID: "7", CourseID: "101", TermID: term.ID}
+	// eligible — студент без нарушений правил записи на курс 101
+	eligible := func(studentId string) {
+		mockRepo.On("GetStudentById", ctx, studentId).Return(&models.Student{StudentYear: 2, Faculty: "IT"}, nil).Once()
+		mockCourses.On("GetCourseByID", ctx, "101").Return(&models.Course{ID: "101", Code: "CS201", Credits: 5, Prerequisites: "CS101"}, nil).Once()
+		mockRepo.On("GetTermCourses", ctx, studentId, term.ID).Return([]models.Course{}, nil).Once()
+		mockRepo.On("GetTermWaitlistCourses", ctx, studentId, term.ID).Return([]models.Course{}, nil).Once()
+		mockRepo.On("GetCompletedCourses", ctx, studentId, term.ID).Return([]models.CourseResult{
+			{Mark: models.Mark{FirstAttestation: 25, SecondAttestation: 25, FinalMark: 30}, Code: "CS101", Total: 80},
+		}, nil).Once()
+	}
+	sectionA := models.CourseSection{ID: "3", OfferingID: offering.ID, Name: "A", Capacity: 2}
+	sectionB := models.CourseSection{ID: "4", OfferingID: offering.ID, Name: "B", Capacity: 2}
 
-	t.Run("Success", func(t *testing.T) {
+	t.Run("Success Without Sections", func(t *testing.T) {
 		studentId := "1"
 		courseId := "101"
-		mockRepo.On("EnrollStudentToCourse", ctx, studentId, courseId).Return(nil).Once()
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, courseId).Return(offering, nil).Once()
+		eligible(studentId)
+		mockSections.On("GetOfferingSections", ctx, offering.ID).Return([]models.CourseSection{}, nil).Once()
+		mockAdvising.On("RequiresApproval", ctx, "1").Return(false, nil).Once()
+		mockRepo.On("EnrollStudentToCourse", ctx, studentId, courseId, offering.ID, "9").Return(nil).Once()
 
 		// Act
-		err := svc.EnrollStudentToCourse(ctx, studentId, courseId)
+		result, err := svc.EnrollStudentToCourse(ctx, studentId, courseId, "", "", "9")
 
 		// Assert
 		assert.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusEnrolled, result.Status)
 		mockRepo.AssertExpectations(t)
+		mockTerms.AssertExpectations(t)
+		mockSections.AssertExpectations(t)
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		studentId := "1"
 		courseId := "101"
 		expectedError := errors.New("database error")
-		mockRepo.On("EnrollStudentToCourse", ctx, studentId, courseId).Return(expectedError).Once()
+		mockTerms.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, courseId).Return(offering, nil).Once()
+		eligible(studentId)
+		mockSections.On("GetOfferingSections", ctx, offering.ID).Return([]models.CourseSection{}, nil).Once()
+		mockAdvising.On("RequiresApproval", ctx, "1").Return(false, nil).Once()
+		mockRepo.On("EnrollStudentToCourse", ctx, studentId, courseId, offering.ID, "9").Return(expectedError).Once()
 
 		// Act
-		err := svc.EnrollStudentToCourse(ctx, studentId, courseId)
+		_, err := svc.EnrollStudentToCourse(ctx, studentId, courseId, term.ID, "", "9")
 
 		// Assert
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
+		mockTerms.AssertExpectations(t)
+	})
+
+	t.Run("Single Section Chosen Automatically", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		eligible("1")
+		mockSections.On("GetOfferingSections", ctx, offering.ID).Return([]models.CourseSection{sectionA}, nil).Once()
+		mockAdvising.On("RequiresApproval", ctx, "1").Return(false, nil).Once()
+		mockSections.On("EnrollInSection", ctx, "1", sectionA.ID, "9").
+			Return(&models.EnrollmentResult{Status: models.EnrollmentStatusEnrolled, SectionID: sectionA.ID}, nil).Once()
+
+		// Act
+		result, err := svc.EnrollStudentToCourse(ctx, "1", "101", "", "", "9")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, sectionA.ID, result.SectionID)
+		mockSections.AssertExpectations(t)
+	})
+
+	t.Run("Full Section Waitlists", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		eligible("1")
+		mockSections.On("GetOfferingSections", ctx, offering.ID).Return([]models.CourseSection{sectionA, sectionB}, nil).Once()
+		mockAdvising.On("RequiresApproval", ctx, "1").Return(false, nil).Once()
+		mockSections.On("EnrollInSection", ctx, "1", sectionB.ID, "9").
+			Return(&models.EnrollmentResult{Status: models.EnrollmentStatusWaitlisted, SectionID: sectionB.ID, WaitlistPosition: 2}, nil).Once()
+
+		// Act
+		result, err := svc.EnrollStudentToCourse(ctx, "1", "101", "", sectionB.ID, "9")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusWaitlisted, result.Status)
+		assert.Equal(t, 2, result.WaitlistPosition)
+		mockSections.AssertExpectations(t)
+	})
+
+	t.Run("Advisor Approval Required", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		eligible("1")
+		mockSections.On("GetOfferingSections", ctx, offering.ID).Return([]models.CourseSection{sectionA}, nil).Once()
+		mockAdvising.On("RequiresApproval", ctx, "1").Return(true, nil).Once()
+		mockAdvising.On("CreateApproval", ctx, mock.MatchedBy(func(a *models.EnrollmentApproval) bool {
+			return a.StudentID == "1" && a.CourseID == "101" && a.OfferingID == offering.ID && *a.SectionID == sectionA.ID
+		})).Return(&models.EnrollmentApproval{ID: "9", Status: models.ApprovalStatusPending}, nil).Once()
+
+		// Act
+		result, err := svc.EnrollStudentToCourse(ctx, "1", "101", "", "", "9")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusPendingApproval, result.Status)
+		assert.Equal(t, "9", result.ApprovalID)
+		mockAdvising.AssertExpectations(t)
+	})
+
+	t.Run("Section Required", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		eligible("1")
+		mockSections.On("GetOfferingSections", ctx, offering.ID).Return([]models.CourseSection{sectionA, sectionB}, nil).Once()
+
+		// Act
+		_, err := svc.EnrollStudentToCourse(ctx, "1", "101", "", "", "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSectionRequired)
+	})
+
+	t.Run("Section Of Another Course", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		eligible("1")
+		mockSections.On("GetOfferingSections", ctx, offering.ID).Return([]models.CourseSection{sectionA}, nil).Once()
+
+		// Act
+		_, err := svc.EnrollStudentToCourse(ctx, "1", "101", "", "99", "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSectionNotFound)
+		mockSections.AssertNotCalled(t, "EnrollInSection", ctx, "1", "99", "9")
+	})
+
+	t.Run("Prerequisite Not Met", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		mockRepo.On("GetStudentById", ctx, "5").Return(&models.Student{StudentYear: 1, Faculty: "IT"}, nil).Once()
+		mockCourses.On("GetCourseByID", ctx, "101").Return(&models.Course{ID: "101", Code: "CS201", Prerequisites: "CS101 >= 70"}, nil).Once()
+		mockRepo.On("GetTermCourses", ctx, "5", term.ID).Return([]models.Course{}, nil).Once()
+		mockRepo.On("GetTermWaitlistCourses", ctx, "5", term.ID).Return([]models.Course{}, nil).Once()
+		mockRepo.On("GetCompletedCourses", ctx, "5", term.ID).Return([]models.CourseResult{}, nil).Once()
+
+		// Act
+		_, err := svc.EnrollStudentToCourse(ctx, "5", "101", "", "", "9")
+
+		// Assert
+		var ruleErr *models.EnrollmentRuleError
+		assert.ErrorAs(t, err, &ruleErr)
+		assert.ErrorIs(t, err, models.ErrEnrollmentRulesFailed)
+		assert.Equal(t, models.RulePrerequisite, ruleErr.Violations[0].Rule)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Already Enrolled This Term", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		mockRepo.On("GetStudentById", ctx, "6").Return(&models.Student{StudentYear: 2}, nil).Once()
+		mockCourses.On("GetCourseByID", ctx, "101").Return(&models.Course{ID: "101", Code: "CS201"}, nil).Once()
+		mockRepo.On("GetTermCourses", ctx, "6", term.ID).Return([]models.Course{{ID: "101", Code: "CS201"}}, nil).Once()
+
+		// Act
+		_, err := svc.EnrollStudentToCourse(ctx, "6", "101", "", "", "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAlreadyEnrolled)
+	})
+
+	t.Run("Course Not Offered", func(t *testing.T) {
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "202").Return(nil, models.ErrOfferingNotFound).Once()
+
+		// Act
+		_, err := svc.EnrollStudentToCourse(ctx, "1", "202", "", "", "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrCourseNotOffered)
+		mockTerms.AssertExpectations(t)
+	})
+
+	t.Run("No Open Enrollment", func(t *testing.T) {
+		closed := fallTerm()
+		closed.EnrollmentEnd = date(2025, time.September, 1)
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{closed}, nil).Once()
+
+		// Act
+		_, err := svc.EnrollStudentToCourse(ctx, "1", "101", "", "", "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNoOpenEnrollment)
+		mockTerms.AssertExpectations(t)
+	})
+
+	t.Run("Requested Term Closed", func(t *testing.T) {
+		closed := fallTerm()
+		closed.Status = models.TermStatusClosed
+		mockTerms.On("GetTermByID", ctx, closed.ID).Return(&closed, nil).Once()
+
+		// Act
+		_, err := svc.EnrollStudentToCourse(ctx, "1", "101", closed.ID, "", "9")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrEnrollmentClosed)
+		mockTerms.AssertExpectations(t)
+	})
+}
+
+func TestStudentService_DropStudentFromCourse(t *testing.T) {
+	// Arrange
+	mockTerms := new(mockTermRepo)
+	mockSections := new(mockSectionRepo)
+	svc := NewStudentService(new(mockStudentRepo), mockTerms, mockSections, new(mockCourseRepo), new(mockOrganizationRepo), new(mockAdvisingRepo))
+	ctx := context.Background()
+	term := fallTerm()
+	offering := &models.CourseOffering{ID: "7", CourseID: "101", TermID: term.ID}
+
+	t.Run("Success On Add Drop Deadline", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2025, time.September, 21).Add(18 * time.Hour) }
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		mockSections.On("DropEnrollment", ctx, "1", offering.ID, "1").Return(nil).Once()
+
+		// Act
+		err := svc.DropStudentFromCourse(ctx, "1", "101", "", "1")
+
+		// Assert
+		assert.NoError(t, err)
+		mockSections.AssertExpectations(t)
+	})
+
+	t.Run("Not Enrolled", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2025, time.September, 5) }
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		mockSections.On("DropEnrollment", ctx, "2", offering.ID, "40").Return(models.ErrStudentNotEnrolled).Once()
+
+		// Act
+		err := svc.DropStudentFromCourse(ctx, "2", "101", "", "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrStudentNotEnrolled)
+		mockSections.AssertExpectations(t)
+	})
+
+	t.Run("After Add Drop Deadline", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2025, time.September, 22) }
+		mockTerms.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+
+		// Act
+		err := svc.DropStudentFromCourse(ctx, "1", "101", term.ID, "1")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAddDropClosed)
+		mockTerms.AssertExpectations(t)
+	})
+
+	t.Run("No Term In Progress", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2026, time.February, 1) }
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+
+		// Act
+		err := svc.DropStudentFromCourse(ctx, "1", "101", "", "1")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNoActiveTerm)
+	})
+}
+
+func TestStudentService_WithdrawStudentFromCourse(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockStudentRepo)
+	mockTerms := new(mockTermRepo)
+	svc := NewStudentService(mockRepo, mockTerms, new(mockSectionRepo), new(mockCourseRepo), new(mockOrganizationRepo), new(mockAdvisingRepo))
+	ctx := context.Background()
+	term := fallTerm()
+	offering := &models.CourseOffering{ID: "7", CourseID: "101", TermID: term.ID}
+
+	t.Run("Success", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2025, time.October, 10) }
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		mockRepo.On("WithdrawEnrollment", ctx, "1", offering.ID, "30").Return(nil).Once()
+
+		// Act
+		err := svc.WithdrawStudentFromCourse(ctx, "1", "101", "", "30")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("During Add Drop", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2025, time.September, 10) }
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+
+		// Act
+		err := svc.WithdrawStudentFromCourse(ctx, "1", "101", "", "1")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrWithdrawalNotOpen)
+	})
+
+	t.Run("After Withdrawal Deadline", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2025, time.November, 20) }
+		mockTerms.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+
+		// Act
+		err := svc.WithdrawStudentFromCourse(ctx, "1", "101", term.ID, "1")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrWithdrawalClosed)
+	})
+
+	t.Run("Already Withdrawn", func(t *testing.T) {
+		svc.(*studentService).now = func() time.Time { return date(2025, time.October, 10) }
+		mockTerms.On("GetTerms", ctx).Return([]models.AcademicTerm{term}, nil).Once()
+		mockTerms.On("GetOffering", ctx, term.ID, "101").Return(offering, nil).Once()
+		mockRepo.On("WithdrawEnrollment", ctx, "2", offering.ID, "2").Return(models.ErrNotActiveEnrollment).Once()
+
+		// Act
+		err := svc.WithdrawStudentFromCourse(ctx, "2", "101", "", "2")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNotActiveEnrollment)
 	})
 }
 
 func TestStudentService_GetStudentCourses(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockStudentRepo)
-	svc := NewStudentService(mockRepo)
+	svc := NewStudentService(mockRepo, new(mockTermRepo), new(mockSectionRepo), new(mockCourseRepo), new(mockOrganizationRepo), new(mockAdvisingRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
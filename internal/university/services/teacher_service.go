@@ -9,19 +9,20 @@ import (
 type TeacherService interface {
 	GetTeachers(ctx context.Context) ([]models.Teacher, error)
 	GetTeacherById(ctx context.Context, id string) (*models.Teacher, error)
-	UpdateTeacher(ctx context.Context, teacher models.Teacher) (*models.Teacher, error)
-	DeleteTeacher(ctx context.Context, id string) error
-	CreateTeacher(ctx context.Context, teacher *models.Teacher) (*models.Teacher, error)
+	UpdateTeacher(ctx context.Context, teacher models.Teacher, managerID string) (*models.Teacher, error)
+	DeleteTeacher(ctx context.Context, id, managerID string) error
+	CreateTeacher(ctx context.Context, teacher *models.Teacher, managerID string) (*models.Teacher, error)
 	GetTeacherCourses(ctx context.Context, teacherID string) ([]models.Course, error)
 	CreateUserWithRole(ctx context.Context, user models.User, role string) (string, error)
 }
 
 type teacherService struct {
 	repo repository.TeacherRepository
+	org  repository.OrganizationRepository
 }
 
-func NewTeacherService(repo repository.TeacherRepository) TeacherService {
-	return &teacherService{repo: repo}
+func NewTeacherService(repo repository.TeacherRepository, org repository.OrganizationRepository) TeacherService {
+	return &teacherService{repo: repo, org: org}
 }
 
 // GetTeachers возвращает список всех преподавателей
@@ -34,21 +35,78 @@ func (s *teacherService) GetTeacherById(ctx context.Context, id string) (*models
 	return s.repo.GetTeacherById(ctx, id)
 }
 
-// UpdateTeacher обновляет информацию о преподавателе
-func (s *teacherService) UpdateTeacher(ctx context.Context, teacher models.Teacher) (*models.Teacher, error) {
+// UpdateTeacher обновляет информацию о преподавателе. Менеджер (managerID не пуст) изменяет только
+// преподавателей своей кафедры и не может перевести их на другую кафедру.
+func (s *teacherService) UpdateTeacher(ctx context.Context, teacher models.Teacher, managerID string) (*models.Teacher, error) {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if scope != nil {
+		current, err := s.repo.GetTeacherById(ctx, teacher.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !inDepartment(scope, current.DepartmentID) {
+			return nil, models.ErrOutsideDepartment
+		}
+	}
+	if err := s.assignDepartment(ctx, &teacher, scope); err != nil {
+		return nil, err
+	}
 	return s.repo.UpdateTeacher(ctx, teacher)
 }
 
 // DeleteTeacher удаляет преподавателя по ID
-func (s *teacherService) DeleteTeacher(ctx context.Context, id string) error {
+func (s *teacherService) DeleteTeacher(ctx context.Context, id, managerID string) error {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return err
+	}
+	if scope != nil {
+		current, err := s.repo.GetTeacherById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !inDepartment(scope, current.DepartmentID) {
+			return models.ErrOutsideDepartment
+		}
+	}
 	return s.repo.DeleteTeacher(ctx, id)
 }
 
-// CreateTeacher создает нового преподавателя
-func (s *teacherService) CreateTeacher(ctx context.Context, teacher *models.Teacher) (*models.Teacher, error) {
+// CreateTeacher создает нового преподавателя; менеджер — только на своей кафедре
+func (s *teacherService) CreateTeacher(ctx context.Context, teacher *models.Teacher, managerID string) (*models.Teacher, error) {
+	scope, err := managerDepartment(ctx, s.org, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignDepartment(ctx, teacher, scope); err != nil {
+		return nil, err
+	}
 	return s.repo.CreateTeacher(ctx, teacher)
 }
 
+// assignDepartment сопоставляет кафедру преподавателя с записью справочника и подставляет
+// её каноническое название. Менеджеру без указанной кафедры подставляется его кафедра.
+func (s *teacherService) assignDepartment(ctx context.Context, teacher *models.Teacher, scope *models.Department) error {
+	department, err := resolveDepartment(ctx, s.org, teacher.DepartmentID, teacher.Department)
+	if err != nil {
+		return err
+	}
+	if department == nil {
+		department = scope
+	}
+	teacher.DepartmentID, teacher.Department = nil, ""
+	if department != nil {
+		teacher.DepartmentID, teacher.Department = &department.ID, department.Name
+	}
+	if !inDepartment(scope, teacher.DepartmentID) {
+		return models.ErrOutsideDepartment
+	}
+	return nil
+}
+
 // GetTeacherCourses возвращает список курсов, которые ведет преподаватель
 func (s *teacherService) GetTeacherCourses(ctx context.Context, teacherID string) ([]models.Course, error) {
 	return s.repo.GetTeacherCourses(ctx, teacherID)
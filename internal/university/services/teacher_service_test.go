@@ -72,7 +72,7 @@ func (m *mockTeacherRepo) CreateUserWithRole(ctx context.Context, user models.Us
 func TestTeacherService_GetTeachers(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockTeacherRepo)
-	svc := NewTeacherService(mockRepo)
+	svc := NewTeacherService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -109,7 +109,7 @@ func TestTeacherService_GetTeachers(t *testing.T) {
 func TestTeacherService_GetTeacherById(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockTeacherRepo)
-	svc := NewTeacherService(mockRepo)
+	svc := NewTeacherService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -157,25 +157,28 @@ func TestTeacherService_GetTeacherById(t *testing.T) {
 
 func TestTeacherService_CreateTeacher(t *testing.T) {
 	// Arrange
-	mockRepo := new(mockTeacherRepo)
-	svc := NewTeacherService(mockRepo)
+	mockRepo, org := new(mockTeacherRepo), new(mockOrganizationRepo)
+	svc := NewTeacherService(mockRepo, org)
 	ctx := context.Background()
+	cs := &models.Department{ID: "2", Code: "CS", Name: "Computer Science"}
 
 	t.Run("Success", func(t *testing.T) {
 		newTeacher := &models.Teacher{
 			User:       models.User{ID: "1"},
-			Department: "Computer Science",
+			Department: "computer science",
 			Position:   "Professor",
 		}
 		expectedTeacher := &models.Teacher{
-			User:       models.User{ID: "1"},
-			Department: "Computer Science",
-			Position:   "Professor",
+			User:         models.User{ID: "1"},
+			Department:   "Computer Science",
+			DepartmentID: strPtr("2"),
+			Position:     "Professor",
 		}
-		mockRepo.On("CreateTeacher", ctx, newTeacher).Return(expectedTeacher, nil).Once()
+		org.On("FindDepartment", ctx, "computer science").Return(cs, nil).Once()
+		mockRepo.On("CreateTeacher", ctx, expectedTeacher).Return(expectedTeacher, nil).Once()
 
 		// Act
-		teacher, err := svc.CreateTeacher(ctx, newTeacher)
+		teacher, err := svc.CreateTeacher(ctx, newTeacher, "")
 
 		// Assert
 		assert.NoError(t, err)
@@ -183,17 +186,41 @@ func TestTeacherService_CreateTeacher(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Manager Defaults To Own Department", func(t *testing.T) {
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		mockRepo.On("CreateTeacher", ctx, mock.MatchedBy(func(t *models.Teacher) bool {
+			return *t.DepartmentID == "5" && t.Department == "Software Engineering"
+		})).Return(&models.Teacher{}, nil).Once()
+
+		// Act
+		_, err := svc.CreateTeacher(ctx, &models.Teacher{User: models.User{ID: "1"}}, "40")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Manager Outside Department", func(t *testing.T) {
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		org.On("FindDepartment", ctx, "CS").Return(cs, nil).Once()
+
+		// Act
+		_, err := svc.CreateTeacher(ctx, &models.Teacher{User: models.User{ID: "1"}, Department: "CS"}, "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		newTeacher := &models.Teacher{
-			User:       models.User{ID: "1"},
-			Department: "Computer Science",
-			Position:   "Professor",
+			User:     models.User{ID: "1"},
+			Position: "Professor",
 		}
 		expectedError := errors.New("database error")
 		mockRepo.On("CreateTeacher", ctx, newTeacher).Return(nil, expectedError).Once()
 
 		// Act
-		teacher, err := svc.CreateTeacher(ctx, newTeacher)
+		teacher, err := svc.CreateTeacher(ctx, newTeacher, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -205,38 +232,55 @@ func TestTeacherService_CreateTeacher(t *testing.T) {
 
 func TestTeacherService_UpdateTeacher(t *testing.T) {
 	// Arrange
-	mockRepo := new(mockTeacherRepo)
-	svc := NewTeacherService(mockRepo)
+	mockRepo, org := new(mockTeacherRepo), new(mockOrganizationRepo)
+	svc := NewTeacherService(mockRepo, org)
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
 		updatedTeacher := models.Teacher{
-			User:       models.User{ID: "1"},
-			Department: "Computer Science",
-			Position:   "Senior Professor", // Изменена должность
+			User:         models.User{ID: "1"},
+			DepartmentID: strPtr("2"),
+			Position:     "Senior Professor", // Изменена должность
 		}
-		mockRepo.On("UpdateTeacher", ctx, updatedTeacher).Return(&updatedTeacher, nil).Once()
+		expected := updatedTeacher
+		expected.Department = "Computer Science"
+		org.On("GetDepartmentByID", ctx, "2").Return(&models.Department{ID: "2", Name: "Computer Science"}, nil).Once()
+		mockRepo.On("UpdateTeacher", ctx, expected).Return(&expected, nil).Once()
 
 		// Act
-		teacher, err := svc.UpdateTeacher(ctx, updatedTeacher)
+		teacher, err := svc.UpdateTeacher(ctx, updatedTeacher, "")
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, &updatedTeacher, teacher)
+		assert.Equal(t, &expected, teacher)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Manager Cannot Move Teacher To Another Department", func(t *testing.T) {
+		mockRepo, org := new(mockTeacherRepo), new(mockOrganizationRepo)
+		svc := NewTeacherService(mockRepo, org)
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		mockRepo.On("GetTeacherById", ctx, "1").Return(&models.Teacher{DepartmentID: strPtr("5")}, nil).Once()
+		org.On("GetDepartmentByID", ctx, "2").Return(&models.Department{ID: "2", Name: "Computer Science"}, nil).Once()
+
+		// Act
+		_, err := svc.UpdateTeacher(ctx, models.Teacher{User: models.User{ID: "1"}, DepartmentID: strPtr("2")}, "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		mockRepo.AssertNotCalled(t, "UpdateTeacher", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		updatedTeacher := models.Teacher{
-			User:       models.User{ID: "1"},
-			Department: "Computer Science",
-			Position:   "Senior Professor",
+			User:     models.User{ID: "1"},
+			Position: "Senior Professor",
 		}
 		expectedError := errors.New("database error")
 		mockRepo.On("UpdateTeacher", ctx, updatedTeacher).Return(nil, expectedError).Once()
 
 		// Act
-		teacher, err := svc.UpdateTeacher(ctx, updatedTeacher)
+		teacher, err := svc.UpdateTeacher(ctx, updatedTeacher, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -248,8 +292,8 @@ func TestTeacherService_UpdateTeacher(t *testing.T) {
 
 func TestTeacherService_DeleteTeacher(t *testing.T) {
 	// Arrange
-	mockRepo := new(mockTeacherRepo)
-	svc := NewTeacherService(mockRepo)
+	mockRepo, org := new(mockTeacherRepo), new(mockOrganizationRepo)
+	svc := NewTeacherService(mockRepo, org)
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -257,20 +301,32 @@ func TestTeacherService_DeleteTeacher(t *testing.T) {
 		mockRepo.On("DeleteTeacher", ctx, teacherID).Return(nil).Once()
 
 		// Act
-		err := svc.DeleteTeacher(ctx, teacherID)
+		err := svc.DeleteTeacher(ctx, teacherID, "")
 
 		// Assert
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Manager Outside Department", func(t *testing.T) {
+		org.On("GetManagerDepartment", ctx, "40").Return(itDepartment(), nil).Once()
+		mockRepo.On("GetTeacherById", ctx, "1").Return(&models.Teacher{}, nil).Once()
+
+		// Act
+		err := svc.DeleteTeacher(ctx, "1", "40")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrOutsideDepartment)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		teacherID := "1"
 		expectedError := errors.New("database error")
 		mockRepo.On("DeleteTeacher", ctx, teacherID).Return(expectedError).Once()
 
 		// Act
-		err := svc.DeleteTeacher(ctx, teacherID)
+		err := svc.DeleteTeacher(ctx, teacherID, "")
 
 		// Assert
 		assert.Error(t, err)
@@ -282,7 +338,7 @@ func TestTeacherService_DeleteTeacher(t *testing.T) {
 func TestTeacherService_GetTeacherCourses(t *testing.T) {
 	// Arrange
 	mockRepo := new(mockTeacherRepo)
-	svc := NewTeacherService(mockRepo)
+	svc := NewTeacherService(mockRepo, new(mockOrganizationRepo))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type TermService interface {
+	GetTerms(ctx context.Context) ([]models.AcademicTerm, error)
+	GetTermByID(ctx context.Context, id string) (*models.AcademicTerm, error)
+	CreateTerm(ctx context.Context, term *models.AcademicTerm) (*models.AcademicTerm, error)
+	UpdateTerm(ctx context.Context, term models.AcademicTerm) (*models.AcademicTerm, error)
+	DeleteTerm(ctx context.Context, id string) error
+	GetTermOfferings(ctx context.Context, termID string) ([]models.CourseOffering, error)
+	CreateOffering(ctx context.Context, offering *models.CourseOffering) (*models.CourseOffering, error)
+	DeleteOffering(ctx context.Context, termID, offeringID string) error
+}
+
+type termService struct {
+	repo      repository.TermRepository
+	standings StandingService
+}
+
+func NewTermService(repo repository.TermRepository, standings StandingService) TermService {
+	return &termService{repo: repo, standings: standings}
+}
+
+// validateTerm проверяет согласованность дат периода и проставляет статус по умолчанию
+func validateTerm(term *models.AcademicTerm) error {
+	if term.Status == "" {
+		term.Status = models.TermStatusPlanned
+	}
+	switch term.Status {
+	case models.TermStatusPlanned, models.TermStatusActive, models.TermStatusClosed:
+	default:
+		return fmt.Errorf("%w: unknown status %q", models.ErrInvalidTerm, term.Status)
+	}
+	if !term.StartDate.Before(term.EndDate) {
+		return fmt.Errorf("%w: start_date must be before end_date", models.ErrInvalidTerm)
+	}
+	if term.EnrollmentEnd.Before(term.EnrollmentStart) {
+		return fmt.Errorf("%w: enrollment_start must not be after enrollment_end", models.ErrInvalidTerm)
+	}
+	if term.EnrollmentEnd.After(term.EndDate) {
+		return fmt.Errorf("%w: enrollment must end before the term ends", models.ErrInvalidTerm)
+	}
+	if term.GradingDeadline.Before(term.EndDate) {
+		return fmt.Errorf("%w: grading_deadline must not be before end_date", models.ErrInvalidTerm)
+	}
+	if term.AddDropDeadline.IsZero() {
+		term.AddDropDeadline = term.EnrollmentEnd
+	}
+	if term.WithdrawalDeadline.IsZero() {
+		term.WithdrawalDeadline = term.EndDate
+	}
+	if term.AddDropDeadline.Before(term.EnrollmentEnd) {
+		return fmt.Errorf("%w: add_drop_deadline must not be before enrollment_end", models.ErrInvalidTerm)
+	}
+	if term.WithdrawalDeadline.Before(term.AddDropDeadline) || term.WithdrawalDeadline.After(term.EndDate) {
+		return fmt.Errorf("%w: withdrawal_deadline must be between add_drop_deadline and end_date", models.ErrInvalidTerm)
+	}
+	return nil
+}
+
+func (s *termService) GetTerms(ctx context.Context) ([]models.AcademicTerm, error) {
+	return s.repo.GetTerms(ctx)
+}
+
+func (s *termService) GetTermByID(ctx context.Context, id string) (*models.AcademicTerm, error) {
+	return s.repo.GetTermByID(ctx, id)
+}
+
+func (s *termService) CreateTerm(ctx context.Context, term *models.AcademicTerm) (*models.AcademicTerm, error) {
+	if err := validateTerm(term); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateTerm(ctx, term)
+}
+
+// UpdateTerm обновляет период. При переводе периода в статус closed активные записи на курсы
+// получают итоговый статус completed или failed по набранным баллам, после чего по правилам положения
+// рассчитываются испытательный срок и список отличников. Записи завершаются до сохранения статуса:
+// если это не удалось, период остаётся открытым и закрытие можно повторить. Изменение уже закрытого
+// периода ничего не пересчитывает; положение пересчитывается явно через ComputeTermStandings.
+func (s *termService) UpdateTerm(ctx context.Context, term models.AcademicTerm) (*models.AcademicTerm, error) {
+	if err := validateTerm(&term); err != nil {
+		return nil, err
+	}
+	current, err := s.repo.GetTermByID(ctx, term.ID)
+	if err != nil {
+		return nil, err
+	}
+	closing := current.Status != models.TermStatusClosed && term.Status == models.TermStatusClosed
+	if closing {
+		if err := s.repo.CompleteTermEnrollments(ctx, term.ID); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := s.repo.UpdateTerm(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	if closing {
+		if _, err := s.standings.ComputeTermStandings(ctx, updated.ID); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+func (s *termService) DeleteTerm(ctx context.Context, id string) error {
+	return s.repo.DeleteTerm(ctx, id)
+}
+
+func (s *termService) GetTermOfferings(ctx context.Context, termID string) ([]models.CourseOffering, error) {
+	return s.repo.GetTermOfferings(ctx, termID)
+}
+
+// CreateOffering открывает курс в периоде; в закрытый период курсы добавлять нельзя
+func (s *termService) CreateOffering(ctx context.Context, offering *models.CourseOffering) (*models.CourseOffering, error) {
+	term, err := s.repo.GetTermByID(ctx, offering.TermID)
+	if err != nil {
+		return nil, err
+	}
+	if term.Status == models.TermStatusClosed {
+		return nil, fmt.Errorf("%w: term is closed", models.ErrInvalidTerm)
+	}
+	return s.repo.CreateOffering(ctx, offering)
+}
+
+func (s *termService) DeleteOffering(ctx context.Context, termID, offeringID string) error {
+	return s.repo.DeleteOffering(ctx, termID, offeringID)
+}
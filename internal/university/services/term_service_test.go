@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockTermRepo struct {
+	mock.Mock
+}
+
+func (m *mockTermRepo) GetTerms(ctx context.Context) ([]models.AcademicTerm, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AcademicTerm), args.Error(1)
+}
+
+func (m *mockTermRepo) GetTermByID(ctx context.Context, id string) (*models.AcademicTerm, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AcademicTerm), args.Error(1)
+}
+
+func (m *mockTermRepo) CreateTerm(ctx context.Context, term *models.AcademicTerm) (*models.AcademicTerm, error) {
+	args := m.Called(ctx, term)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AcademicTerm), args.Error(1)
+}
+
+func (m *mockTermRepo) UpdateTerm(ctx context.Context, term models.AcademicTerm) (*models.AcademicTerm, error) {
+	args := m.Called(ctx, term)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AcademicTerm), args.Error(1)
+}
+
+func (m *mockTermRepo) DeleteTerm(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockTermRepo) CompleteTermEnrollments(ctx context.Context, termID string) error {
+	args := m.Called(ctx, termID)
+	return args.Error(0)
+}
+
+func (m *mockTermRepo) GetTermOfferings(ctx context.Context, termID string) ([]models.CourseOffering, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseOffering), args.Error(1)
+}
+
+func (m *mockTermRepo) GetOffering(ctx context.Context, termID, courseID string) (*models.CourseOffering, error) {
+	args := m.Called(ctx, termID, courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseOffering), args.Error(1)
+}
+
+func (m *mockTermRepo) GetOfferingByID(ctx context.Context, termID, offeringID string) (*models.CourseOffering, error) {
+	args := m.Called(ctx, termID, offeringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseOffering), args.Error(1)
+}
+
+func (m *mockTermRepo) CreateOffering(ctx context.Context, offering *models.CourseOffering) (*models.CourseOffering, error) {
+	args := m.Called(ctx, offering)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseOffering), args.Error(1)
+}
+
+func (m *mockTermRepo) DeleteOffering(ctx context.Context, termID, offeringID string) error {
+	args := m.Called(ctx, termID, offeringID)
+	return args.Error(0)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// fallTerm — осенний семестр с записью в сентябре
+func fallTerm() models.AcademicTerm {
+	return models.AcademicTerm{
+		ID:                 "1",
+		Year:               2025,
+		Season:             models.SeasonFall,
+		StartDate:          date(2025, time.September, 1),
+		EndDate:            date(2025, time.December, 25),
+		EnrollmentStart:    date(2025, time.August, 15),
+		EnrollmentEnd:      date(2025, time.September, 14),
+		AddDropDeadline:    date(2025, time.September, 21),
+		WithdrawalDeadline: date(2025, time.November, 15),
+		GradingDeadline:    date(2026, time.January, 10),
+		Status:             models.TermStatusActive,
+	}
+}
+
+func TestTermService_CreateTerm(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockTermRepo)
+	svc := NewTermService(mockRepo, new(mockStandingService))
+	ctx := context.Background()
+
+	t.Run("Success Defaults Status", func(t *testing.T) {
+		term := fallTerm()
+		term.ID = ""
+		term.Status = ""
+		mockRepo.On("CreateTerm", ctx, mock.MatchedBy(func(tm *models.AcademicTerm) bool {
+			return tm.Status == models.TermStatusPlanned
+		})).Return(&term, nil).Once()
+
+		// Act
+		created, err := svc.CreateTerm(ctx, &term)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.TermStatusPlanned, created.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("End Before Start", func(t *testing.T) {
+		term := fallTerm()
+		term.EndDate = date(2025, time.August, 1)
+
+		// Act
+		_, err := svc.CreateTerm(ctx, &term)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidTerm)
+		mockRepo.AssertNotCalled(t, "CreateTerm", ctx, &term)
+	})
+
+	t.Run("Grading Deadline Before End", func(t *testing.T) {
+		term := fallTerm()
+		term.GradingDeadline = date(2025, time.December, 1)
+
+		// Act
+		_, err := svc.CreateTerm(ctx, &term)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidTerm)
+	})
+
+	t.Run("Deadlines Default To Enrollment And Term End", func(t *testing.T) {
+		term := fallTerm()
+		term.AddDropDeadline = time.Time{}
+		term.WithdrawalDeadline = time.Time{}
+		mockRepo.On("CreateTerm", ctx, mock.MatchedBy(func(tm *models.AcademicTerm) bool {
+			return tm.AddDropDeadline.Equal(tm.EnrollmentEnd) && tm.WithdrawalDeadline.Equal(tm.EndDate)
+		})).Return(&term, nil).Once()
+
+		// Act
+		_, err := svc.CreateTerm(ctx, &term)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Withdrawal Before Add Drop", func(t *testing.T) {
+		term := fallTerm()
+		term.WithdrawalDeadline = date(2025, time.September, 15)
+
+		// Act
+		_, err := svc.CreateTerm(ctx, &term)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidTerm)
+	})
+
+	t.Run("Unknown Status", func(t *testing.T) {
+		term := fallTerm()
+		term.Status = "archived"
+
+		// Act
+		_, err := svc.CreateTerm(ctx, &term)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidTerm)
+	})
+}
+
+func TestTermService_UpdateTerm(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockTermRepo)
+	standings := new(mockStandingService)
+	svc := NewTermService(mockRepo, standings)
+	ctx := context.Background()
+
+	t.Run("Closing Completes Enrollments And Computes Standing", func(t *testing.T) {
+		active := fallTerm()
+		term := fallTerm()
+		term.Status = models.TermStatusClosed
+		mockRepo.On("GetTermByID", ctx, term.ID).Return(&active, nil).Once()
+		mockRepo.On("CompleteTermEnrollments", ctx, term.ID).Return(nil).Once()
+		mockRepo.On("UpdateTerm", ctx, term).Return(&term, nil).Once()
+		standings.On("ComputeTermStandings", ctx, term.ID).Return([]models.AcademicStanding{}, nil).Once()
+
+		// Act
+		updated, err := svc.UpdateTerm(ctx, term)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.TermStatusClosed, updated.Status)
+		mockRepo.AssertExpectations(t)
+		standings.AssertExpectations(t)
+	})
+
+	t.Run("Term Stays Open When Completion Fails", func(t *testing.T) {
+		failingRepo := new(mockTermRepo)
+		active := fallTerm()
+		term := fallTerm()
+		term.Status = models.TermStatusClosed
+		failingRepo.On("GetTermByID", ctx, term.ID).Return(&active, nil).Once()
+		failingRepo.On("CompleteTermEnrollments", ctx, term.ID).Return(errors.New("connection reset")).Once()
+
+		// Act
+		_, err := NewTermService(failingRepo, new(mockStandingService)).UpdateTerm(ctx, term)
+
+		// Assert
+		assert.Error(t, err)
+		failingRepo.AssertNotCalled(t, "UpdateTerm", ctx, term)
+	})
+
+	t.Run("Editing Closed Term Does Not Recompute", func(t *testing.T) {
+		closedRepo, closedStandings := new(mockTermRepo), new(mockStandingService)
+		closed := fallTerm()
+		closed.Status = models.TermStatusClosed
+		term := closed
+		term.MaxCredits = 40
+		closedRepo.On("GetTermByID", ctx, term.ID).Return(&closed, nil).Once()
+		closedRepo.On("UpdateTerm", ctx, term).Return(&term, nil).Once()
+
+		// Act
+		_, err := NewTermService(closedRepo, closedStandings).UpdateTerm(ctx, term)
+
+		// Assert
+		assert.NoError(t, err)
+		closedRepo.AssertNotCalled(t, "CompleteTermEnrollments", ctx, term.ID)
+		closedStandings.AssertNotCalled(t, "ComputeTermStandings", ctx, term.ID)
+	})
+
+	t.Run("Active Term Keeps Enrollments", func(t *testing.T) {
+		activeRepo := new(mockTermRepo)
+		term := fallTerm()
+		activeRepo.On("GetTermByID", ctx, term.ID).Return(&term, nil).Once()
+		activeRepo.On("UpdateTerm", ctx, term).Return(&term, nil).Once()
+
+		// Act
+		_, err := NewTermService(activeRepo, new(mockStandingService)).UpdateTerm(ctx, term)
+
+		// Assert
+		assert.NoError(t, err)
+		activeRepo.AssertNotCalled(t, "CompleteTermEnrollments", ctx, term.ID)
+	})
+}
+
+func TestTermService_CreateOffering(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockTermRepo)
+	svc := NewTermService(mockRepo, new(mockStandingService))
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		term := fallTerm()
+		offering := &models.CourseOffering{CourseID: "101", TermID: "1"}
+		mockRepo.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+		mockRepo.On("CreateOffering", ctx, offering).Return(&models.CourseOffering{ID: "5", CourseID: "101", TermID: "1"}, nil).Once()
+
+		// Act
+		created, err := svc.CreateOffering(ctx, offering)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "5", created.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Closed Term", func(t *testing.T) {
+		term := fallTerm()
+		term.Status = models.TermStatusClosed
+		offering := &models.CourseOffering{CourseID: "101", TermID: "1"}
+		mockRepo.On("GetTermByID", ctx, "1").Return(&term, nil).Once()
+
+		// Act
+		_, err := svc.CreateOffering(ctx, offering)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidTerm)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Term Not Found", func(t *testing.T) {
+		offering := &models.CourseOffering{CourseID: "101", TermID: "9"}
+		mockRepo.On("GetTermByID", ctx, "9").Return(nil, models.ErrTermNotFound).Once()
+
+		// Act
+		_, err := svc.CreateOffering(ctx, offering)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrTermNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestTermService_GetTerms(t *testing.T) {
+	// Arrange
+	mockRepo := new(mockTermRepo)
+	svc := NewTermService(mockRepo, new(mockStandingService))
+	ctx := context.Background()
+
+	t.Run("Error", func(t *testing.T) {
+		expectedError := errors.New("database error")
+		mockRepo.On("GetTerms", ctx).Return(nil, expectedError).Once()
+
+		// Act
+		terms, err := svc.GetTerms(ctx)
+
+		// Assert
+		assert.Equal(t, expectedError, err)
+		assert.Nil(t, terms)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"university_system/internal/domain/models"
+	"university_system/internal/domain/repository"
+)
+
+type TimetableService interface {
+	GetSectionMeetings(ctx context.Context, sectionID string) ([]models.SectionMeeting, error)
+	CreateMeeting(ctx context.Context, meeting *models.SectionMeeting, force bool) (*models.SectionMeeting, error)
+	UpdateMeeting(ctx context.Context, meeting models.SectionMeeting, force bool) (*models.SectionMeeting, error)
+	DeleteMeeting(ctx context.Context, id string) error
+	GetTermConflicts(ctx context.Context, termID string) ([]models.ScheduleConflict, error)
+	GetStudentTimetable(ctx context.Context, studentID, termID string) ([]models.TimetableEntry, error)
+	GetTeacherTimetable(ctx context.Context, teacherID, termID string) ([]models.TimetableEntry, error)
+	GetRoomTimetable(ctx context.Context, roomID, termID string) ([]models.TimetableEntry, error)
+}
+
+type timetableService struct {
+	repo     repository.TimetableRepository
+	rooms    repository.RoomRepository
+	sections repository.SectionRepository
+	terms    repository.TermRepository
+	now      func() time.Time
+}
+
+func NewTimetableService(repo repository.TimetableRepository, rooms repository.RoomRepository,
+	sections repository.SectionRepository, terms repository.TermRepository) TimetableService {
+	return &timetableService{repo: repo, rooms: rooms, sections: sections, terms: terms, now: time.Now}
+}
+
+func (s *timetableService) GetSectionMeetings(ctx context.Context, sectionID string) ([]models.SectionMeeting, error) {
+	if _, err := s.sections.GetSectionByID(ctx, sectionID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSectionMeetings(ctx, sectionID)
+}
+
+// CreateMeeting добавляет еженедельное занятие секции, если оно ни с чем не конфликтует.
+// С force допускаются конфликты преподавателей, студентов и вместимости, но не занятая аудитория.
+func (s *timetableService) CreateMeeting(ctx context.Context, meeting *models.SectionMeeting, force bool) (*models.SectionMeeting, error) {
+	if err := s.checkMeeting(ctx, meeting, force); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateMeeting(ctx, meeting)
+}
+
+// UpdateMeeting переносит занятие с теми же проверками, что и при создании; секция занятия не меняется
+func (s *timetableService) UpdateMeeting(ctx context.Context, meeting models.SectionMeeting, force bool) (*models.SectionMeeting, error) {
+	current, err := s.repo.GetMeetingByID(ctx, meeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	meeting.SectionID = current.SectionID
+	if err := s.checkMeeting(ctx, &meeting, force); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateMeeting(ctx, meeting)
+}
+
+func (s *timetableService) DeleteMeeting(ctx context.Context, id string) error {
+	if _, err := s.repo.GetMeetingByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteMeeting(ctx, id)
+}
+
+// GetTermConflicts возвращает конфликты уже составленного расписания периода, в том числе
+// появившиеся после записи студентов
+func (s *timetableService) GetTermConflicts(ctx context.Context, termID string) ([]models.ScheduleConflict, error) {
+	if _, err := s.terms.GetTermByID(ctx, termID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTermConflicts(ctx, termID)
+}
+
+// GetStudentTimetable возвращает недельное расписание студента в периоде termID, а без него — в идущем
+func (s *timetableService) GetStudentTimetable(ctx context.Context, studentID, termID string) ([]models.TimetableEntry, error) {
+	term, err := termOrCurrent(ctx, s.terms, termID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetStudentTimetable(ctx, studentID, term.ID)
+}
+
+// GetTeacherTimetable возвращает недельное расписание преподавателя в периоде termID, а без него — в идущем
+func (s *timetableService) GetTeacherTimetable(ctx context.Context, teacherID, termID string) ([]models.TimetableEntry, error) {
+	term, err := termOrCurrent(ctx, s.terms, termID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetTeacherTimetable(ctx, teacherID, term.ID)
+}
+
+func (s *timetableService) GetRoomTimetable(ctx context.Context, roomID, termID string) ([]models.TimetableEntry, error) {
+	if _, err := s.rooms.GetRoomByID(ctx, roomID); err != nil {
+		return nil, err
+	}
+	term, err := termOrCurrent(ctx, s.terms, termID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetRoomTimetable(ctx, roomID, term.ID)
+}
+
+// checkMeeting приводит время занятия к виду "15:04" и ищет конфликты: занятость аудитории,
+// преподавателей и записанных студентов в то же время, а также нехватку мест в аудитории
+func (s *timetableService) checkMeeting(ctx context.Context, meeting *models.SectionMeeting, force bool) error {
+	if err := normalizeMeetingTime(meeting); err != nil {
+		return err
+	}
+	section, err := s.sections.GetSectionByID(ctx, meeting.SectionID)
+	if err != nil {
+		return err
+	}
+
+	conflicts := []models.ScheduleConflict{}
+	if meeting.RoomID != nil {
+		room, err := s.rooms.GetRoomByID(ctx, *meeting.RoomID)
+		if err != nil {
+			return err
+		}
+		if room.Capacity < section.Capacity {
+			conflicts = append(conflicts, models.ScheduleConflict{
+				Kind: models.ConflictCapacity, SubjectID: room.ID, MeetingID: meeting.ID, SectionID: section.ID,
+				Weekday: meeting.Weekday, StartTime: meeting.StartTime, EndTime: meeting.EndTime,
+			})
+		}
+	}
+	found, err := s.repo.FindConflicts(ctx, *meeting)
+	if err != nil {
+		return err
+	}
+	conflicts = append(conflicts, found...)
+
+	blocking := []models.ScheduleConflict{}
+	for _, conflict := range conflicts {
+		if !force || conflict.Kind == models.ConflictRoom {
+			blocking = append(blocking, conflict)
+		}
+	}
+	if len(blocking) > 0 {
+		return &models.ScheduleConflictError{Conflicts: blocking}
+	}
+	return nil
+}
+
+func normalizeMeetingTime(meeting *models.SectionMeeting) error {
+	start, err := time.Parse("15:04", meeting.StartTime)
+	if err != nil {
+		return fmt.Errorf("%w: start_time must be HH:MM", models.ErrInvalidMeetingTime)
+	}
+	end, err := time.Parse("15:04", meeting.EndTime)
+	if err != nil {
+		return fmt.Errorf("%w: end_time must be HH:MM", models.ErrInvalidMeetingTime)
+	}
+	if !end.After(start) {
+		return fmt.Errorf("%w: end_time must be after start_time", models.ErrInvalidMeetingTime)
+	}
+	meeting.StartTime, meeting.EndTime = start.Format("15:04"), end.Format("15:04")
+	return nil
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"university_system/internal/domain/models"
+)
+
+type mockTimetableRepo struct {
+	mock.Mock
+}
+
+func (m *mockTimetableRepo) GetSectionMeetings(ctx context.Context, sectionID string) ([]models.SectionMeeting, error) {
+	args := m.Called(ctx, sectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SectionMeeting), args.Error(1)
+}
+
+func (m *mockTimetableRepo) GetMeetingByID(ctx context.Context, id string) (*models.SectionMeeting, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SectionMeeting), args.Error(1)
+}
+
+func (m *mockTimetableRepo) CreateMeeting(ctx context.Context, meeting *models.SectionMeeting) (*models.SectionMeeting, error) {
+	args := m.Called(ctx, meeting)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SectionMeeting), args.Error(1)
+}
+
+func (m *mockTimetableRepo) UpdateMeeting(ctx context.Context, meeting models.SectionMeeting) (*models.SectionMeeting, error) {
+	args := m.Called(ctx, meeting)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SectionMeeting), args.Error(1)
+}
+
+func (m *mockTimetableRepo) DeleteMeeting(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockTimetableRepo) FindConflicts(ctx context.Context, meeting models.SectionMeeting) ([]models.ScheduleConflict, error) {
+	args := m.Called(ctx, meeting)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ScheduleConflict), args.Error(1)
+}
+
+func (m *mockTimetableRepo) GetTermConflicts(ctx context.Context, termID string) ([]models.ScheduleConflict, error) {
+	args := m.Called(ctx, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ScheduleConflict), args.Error(1)
+}
+
+func (m *mockTimetableRepo) GetStudentTimetable(ctx context.Context, studentID, termID string) ([]models.TimetableEntry, error) {
+	args := m.Called(ctx, studentID, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TimetableEntry), args.Error(1)
+}
+
+func (m *mockTimetableRepo) GetTeacherTimetable(ctx context.Context, teacherID, termID string) ([]models.TimetableEntry, error) {
+	args := m.Called(ctx, teacherID, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TimetableEntry), args.Error(1)
+}
+
+func (m *mockTimetableRepo) GetRoomTimetable(ctx context.Context, roomID, termID string) ([]models.TimetableEntry, error) {
+	args := m.Called(ctx, roomID, termID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TimetableEntry), args.Error(1)
+}
+
+func TestTimetableService_CreateMeeting(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomID := "3"
+	section := &models.CourseSection{ID: "5", OfferingID: "7", Capacity: 30}
+	room := &models.Room{ID: "3", Capacity: 40}
+	newMeeting := func() *models.SectionMeeting {
+		return &models.SectionMeeting{SectionID: "5", RoomID: &roomID, Weekday: 1, StartTime: "9:00", EndTime: "10:30"}
+	}
+	teacherConflict := models.ScheduleConflict{Kind: models.ConflictTeacher, SubjectID: "12", SectionID: "5", ConflictSectionID: "6"}
+	roomConflict := models.ScheduleConflict{Kind: models.ConflictRoom, SubjectID: "3", SectionID: "5", ConflictSectionID: "8"}
+
+	t.Run("Success Normalizes Time", func(t *testing.T) {
+		repo, rooms, sections := new(mockTimetableRepo), new(mockRoomRepo), new(mockSectionRepo)
+		svc := NewTimetableService(repo, rooms, sections, new(mockTermRepo))
+		meeting := newMeeting()
+		sections.On("GetSectionByID", ctx, "5").Return(section, nil).Once()
+		rooms.On("GetRoomByID", ctx, "3").Return(room, nil).Once()
+		repo.On("FindConflicts", ctx, mock.MatchedBy(func(m models.SectionMeeting) bool {
+			return m.StartTime == "09:00" && m.EndTime == "10:30"
+		})).Return([]models.ScheduleConflict{}, nil).Once()
+		repo.On("CreateMeeting", ctx, meeting).Return(meeting, nil).Once()
+
+		// Act
+		_, err := svc.CreateMeeting(ctx, meeting, false)
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("End Before Start", func(t *testing.T) {
+		repo := new(mockTimetableRepo)
+		svc := NewTimetableService(repo, new(mockRoomRepo), new(mockSectionRepo), new(mockTermRepo))
+		meeting := newMeeting()
+		meeting.EndTime = "08:00"
+
+		// Act
+		_, err := svc.CreateMeeting(ctx, meeting, false)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrInvalidMeetingTime)
+		repo.AssertNotCalled(t, "CreateMeeting", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Teacher Conflict Blocks", func(t *testing.T) {
+		repo, rooms, sections := new(mockTimetableRepo), new(mockRoomRepo), new(mockSectionRepo)
+		svc := NewTimetableService(repo, rooms, sections, new(mockTermRepo))
+		sections.On("GetSectionByID", ctx, "5").Return(section, nil).Once()
+		rooms.On("GetRoomByID", ctx, "3").Return(room, nil).Once()
+		repo.On("FindConflicts", ctx, mock.Anything).Return([]models.ScheduleConflict{teacherConflict}, nil).Once()
+
+		// Act
+		_, err := svc.CreateMeeting(ctx, newMeeting(), false)
+
+		// Assert
+		var conflictErr *models.ScheduleConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.ErrorIs(t, err, models.ErrScheduleConflict)
+		assert.Equal(t, []models.ScheduleConflict{teacherConflict}, conflictErr.Conflicts)
+		repo.AssertNotCalled(t, "CreateMeeting", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Force Allows Teacher Conflict", func(t *testing.T) {
+		repo, rooms, sections := new(mockTimetableRepo), new(mockRoomRepo), new(mockSectionRepo)
+		svc := NewTimetableService(repo, rooms, sections, new(mockTermRepo))
+		meeting := newMeeting()
+		sections.On("GetSectionByID", ctx, "5").Return(section, nil).Once()
+		rooms.On("GetRoomByID", ctx, "3").Return(room, nil).Once()
+		repo.On("FindConflicts", ctx, mock.Anything).Return([]models.ScheduleConflict{teacherConflict}, nil).Once()
+		repo.On("CreateMeeting", ctx, meeting).Return(meeting, nil).Once()
+
+		// Act
+		_, err := svc.CreateMeeting(ctx, meeting, true)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Force Does Not Allow Room Conflict", func(t *testing.T) {
+		repo, rooms, sections := new(mockTimetableRepo), new(mockRoomRepo), new(mockSectionRepo)
+		svc := NewTimetableService(repo, rooms, sections, new(mockTermRepo))
+		sections.On("GetSectionByID", ctx, "5").Return(section, nil).Once()
+		rooms.On("GetRoomByID", ctx, "3").Return(room, nil).Once()
+		repo.On("FindConflicts", ctx, mock.Anything).Return([]models.ScheduleConflict{teacherConflict, roomConflict}, nil).Once()
+
+		// Act
+		_, err := svc.CreateMeeting(ctx, newMeeting(), true)
+
+		// Assert
+		var conflictErr *models.ScheduleConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, []models.ScheduleConflict{roomConflict}, conflictErr.Conflicts)
+	})
+
+	t.Run("Room Too Small", func(t *testing.T) {
+		repo, rooms, sections := new(mockTimetableRepo), new(mockRoomRepo), new(mockSectionRepo)
+		svc := NewTimetableService(repo, rooms, sections, new(mockTermRepo))
+		sections.On("GetSectionByID", ctx, "5").Return(section, nil).Once()
+		rooms.On("GetRoomByID", ctx, "3").Return(&models.Room{ID: "3", Capacity: 20}, nil).Once()
+		repo.On("FindConflicts", ctx, mock.Anything).Return([]models.ScheduleConflict{}, nil).Once()
+
+		// Act
+		_, err := svc.CreateMeeting(ctx, newMeeting(), false)
+
+		// Assert
+		var conflictErr *models.ScheduleConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, models.ConflictCapacity, conflictErr.Conflicts[0].Kind)
+	})
+}
+
+func TestTimetableService_UpdateMeeting(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, sections := new(mockTimetableRepo), new(mockSectionRepo)
+	svc := NewTimetableService(repo, new(mockRoomRepo), sections, new(mockTermRepo))
+	repo.On("GetMeetingByID", ctx, "21").Return(&models.SectionMeeting{ID: "21", SectionID: "5"}, nil).Once()
+	sections.On("GetSectionByID", ctx, "5").Return(&models.CourseSection{ID: "5", Capacity: 30}, nil).Once()
+	repo.On("FindConflicts", ctx, mock.MatchedBy(func(m models.SectionMeeting) bool {
+		return m.ID == "21" && m.SectionID == "5"
+	})).Return([]models.ScheduleConflict{}, nil).Once()
+	repo.On("UpdateMeeting", ctx, mock.Anything).Return(&models.SectionMeeting{ID: "21", SectionID: "5", Weekday: 2}, nil).Once()
+
+	// Act
+	updated, err := svc.UpdateMeeting(ctx, models.SectionMeeting{ID: "21", SectionID: "99", Weekday: 2, StartTime: "12:00", EndTime: "13:00"}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "5", updated.SectionID)
+	repo.AssertExpectations(t)
+}
+
+func TestTimetableService_GetStudentTimetable(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	t.Run("Defaults To Current Term", func(t *testing.T) {
+		repo, terms := new(mockTimetableRepo), new(mockTermRepo)
+		svc := NewTimetableService(repo, new(mockRoomRepo), new(mockSectionRepo), terms)
+		svc.(*timetableService).now = func() time.Time { return date(2025, time.October, 1) }
+		terms.On("GetTerms", ctx).Return([]models.AcademicTerm{fallTerm()}, nil).Once()
+		repo.On("GetStudentTimetable", ctx, "101", "1").Return([]models.TimetableEntry{{MeetingID: "21"}}, nil).Once()
+
+		// Act
+		entries, err := svc.GetStudentTimetable(ctx, "101", "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("No Current Term", func(t *testing.T) {
+		repo, terms := new(mockTimetableRepo), new(mockTermRepo)
+		svc := NewTimetableService(repo, new(mockRoomRepo), new(mockSectionRepo), terms)
+		svc.(*timetableService).now = func() time.Time { return date(2026, time.February, 1) }
+		terms.On("GetTerms", ctx).Return([]models.AcademicTerm{fallTerm()}, nil).Once()
+
+		// Act
+		_, err := svc.GetStudentTimetable(ctx, "101", "")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrNoActiveTerm)
+		repo.AssertNotCalled(t, "GetStudentTimetable", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 	"os"
+	"strings"
 )
 
 type DBConfig struct {
@@ -15,9 +16,26 @@ type DBConfig struct {
 	SSLMode  string
 }
 
+// JWTConfig описывает ключи подписи токенов.
+// Для HS256 используется Secret, для RS256/EdDSA — PEM-файл PrivateKeyFile.
+// VerificationKeys — ключи, которые больше не подписывают, но ещё принимаются
+// при проверке (ротация), в формате "kid1:/path/key1.pem,kid2:/path/key2.pem".
+type JWTConfig struct {
+	Algorithm        string
+	KeyID            string
+	Secret           string
+	PrivateKeyFile   string
+	VerificationKeys string
+	RefreshSecret    string
+}
+
 type Config struct {
-	DB        DBConfig
-	JWTSecret string
+	DB  DBConfig
+	JWT JWTConfig
+	// PublicURL — внешний адрес сервиса, из него строятся ссылки проверки документов
+	PublicURL string
+	// StorageDir — каталог, в котором хранятся загруженные файлы (работы по заданиям)
+	StorageDir string
 }
 
 func LoadConfig() *Config {
@@ -31,7 +49,36 @@ func LoadConfig() *Config {
 			DBName:   os.Getenv("DB_NAME"),
 			SSLMode:  os.Getenv("DB_SSLMODE"),
 		},
-		JWTSecret: os.Getenv("JWT_SECRET"),
+		JWT: JWTConfig{
+			Algorithm:        os.Getenv("JWT_ALGORITHM"),
+			KeyID:            os.Getenv("JWT_KEY_ID"),
+			Secret:           os.Getenv("JWT_SECRET"),
+			PrivateKeyFile:   os.Getenv("JWT_PRIVATE_KEY_FILE"),
+			VerificationKeys: os.Getenv("JWT_VERIFICATION_KEYS"),
+			RefreshSecret:    os.Getenv("JWT_REFRESH_SECRET"),
+		},
+		PublicURL:  os.Getenv("PUBLIC_URL"),
+		StorageDir: os.Getenv("STORAGE_DIR"),
+	}
+
+	if cfg.JWT.Algorithm == "" {
+		cfg.JWT.Algorithm = "HS256"
+	}
+	if cfg.JWT.KeyID == "" {
+		cfg.JWT.KeyID = "default"
+	}
+
+	if cfg.PublicURL == "" {
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		cfg.PublicURL = "http://localhost:" + port
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+
+	if cfg.StorageDir == "" {
+		cfg.StorageDir = "./data/uploads"
 	}
 
 	if cfg.DB.Host == "" {
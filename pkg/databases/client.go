@@ -5,6 +5,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
+	"time"
 )
 
 var Instance *sqlx.DB
@@ -139,6 +140,857 @@ func Migrate(ctx context.Context) error {
 		return err
 	}
 
+	// Академические периоды (семестры)
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS academic_terms (
+			id SERIAL PRIMARY KEY,
+			year INTEGER NOT NULL,
+			season VARCHAR(20) NOT NULL CHECK (season IN ('fall', 'spring', 'summer')),
+			start_date DATE NOT NULL,
+			end_date DATE NOT NULL,
+			enrollment_start DATE NOT NULL,
+			enrollment_end DATE NOT NULL,
+			grading_deadline DATE NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'planned' CHECK (status IN ('planned', 'active', 'closed')),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (year, season)
+		)
+	`); err != nil {
+		return err
+	}
+
+	// Курсы, открытые в конкретном периоде
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS course_offerings (
+			id SERIAL PRIMARY KEY,
+			course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+			term_id INTEGER NOT NULL REFERENCES academic_terms(id) ON DELETE CASCADE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (course_id, term_id)
+		)
+	`); err != nil {
+		return err
+	}
+
+	// Записи и оценки привязываются к курсу в периоде; пока они есть, курс и период удалить нельзя
+	if _, err := Instance.ExecContext(ctx, `
+		ALTER TABLE student_courses ADD COLUMN IF NOT EXISTS offering_id INTEGER REFERENCES course_offerings(id) ON DELETE NO ACTION;
+		ALTER TABLE course_marks ADD COLUMN IF NOT EXISTS offering_id INTEGER REFERENCES course_offerings(id) ON DELETE NO ACTION;
+	`); err != nil {
+		return err
+	}
+
+	// Секции курса в периоде с лимитом мест
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS course_sections (
+			id SERIAL PRIMARY KEY,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			name VARCHAR(50) NOT NULL,
+			teacher_id INTEGER REFERENCES teachers(id) ON DELETE SET NULL,
+			capacity INTEGER NOT NULL CHECK (capacity > 0),
+			room VARCHAR(50) NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (offering_id, name)
+		);
+		ALTER TABLE student_courses ADD COLUMN IF NOT EXISTS section_id INTEGER REFERENCES course_sections(id) ON DELETE SET NULL;
+		CREATE INDEX IF NOT EXISTS student_courses_section_id_idx ON student_courses (section_id);
+	`); err != nil {
+		return err
+	}
+
+	// Лист ожидания секции: порядок очереди задаётся id
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS section_waitlist (
+			id SERIAL PRIMARY KEY,
+			section_id INTEGER NOT NULL REFERENCES course_sections(id) ON DELETE CASCADE,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (section_id, student_id)
+		)
+	`); err != nil {
+		return err
+	}
+
+	// Условия записи на курс и лимит нагрузки за период
+	if _, err := Instance.ExecContext(ctx, `
+		ALTER TABLE courses ADD COLUMN IF NOT EXISTS prerequisites TEXT NOT NULL DEFAULT '';
+		ALTER TABLE courses ADD COLUMN IF NOT EXISTS corequisites TEXT NOT NULL DEFAULT '';
+		ALTER TABLE courses ADD COLUMN IF NOT EXISTS min_student_year INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE courses ADD COLUMN IF NOT EXISTS allowed_faculties TEXT[];
+		ALTER TABLE academic_terms ADD COLUMN IF NOT EXISTS max_credits INTEGER NOT NULL DEFAULT 0;
+	`); err != nil {
+		return err
+	}
+
+	// Статус записи на курс и сроки отписки/отзыва в периоде
+	if _, err := Instance.ExecContext(ctx, `
+		ALTER TABLE student_courses ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'enrolled'
+			CHECK (status IN ('enrolled', 'dropped', 'withdrawn', 'completed', 'failed'));
+		ALTER TABLE student_courses ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+		ALTER TABLE academic_terms ADD COLUMN IF NOT EXISTS add_drop_deadline DATE;
+		ALTER TABLE academic_terms ADD COLUMN IF NOT EXISTS withdrawal_deadline DATE;
+		UPDATE academic_terms SET add_drop_deadline = enrollment_end WHERE add_drop_deadline IS NULL;
+		UPDATE academic_terms SET withdrawal_deadline = end_date WHERE withdrawal_deadline IS NULL;
+		ALTER TABLE academic_terms ALTER COLUMN add_drop_deadline SET NOT NULL;
+		ALTER TABLE academic_terms ALTER COLUMN withdrawal_deadline SET NOT NULL;
+	`); err != nil {
+		return err
+	}
+
+	// Журнал смены статусов записей на курсы
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS enrollment_events (
+			id SERIAL PRIMARY KEY,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+			from_status VARCHAR(20),
+			to_status VARCHAR(20) NOT NULL,
+			changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS enrollment_events_student_id_idx ON enrollment_events (student_id);
+	`); err != nil {
+		return err
+	}
+
+	// Схемы оценивания и границы буквенных оценок. На пару (курс, период) — не больше одной схемы,
+	// пустые course_id и term_id означают «любой».
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS grading_schemes (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			course_id INTEGER REFERENCES courses(id) ON DELETE CASCADE,
+			term_id INTEGER REFERENCES academic_terms(id) ON DELETE CASCADE,
+			first_attestation_max FLOAT NOT NULL CHECK (first_attestation_max > 0),
+			second_attestation_max FLOAT NOT NULL CHECK (second_attestation_max > 0),
+			final_max FLOAT NOT NULL CHECK (final_max > 0),
+			first_attestation_weight FLOAT NOT NULL CHECK (first_attestation_weight >= 0),
+			second_attestation_weight FLOAT NOT NULL CHECK (second_attestation_weight >= 0),
+			final_weight FLOAT NOT NULL CHECK (final_weight >= 0),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS grading_schemes_scope_idx
+			ON grading_schemes (COALESCE(course_id, 0), COALESCE(term_id, 0));
+		CREATE TABLE IF NOT EXISTS grade_boundaries (
+			scheme_id INTEGER NOT NULL REFERENCES grading_schemes(id) ON DELETE CASCADE,
+			letter VARCHAR(5) NOT NULL,
+			min_total FLOAT NOT NULL CHECK (min_total >= 0 AND min_total <= 100),
+			gpa_points FLOAT NOT NULL CHECK (gpa_points >= 0),
+			PRIMARY KEY (scheme_id, letter)
+		);
+	`); err != nil {
+		return err
+	}
+
+	// Выданные официальные документы: код проверки и хеш содержимого PDF
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS issued_documents (
+			id SERIAL PRIMARY KEY,
+			code VARCHAR(32) UNIQUE NOT NULL,
+			type VARCHAR(30) NOT NULL CHECK (type IN ('transcript', 'enrollment_certificate')),
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			student_name VARCHAR(255) NOT NULL,
+			term_id INTEGER REFERENCES academic_terms(id) ON DELETE SET NULL,
+			content_hash CHAR(64) NOT NULL,
+			issued_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS issued_documents_student_id_idx ON issued_documents (student_id);
+	`); err != nil {
+		return err
+	}
+
+	// Ведомости курсов в периоде, запросы на изменение утверждённых оценок и журнал изменений оценок
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS grade_sheets (
+			offering_id INTEGER PRIMARY KEY REFERENCES course_offerings(id) ON DELETE CASCADE,
+			status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'submitted', 'locked')),
+			submitted_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			submitted_at TIMESTAMP,
+			reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			reviewed_at TIMESTAMP,
+			review_comment TEXT NOT NULL DEFAULT ''
+		);
+		CREATE TABLE IF NOT EXISTS grade_change_requests (
+			id SERIAL PRIMARY KEY,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			mark_type VARCHAR(30) NOT NULL CHECK (mark_type IN ('first_attestation', 'second_attestation', 'final')),
+			old_value FLOAT NOT NULL,
+			new_value FLOAT NOT NULL,
+			reason TEXT NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+			requested_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			reviewed_at TIMESTAMP,
+			review_comment TEXT NOT NULL DEFAULT ''
+		);
+		CREATE INDEX IF NOT EXISTS grade_change_requests_status_idx ON grade_change_requests (status);
+		CREATE TABLE IF NOT EXISTS mark_changes (
+			id SERIAL PRIMARY KEY,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			mark_type VARCHAR(30) NOT NULL,
+			old_value FLOAT,
+			new_value FLOAT NOT NULL,
+			changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			change_request_id INTEGER REFERENCES grade_change_requests(id) ON DELETE SET NULL,
+			changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS mark_changes_offering_id_idx ON mark_changes (offering_id);
+	`); err != nil {
+		return err
+	}
+
+	// Апелляции студентов на оценки и комментарии к ним; открытая апелляция на оценку может быть только одна
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS grade_appeals (
+			id SERIAL PRIMARY KEY,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+			mark_type VARCHAR(30) NOT NULL CHECK (mark_type IN ('first_attestation', 'second_attestation', 'final')),
+			reason TEXT NOT NULL,
+			original_value FLOAT NOT NULL,
+			resolved_value FLOAT,
+			status VARCHAR(20) NOT NULL DEFAULT 'submitted'
+				CHECK (status IN ('submitted', 'under_review', 'upheld', 'changed', 'withdrawn')),
+			attachment_name VARCHAR(255),
+			attachment_type VARCHAR(100),
+			attachment BYTEA,
+			resolved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			resolved_at TIMESTAMP,
+			filed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS grade_appeals_open_idx ON grade_appeals (student_id, offering_id, mark_type)
+			WHERE status IN ('submitted', 'under_review');
+		CREATE TABLE IF NOT EXISTS grade_appeal_comments (
+			id SERIAL PRIMARY KEY,
+			appeal_id INTEGER NOT NULL REFERENCES grade_appeals(id) ON DELETE CASCADE,
+			author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			author_role VARCHAR(20) NOT NULL,
+			body TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS grade_appeal_comments_appeal_id_idx ON grade_appeal_comments (appeal_id);
+	`); err != nil {
+		return err
+	}
+
+	// Занятия курса в периоде, отметки посещаемости и правило посещаемости (одна строка настроек)
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS class_sessions (
+			id SERIAL PRIMARY KEY,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			section_id INTEGER REFERENCES course_sections(id) ON DELETE CASCADE,
+			starts_at TIMESTAMP NOT NULL,
+			ends_at TIMESTAMP NOT NULL CHECK (ends_at > starts_at),
+			topic VARCHAR(255) NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS class_sessions_slot_idx ON class_sessions (offering_id, COALESCE(section_id, 0), starts_at);
+		CREATE TABLE IF NOT EXISTS attendance_records (
+			session_id INTEGER NOT NULL REFERENCES class_sessions(id) ON DELETE CASCADE,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			status VARCHAR(20) NOT NULL CHECK (status IN ('present', 'absent', 'late', 'excused')),
+			note TEXT NOT NULL DEFAULT '',
+			marked_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			marked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (session_id, student_id)
+		);
+		CREATE INDEX IF NOT EXISTS attendance_records_student_id_idx ON attendance_records (student_id);
+		CREATE TABLE IF NOT EXISTS attendance_settings (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			min_percentage FLOAT NOT NULL CHECK (min_percentage >= 0 AND min_percentage <= 100),
+			min_sessions INTEGER NOT NULL CHECK (min_sessions >= 0),
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO attendance_settings (id, min_percentage, min_sessions) VALUES (1, 75, 3) ON CONFLICT (id) DO NOTHING;
+	`); err != nil {
+		return err
+	}
+
+	// Аудитории и еженедельные занятия секций
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS rooms (
+			id SERIAL PRIMARY KEY,
+			building VARCHAR(100) NOT NULL,
+			name VARCHAR(50) NOT NULL,
+			capacity INTEGER NOT NULL CHECK (capacity > 0),
+			equipment TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (building, name)
+		);
+		CREATE TABLE IF NOT EXISTS section_meetings (
+			id SERIAL PRIMARY KEY,
+			section_id INTEGER NOT NULL REFERENCES course_sections(id) ON DELETE CASCADE,
+			room_id INTEGER REFERENCES rooms(id) ON DELETE RESTRICT,
+			weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+			start_time TIME NOT NULL,
+			end_time TIME NOT NULL CHECK (end_time > start_time),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS section_meetings_section_id_idx ON section_meetings (section_id);
+		CREATE INDEX IF NOT EXISTS section_meetings_room_id_idx ON section_meetings (room_id, weekday);
+	`); err != nil {
+		return err
+	}
+
+	// Личные календарные ленты и праздники периодов
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS calendar_feeds (
+			user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			token VARCHAR(64) UNIQUE NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS term_holidays (
+			id SERIAL PRIMARY KEY,
+			term_id INTEGER NOT NULL REFERENCES academic_terms(id) ON DELETE CASCADE,
+			date DATE NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			UNIQUE (term_id, date)
+		);
+	`); err != nil {
+		return err
+	}
+
+	// Задания курсов и сданные работы; файлы работ лежат в файловом хранилище по storage_key
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS assignments (
+			id SERIAL PRIMARY KEY,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			title VARCHAR(255) NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			due_at TIMESTAMP NOT NULL,
+			max_points FLOAT NOT NULL CHECK (max_points > 0),
+			late_policy VARCHAR(20) NOT NULL DEFAULT 'accept' CHECK (late_policy IN ('accept', 'reject', 'penalty')),
+			late_penalty_percent FLOAT NOT NULL DEFAULT 0 CHECK (late_penalty_percent BETWEEN 0 AND 100),
+			mark_type VARCHAR(30) CHECK (mark_type IN ('first_attestation', 'second_attestation')),
+			created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS assignments_offering_id_idx ON assignments (offering_id);
+		CREATE TABLE IF NOT EXISTS assignment_submissions (
+			id SERIAL PRIMARY KEY,
+			assignment_id INTEGER NOT NULL REFERENCES assignments(id) ON DELETE CASCADE,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			file_name VARCHAR(255) NOT NULL,
+			content_type VARCHAR(255) NOT NULL,
+			size BIGINT NOT NULL,
+			storage_key VARCHAR(255) NOT NULL,
+			comment TEXT NOT NULL DEFAULT '',
+			submitted_at TIMESTAMP NOT NULL,
+			days_late INTEGER NOT NULL DEFAULT 0,
+			score FLOAT,
+			final_score FLOAT,
+			feedback TEXT NOT NULL DEFAULT '',
+			graded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			graded_at TIMESTAMP,
+			UNIQUE (assignment_id, student_id)
+		);
+		CREATE INDEX IF NOT EXISTS assignment_submissions_student_id_idx ON assignment_submissions (student_id);
+	`); err != nil {
+		return err
+	}
+
+	// Экзамены курсов: аудитории, наблюдатели и рассадка студентов по местам
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS exams (
+			id SERIAL PRIMARY KEY,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			title VARCHAR(255) NOT NULL,
+			starts_at TIMESTAMP NOT NULL,
+			duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+			ends_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			CHECK (ends_at > starts_at)
+		);
+		CREATE INDEX IF NOT EXISTS exams_offering_id_idx ON exams (offering_id);
+		CREATE INDEX IF NOT EXISTS exams_starts_at_idx ON exams (starts_at, ends_at);
+		CREATE TABLE IF NOT EXISTS exam_rooms (
+			exam_id INTEGER NOT NULL REFERENCES exams(id) ON DELETE CASCADE,
+			room_id INTEGER NOT NULL REFERENCES rooms(id) ON DELETE RESTRICT,
+			PRIMARY KEY (exam_id, room_id)
+		);
+		CREATE TABLE IF NOT EXISTS exam_proctors (
+			exam_id INTEGER NOT NULL REFERENCES exams(id) ON DELETE CASCADE,
+			teacher_id INTEGER NOT NULL REFERENCES teachers(id) ON DELETE CASCADE,
+			PRIMARY KEY (exam_id, teacher_id)
+		);
+		CREATE TABLE IF NOT EXISTS exam_seats (
+			exam_id INTEGER NOT NULL REFERENCES exams(id) ON DELETE CASCADE,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			room_id INTEGER NOT NULL,
+			seat INTEGER NOT NULL CHECK (seat > 0),
+			PRIMARY KEY (exam_id, student_id),
+			UNIQUE (exam_id, room_id, seat),
+			FOREIGN KEY (exam_id, room_id) REFERENCES exam_rooms(exam_id, room_id) ON DELETE CASCADE
+		);
+	`); err != nil {
+		return err
+	}
+
+	// Образовательные программы и учебные планы; студенту назначаются программа и план
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS programs (
+			id SERIAL PRIMARY KEY,
+			code VARCHAR(50) UNIQUE NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			degree VARCHAR(20) NOT NULL CHECK (degree IN ('bachelor', 'master', 'doctorate')),
+			faculty VARCHAR(255) NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS curricula (
+			id SERIAL PRIMARY KEY,
+			program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			catalog_year INTEGER NOT NULL,
+			total_credits INTEGER NOT NULL CHECK (total_credits > 0),
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (program_id, name)
+		);
+		CREATE TABLE IF NOT EXISTS curriculum_courses (
+			curriculum_id INTEGER NOT NULL REFERENCES curricula(id) ON DELETE CASCADE,
+			course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+			PRIMARY KEY (curriculum_id, course_id)
+		);
+		CREATE TABLE IF NOT EXISTS elective_groups (
+			id SERIAL PRIMARY KEY,
+			curriculum_id INTEGER NOT NULL REFERENCES curricula(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			min_credits INTEGER NOT NULL CHECK (min_credits > 0),
+			position INTEGER NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS elective_group_courses (
+			group_id INTEGER NOT NULL REFERENCES elective_groups(id) ON DELETE CASCADE,
+			course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+			PRIMARY KEY (group_id, course_id)
+		);
+		ALTER TABLE students ADD COLUMN IF NOT EXISTS program_id INTEGER REFERENCES programs(id) ON DELETE RESTRICT;
+		ALTER TABLE students ADD COLUMN IF NOT EXISTS curriculum_id INTEGER REFERENCES curricula(id) ON DELETE RESTRICT;
+	`); err != nil {
+		return err
+	}
+
+	// Факультеты и кафедры; строковые faculty/department в профилях остаются копией названия
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS faculties (
+			id SERIAL PRIMARY KEY,
+			code VARCHAR(50) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			aliases TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS faculties_code_key ON faculties (lower(code));
+		CREATE TABLE IF NOT EXISTS departments (
+			id SERIAL PRIMARY KEY,
+			faculty_id INTEGER REFERENCES faculties(id) ON DELETE RESTRICT,
+			code VARCHAR(50) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			aliases TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS departments_code_key ON departments (lower(code));
+		ALTER TABLE students ADD COLUMN IF NOT EXISTS faculty_id INTEGER REFERENCES faculties(id) ON DELETE RESTRICT;
+		ALTER TABLE teachers ADD COLUMN IF NOT EXISTS department_id INTEGER REFERENCES departments(id) ON DELETE RESTRICT;
+		ALTER TABLE managers ADD COLUMN IF NOT EXISTS department_id INTEGER REFERENCES departments(id) ON DELETE RESTRICT;
+		ALTER TABLE courses ADD COLUMN IF NOT EXISTS department_id INTEGER REFERENCES departments(id) ON DELETE RESTRICT;
+	`); err != nil {
+		return err
+	}
+
+	// Консультанты студентов, заметки о консультациях и согласование записи на курсы
+	if _, err := Instance.ExecContext(ctx, `
+		ALTER TABLE programs ADD COLUMN IF NOT EXISTS advisor_approval BOOLEAN NOT NULL DEFAULT FALSE;
+		CREATE TABLE IF NOT EXISTS advisor_assignments (
+			id SERIAL PRIMARY KEY,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			teacher_id INTEGER NOT NULL REFERENCES teachers(id) ON DELETE CASCADE,
+			program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+			assigned_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (student_id, program_id)
+		);
+		CREATE INDEX IF NOT EXISTS advisor_assignments_teacher_id_idx ON advisor_assignments (teacher_id);
+		CREATE TABLE IF NOT EXISTS advising_notes (
+			id SERIAL PRIMARY KEY,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			author_role VARCHAR(20) NOT NULL,
+			visibility VARCHAR(20) NOT NULL DEFAULT 'advisors' CHECK (visibility IN ('private', 'advisors', 'student')),
+			body TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS advising_notes_student_id_idx ON advising_notes (student_id);
+		CREATE TABLE IF NOT EXISTS enrollment_approvals (
+			id SERIAL PRIMARY KEY,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+			offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+			section_id INTEGER REFERENCES course_sections(id) ON DELETE SET NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+			comment TEXT NOT NULL DEFAULT '',
+			decided_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			decided_at TIMESTAMP,
+			requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS enrollment_approvals_pending_idx ON enrollment_approvals (student_id, offering_id)
+			WHERE status = 'pending';
+	`); err != nil {
+		return err
+	}
+
+	// Правила и результаты расчёта академического положения по итогам периода
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS standing_rules (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			probation_gpa FLOAT NOT NULL CHECK (probation_gpa >= 0 AND probation_gpa <= 4),
+			probation_terms INTEGER NOT NULL CHECK (probation_terms >= 1),
+			honors_percent FLOAT NOT NULL CHECK (honors_percent >= 0 AND honors_percent <= 100),
+			honors_min_credits INTEGER NOT NULL CHECK (honors_min_credits >= 0),
+			honors_min_gpa FLOAT NOT NULL CHECK (honors_min_gpa >= 0 AND honors_min_gpa <= 4),
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO standing_rules (id, probation_gpa, probation_terms, honors_percent, honors_min_credits, honors_min_gpa)
+		VALUES (1, 2.0, 2, 10, 15, 3.5) ON CONFLICT (id) DO NOTHING;
+		CREATE TABLE IF NOT EXISTS academic_standings (
+			id SERIAL PRIMARY KEY,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			term_id INTEGER NOT NULL REFERENCES academic_terms(id) ON DELETE CASCADE,
+			status VARCHAR(20) NOT NULL CHECK (status IN ('good', 'warning', 'probation', 'honors')),
+			term_gpa FLOAT NOT NULL,
+			cumulative_gpa FLOAT NOT NULL,
+			attempted_credits INTEGER NOT NULL,
+			earned_credits INTEGER NOT NULL,
+			computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (student_id, term_id)
+		);
+		CREATE INDEX IF NOT EXISTS academic_standings_term_id_idx ON academic_standings (term_id, status);
+	`); err != nil {
+		return err
+	}
+
+	// Анкеты оценки преподавания: участие студента хранится отдельно от его ответов
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS evaluation_templates (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			min_responses INTEGER NOT NULL CHECK (min_responses >= 1),
+			created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS evaluation_questions (
+			id SERIAL PRIMARY KEY,
+			template_id INTEGER NOT NULL REFERENCES evaluation_templates(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			kind VARCHAR(20) NOT NULL CHECK (kind IN ('likert', 'text')),
+			text TEXT NOT NULL,
+			required BOOLEAN NOT NULL DEFAULT FALSE
+		);
+		CREATE TABLE IF NOT EXISTS course_evaluations (
+			id SERIAL PRIMARY KEY,
+			offering_id INTEGER NOT NULL UNIQUE REFERENCES course_offerings(id) ON DELETE CASCADE,
+			template_id INTEGER NOT NULL REFERENCES evaluation_templates(id) ON DELETE RESTRICT,
+			closes_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS evaluation_participants (
+			evaluation_id INTEGER NOT NULL REFERENCES course_evaluations(id) ON DELETE CASCADE,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			PRIMARY KEY (evaluation_id, student_id)
+		);
+		CREATE TABLE IF NOT EXISTS evaluation_responses (
+			id SERIAL PRIMARY KEY,
+			evaluation_id INTEGER NOT NULL REFERENCES course_evaluations(id) ON DELETE CASCADE
+		);
+		CREATE TABLE IF NOT EXISTS evaluation_answers (
+			response_id INTEGER NOT NULL REFERENCES evaluation_responses(id) ON DELETE CASCADE,
+			question_id INTEGER NOT NULL REFERENCES evaluation_questions(id) ON DELETE CASCADE,
+			rating INTEGER CHECK (rating BETWEEN 1 AND 5),
+			comment TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY (response_id, question_id)
+		);
+		CREATE INDEX IF NOT EXISTS evaluation_responses_evaluation_id_idx ON evaluation_responses (evaluation_id);
+	`); err != nil {
+		return err
+	}
+
+	// Часы консультаций и записи на них: действующая запись занимает слот, частичный уникальный
+	// индекс не даёт записать двоих на один слот. Пересечение записей одного студента запрещает
+	// ограничение-исключение из миграции 0024 (для него нужен btree_gist).
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE EXTENSION IF NOT EXISTS btree_gist;
+		CREATE TABLE IF NOT EXISTS office_hours (
+			id SERIAL PRIMARY KEY,
+			teacher_id INTEGER NOT NULL REFERENCES teachers(id) ON DELETE CASCADE,
+			weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+			start_time TIME NOT NULL,
+			end_time TIME NOT NULL CHECK (end_time > start_time),
+			slot_minutes INTEGER NOT NULL CHECK (slot_minutes > 0),
+			location VARCHAR(255) NOT NULL DEFAULT '',
+			valid_from DATE,
+			valid_until DATE,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS office_hours_teacher_id_idx ON office_hours (teacher_id);
+		CREATE TABLE IF NOT EXISTS office_hour_bookings (
+			id SERIAL PRIMARY KEY,
+			office_hour_id INTEGER NOT NULL REFERENCES office_hours(id) ON DELETE CASCADE,
+			student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+			starts_at TIMESTAMP NOT NULL,
+			ends_at TIMESTAMP NOT NULL CHECK (ends_at > starts_at),
+			topic TEXT NOT NULL DEFAULT '',
+			status VARCHAR(20) NOT NULL DEFAULT 'booked' CHECK (status IN ('booked', 'cancelled')),
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			cancelled_at TIMESTAMP,
+			cancelled_by INTEGER REFERENCES users(id) ON DELETE SET NULL
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS office_hour_bookings_slot_idx
+			ON office_hour_bookings (office_hour_id, starts_at) WHERE status = 'booked';
+	`); err != nil {
+		return err
+	}
+
+	// Объявления университета, кафедр и курсов и отметки о прочтении
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS announcements (
+			id SERIAL PRIMARY KEY,
+			scope VARCHAR(20) NOT NULL CHECK (scope IN ('university', 'department', 'course')),
+			department_id INTEGER REFERENCES departments(id) ON DELETE CASCADE,
+			course_id INTEGER REFERENCES courses(id) ON DELETE CASCADE,
+			audience VARCHAR(20) NOT NULL DEFAULT 'all' CHECK (audience IN ('all', 'student', 'teacher')),
+			title VARCHAR(255) NOT NULL,
+			body TEXT NOT NULL,
+			author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			CHECK (scope <> 'department' OR department_id IS NOT NULL),
+			CHECK (scope <> 'course' OR course_id IS NOT NULL)
+		);
+		CREATE INDEX IF NOT EXISTS announcements_created_at_idx ON announcements (created_at DESC);
+		CREATE INDEX IF NOT EXISTS announcements_course_id_idx ON announcements (course_id);
+		CREATE TABLE IF NOT EXISTS announcement_reads (
+			announcement_id INTEGER NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (announcement_id, user_id)
+		);
+	`); err != nil {
+		return err
+	}
+
+	// Таблица выданных refresh-токенов (ротация и отзыв)
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS refresh_tokens (
+			id SERIAL PRIMARY KEY,
+			jti VARCHAR(64) UNIQUE NOT NULL,
+			family_id VARCHAR(64) NOT NULL,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			user_agent TEXT NOT NULL DEFAULT '',
+			ip_address VARCHAR(64) NOT NULL DEFAULT '',
+			issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			expires_at TIMESTAMP NOT NULL,
+			revoked_at TIMESTAMP,
+			replaced_by VARCHAR(64)
+		);
+		CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+		CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+	`); err != nil {
+		return err
+	}
+
+	// Журнал одноразовых миграций данных
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return err
+	}
+
+	// Правила доступа Casbin
+	if _, err := Instance.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS casbin_rule (
+			id SERIAL PRIMARY KEY,
+			ptype VARCHAR(100) NOT NULL,
+			v0 VARCHAR(255) NOT NULL DEFAULT '',
+			v1 VARCHAR(255) NOT NULL DEFAULT '',
+			v2 VARCHAR(255) NOT NULL DEFAULT '',
+			v3 VARCHAR(255) NOT NULL DEFAULT '',
+			v4 VARCHAR(255) NOT NULL DEFAULT '',
+			v5 VARCHAR(255) NOT NULL DEFAULT '',
+			UNIQUE (ptype, v0, v1, v2, v3, v4, v5)
+		)
+	`); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0001_seed_casbin_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, initialPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0002_default_academic_term", func(tx *sqlx.Tx) error {
+		return moveToDefaultTerm(ctx, tx, time.Now())
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0003_seed_term_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, termPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0004_seed_section_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, sectionPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0005_seed_enrollment_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, enrollmentPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0006_seed_grading_scheme_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, gradingSchemePolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0007_seed_transcript_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, transcriptPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0008_seed_document_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, documentPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0009_seed_grade_workflow_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, gradeWorkflowPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0010_seed_grade_appeal_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, gradeAppealPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0011_seed_attendance_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, attendancePolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0012_seed_timetable_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, timetablePolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0013_seed_calendar_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, calendarPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0014_seed_assignment_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, assignmentPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0015_seed_exam_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, examPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0016_seed_program_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, programPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0017_link_faculties_and_departments", func(tx *sqlx.Tx) error {
+		return linkOrganizationNames(ctx, tx)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0018_seed_organization_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, organizationPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0019_seed_advising_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, advisingPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0020_seed_standing_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, standingPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0021_seed_evaluation_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, evaluationPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0022_seed_office_hour_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, officeHourPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0023_seed_announcement_policies", func(tx *sqlx.Tx) error {
+		return seedPolicies(ctx, tx, announcementPolicies)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0024_exclude_overlapping_office_hour_bookings", func(tx *sqlx.Tx) error {
+		return excludeOverlappingBookings(ctx, tx)
+	}); err != nil {
+		return err
+	}
+
+	if err := applyOnce(ctx, "0025_restrict_offering_history_deletes", func(tx *sqlx.Tx) error {
+		return restrictOfferingHistoryDeletes(ctx, tx)
+	}); err != nil {
+		return err
+	}
+
 	// Создание первого админа (ручная запись)
 	if _, err := Instance.ExecContext(ctx, `
 		INSERT INTO users (id, username, password, firstname, lastname, email, role, created_at, updated_at)
@@ -0,0 +1,181 @@
+package databases
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// applyOnce выполняет одноразовую миграцию данных и запоминает её в schema_migrations,
+// чтобы при следующем запуске не повторять её (например, не возвращать удалённые политики)
+func applyOnce(ctx context.Context, name string, apply func(tx *sqlx.Tx) error) error {
+	tx, err := Instance.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var applied bool
+	if err := tx.GetContext(ctx, &applied, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE name = $1)", name); err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if err := apply(tx); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (name) VALUES ($1)", name); err != nil {
+		return err
+	}
+
+	logrus.Infof("Applied migration %s", name)
+	return tx.Commit()
+}
+
+// seedPolicies добавляет правила Casbin вида [роль, маршрут, метод]
+func seedPolicies(ctx context.Context, tx *sqlx.Tx, policies [][3]string) error {
+	for _, p := range policies {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES ('p', $1, $2, $3) ON CONFLICT DO NOTHING",
+			p[0], p[1], p[2]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveToDefaultTerm переносит записи и оценки, созданные до появления периодов, в период
+// по умолчанию. После этого уникальность записей и оценок считается в рамках курса в периоде.
+// На пустой базе период не создаётся: его заведёт администратор.
+func moveToDefaultTerm(ctx context.Context, tx *sqlx.Tx, now time.Time) error {
+	var pending bool
+	if err := tx.GetContext(ctx, &pending, `
+		SELECT EXISTS (SELECT 1 FROM student_courses WHERE offering_id IS NULL)
+			OR EXISTS (SELECT 1 FROM course_marks WHERE offering_id IS NULL)`); err != nil {
+		return err
+	}
+	if pending {
+		if err := backfillDefaultTerm(ctx, tx, now); err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE student_courses DROP CONSTRAINT IF EXISTS student_courses_pkey;
+		ALTER TABLE student_courses ALTER COLUMN offering_id SET NOT NULL;
+		ALTER TABLE student_courses ADD PRIMARY KEY (student_id, offering_id);
+		ALTER TABLE course_marks DROP CONSTRAINT IF EXISTS course_marks_student_id_course_id_key;
+		ALTER TABLE course_marks ALTER COLUMN offering_id SET NOT NULL;
+		ALTER TABLE course_marks ADD CONSTRAINT course_marks_student_id_offering_id_key UNIQUE (student_id, offering_id);
+	`)
+	return err
+}
+
+// backfillDefaultTerm создаёт период, содержащий дату now, открывает в нём все курсы
+// и привязывает к ним записи и оценки без курса в периоде
+func backfillDefaultTerm(ctx context.Context, tx *sqlx.Tx, now time.Time) error {
+	year := now.Year()
+	season, start, end := "fall", time.Date(year, time.September, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	switch {
+	case now.Month() <= time.May:
+		season, start, end = "spring", time.Date(year, time.January, 15, 0, 0, 0, 0, time.UTC), time.Date(year, time.May, 31, 0, 0, 0, 0, time.UTC)
+	case now.Month() <= time.August:
+		season, start, end = "summer", time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.August, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	var termID int
+	if err := tx.GetContext(ctx, &termID, `
+		INSERT INTO academic_terms (year, season, start_date, end_date, enrollment_start, enrollment_end, grading_deadline,
+			add_drop_deadline, withdrawal_deadline, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $6, $4, 'active')
+		ON CONFLICT (year, season) DO UPDATE SET year = EXCLUDED.year
+		RETURNING id`,
+		year, season, start, end, start.AddDate(0, 0, -30), start.AddDate(0, 0, 14), end.AddDate(0, 0, 14)); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO course_offerings (course_id, term_id)
+		SELECT id, $1 FROM courses
+		ON CONFLICT (course_id, term_id) DO NOTHING`, termID); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"student_courses", "course_marks"} {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE `+table+` t SET offering_id = co.id
+			FROM course_offerings co
+			WHERE co.course_id = t.course_id AND co.term_id = $1 AND t.offering_id IS NULL`, termID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkOrganizationNames связывает строковые факультеты студентов и кафедры преподавателей
+// и менеджеров с таблицами faculties и departments. Строка сопоставляется с кодом, названием
+// или псевдонимом без учёта регистра; для каждой несопоставленной строки создаётся отдельная
+// запись, а дубликаты вроде "IT" и "FIT" затем объединяются через слияние.
+func linkOrganizationNames(ctx context.Context, tx *sqlx.Tx) error {
+	links := []struct{ entity, source, column, link string }{
+		{"faculties", "students", "faculty", "faculty_id"},
+		{"departments", "teachers", "department", "department_id"},
+		{"departments", "managers", "department", "department_id"},
+	}
+	for _, l := range links {
+		match := `(lower(e.code) = lower(btrim(x.` + l.column + `)) OR lower(e.name) = lower(btrim(x.` + l.column + `))
+			OR EXISTS (SELECT 1 FROM unnest(e.aliases) a WHERE lower(a) = lower(btrim(x.` + l.column + `))))`
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO `+l.entity+` (code, name)
+			SELECT DISTINCT ON (lower(btrim(x.`+l.column+`))) btrim(x.`+l.column+`), btrim(x.`+l.column+`)
+			FROM `+l.source+` x
+			WHERE btrim(x.`+l.column+`) <> '' AND NOT EXISTS (SELECT 1 FROM `+l.entity+` e WHERE `+match+`)
+			ORDER BY lower(btrim(x.`+l.column+`))`); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE `+l.source+` x SET `+l.link+` = e.id, `+l.column+` = e.name
+			FROM `+l.entity+` e
+			WHERE x.`+l.link+` IS NULL AND btrim(x.`+l.column+`) <> '' AND `+match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// excludeOverlappingBookings заменяет уникальный индекс (student_id, starts_at) ограничением-исключением:
+// действующие записи одного студента не должны пересекаться по времени, даже если слоты разной длины
+// начинаются в разное время. Пересекающиеся записи, которые индекс пропустил, отменяются,
+// кроме самой ранней по времени создания.
+func excludeOverlappingBookings(ctx context.Context, tx *sqlx.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP INDEX IF EXISTS office_hour_bookings_student_idx;
+		UPDATE office_hour_bookings b SET status = 'cancelled', cancelled_at = CURRENT_TIMESTAMP
+		WHERE b.status = 'booked' AND EXISTS (
+			SELECT 1 FROM office_hour_bookings e
+			WHERE e.student_id = b.student_id AND e.status = 'booked' AND e.id < b.id
+				AND e.starts_at < b.ends_at AND b.starts_at < e.ends_at);
+		ALTER TABLE office_hour_bookings ADD CONSTRAINT office_hour_bookings_student_overlap
+			EXCLUDE USING gist (student_id WITH =, tsrange(starts_at, ends_at) WITH &&) WHERE (status = 'booked');
+	`)
+	return err
+}
+
+// restrictOfferingHistoryDeletes запрещает удалять курс в периоде (и сам период), пока на него
+// ссылаются записи студентов или оценки: раньше удаление каскадом стирало историю обучения.
+// NO ACTION, а не RESTRICT: проверка выполняется в конце запроса, поэтому удаление самого курса
+// по-прежнему каскадно удаляет его записи через student_courses.course_id.
+func restrictOfferingHistoryDeletes(ctx context.Context, tx *sqlx.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE student_courses DROP CONSTRAINT IF EXISTS student_courses_offering_id_fkey;
+		ALTER TABLE student_courses ADD CONSTRAINT student_courses_offering_id_fkey
+			FOREIGN KEY (offering_id) REFERENCES course_offerings(id) ON DELETE NO ACTION;
+		ALTER TABLE course_marks DROP CONSTRAINT IF EXISTS course_marks_offering_id_fkey;
+		ALTER TABLE course_marks ADD CONSTRAINT course_marks_offering_id_fkey
+			FOREIGN KEY (offering_id) REFERENCES course_offerings(id) ON DELETE NO ACTION;
+	`)
+	return err
+}
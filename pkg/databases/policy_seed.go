@@ -0,0 +1,424 @@
+package databases
+
+// Начальные правила доступа — бывшие списки RoleMiddleware из routes.RegisterUserRoutes.
+// Роль admin в правилах не нужна: модель разрешает ей все маршруты.
+var initialPolicies = [][3]string{
+	{"manager", "/api/users", "GET"},
+	{"manager", "/api/users", "POST"},
+	{"manager", "/api/users/:id", "GET"},
+	{"manager", "/api/users/:id", "PUT"},
+
+	{"manager", "/students/", "GET"},
+	{"teacher", "/students/", "GET"},
+	{"manager", "/students", "POST"},
+	{"manager", "/students/:id", "GET"},
+	{"teacher", "/students/:id", "GET"},
+	{"student", "/students/:id", "GET"},
+	{"manager", "/students/:id", "PUT"},
+	{"student", "/students/:id", "PUT"},
+	{"manager", "/students/:id", "DELETE"},
+	{"manager", "/students/:student_id/courses/:course_id", "POST"},
+	{"student", "/students/:student_id/courses/:course_id", "POST"},
+	{"manager", "/students/:id/courses", "GET"},
+	{"teacher", "/students/:id/courses", "GET"},
+	{"student", "/students/:id/courses", "GET"},
+
+	{"manager", "/courses/", "POST"},
+	{"teacher", "/courses/", "POST"},
+	{"manager", "/courses/:id", "GET"},
+	{"teacher", "/courses/:id", "GET"},
+	{"student", "/courses/:id", "GET"},
+	{"manager", "/courses/", "GET"},
+	{"teacher", "/courses/", "GET"},
+	{"student", "/courses/", "GET"},
+	{"manager", "/courses/:id", "PUT"},
+	{"teacher", "/courses/:id", "PUT"},
+	{"manager", "/courses/:id", "DELETE"},
+	{"manager", "/courses/:id/students", "GET"},
+	{"teacher", "/courses/:id/students", "GET"},
+	{"manager", "/courses/:id/teachers", "GET"},
+	{"teacher", "/courses/:id/teachers", "GET"},
+
+	{"manager", "/teachers/", "GET"},
+	{"teacher", "/teachers/", "GET"},
+	{"manager", "/teachers/:id", "GET"},
+	{"teacher", "/teachers/:id", "GET"},
+	{"manager", "/teachers/:id", "PUT"},
+	{"teacher", "/teachers/:id", "PUT"},
+	{"manager", "/teachers/:id", "DELETE"},
+	{"manager", "/teachers/", "POST"},
+	{"manager", "/teachers/:id/courses", "GET"},
+	{"teacher", "/teachers/:id/courses", "GET"},
+	{"teacher", "/teachers/:id/courses/:course_id/students/:student_id/PutFirstAtt", "POST"},
+	{"teacher", "/teachers/:id/courses/:course_id/students/:student_id/PutSecondAtt", "POST"},
+	{"teacher", "/teachers/:id/courses/:course_id/students/:student_id/PutFinalMark", "POST"},
+
+	{"manager", "/managers/", "GET"},
+	{"manager", "/managers/:id", "GET"},
+	{"manager", "/managers/:id", "PUT"},
+	{"manager", "/managers/", "POST"},
+	{"manager", "/managers/:id/teachers/:teacher_id/courses/:course_id", "POST"},
+
+	{"manager", "/marks/student/:student_id", "GET"},
+	{"teacher", "/marks/student/:student_id", "GET"},
+	{"student", "/marks/student/:student_id", "GET"},
+	{"manager", "/marks/course/:course_id", "GET"},
+	{"teacher", "/marks/course/:course_id", "GET"},
+	{"student", "/marks/course/:course_id", "GET"},
+}
+
+var termPolicies = [][3]string{
+	{"manager", "/terms", "GET"},
+	{"teacher", "/terms", "GET"},
+	{"student", "/terms", "GET"},
+	{"manager", "/terms", "POST"},
+	{"manager", "/terms/:id", "GET"},
+	{"teacher", "/terms/:id", "GET"},
+	{"student", "/terms/:id", "GET"},
+	{"manager", "/terms/:id", "PUT"},
+	{"manager", "/terms/:id", "DELETE"},
+	{"manager", "/terms/:id/offerings", "GET"},
+	{"teacher", "/terms/:id/offerings", "GET"},
+	{"student", "/terms/:id/offerings", "GET"},
+	{"manager", "/terms/:id/offerings", "POST"},
+	{"manager", "/terms/:id/offerings/:offering_id", "DELETE"},
+}
+
+var sectionPolicies = [][3]string{
+	{"manager", "/terms/:id/offerings/:offering_id/sections", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/sections", "GET"},
+	{"student", "/terms/:id/offerings/:offering_id/sections", "GET"},
+	{"manager", "/terms/:id/offerings/:offering_id/sections", "POST"},
+	{"manager", "/sections/:id", "GET"},
+	{"teacher", "/sections/:id", "GET"},
+	{"student", "/sections/:id", "GET"},
+	{"manager", "/sections/:id", "PUT"},
+	{"manager", "/sections/:id", "DELETE"},
+	{"manager", "/sections/:id/waitlist", "GET"},
+	{"teacher", "/sections/:id/waitlist", "GET"},
+	{"manager", "/students/:id/courses/:course_id", "DELETE"},
+	{"student", "/students/:id/courses/:course_id", "DELETE"},
+	{"manager", "/students/:id/waitlist", "GET"},
+	{"student", "/students/:id/waitlist", "GET"},
+	{"manager", "/students/:id/waitlist/:section_id", "DELETE"},
+	{"student", "/students/:id/waitlist/:section_id", "DELETE"},
+}
+
+var enrollmentPolicies = [][3]string{
+	{"manager", "/students/:student_id/courses/:course_id/withdraw", "POST"},
+	{"student", "/students/:student_id/courses/:course_id/withdraw", "POST"},
+	{"manager", "/students/:id/enrollments", "GET"},
+	{"student", "/students/:id/enrollments", "GET"},
+	{"manager", "/students/:id/enrollments/history", "GET"},
+	{"student", "/students/:id/enrollments/history", "GET"},
+}
+
+var gradingSchemePolicies = [][3]string{
+	{"manager", "/grading-schemes", "GET"},
+	{"teacher", "/grading-schemes", "GET"},
+	{"manager", "/grading-schemes", "POST"},
+	{"manager", "/grading-schemes/:id", "GET"},
+	{"teacher", "/grading-schemes/:id", "GET"},
+	{"manager", "/grading-schemes/:id", "PUT"},
+	{"manager", "/grading-schemes/:id", "DELETE"},
+}
+
+var transcriptPolicies = [][3]string{
+	{"manager", "/students/:id/transcript", "GET"},
+	{"student", "/students/:id/transcript", "GET"},
+}
+
+var documentPolicies = [][3]string{
+	{"manager", "/students/:student_id/documents/transcript", "POST"},
+	{"student", "/students/:student_id/documents/transcript", "POST"},
+	{"manager", "/students/:student_id/documents/enrollment-certificate", "POST"},
+	{"student", "/students/:student_id/documents/enrollment-certificate", "POST"},
+	{"manager", "/students/:id/documents", "GET"},
+	{"student", "/students/:id/documents", "GET"},
+}
+
+var gradeWorkflowPolicies = [][3]string{
+	{"manager", "/terms/:id/offerings/:offering_id/grades", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/grades", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/grades/submit", "POST"},
+	{"manager", "/terms/:id/offerings/:offering_id/grades/approve", "POST"},
+	{"manager", "/terms/:id/offerings/:offering_id/grades/return", "POST"},
+	{"manager", "/terms/:id/offerings/:offering_id/grades/history", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/grades/history", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/grade-changes", "POST"},
+	{"manager", "/grade-changes", "GET"},
+	{"manager", "/grade-changes/:id/approve", "POST"},
+	{"manager", "/grade-changes/:id/reject", "POST"},
+}
+
+var gradeAppealPolicies = [][3]string{
+	{"student", "/students/:student_id/appeals", "POST"},
+	{"manager", "/students/:id/appeals", "GET"},
+	{"student", "/students/:id/appeals", "GET"},
+	{"manager", "/appeals", "GET"},
+	{"teacher", "/appeals", "GET"},
+	{"manager", "/appeals/:id", "GET"},
+	{"teacher", "/appeals/:id", "GET"},
+	{"student", "/appeals/:id", "GET"},
+	{"manager", "/appeals/:id/attachment", "GET"},
+	{"teacher", "/appeals/:id/attachment", "GET"},
+	{"student", "/appeals/:id/attachment", "GET"},
+	{"manager", "/appeals/:id/comments", "POST"},
+	{"teacher", "/appeals/:id/comments", "POST"},
+	{"student", "/appeals/:id/comments", "POST"},
+	{"manager", "/appeals/:id/review", "POST"},
+	{"teacher", "/appeals/:id/review", "POST"},
+	{"manager", "/appeals/:id/resolve", "POST"},
+	{"teacher", "/appeals/:id/resolve", "POST"},
+	{"student", "/appeals/:id/withdraw", "POST"},
+}
+
+var attendancePolicies = [][3]string{
+	{"manager", "/terms/:id/offerings/:offering_id/sessions", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/sessions", "GET"},
+	{"student", "/terms/:id/offerings/:offering_id/sessions", "GET"},
+	{"manager", "/terms/:id/offerings/:offering_id/sessions", "POST"},
+	{"teacher", "/terms/:id/offerings/:offering_id/sessions", "POST"},
+	{"manager", "/terms/:id/attendance/flags", "GET"},
+	{"manager", "/sessions/:id", "DELETE"},
+	{"teacher", "/sessions/:id", "DELETE"},
+	{"manager", "/sessions/:id/attendance", "GET"},
+	{"teacher", "/sessions/:id/attendance", "GET"},
+	{"manager", "/sessions/:id/attendance", "PUT"},
+	{"teacher", "/sessions/:id/attendance", "PUT"},
+	{"manager", "/sessions/:id/attendance/:student_id", "PUT"},
+	{"teacher", "/sessions/:id/attendance/:student_id", "PUT"},
+	{"manager", "/students/:id/attendance", "GET"},
+	{"student", "/students/:id/attendance", "GET"},
+	{"manager", "/attendance/settings", "GET"},
+	{"manager", "/attendance/settings", "PUT"},
+}
+
+var timetablePolicies = [][3]string{
+	{"manager", "/rooms", "GET"},
+	{"teacher", "/rooms", "GET"},
+	{"manager", "/rooms", "POST"},
+	{"manager", "/rooms/:id", "GET"},
+	{"teacher", "/rooms/:id", "GET"},
+	{"manager", "/rooms/:id", "PUT"},
+	{"manager", "/rooms/:id", "DELETE"},
+	{"manager", "/rooms/:id/timetable", "GET"},
+	{"teacher", "/rooms/:id/timetable", "GET"},
+	{"manager", "/sections/:id/meetings", "GET"},
+	{"teacher", "/sections/:id/meetings", "GET"},
+	{"student", "/sections/:id/meetings", "GET"},
+	{"manager", "/sections/:id/meetings", "POST"},
+	{"manager", "/meetings/:id", "PUT"},
+	{"manager", "/meetings/:id", "DELETE"},
+	{"manager", "/terms/:id/timetable/conflicts", "GET"},
+	{"manager", "/students/:id/timetable", "GET"},
+	{"student", "/students/:id/timetable", "GET"},
+	{"manager", "/teachers/:id/timetable", "GET"},
+	{"teacher", "/teachers/:id/timetable", "GET"},
+}
+
+var calendarPolicies = [][3]string{
+	{"manager", "/calendar/feed", "GET"},
+	{"teacher", "/calendar/feed", "GET"},
+	{"student", "/calendar/feed", "GET"},
+	{"manager", "/calendar/feed/rotate", "POST"},
+	{"teacher", "/calendar/feed/rotate", "POST"},
+	{"student", "/calendar/feed/rotate", "POST"},
+	{"manager", "/terms/:id/holidays", "GET"},
+	{"teacher", "/terms/:id/holidays", "GET"},
+	{"student", "/terms/:id/holidays", "GET"},
+	{"manager", "/terms/:id/holidays", "POST"},
+	{"manager", "/terms/:id/holidays/:holiday_id", "DELETE"},
+}
+
+var assignmentPolicies = [][3]string{
+	{"manager", "/terms/:id/offerings/:offering_id/assignments", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/assignments", "GET"},
+	{"student", "/terms/:id/offerings/:offering_id/assignments", "GET"},
+	{"manager", "/terms/:id/offerings/:offering_id/assignments", "POST"},
+	{"teacher", "/terms/:id/offerings/:offering_id/assignments", "POST"},
+	{"manager", "/terms/:id/offerings/:offering_id/assignments/apply-marks", "POST"},
+	{"teacher", "/terms/:id/offerings/:offering_id/assignments/apply-marks", "POST"},
+	{"manager", "/assignments/:id", "GET"},
+	{"teacher", "/assignments/:id", "GET"},
+	{"student", "/assignments/:id", "GET"},
+	{"manager", "/assignments/:id", "PUT"},
+	{"teacher", "/assignments/:id", "PUT"},
+	{"manager", "/assignments/:id", "DELETE"},
+	{"teacher", "/assignments/:id", "DELETE"},
+	{"manager", "/assignments/:id/submissions", "GET"},
+	{"teacher", "/assignments/:id/submissions", "GET"},
+	{"student", "/assignments/:id/submissions", "POST"},
+	{"manager", "/submissions/:id", "GET"},
+	{"teacher", "/submissions/:id", "GET"},
+	{"student", "/submissions/:id", "GET"},
+	{"manager", "/submissions/:id/file", "GET"},
+	{"teacher", "/submissions/:id/file", "GET"},
+	{"student", "/submissions/:id/file", "GET"},
+	{"manager", "/submissions/:id/grade", "PUT"},
+	{"teacher", "/submissions/:id/grade", "PUT"},
+	{"manager", "/students/:id/submissions", "GET"},
+	{"student", "/students/:id/submissions", "GET"},
+}
+
+var examPolicies = [][3]string{
+	{"manager", "/terms/:id/offerings/:offering_id/exams", "GET"},
+	{"teacher", "/terms/:id/offerings/:offering_id/exams", "GET"},
+	{"student", "/terms/:id/offerings/:offering_id/exams", "GET"},
+	{"manager", "/terms/:id/offerings/:offering_id/exams", "POST"},
+	{"manager", "/terms/:id/exams", "GET"},
+	{"teacher", "/terms/:id/exams", "GET"},
+	{"student", "/terms/:id/exams", "GET"},
+	{"manager", "/terms/:id/exams/conflicts", "GET"},
+	{"manager", "/exams/:id", "GET"},
+	{"teacher", "/exams/:id", "GET"},
+	{"student", "/exams/:id", "GET"},
+	{"manager", "/exams/:id", "PUT"},
+	{"manager", "/exams/:id", "DELETE"},
+	{"manager", "/exams/:id/seating", "GET"},
+	{"teacher", "/exams/:id/seating", "GET"},
+	{"manager", "/exams/:id/seating", "POST"},
+	{"manager", "/exams/:id/rooms/:room_id/seating", "GET"},
+	{"teacher", "/exams/:id/rooms/:room_id/seating", "GET"},
+	{"manager", "/students/:id/exams", "GET"},
+	{"student", "/students/:id/exams", "GET"},
+	{"manager", "/teachers/:id/exams", "GET"},
+	{"teacher", "/teachers/:id/exams", "GET"},
+}
+
+var programPolicies = [][3]string{
+	{"manager", "/programs", "GET"},
+	{"teacher", "/programs", "GET"},
+	{"student", "/programs", "GET"},
+	{"manager", "/programs", "POST"},
+	{"manager", "/programs/:id", "GET"},
+	{"teacher", "/programs/:id", "GET"},
+	{"student", "/programs/:id", "GET"},
+	{"manager", "/programs/:id", "PUT"},
+	{"manager", "/programs/:id", "DELETE"},
+	{"manager", "/programs/:id/curricula", "GET"},
+	{"teacher", "/programs/:id/curricula", "GET"},
+	{"student", "/programs/:id/curricula", "GET"},
+	{"manager", "/programs/:id/curricula", "POST"},
+	{"manager", "/curricula/:id", "GET"},
+	{"teacher", "/curricula/:id", "GET"},
+	{"student", "/curricula/:id", "GET"},
+	{"manager", "/curricula/:id", "PUT"},
+	{"manager", "/curricula/:id", "DELETE"},
+	{"manager", "/students/:id/program", "PUT"},
+	{"manager", "/students/:id/degree-audit", "GET"},
+	{"student", "/students/:id/degree-audit", "GET"},
+}
+
+// Справочник факультетов и кафедр читают все роли, изменяет только администратор
+var organizationPolicies = [][3]string{
+	{"manager", "/faculties", "GET"},
+	{"teacher", "/faculties", "GET"},
+	{"student", "/faculties", "GET"},
+	{"manager", "/faculties/:id", "GET"},
+	{"teacher", "/faculties/:id", "GET"},
+	{"student", "/faculties/:id", "GET"},
+	{"manager", "/faculties/:id/departments", "GET"},
+	{"teacher", "/faculties/:id/departments", "GET"},
+	{"student", "/faculties/:id/departments", "GET"},
+	{"manager", "/departments", "GET"},
+	{"teacher", "/departments", "GET"},
+	{"student", "/departments", "GET"},
+	{"manager", "/departments/:id", "GET"},
+	{"teacher", "/departments/:id", "GET"},
+	{"student", "/departments/:id", "GET"},
+}
+
+// Консультантов назначает менеджер; заметки и согласование записи доступны консультантам,
+// права на конкретного студента проверяет сервис
+var advisingPolicies = [][3]string{
+	{"manager", "/students/:id/advisors", "GET"},
+	{"teacher", "/students/:id/advisors", "GET"},
+	{"student", "/students/:id/advisors", "GET"},
+	{"manager", "/students/:id/advisors", "PUT"},
+	{"manager", "/students/:id/advisors/:program_id", "DELETE"},
+	{"manager", "/students/:id/advising-notes", "GET"},
+	{"teacher", "/students/:id/advising-notes", "GET"},
+	{"student", "/students/:id/advising-notes", "GET"},
+	{"manager", "/students/:student_id/advising-notes", "POST"},
+	{"teacher", "/students/:student_id/advising-notes", "POST"},
+	{"manager", "/teachers/:id/advisees", "GET"},
+	{"teacher", "/teachers/:id/advisees", "GET"},
+	{"manager", "/advising-notes/:id", "PUT"},
+	{"teacher", "/advising-notes/:id", "PUT"},
+	{"manager", "/advising-notes/:id", "DELETE"},
+	{"teacher", "/advising-notes/:id", "DELETE"},
+	{"manager", "/enrollment-approvals", "GET"},
+	{"teacher", "/enrollment-approvals", "GET"},
+	{"student", "/enrollment-approvals", "GET"},
+	{"manager", "/enrollment-approvals/:id/approve", "POST"},
+	{"teacher", "/enrollment-approvals/:id/approve", "POST"},
+	{"manager", "/enrollment-approvals/:id/reject", "POST"},
+	{"teacher", "/enrollment-approvals/:id/reject", "POST"},
+}
+
+// Правила положения и пересчёт за период — у менеджера; студент видит своё положение
+var standingPolicies = [][3]string{
+	{"manager", "/standing-rules", "GET"},
+	{"manager", "/standing-rules", "PUT"},
+	{"manager", "/terms/:id/standings", "GET"},
+	{"manager", "/terms/:id/standings", "POST"},
+	{"manager", "/students/:id/standings", "GET"},
+	{"student", "/students/:id/standings", "GET"},
+}
+
+// Шаблоны и открытие анкет — у менеджера; заполняет анкеты студент, результаты своих курсов видит преподаватель
+var evaluationPolicies = [][3]string{
+	{"manager", "/evaluation-templates", "GET"},
+	{"manager", "/evaluation-templates", "POST"},
+	{"manager", "/evaluation-templates/:id", "GET"},
+	{"manager", "/evaluation-templates/:id", "DELETE"},
+	{"manager", "/terms/:id/evaluations", "GET"},
+	{"manager", "/terms/:id/evaluations", "POST"},
+	{"manager", "/students/:id/evaluations", "GET"},
+	{"student", "/students/:id/evaluations", "GET"},
+	{"manager", "/teachers/:id/evaluations", "GET"},
+	{"teacher", "/teachers/:id/evaluations", "GET"},
+	{"student", "/evaluations/:id/responses", "POST"},
+	{"manager", "/evaluations/:id/results", "GET"},
+	{"teacher", "/evaluations/:id/results", "GET"},
+}
+
+// Часы консультаций публикует преподаватель, записывается студент его курсов; менеджер видит расписание и записи
+var officeHourPolicies = [][3]string{
+	{"teacher", "/teachers/:id/office-hours", "GET"},
+	{"student", "/teachers/:id/office-hours", "GET"},
+	{"manager", "/teachers/:id/office-hours", "GET"},
+	{"teacher", "/teachers/:id/office-hours", "POST"},
+	{"teacher", "/office-hours/:id", "PUT"},
+	{"teacher", "/office-hours/:id", "DELETE"},
+	{"teacher", "/office-hours/:id/slots", "GET"},
+	{"student", "/office-hours/:id/slots", "GET"},
+	{"manager", "/office-hours/:id/slots", "GET"},
+	{"student", "/office-hours/:id/bookings", "POST"},
+	{"teacher", "/teachers/:id/bookings", "GET"},
+	{"manager", "/teachers/:id/bookings", "GET"},
+	{"student", "/students/:id/bookings", "GET"},
+	{"manager", "/students/:id/bookings", "GET"},
+	{"student", "/bookings/:id/cancel", "POST"},
+	{"teacher", "/bookings/:id/cancel", "POST"},
+}
+
+// Ленту объявлений читают все роли; публикуют менеджеры и преподаватели (охват проверяет сервис)
+var announcementPolicies = [][3]string{
+	{"manager", "/announcements/feed", "GET"},
+	{"teacher", "/announcements/feed", "GET"},
+	{"student", "/announcements/feed", "GET"},
+	{"manager", "/announcements", "POST"},
+	{"teacher", "/announcements", "POST"},
+	{"manager", "/announcements/:id", "PUT"},
+	{"teacher", "/announcements/:id", "PUT"},
+	{"manager", "/announcements/:id", "DELETE"},
+	{"teacher", "/announcements/:id", "DELETE"},
+	{"manager", "/announcements/:id/read", "POST"},
+	{"teacher", "/announcements/:id/read", "POST"},
+	{"student", "/announcements/:id/read", "POST"},
+	{"manager", "/announcements/read-all", "POST"},
+	{"teacher", "/announcements/read-all", "POST"},
+	{"student", "/announcements/read-all", "POST"},
+}
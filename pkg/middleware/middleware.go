@@ -6,6 +6,7 @@ import (
 	"university_system/internal/auth"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 func AuthMiddleware() gin.HandlerFunc {
@@ -25,6 +26,19 @@ func AuthMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+
+		// Сохраняем данные пользователя для проверок владения и контроллеров
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			if userID, ok := claims["user_id"].(string); ok {
+				c.Set(auth.ContextUserID, userID)
+			}
+			if username, ok := claims["username"].(string); ok {
+				c.Set(auth.ContextUsername, username)
+			}
+			if role, ok := claims["role"].(string); ok {
+				c.Set(auth.ContextUserRole, role)
+			}
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"university_system/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SelfOrRoles пропускает запрос, если роль пользователя входит в privileged
+// или если параметр маршрута param совпадает с ID пользователя из токена.
+// Должен стоять после AuthMiddleware.
+func SelfOrRoles(param string, privileged ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := auth.CurrentUserRole(c)
+		for _, allowed := range privileged {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		userID := auth.CurrentUserID(c)
+		if userID != "" && userID == c.Param(param) {
+			c.Next()
+			return
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: access to another user's data"})
+		c.Abort()
+	}
+}
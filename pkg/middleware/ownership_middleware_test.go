@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"university_system/internal/auth"
+)
+
+func newOwnershipRouter(userID, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/students/:id", func(c *gin.Context) {
+		c.Set(auth.ContextUserID, userID)
+		c.Set(auth.ContextUserRole, role)
+		c.Next()
+	}, SelfOrRoles("id", "admin", "manager"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestSelfOrRoles(t *testing.T) {
+	cases := []struct {
+		name   string
+		userID string
+		role   string
+		path   string
+		status int
+	}{
+		{"Own Profile", "7", "student", "/students/7", http.StatusOK},
+		{"Another Student", "7", "student", "/students/8", http.StatusForbidden},
+		{"Privileged Role", "1", "manager", "/students/8", http.StatusOK},
+		{"No User ID In Token", "", "student", "/students/8", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+
+			newOwnershipRouter(tc.userID, tc.role).ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
@@ -1,47 +0,0 @@
-package middleware
-
-import (
-	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"net/http"
-	"strings"
-	"university_system/internal/auth"
-)
-
-func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		tokenString := c.GetHeader("Authorization")
-		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
-			c.Abort()
-			return
-		}
-		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-		token, err := auth.ParseAccessToken(tokenString)
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
-		}
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid token claims"})
-			c.Abort()
-			return
-		}
-		role, ok := claims["role"].(string)
-		if !ok {
-			c.JSON(http.StatusForbidden, gin.H{"error": "No role in token"})
-			c.Abort()
-			return
-		}
-		for _, allowed := range allowedRoles {
-			if role == allowed {
-				c.Next()
-				return
-			}
-		}
-		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: insufficient rights"})
-		c.Abort()
-	}
-}